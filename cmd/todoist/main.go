@@ -2,10 +2,15 @@ package main
 
 import (
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/agisilaos/todoist-cli/internal/cli"
 )
 
 func main() {
+	// Surface closed pipes as EPIPE write errors instead of dying on SIGPIPE,
+	// so streaming commands can stop paginating cleanly.
+	signal.Ignore(syscall.SIGPIPE)
 	os.Exit(cli.Execute(os.Args[1:], os.Stdout, os.Stderr))
 }
@@ -0,0 +1,90 @@
+package agent
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Plan-local aliases let later actions reference entities created earlier in
+// the same plan: an action declares "alias": "launch" and a later action uses
+// "$launch" in one of its id fields.
+
+var aliasNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+var aliasEntityByType = map[string]string{
+	"task_add":    "task",
+	"project_add": "project",
+	"section_add": "section",
+	"label_add":   "label",
+	"comment_add": "comment",
+}
+
+type AliasField struct {
+	Name   string
+	Entity string
+	Value  *string
+}
+
+func AliasRef(value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, "$") || len(value) == 1 {
+		return "", false
+	}
+	return value[1:], true
+}
+
+func AliasEntity(actionType string) string {
+	return aliasEntityByType[actionType]
+}
+
+func AliasFields(a *Action) []AliasField {
+	parentEntity := "task"
+	if a.Type == "project_add" {
+		parentEntity = "project"
+	}
+	return []AliasField{
+		{Name: "task_id", Entity: "task", Value: &a.TaskID},
+		{Name: "project_id", Entity: "project", Value: &a.ProjectID},
+		{Name: "section_id", Entity: "section", Value: &a.SectionID},
+		{Name: "label_id", Entity: "label", Value: &a.LabelID},
+		{Name: "comment_id", Entity: "comment", Value: &a.CommentID},
+		{Name: "parent", Entity: parentEntity, Value: &a.Parent},
+	}
+}
+
+func ValidateAliases(actions []Action) error {
+	defined := map[string]string{}
+	for i := range actions {
+		action := actions[i]
+		for _, field := range AliasFields(&action) {
+			name, ok := AliasRef(*field.Value)
+			if !ok {
+				continue
+			}
+			entity, ok := defined[name]
+			if !ok {
+				return fmt.Errorf("action %d (%s): %s references unknown alias $%s (aliases must be defined by an earlier action)", i+1, action.Type, field.Name, name)
+			}
+			if entity != field.Entity {
+				return fmt.Errorf("action %d (%s): %s references $%s, which is a %s", i+1, action.Type, field.Name, name, entity)
+			}
+		}
+		alias := strings.TrimSpace(action.Alias)
+		if alias == "" {
+			continue
+		}
+		entity := AliasEntity(action.Type)
+		if entity == "" {
+			return fmt.Errorf("action %d (%s): alias is only supported on create actions", i+1, action.Type)
+		}
+		if !aliasNamePattern.MatchString(alias) {
+			return fmt.Errorf("action %d (%s): invalid alias %q (use letters, digits, - or _)", i+1, action.Type, alias)
+		}
+		if _, exists := defined[alias]; exists {
+			return fmt.Errorf("action %d (%s): duplicate alias %q", i+1, action.Type, alias)
+		}
+		defined[alias] = entity
+	}
+	return nil
+}
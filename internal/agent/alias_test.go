@@ -0,0 +1,39 @@
+package agent
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateAliasesAcceptsForwardReferences(t *testing.T) {
+	actions := []Action{
+		{Type: "project_add", Name: "Launch", Alias: "launch"},
+		{Type: "section_add", Name: "Prep", ProjectID: "$launch", Alias: "prep"},
+		{Type: "task_add", Content: "Draft", ProjectID: "$launch", SectionID: "$prep", Alias: "draft"},
+		{Type: "task_add", Content: "Outline", Parent: "$draft"},
+		{Type: "comment_add", Content: "ctx", TaskID: "$draft"},
+	}
+	if err := ValidateAliases(actions); err != nil {
+		t.Fatalf("ValidateAliases: %v", err)
+	}
+}
+
+func TestValidateAliasesRejectsInvalidReferences(t *testing.T) {
+	cases := []struct {
+		name    string
+		actions []Action
+		want    string
+	}{
+		{"unknown", []Action{{Type: "task_complete", TaskID: "$missing"}}, "unknown alias"},
+		{"forward", []Action{{Type: "task_complete", TaskID: "$t"}, {Type: "task_add", Content: "x", Alias: "t"}}, "unknown alias"},
+		{"entity mismatch", []Action{{Type: "project_add", Name: "P", Alias: "p"}, {Type: "task_complete", TaskID: "$p"}}, "is a project"},
+		{"duplicate", []Action{{Type: "task_add", Content: "a", Alias: "t"}, {Type: "task_add", Content: "b", Alias: "t"}}, "duplicate alias"},
+		{"non create", []Action{{Type: "task_complete", TaskID: "1", Alias: "t"}}, "only supported on create"},
+	}
+	for _, tc := range cases {
+		err := ValidateAliases(tc.actions)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%s: expected %q error, got %v", tc.name, tc.want, err)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// MaxPlanVersion is the newest plan version. Version 1 plans are flat lists
+// of literal actions; version 2 adds plan-level vars, foreach expansion over
+// filter queries and when guards, all resolved before apply.
+const MaxPlanVersion = 2
+
+// ItemFields are the task fields a foreach action references as
+// {{item.<field>}}.
+var ItemFields = []string{"id", "content", "description", "project_id", "section_id", "parent_id", "due"}
+
+var (
+	templateRefPattern = regexp.MustCompile(`\{\{\s*([^{}]*?)\s*\}\}`)
+	varNamePattern     = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// RenderTemplate substitutes {{name}} with vars[name] and {{item.field}}
+// with item[field]. Unknown references are errors; substituted values are
+// not rendered again.
+func RenderTemplate(s string, vars, item map[string]string) (string, error) {
+	var renderErr error
+	out := templateRefPattern.ReplaceAllStringFunc(s, func(match string) string {
+		ref := templateRefPattern.FindStringSubmatch(match)[1]
+		value, err := lookupTemplateRef(ref, vars, item)
+		if err != nil && renderErr == nil {
+			renderErr = err
+		}
+		return value
+	})
+	return out, renderErr
+}
+
+func lookupTemplateRef(ref string, vars, item map[string]string) (string, error) {
+	if field, ok := strings.CutPrefix(ref, "item."); ok {
+		if item == nil {
+			return "", fmt.Errorf("{{%s}} is only available in foreach actions", ref)
+		}
+		value, ok := item[field]
+		if !ok {
+			return "", fmt.Errorf("unknown item field {{%s}} (fields: %s)", ref, strings.Join(ItemFields, ", "))
+		}
+		return value, nil
+	}
+	value, ok := vars[ref]
+	if !ok {
+		return "", fmt.Errorf("unknown variable {{%s}}", ref)
+	}
+	return value, nil
+}
+
+// RenderAction renders every string field of a, including foreach and when.
+func RenderAction(a Action, vars, item map[string]string) (Action, error) {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return Action{}, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return Action{}, err
+	}
+	for key, value := range fields {
+		rendered, err := renderValue(value, vars, item)
+		if err != nil {
+			return Action{}, fmt.Errorf("%s: %w", key, err)
+		}
+		fields[key] = rendered
+	}
+	data, err = json.Marshal(fields)
+	if err != nil {
+		return Action{}, err
+	}
+	var out Action
+	if err := json.Unmarshal(data, &out); err != nil {
+		return Action{}, err
+	}
+	return out, nil
+}
+
+func renderValue(value any, vars, item map[string]string) (any, error) {
+	switch v := value.(type) {
+	case string:
+		return RenderTemplate(v, vars, item)
+	case []any:
+		for i := range v {
+			rendered, err := renderValue(v[i], vars, item)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = rendered
+		}
+	}
+	return value, nil
+}
+
+// templateActions checks the v2 parts of plan and returns its actions
+// rendered with placeholder items, so the usual field checks apply.
+func templateActions(plan Plan) ([]Action, error) {
+	for name := range plan.Vars {
+		if !varNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid variable name %q", name)
+		}
+	}
+	sample := map[string]string{}
+	for _, field := range ItemFields {
+		sample[field] = "item_" + field
+	}
+	actions := make([]Action, 0, len(plan.Actions))
+	for i, a := range plan.Actions {
+		for _, guard := range []string{a.Foreach, a.When} {
+			if _, err := RenderTemplate(guard, plan.Vars, nil); err != nil {
+				return nil, fmt.Errorf("action %d (%s): %w", i+1, a.Type, err)
+			}
+		}
+		var item map[string]string
+		if strings.TrimSpace(a.Foreach) != "" {
+			if a.Alias != "" {
+				return nil, fmt.Errorf("action %d (%s): foreach actions cannot define an alias", i+1, a.Type)
+			}
+			item = sample
+		}
+		rendered, err := RenderAction(a, plan.Vars, item)
+		if err != nil {
+			return nil, fmt.Errorf("action %d (%s): %w", i+1, a.Type, err)
+		}
+		rendered.Foreach, rendered.When = "", ""
+		actions = append(actions, rendered)
+	}
+	return actions, nil
+}
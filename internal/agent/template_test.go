@@ -0,0 +1,63 @@
+package agent
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	got, err := RenderTemplate("Tag {{ label }} on {{item.content}}", map[string]string{"label": "stale"}, map[string]string{"content": "{{label}}"})
+	if err != nil || got != "Tag stale on {{label}}" {
+		t.Fatalf("RenderTemplate = %q, %v", got, err)
+	}
+	for _, tc := range []struct{ in, want string }{
+		{"{{missing}}", "unknown variable {{missing}}"},
+		{"{{item.id}}", "only available in foreach actions"},
+	} {
+		if _, err := RenderTemplate(tc.in, nil, nil); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("RenderTemplate(%q) error = %v", tc.in, err)
+		}
+	}
+	if _, err := RenderTemplate("{{item.owner}}", nil, map[string]string{"id": "1"}); err == nil || !strings.Contains(err.Error(), "unknown item field") {
+		t.Fatalf("expected unknown item field, got %v", err)
+	}
+}
+
+func TestValidatePlanVersion2(t *testing.T) {
+	valid := Plan{Version: 2, ConfirmToken: "abcd", Vars: map[string]string{"label": "stale"}, Actions: []Action{
+		{Type: "task_update", Foreach: "overdue & @waiting", When: "!@{{label}}", TaskID: "{{item.id}}", AddLabels: []string{"{{label}}"}},
+		{Type: "task_add", Alias: "note", Content: "Review {{label}}", When: "today"},
+		{Type: "comment_add", TaskID: "$note", Content: "{{label}}"},
+	}}
+	if err := ValidatePlan(valid, 0, false); err != nil {
+		t.Fatalf("ValidatePlan: %v", err)
+	}
+	cases := []struct {
+		name string
+		plan Plan
+		want string
+	}{
+		{"item outside foreach", Plan{Version: 2, Actions: []Action{{Type: "task_complete", TaskID: "{{item.id}}"}}}, "only available in foreach actions"},
+		{"unknown var in guard", Plan{Version: 2, Actions: []Action{{Type: "task_add", Content: "x", When: "@{{nope}}"}}}, "unknown variable {{nope}}"},
+		{"foreach alias", Plan{Version: 2, Actions: []Action{{Type: "task_add", Alias: "a", Content: "x", Foreach: "today"}}}, "cannot define an alias"},
+		{"missing field", Plan{Version: 2, Actions: []Action{{Type: "task_complete", Foreach: "today"}}}, "task_complete requires task_id"},
+		{"bad var name", Plan{Version: 2, Vars: map[string]string{"a-b": "x"}, Actions: []Action{{Type: "task_add", Content: "x"}}}, "invalid variable name"},
+		{"v1 foreach", Plan{Version: 1, Actions: []Action{{Type: "task_complete", TaskID: "t1", Foreach: "today"}}}, "require plan version 2"},
+		{"v1 vars", Plan{Version: 1, Vars: map[string]string{"a": "b"}, Actions: []Action{{Type: "task_add", Content: "x"}}}, "vars require plan version 2"},
+		{"v3", Plan{Version: 3, Actions: []Action{{Type: "task_add", Content: "x"}}}, "unsupported plan version 3"},
+		{"expected v1", Plan{Version: 2, Actions: []Action{{Type: "task_add", Content: "x"}}}, "expected 1"},
+	}
+	for _, tc := range cases {
+		tc.plan.ConfirmToken = "abcd"
+		expected := 0
+		if tc.name == "expected v1" {
+			expected = 1
+		}
+		if err := ValidatePlan(tc.plan, expected, false); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%s: error = %v, want %q", tc.name, err, tc.want)
+		}
+	}
+	if err := ValidatePlan(Plan{Version: 1, ConfirmToken: "abcd", Actions: []Action{{Type: "task_add", Content: "Literal {{braces}}"}}}, 1, false); err != nil {
+		t.Fatalf("v1 content is literal: %v", err)
+	}
+}
@@ -23,6 +23,12 @@ type Plan struct {
 	Summary      PlanSummary `json:"summary"`
 	Actions      []Action    `json:"actions"`
 	AppliedAt    string      `json:"applied_at,omitempty"`
+	ApplyID      string      `json:"apply_id,omitempty"`
+	// Fingerprints maps "task:<id>"-style keys of the entities the actions
+	// target to their version at planning time, for stale-plan checks.
+	Fingerprints map[string]string `json:"fingerprints,omitempty"`
+	// Vars are version 2 plan variables, referenced as {{name}}.
+	Vars map[string]string `json:"vars,omitempty"`
 }
 
 type PlanSummary struct {
@@ -35,6 +41,7 @@ type PlanSummary struct {
 
 type Action struct {
 	Type         string   `json:"type"`
+	Alias        string   `json:"alias,omitempty"`
 	TaskID       string   `json:"task_id,omitempty"`
 	ProjectID    string   `json:"project_id,omitempty"`
 	SectionID    string   `json:"section_id,omitempty"`
@@ -46,6 +53,8 @@ type Action struct {
 	Description  string   `json:"description,omitempty"`
 	Name         string   `json:"name,omitempty"`
 	Labels       []string `json:"labels,omitempty"`
+	AddLabels    []string `json:"add_labels,omitempty"`
+	RemoveLabels []string `json:"remove_labels,omitempty"`
 	Project      string   `json:"project,omitempty"`
 	Section      string   `json:"section,omitempty"`
 	Parent       string   `json:"parent,omitempty"`
@@ -61,4 +70,8 @@ type Action struct {
 	Color        string   `json:"color,omitempty"`
 	Order        int      `json:"order,omitempty"`
 	Favorite     *bool    `json:"is_favorite,omitempty"`
+	// Foreach (version 2) repeats the action for every task matching a
+	// filter query; When skips it unless its filter query matches.
+	Foreach string `json:"foreach,omitempty"`
+	When    string `json:"when,omitempty"`
 }
@@ -35,6 +35,25 @@ func ValidatePlan(plan Plan, expectedVersion int, allowEmptyActions bool) error
 	if expectedVersion > 0 && plan.Version != 0 && plan.Version != expectedVersion {
 		return fmt.Errorf("unsupported plan version %d (expected %d)", plan.Version, expectedVersion)
 	}
+	if plan.Version < 0 || plan.Version > MaxPlanVersion {
+		return fmt.Errorf("unsupported plan version %d (supported: 1-%d)", plan.Version, MaxPlanVersion)
+	}
+	actions := plan.Actions
+	if plan.Version >= 2 {
+		var err error
+		if actions, err = templateActions(plan); err != nil {
+			return err
+		}
+	} else {
+		if len(plan.Vars) > 0 {
+			return errors.New("vars require plan version 2")
+		}
+		for i, a := range actions {
+			if a.Foreach != "" || a.When != "" {
+				return fmt.Errorf("action %d (%s): foreach/when require plan version 2", i+1, a.Type)
+			}
+		}
+	}
 	allowed := map[string]struct{}{
 		"task_add":          {},
 		"task_update":       {},
@@ -57,7 +76,7 @@ func ValidatePlan(plan Plan, expectedVersion int, allowEmptyActions bool) error
 		"comment_update":    {},
 		"comment_delete":    {},
 	}
-	for _, a := range plan.Actions {
+	for _, a := range actions {
 		if _, ok := allowed[a.Type]; !ok {
 			return fmt.Errorf("unsupported action type: %s", a.Type)
 		}
@@ -65,10 +84,13 @@ func ValidatePlan(plan Plan, expectedVersion int, allowEmptyActions bool) error
 			return err
 		}
 	}
-	return nil
+	return ValidateAliases(actions)
 }
 
 func ValidateActionFields(a Action) error {
+	if a.Type != "task_update" && (len(a.AddLabels) > 0 || len(a.RemoveLabels) > 0) {
+		return fmt.Errorf("%s does not support add_labels/remove_labels", a.Type)
+	}
 	switch a.Type {
 	case "task_add":
 		if a.Content == "" {
@@ -81,6 +103,9 @@ func ValidateActionFields(a Action) error {
 		if a.Type == "task_move" && a.Project == "" && a.ProjectID == "" && a.Section == "" && a.SectionID == "" && a.Parent == "" {
 			return errors.New("task_move requires project/project_id, section/section_id, or parent")
 		}
+		if a.Type == "task_update" && len(a.Labels) > 0 && (len(a.AddLabels) > 0 || len(a.RemoveLabels) > 0) {
+			return errors.New("task_update cannot combine labels with add_labels/remove_labels")
+		}
 	case "project_add":
 		if a.Name == "" {
 			return errors.New("project_add requires name")
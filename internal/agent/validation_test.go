@@ -22,3 +22,15 @@ func TestSummarizeActions(t *testing.T) {
 		t.Fatalf("unexpected summary: %#v", s)
 	}
 }
+
+func TestValidateActionFieldsLabelEdits(t *testing.T) {
+	if err := ValidateActionFields(Action{Type: "task_update", TaskID: "t1", AddLabels: []string{"a"}, RemoveLabels: []string{"b"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ValidateActionFields(Action{Type: "task_update", TaskID: "t1", Labels: []string{"a"}, AddLabels: []string{"b"}}); err == nil {
+		t.Fatalf("expected error combining labels with add_labels")
+	}
+	if err := ValidateActionFields(Action{Type: "task_add", Content: "x", AddLabels: []string{"a"}}); err == nil {
+		t.Fatalf("expected error for add_labels on task_add")
+	}
+}
@@ -0,0 +1,255 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	CassetteRecord = "record"
+	CassetteReplay = "replay"
+)
+
+type Cassette struct {
+	Interactions []CassetteInteraction `json:"interactions"`
+}
+
+type CassetteInteraction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+type CassetteRequest struct {
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Query   string            `json:"query,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+type CassetteResponse struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+// CassetteMissError is returned on replay when no recorded interaction
+// matches a request. It is deliberately not a transport error so callers do
+// not retry or queue it.
+type CassetteMissError struct {
+	Path    string
+	Request CassetteRequest
+}
+
+func (e *CassetteMissError) Error() string {
+	msg := fmt.Sprintf("cassette %s: no recorded interaction for %s %s", e.Path, e.Request.Method, e.Request.Path)
+	if e.Request.Query != "" {
+		msg += "?" + e.Request.Query
+	}
+	return msg
+}
+
+// CassetteTransport records request/response pairs to a JSON cassette,
+// appending to any interactions already in it, or serves them back.
+// Requests match on method, path, query and body; the Authorization header
+// is never written to disk.
+type CassetteTransport struct {
+	Mode string
+	Path string
+	Next http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+func NewCassetteTransport(mode, path string, next http.RoundTripper) (*CassetteTransport, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, errors.New("cassette path is required")
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	t := &CassetteTransport{Mode: mode, Path: path, Next: next}
+	switch mode {
+	case CassetteRecord:
+		// Append to an existing cassette so a script of several commands
+		// records all of them.
+		if err := t.load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	case CassetteReplay:
+		if err := t.load(); err != nil {
+			return nil, err
+		}
+		t.used = make([]bool, len(t.cassette.Interactions))
+	default:
+		return nil, fmt.Errorf("invalid cassette mode %q (expected record or replay)", mode)
+	}
+	return t, nil
+}
+
+func (t *CassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := cassetteRequestFrom(req)
+	if err != nil {
+		return nil, err
+	}
+	if t.Mode == CassetteReplay {
+		return t.replay(req, recorded)
+	}
+	resp, err := t.Next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.cassette.Interactions = append(t.cassette.Interactions, CassetteInteraction{
+		Request: recorded,
+		Response: CassetteResponse{
+			Status:  resp.StatusCode,
+			Headers: flattenHeaders(resp.Header),
+			Body:    string(body),
+		},
+	})
+	if err := t.save(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// replay serves the first unused matching interaction. Once those run out,
+// GET requests may reuse the last match; anything else fails.
+func (t *CassetteTransport) replay(req *http.Request, recorded CassetteRequest) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	reuse := -1
+	for idx, interaction := range t.cassette.Interactions {
+		if !cassetteRequestsMatch(interaction.Request, recorded) {
+			continue
+		}
+		if !t.used[idx] {
+			t.used[idx] = true
+			return cassetteResponse(req, interaction.Response), nil
+		}
+		reuse = idx
+	}
+	if reuse >= 0 && recorded.Method == http.MethodGet {
+		return cassetteResponse(req, t.cassette.Interactions[reuse].Response), nil
+	}
+	return nil, &CassetteMissError{Path: t.Path, Request: recorded}
+}
+
+func (t *CassetteTransport) load() error {
+	data, err := os.ReadFile(t.Path)
+	if err != nil {
+		return fmt.Errorf("read cassette: %w", err)
+	}
+	if err := json.Unmarshal(data, &t.cassette); err != nil {
+		return fmt.Errorf("decode cassette %s: %w", t.Path, err)
+	}
+	return nil
+}
+
+func (t *CassetteTransport) save() error {
+	if err := os.MkdirAll(filepath.Dir(t.Path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(t.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := t.Path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, t.Path)
+}
+
+func cassetteRequestFrom(req *http.Request) (CassetteRequest, error) {
+	var body []byte
+	if req.Body != nil {
+		data, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return CassetteRequest{}, err
+		}
+		body = data
+		req.Body = io.NopCloser(bytes.NewReader(data))
+	}
+	headers := flattenHeaders(req.Header)
+	delete(headers, "Authorization")
+	delete(headers, "X-Request-Id")
+	return CassetteRequest{
+		Method:  req.Method,
+		Path:    req.URL.Path,
+		Query:   req.URL.Query().Encode(),
+		Headers: headers,
+		Body:    canonicalCassetteBody(req.Header.Get("Content-Type"), body),
+	}, nil
+}
+
+// canonicalCassetteBody re-encodes JSON and form bodies so map ordering does
+// not affect matching.
+func canonicalCassetteBody(contentType string, body []byte) string {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return ""
+	}
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		if values, err := url.ParseQuery(string(body)); err == nil {
+			return values.Encode()
+		}
+	}
+	var decoded any
+	if err := json.Unmarshal(body, &decoded); err == nil {
+		if data, err := json.Marshal(decoded); err == nil {
+			return string(data)
+		}
+	}
+	return string(body)
+}
+
+func cassetteRequestsMatch(a, b CassetteRequest) bool {
+	return a.Method == b.Method && a.Path == b.Path && a.Query == b.Query && a.Body == b.Body
+}
+
+func cassetteResponse(req *http.Request, recorded CassetteResponse) *http.Response {
+	header := http.Header{}
+	for key, value := range recorded.Headers {
+		header.Set(key, value)
+	}
+	return &http.Response{
+		StatusCode: recorded.Status,
+		Status:     fmt.Sprintf("%d %s", recorded.Status, http.StatusText(recorded.Status)),
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(recorded.Body)),
+		Request:    req,
+	}
+}
+
+func flattenHeaders(h http.Header) map[string]string {
+	if len(h) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(h))
+	for key, values := range h {
+		if len(values) > 0 {
+			out[http.CanonicalHeaderKey(key)] = values[0]
+		}
+	}
+	return out
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCassetteRecordThenReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder, err := NewCassetteTransport(CassetteRecord, path, roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		payload := `{"id":"t1"}`
+		if r.Method == http.MethodGet {
+			payload = `{"results":[],"next_cursor":""}`
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString(payload)),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+		}, nil
+	}))
+	if err != nil {
+		t.Fatalf("NewCassetteTransport record: %v", err)
+	}
+	client := NewClient("https://example.com", "secret-token", time.Second)
+	client.HTTP.Transport = recorder
+	if _, err := client.Post(context.Background(), "/tasks", nil, map[string]any{"content": "a", "priority": 4}, nil, true); err != nil {
+		t.Fatalf("record post: %v", err)
+	}
+	if _, err := client.Get(context.Background(), "/tasks", nil, nil); err != nil {
+		t.Fatalf("record get: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read cassette: %v", err)
+	}
+	if strings.Contains(string(data), "secret-token") {
+		t.Fatalf("authorization leaked into cassette: %s", data)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Fatalf("expected the temp file to be renamed into place, got %v", err)
+	}
+
+	player, err := NewCassetteTransport(CassetteReplay, path, nil)
+	if err != nil {
+		t.Fatalf("NewCassetteTransport replay: %v", err)
+	}
+	client = NewClient("https://example.com", "other-token", time.Second)
+	client.HTTP.Transport = player
+	var created struct {
+		ID string `json:"id"`
+	}
+	if _, err := client.Post(context.Background(), "/tasks", nil, map[string]any{"priority": 4, "content": "a"}, &created, true); err != nil {
+		t.Fatalf("replay post: %v", err)
+	}
+	if created.ID != "t1" {
+		t.Fatalf("unexpected replayed response: %#v", created)
+	}
+	if _, err := client.Get(context.Background(), "/tasks", nil, nil); err != nil {
+		t.Fatalf("replay get: %v", err)
+	}
+
+	_, err = client.Post(context.Background(), "/tasks", nil, map[string]any{"content": "b"}, nil, true)
+	var missErr *CassetteMissError
+	if !errors.As(err, &missErr) {
+		t.Fatalf("expected cassette miss, got %v", err)
+	}
+	if IsTransportError(err) {
+		t.Fatalf("cassette miss must not count as a transport error")
+	}
+}
+
+func TestNewCassetteTransportRejectsUnknownMode(t *testing.T) {
+	if _, err := NewCassetteTransport("rewind", filepath.Join(t.TempDir(), "c.json"), nil); err == nil {
+		t.Fatalf("expected invalid mode error")
+	}
+	if _, err := NewCassetteTransport(CassetteReplay, filepath.Join(t.TempDir(), "missing.json"), nil); err == nil {
+		t.Fatalf("expected missing cassette error")
+	}
+}
+
+func TestCassetteRecordAppendsAcrossTransports(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	next := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"path":"` + r.URL.Path + `"}`)),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+		}, nil
+	})
+	for _, endpoint := range []string{"/projects", "/labels"} {
+		recorder, err := NewCassetteTransport(CassetteRecord, path, next)
+		if err != nil {
+			t.Fatalf("NewCassetteTransport record: %v", err)
+		}
+		client := NewClient("https://example.com", "secret-token", time.Second)
+		client.HTTP.Transport = recorder
+		if _, err := client.Get(context.Background(), endpoint, nil, nil); err != nil {
+			t.Fatalf("record %s: %v", endpoint, err)
+		}
+	}
+
+	player, err := NewCassetteTransport(CassetteReplay, path, nil)
+	if err != nil {
+		t.Fatalf("NewCassetteTransport replay: %v", err)
+	}
+	if len(player.cassette.Interactions) != 2 {
+		t.Fatalf("expected both runs recorded, got %d interactions", len(player.cassette.Interactions))
+	}
+	client := NewClient("https://example.com", "", time.Second)
+	client.HTTP.Transport = player
+	for _, endpoint := range []string{"/projects", "/labels"} {
+		if _, err := client.Get(context.Background(), endpoint, nil, nil); err != nil {
+			t.Fatalf("replay %s: %v", endpoint, err)
+		}
+	}
+
+	if err := os.WriteFile(path, []byte("not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewCassetteTransport(CassetteRecord, path, next); err == nil {
+		t.Fatalf("expected decode error for a corrupt cassette")
+	}
+}
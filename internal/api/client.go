@@ -18,6 +18,9 @@ type Client struct {
 	BaseURL string
 	Token   string
 	HTTP    *http.Client
+	Limiter *RateLimiter
+	// OnThrottle, when set, is called before every limiter or Retry-After wait.
+	OnThrottle func(ThrottleEvent)
 }
 
 type APIError struct {
@@ -28,6 +31,9 @@ type APIError struct {
 
 const maxRetries = 2
 
+// MaxThrottleWait caps how long a single Retry-After may pause requests.
+const MaxThrottleWait = 60 * time.Second
+
 var waitForRetry = func(ctx context.Context, delay time.Duration) error {
 	timer := time.NewTimer(delay)
 	defer timer.Stop()
@@ -46,6 +52,22 @@ func (e *APIError) Error() string {
 	return fmt.Sprintf("api error: status %d: %s", e.Status, e.Message)
 }
 
+// Tag returns the Todoist error_tag (for example INVALID_SEARCH_QUERY) carried
+// by a JSON error body or a "TAG: message" sync status, or "".
+func (e *APIError) Tag() string {
+	var body struct {
+		ErrorTag string `json:"error_tag"`
+	}
+	if err := json.Unmarshal([]byte(e.Message), &body); err == nil {
+		return body.ErrorTag
+	}
+	tag, _, ok := strings.Cut(e.Message, ": ")
+	if !ok || tag == "" || strings.ToUpper(tag) != tag || strings.ContainsAny(tag, " \t") {
+		return ""
+	}
+	return tag
+}
+
 func NewClient(baseURL, token string, timeout time.Duration) *Client {
 	if baseURL == "" {
 		baseURL = "https://api.todoist.com/api/v1"
@@ -56,6 +78,7 @@ func NewClient(baseURL, token string, timeout time.Duration) *Client {
 		HTTP: &http.Client{
 			Timeout: timeout,
 		},
+		Limiter: NewDefaultRateLimiter(),
 	}
 }
 
@@ -67,6 +90,15 @@ func (c *Client) Post(ctx context.Context, path string, query url.Values, body a
 	return c.doJSON(ctx, http.MethodPost, path, query, body, out, includeRequestID)
 }
 
+// PostWithRequestID posts with a caller-assigned X-Request-Id so a replayed
+// mutation keeps the idempotency key it was first sent with.
+func (c *Client) PostWithRequestID(ctx context.Context, path string, query url.Values, body any, out any, requestID string) (string, error) {
+	if strings.TrimSpace(requestID) == "" {
+		requestID = NewRequestID()
+	}
+	return c.doJSONWithRequestID(ctx, http.MethodPost, path, query, body, out, requestID)
+}
+
 func (c *Client) Delete(ctx context.Context, path string, query url.Values) (string, error) {
 	return c.doJSON(ctx, http.MethodDelete, path, query, nil, nil, true)
 }
@@ -98,7 +130,7 @@ func (c *Client) SyncWorkspaces(ctx context.Context) ([]Workspace, string, error
 	if c.Token != "" {
 		req.Header.Set("Authorization", "Bearer "+c.Token)
 	}
-	resp, err := c.HTTP.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return nil, requestID, err
 	}
@@ -134,7 +166,7 @@ func (c *Client) SyncCurrentUserID(ctx context.Context) (string, string, error)
 	if c.Token != "" {
 		req.Header.Set("Authorization", "Bearer "+c.Token)
 	}
-	resp, err := c.HTTP.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return "", requestID, err
 	}
@@ -162,6 +194,14 @@ func (c *Client) SyncCurrentUserID(ctx context.Context) (string, string, error)
 }
 
 func (c *Client) doJSON(ctx context.Context, method, path string, query url.Values, body any, out any, includeRequestID bool) (string, error) {
+	requestID := ""
+	if includeRequestID {
+		requestID = NewRequestID()
+	}
+	return c.doJSONWithRequestID(ctx, method, path, query, body, out, requestID)
+}
+
+func (c *Client) doJSONWithRequestID(ctx context.Context, method, path string, query url.Values, body any, out any, requestID string) (string, error) {
 	fullURL, err := c.buildURL(path, query)
 	if err != nil {
 		return "", err
@@ -173,10 +213,7 @@ func (c *Client) doJSON(ctx context.Context, method, path string, query url.Valu
 			return "", fmt.Errorf("encode body: %w", err)
 		}
 	}
-	requestID := ""
-	if includeRequestID {
-		requestID = NewRequestID()
-	}
+	includeRequestID := requestID != ""
 	for attempt := 0; attempt <= maxRetries; attempt++ {
 		var buf io.Reader
 		if payload != nil {
@@ -196,7 +233,7 @@ func (c *Client) doJSON(ctx context.Context, method, path string, query url.Valu
 			req.Header.Set("X-Request-Id", requestID)
 		}
 
-		resp, err := c.HTTP.Do(req)
+		resp, err := c.send(req)
 		if err != nil {
 			if shouldRetryTransport(method, includeRequestID, err) && attempt < maxRetries {
 				if err := waitForRetry(ctx, retryDelay(attempt, "")); err != nil {
@@ -211,7 +248,9 @@ func (c *Client) doJSON(ctx context.Context, method, path string, query url.Valu
 			msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4*1024))
 			_ = resp.Body.Close()
 			if shouldRetryStatus(method, includeRequestID, resp.StatusCode) && attempt < maxRetries {
-				if err := waitForRetry(ctx, retryDelay(attempt, resp.Header.Get("Retry-After"))); err != nil {
+				delay := retryDelay(attempt, resp.Header.Get("Retry-After"))
+				c.reportThrottle(ThrottleEvent{Path: path, Wait: delay, Reason: "retry"})
+				if err := waitForRetry(ctx, delay); err != nil {
 					return requestID, err
 				}
 				continue
@@ -239,6 +278,22 @@ func (c *Client) doJSON(ctx context.Context, method, path string, query url.Valu
 	return requestID, errors.New("exhausted retries")
 }
 
+// send passes every request through the rate limiter and pauses the limiter
+// when the API answers 429 with a Retry-After header. The HTTP client's
+// timeout starts once throttle returns, so waiting never eats into it.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	if err := c.throttle(req.Context(), req.URL.Path); err != nil {
+		return nil, err
+	}
+	resp, err := c.HTTP.Do(req)
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		if secs, perr := strconv.Atoi(strings.TrimSpace(resp.Header.Get("Retry-After"))); perr == nil {
+			c.Limiter.PauseFor(min(time.Duration(secs)*time.Second, MaxThrottleWait))
+		}
+	}
+	return resp, err
+}
+
 func (c *Client) buildURL(path string, query url.Values) (string, error) {
 	u, err := url.Parse(c.BaseURL + path)
 	if err != nil {
@@ -263,7 +318,28 @@ func shouldRetryStatus(method string, includeRequestID bool, status int) bool {
 }
 
 func shouldRetryTransport(method string, includeRequestID bool, err error) bool {
-	return isRetrySafe(method, includeRequestID) && err != nil
+	var missErr *CassetteMissError
+	return isRetrySafe(method, includeRequestID) && err != nil && !errors.As(err, &missErr)
+}
+
+func IsTransportError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return false
+	}
+	var missErr *CassetteMissError
+	if errors.As(err, &missErr) {
+		return false
+	}
+	// A cancelled request (Ctrl-C) was given up on, not lost to the network.
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) && urlErr.Op != "parse"
 }
 
 func isRetrySafe(method string, includeRequestID bool) bool {
@@ -272,11 +348,7 @@ func isRetrySafe(method string, includeRequestID bool) bool {
 
 func retryDelay(attempt int, retryAfter string) time.Duration {
 	if secs, err := strconv.Atoi(strings.TrimSpace(retryAfter)); err == nil && secs >= 0 {
-		delay := time.Duration(secs) * time.Second
-		if delay > 3*time.Second {
-			return 3 * time.Second
-		}
-		return delay
+		return min(time.Duration(secs)*time.Second, MaxThrottleWait)
 	}
 	delay := 200 * time.Millisecond
 	for i := 0; i < attempt; i++ {
@@ -77,6 +77,57 @@ func TestClientPostRequestID(t *testing.T) {
 	}
 }
 
+func TestClientPostWithRequestIDReusesID(t *testing.T) {
+	client := NewClient("https://example.com", "token", 2*time.Second)
+	client.HTTP = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if got := r.Header.Get("X-Request-Id"); got != "req-queued" {
+			t.Fatalf("unexpected request id: %q", got)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString(`{}`)),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+		}, nil
+	})}
+
+	reqID, err := client.PostWithRequestID(context.Background(), "/tasks", nil, map[string]any{"content": "a"}, nil, "req-queued")
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	if reqID != "req-queued" {
+		t.Fatalf("unexpected returned request id: %q", reqID)
+	}
+}
+
+func TestIsTransportError(t *testing.T) {
+	if !IsTransportError(&url.Error{Op: "Post", URL: "https://example.com", Err: errors.New("connection refused")}) {
+		t.Fatalf("expected url error to be a transport error")
+	}
+	if IsTransportError(&APIError{Status: http.StatusBadRequest, Message: "bad"}) {
+		t.Fatalf("api error must not be a transport error")
+	}
+	if IsTransportError(&url.Error{Op: "Post", URL: "https://example.com", Err: context.Canceled}) {
+		t.Fatalf("a cancelled request must not be a transport error")
+	}
+	if IsTransportError(nil) {
+		t.Fatalf("nil must not be a transport error")
+	}
+}
+
+func TestAPIErrorTag(t *testing.T) {
+	cases := map[string]string{
+		`{"error":"The search query is incorrect","error_tag":"INVALID_SEARCH_QUERY","http_code":400}`: "INVALID_SEARCH_QUERY",
+		"ITEM_NOT_FOUND: Item not found": "ITEM_NOT_FOUND",
+		"Invalid argument: content":      "",
+		"boom":                           "",
+	}
+	for message, want := range cases {
+		if got := (&APIError{Status: 400, Message: message}).Tag(); got != want {
+			t.Fatalf("Tag(%q)=%q, want %q", message, got, want)
+		}
+	}
+}
+
 func TestClientQuickAdd(t *testing.T) {
 	client := NewClient("https://example.com", "token", 2*time.Second)
 	client.HTTP = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
@@ -286,7 +337,8 @@ func TestRetryDelay(t *testing.T) {
 		want       time.Duration
 	}{
 		{name: "retry-after seconds", retryAfter: "2", want: 2 * time.Second},
-		{name: "retry-after capped to 3s", retryAfter: "99", want: 3 * time.Second},
+		{name: "retry-after honored", retryAfter: "30", want: 30 * time.Second},
+		{name: "retry-after capped to 60s", retryAfter: "999", want: 60 * time.Second},
 		{name: "attempt0 backoff", attempt: 0, want: 200 * time.Millisecond},
 		{name: "attempt1 backoff", attempt: 1, want: 400 * time.Millisecond},
 		{name: "attempt2 backoff", attempt: 2, want: 800 * time.Millisecond},
@@ -43,23 +43,7 @@ func (c *Client) FetchLiveNotifications(ctx context.Context) ([]Notification, st
 		if !ok {
 			continue
 		}
-		n := Notification{
-			ID:               stringifyAny(m["id"]),
-			Type:             firstNonEmpty(stringifyAny(m["notification_type"]), stringifyAny(m["type"])),
-			IsUnread:         boolAny(m["is_unread"]),
-			IsDeleted:        boolAny(m["is_deleted"]),
-			CreatedAt:        firstNonEmpty(stringifyAny(m["created_at"]), stringifyAny(m["created"])),
-			FromUserID:       stringifyAny(m["from_uid"]),
-			ProjectID:        stringifyAny(m["project_id"]),
-			ProjectName:      stringifyAny(m["project_name"]),
-			TaskID:           stringifyAny(m["item_id"]),
-			TaskContent:      stringifyAny(m["item_content"]),
-			InvitationID:     stringifyAny(m["invitation_id"]),
-			InvitationSecret: stringifyAny(m["invitation_secret"]),
-		}
-		if fromUser, ok := m["from_user"].(map[string]any); ok {
-			n.FromUserName = firstNonEmpty(stringifyAny(fromUser["full_name"]), stringifyAny(fromUser["name"]))
-		}
+		n := notificationFromMap(m)
 		if n.IsDeleted {
 			continue
 		}
@@ -68,6 +52,27 @@ func (c *Client) FetchLiveNotifications(ctx context.Context) ([]Notification, st
 	return notifications, requestID, nil
 }
 
+func notificationFromMap(m map[string]any) Notification {
+	n := Notification{
+		ID:               stringifyAny(m["id"]),
+		Type:             firstNonEmpty(stringifyAny(m["notification_type"]), stringifyAny(m["type"])),
+		IsUnread:         boolAny(m["is_unread"]),
+		IsDeleted:        boolAny(m["is_deleted"]),
+		CreatedAt:        firstNonEmpty(stringifyAny(m["created_at"]), stringifyAny(m["created"])),
+		FromUserID:       stringifyAny(m["from_uid"]),
+		ProjectID:        stringifyAny(m["project_id"]),
+		ProjectName:      stringifyAny(m["project_name"]),
+		TaskID:           stringifyAny(m["item_id"]),
+		TaskContent:      stringifyAny(m["item_content"]),
+		InvitationID:     stringifyAny(m["invitation_id"]),
+		InvitationSecret: stringifyAny(m["invitation_secret"]),
+	}
+	if fromUser, ok := m["from_user"].(map[string]any); ok {
+		n.FromUserName = firstNonEmpty(stringifyAny(fromUser["full_name"]), stringifyAny(fromUser["name"]))
+	}
+	return n
+}
+
 func (c *Client) MarkNotificationsRead(ctx context.Context, ids []string) (string, error) {
 	filtered := make([]string, 0, len(ids))
 	for _, id := range ids {
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// PageEvent describes one page fetched by a Pager.
+type PageEvent struct {
+	Path      string
+	Page      int
+	Items     int
+	Total     int
+	HasMore   bool
+	RequestID string
+}
+
+// Pager walks a cursor-paginated endpoint one page at a time. Callers stop
+// early simply by not calling Next again; Cursor then resumes after the last
+// page fetched. MaxItems caps the total, shrinking the final request's limit
+// so no fetched item is dropped.
+type Pager[T any] struct {
+	MaxItems int
+	OnPage   func(PageEvent)
+
+	client    *Client
+	path      string
+	query     url.Values
+	cursor    string
+	done      bool
+	pages     int
+	total     int
+	requestID string
+}
+
+func NewPager[T any](client *Client, path string, query url.Values) *Pager[T] {
+	q := url.Values{}
+	for key, values := range query {
+		q[key] = append([]string(nil), values...)
+	}
+	cursor := q.Get("cursor")
+	q.Del("cursor")
+	return &Pager[T]{client: client, path: path, query: q, cursor: cursor}
+}
+
+// Done reports whether the endpoint or the MaxItems cap is exhausted.
+func (p *Pager[T]) Done() bool {
+	return p.done
+}
+
+// Cursor returns the cursor of the next unfetched page, or "" once the
+// endpoint is exhausted. It stays set when MaxItems stopped the pager.
+func (p *Pager[T]) Cursor() string {
+	return p.cursor
+}
+
+// RequestID returns the request ID of the most recent page.
+func (p *Pager[T]) RequestID() string {
+	return p.requestID
+}
+
+// Next fetches the following page. It returns nil once Done is true.
+func (p *Pager[T]) Next(ctx context.Context) ([]T, error) {
+	if p.done {
+		return nil, nil
+	}
+	query := url.Values{}
+	for key, values := range p.query {
+		query[key] = values
+	}
+	if p.cursor != "" {
+		query.Set("cursor", p.cursor)
+	}
+	if p.MaxItems > 0 {
+		remaining := p.MaxItems - p.total
+		if limit, err := strconv.Atoi(query.Get("limit")); err != nil || limit > remaining {
+			query.Set("limit", strconv.Itoa(remaining))
+		}
+	}
+	var page Paginated[T]
+	reqID, err := p.client.Get(ctx, p.path, query, &page)
+	if err != nil {
+		return nil, err
+	}
+	p.requestID = reqID
+	p.pages++
+	p.total += len(page.Results)
+	p.cursor = page.NextCursor
+	p.done = p.cursor == "" || (p.MaxItems > 0 && p.total >= p.MaxItems)
+	if p.OnPage != nil {
+		p.OnPage(PageEvent{
+			Path:      p.path,
+			Page:      p.pages,
+			Items:     len(page.Results),
+			Total:     p.total,
+			HasMore:   p.cursor != "",
+			RequestID: reqID,
+		})
+	}
+	return page.Results, nil
+}
+
+// All fetches every remaining page with the same context.
+func (p *Pager[T]) All(ctx context.Context) ([]T, error) {
+	var out []T
+	for !p.Done() {
+		items, err := p.Next(ctx)
+		if err != nil {
+			return out, err
+		}
+		out = append(out, items...)
+	}
+	return out, nil
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestPagerFetchesLazilyAndResumes(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			_, _ = w.Write([]byte(`{"results":[{"id":"1"},{"id":"2"}],"next_cursor":"c1"}`))
+		case "c1":
+			_, _ = w.Write([]byte(`{"results":[{"id":"3"}],"next_cursor":null}`))
+		}
+	}))
+	defer ts.Close()
+
+	var events []PageEvent
+	pager := NewPager[Task](NewClient(ts.URL, "token", time.Second), "/tasks", url.Values{"limit": {"2"}})
+	pager.OnPage = func(event PageEvent) { events = append(events, event) }
+	page, err := pager.Next(context.Background())
+	if err != nil {
+		t.Fatalf("first page: %v", err)
+	}
+	if len(page) != 2 || requests != 1 || pager.Done() || pager.Cursor() != "c1" {
+		t.Fatalf("unexpected first page state: page=%v requests=%d cursor=%q", page, requests, pager.Cursor())
+	}
+
+	resumed := NewPager[Task](NewClient(ts.URL, "token", time.Second), "/tasks", url.Values{"cursor": {pager.Cursor()}})
+	rest, err := resumed.All(context.Background())
+	if err != nil {
+		t.Fatalf("resume: %v", err)
+	}
+	if len(rest) != 1 || rest[0].ID != "3" || !resumed.Done() || resumed.Cursor() != "" {
+		t.Fatalf("unexpected resumed results: %#v", rest)
+	}
+	if len(events) != 1 || events[0].Page != 1 || events[0].Items != 2 || !events[0].HasMore {
+		t.Fatalf("unexpected page events: %#v", events)
+	}
+	if page, err := resumed.Next(context.Background()); page != nil || err != nil || requests != 2 {
+		t.Fatalf("expected no request after done, got %v %v requests=%d", page, err, requests)
+	}
+}
+
+func TestPagerMaxItemsShrinksFinalLimit(t *testing.T) {
+	var limits []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limits = append(limits, r.URL.Query().Get("limit"))
+		if r.URL.Query().Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"results":[{"id":"1"},{"id":"2"}],"next_cursor":"c1"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"results":[{"id":"3"}],"next_cursor":"c2"}`))
+	}))
+	defer ts.Close()
+
+	pager := NewPager[Task](NewClient(ts.URL, "token", time.Second), "/tasks", url.Values{"limit": {"2"}})
+	pager.MaxItems = 3
+	items, err := pager.All(context.Background())
+	if err != nil {
+		t.Fatalf("all: %v", err)
+	}
+	if len(items) != 3 || len(limits) != 2 || limits[0] != "2" || limits[1] != "1" {
+		t.Fatalf("unexpected capped fetch: items=%d limits=%v", len(items), limits)
+	}
+	if !pager.Done() || pager.Cursor() != "c2" {
+		t.Fatalf("expected capped pager to keep resume cursor, got done=%v cursor=%q", pager.Done(), pager.Cursor())
+	}
+}
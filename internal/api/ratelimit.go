@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Todoist documents a per-user limit of 1000 requests per 15 minutes.
+const (
+	DefaultRateLimitRequests = 1000
+	DefaultRateLimitWindow   = 15 * time.Minute
+	DefaultRateLimitBurst    = 50
+)
+
+// RateLimiter is a token bucket shared by every request a Client sends.
+// A 429 Retry-After pauses the whole bucket so parallel workers back off
+// together instead of each tripping the limit.
+type RateLimiter struct {
+	mu          sync.Mutex
+	capacity    float64
+	tokens      float64
+	perSecond   float64
+	last        time.Time
+	pausedUntil time.Time
+	now         func() time.Time
+}
+
+// ThrottleEvent describes a wait imposed before sending a request.
+type ThrottleEvent struct {
+	Path   string
+	Wait   time.Duration
+	Reason string
+}
+
+func NewRateLimiter(requests int, window time.Duration, burst int) *RateLimiter {
+	if requests <= 0 || window <= 0 {
+		return nil
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+	return &RateLimiter{
+		capacity:  float64(burst),
+		tokens:    float64(burst),
+		perSecond: float64(requests) / window.Seconds(),
+		now:       time.Now,
+	}
+}
+
+func NewDefaultRateLimiter() *RateLimiter {
+	return NewRateLimiter(DefaultRateLimitRequests, DefaultRateLimitWindow, DefaultRateLimitBurst)
+}
+
+// Reserve takes one token and returns how long the caller must wait before
+// sending, and whether the wait comes from a Retry-After pause.
+func (l *RateLimiter) Reserve() (time.Duration, bool) {
+	if l == nil {
+		return 0, false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.perSecond
+		if l.tokens > l.capacity {
+			l.tokens = l.capacity
+		}
+	}
+	l.last = now
+	l.tokens--
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.perSecond * float64(time.Second))
+	}
+	if pause := l.pausedUntil.Sub(now); pause > wait {
+		return pause, true
+	}
+	return wait, false
+}
+
+// PauseFor blocks new reservations for d, as requested by a Retry-After header.
+func (l *RateLimiter) PauseFor(d time.Duration) {
+	if l == nil || d <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	until := l.now().Add(d)
+	if until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+func (c *Client) throttle(ctx context.Context, path string) error {
+	wait, paused := c.Limiter.Reserve()
+	if wait <= 0 {
+		return nil
+	}
+	reason := "rate_limit"
+	if paused {
+		reason = "retry_after"
+	}
+	c.reportThrottle(ThrottleEvent{Path: path, Wait: wait, Reason: reason})
+	return waitForRetry(ctx, wait)
+}
+
+func (c *Client) reportThrottle(event ThrottleEvent) {
+	if c.OnThrottle != nil {
+		c.OnThrottle(event)
+	}
+}
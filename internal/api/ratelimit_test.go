@@ -0,0 +1,97 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterReserveRefillsAndPauses(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(60, time.Minute, 2)
+	limiter.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if wait, _ := limiter.Reserve(); wait != 0 {
+			t.Fatalf("burst reservation %d waited %v", i, wait)
+		}
+	}
+	if wait, paused := limiter.Reserve(); wait != time.Second || paused {
+		t.Fatalf("expected 1s token wait, got %v paused=%v", wait, paused)
+	}
+	now = now.Add(3 * time.Second)
+	if wait, _ := limiter.Reserve(); wait != 0 {
+		t.Fatalf("expected refilled token, waited %v", wait)
+	}
+	limiter.PauseFor(10 * time.Second)
+	if wait, paused := limiter.Reserve(); wait != 10*time.Second || !paused {
+		t.Fatalf("expected retry-after pause, got %v paused=%v", wait, paused)
+	}
+}
+
+func TestClientReportsThrottleAndPausesOn429(t *testing.T) {
+	origWait := waitForRetry
+	var waits []time.Duration
+	waitForRetry = func(ctx context.Context, delay time.Duration) error {
+		waits = append(waits, delay)
+		return nil
+	}
+	defer func() { waitForRetry = origWait }()
+
+	calls := 0
+	client := NewClient("https://example.com", "token", time.Second)
+	client.HTTP = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Body:       io.NopCloser(bytes.NewBufferString(`{}`)),
+				Header:     http.Header{"Retry-After": []string{"7"}},
+			}, nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(`{}`)), Header: http.Header{}}, nil
+	})}
+	var events []ThrottleEvent
+	client.OnThrottle = func(event ThrottleEvent) { events = append(events, event) }
+
+	if _, err := client.Get(context.Background(), "/tasks", nil, nil); err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected one retry, got %d calls", calls)
+	}
+	if len(events) == 0 || events[0].Reason != "retry" || events[0].Wait != 7*time.Second || events[0].Path != "/tasks" {
+		t.Fatalf("unexpected throttle events: %#v", events)
+	}
+	if len(waits) == 0 || waits[0] != 7*time.Second {
+		t.Fatalf("expected Retry-After wait of 7s, got %v", waits)
+	}
+}
+
+func TestThrottleWaitDoesNotConsumeRequestTimeout(t *testing.T) {
+	client := NewClient("https://example.com", "token", 30*time.Millisecond)
+	client.HTTP.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(`{}`)), Header: http.Header{}}, nil
+	})
+	client.Limiter.PauseFor(80 * time.Millisecond)
+	if _, err := client.Get(context.Background(), "/tasks", nil, nil); err != nil {
+		t.Fatalf("expected the timeout to start after the throttle wait: %v", err)
+	}
+
+	client.HTTP.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		<-r.Context().Done()
+		return nil, r.Context().Err()
+	})
+	started := time.Now()
+	_, err := client.Get(context.Background(), "/tasks", nil, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Fatalf("unthrottled request ran past its timeout: %v", elapsed)
+	}
+}
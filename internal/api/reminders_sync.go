@@ -142,9 +142,33 @@ func (c *Client) DeleteReminder(ctx context.Context, id string) (string, error)
 }
 
 func (c *Client) syncRequest(ctx context.Context, formValues map[string]string) (reminderSyncResponse, string, error) {
+	data, requestID, err := c.syncRaw(ctx, formValues)
+	if err != nil {
+		return reminderSyncResponse{}, requestID, err
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return reminderSyncResponse{}, requestID, fmt.Errorf("decode sync response: %w", err)
+	}
+	var payload reminderSyncResponse
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return reminderSyncResponse{}, requestID, fmt.Errorf("decode sync response: %w", err)
+	}
+	payload.ExtraData = raw
+	if payload.Error != "" {
+		msg := payload.Error
+		if payload.ErrorTag != "" {
+			msg = payload.ErrorTag + ": " + payload.Error
+		}
+		return reminderSyncResponse{}, requestID, &APIError{Status: 400, Message: msg, RequestID: requestID}
+	}
+	return payload, requestID, nil
+}
+
+func (c *Client) syncRaw(ctx context.Context, formValues map[string]string) ([]byte, string, error) {
 	fullURL, err := c.buildURL("/sync", nil)
 	if err != nil {
-		return reminderSyncResponse{}, "", err
+		return nil, "", err
 	}
 	requestID := NewRequestID()
 	form := url.Values{}
@@ -153,37 +177,24 @@ func (c *Client) syncRequest(ctx context.Context, formValues map[string]string)
 	}
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fullURL, strings.NewReader(form.Encode()))
 	if err != nil {
-		return reminderSyncResponse{}, requestID, err
+		return nil, requestID, err
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Set("X-Request-Id", requestID)
 	if c.Token != "" {
 		req.Header.Set("Authorization", "Bearer "+c.Token)
 	}
-	resp, err := c.HTTP.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
-		return reminderSyncResponse{}, requestID, err
+		return nil, requestID, err
 	}
 	defer resp.Body.Close()
-	data, _ := io.ReadAll(io.LimitReader(resp.Body, 256*1024))
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxSyncResponseBytes+1))
 	if resp.StatusCode >= 400 {
-		return reminderSyncResponse{}, requestID, &APIError{Status: resp.StatusCode, Message: strings.TrimSpace(string(data)), RequestID: requestID}
+		return nil, requestID, &APIError{Status: resp.StatusCode, Message: strings.TrimSpace(string(data)), RequestID: requestID}
 	}
-	var raw map[string]any
-	if err := json.Unmarshal(data, &raw); err != nil {
-		return reminderSyncResponse{}, requestID, fmt.Errorf("decode sync response: %w", err)
+	if len(data) > maxSyncResponseBytes {
+		return nil, requestID, fmt.Errorf("sync response too large (over %d MB)", maxSyncResponseBytes>>20)
 	}
-	var payload reminderSyncResponse
-	if err := json.Unmarshal(data, &payload); err != nil {
-		return reminderSyncResponse{}, requestID, fmt.Errorf("decode sync response: %w", err)
-	}
-	payload.ExtraData = raw
-	if payload.Error != "" {
-		msg := payload.Error
-		if payload.ErrorTag != "" {
-			msg = payload.ErrorTag + ": " + payload.Error
-		}
-		return reminderSyncResponse{}, requestID, &APIError{Status: 400, Message: msg, RequestID: requestID}
-	}
-	return payload, requestID, nil
+	return data, requestID, nil
 }
@@ -0,0 +1,327 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const maxSyncResponseBytes = 32 << 20
+
+var SyncResourceTypes = []string{
+	"items",
+	"projects",
+	"sections",
+	"labels",
+	"filters",
+	"notes",
+	"reminders",
+	"live_notifications",
+}
+
+type SyncNote struct {
+	Comment
+	ItemID    string `json:"item_id,omitempty"`
+	ProjectID string `json:"project_id,omitempty"`
+}
+
+// SyncState is the locally persisted Sync API snapshot. Deltas returned for
+// SyncToken are merged into the resource lists by id.
+type SyncState struct {
+	SyncToken         string         `json:"sync_token"`
+	FullSyncAt        string         `json:"full_sync_at,omitempty"`
+	UpdatedAt         string         `json:"updated_at,omitempty"`
+	Items             []Task         `json:"items"`
+	Projects          []Project      `json:"projects"`
+	Sections          []Section      `json:"sections"`
+	Labels            []Label        `json:"labels"`
+	Filters           []Filter       `json:"filters"`
+	Notes             []SyncNote     `json:"notes"`
+	Reminders         []Reminder     `json:"reminders"`
+	LiveNotifications []Notification `json:"live_notifications"`
+}
+
+type SyncChange struct {
+	Updated int `json:"updated"`
+	Deleted int `json:"deleted"`
+}
+
+type SyncResult struct {
+	FullSync bool                  `json:"full_sync"`
+	Changes  map[string]SyncChange `json:"changes"`
+}
+
+type syncDelta struct {
+	SyncToken string `json:"sync_token"`
+	FullSync  bool   `json:"full_sync"`
+	Error     string `json:"error"`
+	ErrorTag  string `json:"error_tag"`
+	resources map[string]json.RawMessage
+}
+
+func (s SyncState) HasToken() bool {
+	token := strings.TrimSpace(s.SyncToken)
+	return token != "" && token != "*"
+}
+
+func (s SyncState) ActiveItems() []Task {
+	out := make([]Task, 0, len(s.Items))
+	for _, item := range s.Items {
+		if item.Checked {
+			continue
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
+func (s SyncState) Counts() map[string]int {
+	return map[string]int{
+		"items":              len(s.Items),
+		"projects":           len(s.Projects),
+		"sections":           len(s.Sections),
+		"labels":             len(s.Labels),
+		"filters":            len(s.Filters),
+		"notes":              len(s.Notes),
+		"reminders":          len(s.Reminders),
+		"live_notifications": len(s.LiveNotifications),
+	}
+}
+
+// Sync runs a full sync when state has no token (or full is set) and an
+// incremental sync otherwise, merging the response into state in place.
+func (c *Client) Sync(ctx context.Context, state *SyncState, full bool, now time.Time) (SyncResult, string, error) {
+	if state == nil {
+		return SyncResult{}, "", fmt.Errorf("sync state is required")
+	}
+	token := state.SyncToken
+	if full || !state.HasToken() {
+		token = "*"
+	}
+	resourceTypes, err := json.Marshal(SyncResourceTypes)
+	if err != nil {
+		return SyncResult{}, "", err
+	}
+	data, requestID, err := c.syncRaw(ctx, map[string]string{
+		"sync_token":     token,
+		"resource_types": string(resourceTypes),
+	})
+	if err != nil {
+		return SyncResult{}, requestID, err
+	}
+	delta, err := decodeSyncDelta(data)
+	if err != nil {
+		return SyncResult{}, requestID, err
+	}
+	if delta.Error != "" {
+		msg := delta.Error
+		if delta.ErrorTag != "" {
+			msg = delta.ErrorTag + ": " + delta.Error
+		}
+		return SyncResult{}, requestID, &APIError{Status: 400, Message: msg, RequestID: requestID}
+	}
+	if token == "*" {
+		delta.FullSync = true
+	}
+	result, err := state.apply(delta)
+	if err != nil {
+		return SyncResult{}, requestID, err
+	}
+	stamp := now.UTC().Format(time.RFC3339)
+	state.UpdatedAt = stamp
+	if result.FullSync {
+		state.FullSyncAt = stamp
+	}
+	return result, requestID, nil
+}
+
+func decodeSyncDelta(data []byte) (syncDelta, error) {
+	var delta syncDelta
+	if err := json.Unmarshal(data, &delta); err != nil {
+		return syncDelta{}, fmt.Errorf("decode sync response: %w", err)
+	}
+	if err := json.Unmarshal(data, &delta.resources); err != nil {
+		return syncDelta{}, fmt.Errorf("decode sync response: %w", err)
+	}
+	return delta, nil
+}
+
+func (s *SyncState) apply(delta syncDelta) (SyncResult, error) {
+	next := *s
+	if delta.FullSync {
+		next = SyncState{FullSyncAt: s.FullSyncAt, UpdatedAt: s.UpdatedAt}
+	}
+	result := SyncResult{FullSync: delta.FullSync, Changes: map[string]SyncChange{}}
+	var err error
+	merge := func(name string, fn func(json.RawMessage) (SyncChange, error)) {
+		if err != nil {
+			return
+		}
+		raw, ok := delta.resources[name]
+		if !ok {
+			return
+		}
+		var change SyncChange
+		change, err = fn(raw)
+		if err != nil {
+			err = fmt.Errorf("merge sync %s: %w", name, err)
+			return
+		}
+		if change.Updated > 0 || change.Deleted > 0 {
+			result.Changes[name] = change
+		}
+	}
+	merge("items", func(raw json.RawMessage) (change SyncChange, err error) {
+		next.Items, change, err = mergeSyncRecords(next.Items, raw, func(t Task) string { return t.ID }, nil)
+		return change, err
+	})
+	merge("projects", func(raw json.RawMessage) (change SyncChange, err error) {
+		next.Projects, change, err = mergeSyncRecords(next.Projects, raw, func(p Project) string { return p.ID }, nil)
+		return change, err
+	})
+	merge("sections", func(raw json.RawMessage) (change SyncChange, err error) {
+		next.Sections, change, err = mergeSyncRecords(next.Sections, raw, func(sec Section) string { return sec.ID }, nil)
+		return change, err
+	})
+	merge("labels", func(raw json.RawMessage) (change SyncChange, err error) {
+		next.Labels, change, err = mergeSyncRecords(next.Labels, raw, func(l Label) string { return l.ID }, nil)
+		return change, err
+	})
+	merge("filters", func(raw json.RawMessage) (change SyncChange, err error) {
+		next.Filters, change, err = mergeSyncRecords(next.Filters, raw, func(f Filter) string { return f.ID }, nil)
+		return change, err
+	})
+	merge("notes", func(raw json.RawMessage) (change SyncChange, err error) {
+		next.Notes, change, err = mergeSyncRecords(next.Notes, raw, func(n SyncNote) string { return n.ID }, nil)
+		return change, err
+	})
+	merge("reminders", func(raw json.RawMessage) (change SyncChange, err error) {
+		next.Reminders, change, err = mergeSyncRecords(next.Reminders, raw, func(r Reminder) string { return r.ID }, nil)
+		return change, err
+	})
+	merge("live_notifications", func(raw json.RawMessage) (change SyncChange, err error) {
+		next.LiveNotifications, change, err = mergeSyncRecords(next.LiveNotifications, raw, func(n Notification) string { return n.ID }, func(data []byte) (Notification, error) {
+			var m map[string]any
+			if err := json.Unmarshal(data, &m); err != nil {
+				return Notification{}, err
+			}
+			return notificationFromMap(m), nil
+		})
+		return change, err
+	})
+	if err != nil {
+		return SyncResult{}, err
+	}
+	if strings.TrimSpace(delta.SyncToken) != "" {
+		next.SyncToken = delta.SyncToken
+	}
+	*s = next
+	return result, nil
+}
+
+func mergeSyncRecords[T any](current []T, raw json.RawMessage, idOf func(T) string, decode func([]byte) (T, error)) ([]T, SyncChange, error) {
+	var change SyncChange
+	text := strings.TrimSpace(string(raw))
+	if text == "" || text == "null" {
+		return current, change, nil
+	}
+	var records []json.RawMessage
+	if err := json.Unmarshal(raw, &records); err != nil {
+		return current, change, err
+	}
+	out := append([]T(nil), current...)
+	index := make(map[string]int, len(out))
+	for i, item := range out {
+		index[idOf(item)] = i
+	}
+	deleted := map[string]bool{}
+	for _, record := range records {
+		var marker struct {
+			ID        json.RawMessage `json:"id"`
+			IsDeleted bool            `json:"is_deleted"`
+		}
+		if err := json.Unmarshal(record, &marker); err != nil {
+			return current, change, err
+		}
+		id := rawJSONScalarToString(marker.ID)
+		if id == "" {
+			continue
+		}
+		if marker.IsDeleted {
+			if _, ok := index[id]; ok {
+				deleted[id] = true
+			}
+			change.Deleted++
+			continue
+		}
+		var item T
+		if decode != nil {
+			decoded, err := decode(record)
+			if err != nil {
+				return current, change, err
+			}
+			item = decoded
+		} else if err := json.Unmarshal(record, &item); err != nil {
+			return current, change, err
+		}
+		change.Updated++
+		delete(deleted, id)
+		if pos, ok := index[id]; ok {
+			out[pos] = item
+			continue
+		}
+		index[id] = len(out)
+		out = append(out, item)
+	}
+	if len(deleted) == 0 {
+		return out, change, nil
+	}
+	kept := out[:0]
+	for _, item := range out {
+		if deleted[idOf(item)] {
+			continue
+		}
+		kept = append(kept, item)
+	}
+	return kept, change, nil
+}
+
+func LoadSyncState(path string) (SyncState, error) {
+	if path == "" {
+		return SyncState{}, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return SyncState{}, nil
+		}
+		return SyncState{}, err
+	}
+	var state SyncState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return SyncState{}, fmt.Errorf("decode sync state: %w", err)
+	}
+	return state, nil
+}
+
+func SaveSyncState(path string, state SyncState) error {
+	if path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
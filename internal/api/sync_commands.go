@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const MaxSyncCommands = 100
+
+type SyncCommand struct {
+	Type   string         `json:"type"`
+	UUID   string         `json:"uuid"`
+	TempID string         `json:"temp_id,omitempty"`
+	Args   map[string]any `json:"args"`
+}
+
+type SyncCommandResult struct {
+	SyncStatus    map[string]any
+	TempIDMapping map[string]string
+}
+
+func NewSyncCommand(commandType string, args map[string]any) SyncCommand {
+	if args == nil {
+		args = map[string]any{}
+	}
+	return SyncCommand{Type: commandType, UUID: NewRequestID(), Args: args}
+}
+
+func (c *Client) ExecuteCommands(ctx context.Context, commands []SyncCommand) (SyncCommandResult, string, error) {
+	if len(commands) == 0 {
+		return SyncCommandResult{}, "", fmt.Errorf("at least one sync command is required")
+	}
+	if len(commands) > MaxSyncCommands {
+		return SyncCommandResult{}, "", fmt.Errorf("too many sync commands: %d (max %d)", len(commands), MaxSyncCommands)
+	}
+	payload, err := json.Marshal(commands)
+	if err != nil {
+		return SyncCommandResult{}, "", err
+	}
+	resp, requestID, err := c.syncRequest(ctx, map[string]string{"commands": string(payload)})
+	if err != nil {
+		return SyncCommandResult{}, requestID, err
+	}
+	return SyncCommandResult{SyncStatus: resp.SyncStatus, TempIDMapping: resp.TempIDMapping}, requestID, nil
+}
+
+// CommandError reports the per-command outcome from sync_status; nil means "ok".
+func (r SyncCommandResult) CommandError(uuid string) error {
+	status, ok := r.SyncStatus[uuid]
+	if !ok {
+		return fmt.Errorf("sync status missing for command %s", uuid)
+	}
+	if text, ok := status.(string); ok {
+		if strings.EqualFold(text, "ok") {
+			return nil
+		}
+		return &APIError{Status: 400, Message: text}
+	}
+	detail, ok := status.(map[string]any)
+	if !ok {
+		return fmt.Errorf("unexpected sync status for command %s", uuid)
+	}
+	code := intAny(detail["http_code"])
+	if code == 0 {
+		code = 400
+	}
+	msg := stringifyAny(detail["error"])
+	if tag := stringifyAny(detail["error_tag"]); tag != "" {
+		msg = tag + ": " + msg
+	}
+	return &APIError{Status: code, Message: msg}
+}
+
+func (r SyncCommandResult) ResolveTempID(tempID string) string {
+	if mapped := strings.TrimSpace(r.TempIDMapping[tempID]); mapped != "" {
+		return mapped
+	}
+	return tempID
+}
@@ -0,0 +1,27 @@
+package api
+
+import "testing"
+
+func TestSyncCommandResultCommandError(t *testing.T) {
+	result := SyncCommandResult{
+		SyncStatus: map[string]any{
+			"u1": "ok",
+			"u2": map[string]any{"error": "Invalid argument", "error_tag": "INVALID_ARGUMENT_VALUE", "http_code": float64(400)},
+		},
+		TempIDMapping: map[string]string{"tmp": "123"},
+	}
+	if err := result.CommandError("u1"); err != nil {
+		t.Fatalf("expected ok, got %v", err)
+	}
+	err := result.CommandError("u2")
+	apiErr, ok := err.(*APIError)
+	if !ok || apiErr.Status != 400 || apiErr.Message != "INVALID_ARGUMENT_VALUE: Invalid argument" {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+	if result.CommandError("missing") == nil {
+		t.Fatalf("expected missing status error")
+	}
+	if result.ResolveTempID("tmp") != "123" || result.ResolveTempID("other") != "other" {
+		t.Fatalf("unexpected temp id resolution")
+	}
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSyncFullThenIncrementalMergesDeltas(t *testing.T) {
+	responses := []string{
+		`{"sync_token":"tok1","full_sync":true,
+			"items":[{"id":"t1","content":"Write spec","project_id":"p1"},{"id":"t2","content":"Review","project_id":"p1"}],
+			"projects":[{"id":"p1","name":"Work"}],
+			"labels":[{"id":"l1","name":"next"}],
+			"notes":[{"id":"n1","item_id":"t1","content":"draft link"}],
+			"live_notifications":[{"id":"ln1","notification_type":"note_added","is_unread":true}]}`,
+		`{"sync_token":"tok2","full_sync":false,
+			"items":[{"id":"t1","content":"Write spec v2","project_id":"p1"},{"id":"t2","is_deleted":true},{"id":"t3","content":"Ship","project_id":"p1"}],
+			"projects":[]}`,
+	}
+	var tokens []string
+	client := NewClient("https://example.com", "token", time.Second)
+	client.HTTP = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(r.Body)
+		values, _ := url.ParseQuery(string(body))
+		tokens = append(tokens, values.Get("sync_token"))
+		payload := responses[len(tokens)-1]
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString(payload)),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+		}, nil
+	})}
+
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	var state SyncState
+	result, _, err := client.Sync(context.Background(), &state, false, now)
+	if err != nil {
+		t.Fatalf("full sync: %v", err)
+	}
+	if !result.FullSync || state.SyncToken != "tok1" || len(state.Items) != 2 {
+		t.Fatalf("unexpected full sync state: %#v %#v", result, state)
+	}
+	if len(state.Notes) != 1 || state.Notes[0].ItemID != "t1" {
+		t.Fatalf("unexpected notes: %#v", state.Notes)
+	}
+	if len(state.LiveNotifications) != 1 || state.LiveNotifications[0].Type != "note_added" {
+		t.Fatalf("unexpected live notifications: %#v", state.LiveNotifications)
+	}
+
+	result, _, err = client.Sync(context.Background(), &state, false, now)
+	if err != nil {
+		t.Fatalf("incremental sync: %v", err)
+	}
+	if tokens[0] != "*" || tokens[1] != "tok1" {
+		t.Fatalf("unexpected sync tokens: %#v", tokens)
+	}
+	if result.FullSync || result.Changes["items"] != (SyncChange{Updated: 2, Deleted: 1}) {
+		t.Fatalf("unexpected incremental result: %#v", result)
+	}
+	if state.SyncToken != "tok2" || len(state.Items) != 2 {
+		t.Fatalf("unexpected merged items: %#v", state.Items)
+	}
+	if state.Items[0].Content != "Write spec v2" || state.Items[1].ID != "t3" {
+		t.Fatalf("unexpected merged items: %#v", state.Items)
+	}
+	if len(state.Projects) != 1 || len(state.Labels) != 1 {
+		t.Fatalf("untouched resources should be kept: %#v %#v", state.Projects, state.Labels)
+	}
+}
+
+func TestSyncRejectsOversizedResponse(t *testing.T) {
+	client := NewClient("https://example.com", "token", time.Second)
+	client.HTTP = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		body := io.MultiReader(strings.NewReader(`{"sync_token":"tok1","items":"`), io.LimitReader(zeroReader{}, maxSyncResponseBytes))
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(body), Header: http.Header{}}, nil
+	})}
+	var state SyncState
+	_, _, err := client.Sync(context.Background(), &state, false, time.Now())
+	if err == nil || !strings.Contains(err.Error(), "sync response too large") {
+		t.Fatalf("expected size error, got %v", err)
+	}
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = '0'
+	}
+	return len(p), nil
+}
+
+func TestSyncStateRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sync", "default.json")
+	empty, err := LoadSyncState(path)
+	if err != nil {
+		t.Fatalf("LoadSyncState missing: %v", err)
+	}
+	if empty.HasToken() {
+		t.Fatalf("expected empty state")
+	}
+	state := SyncState{SyncToken: "tok", Items: []Task{{ID: "t1", Content: "a"}, {ID: "t2", Checked: true}}}
+	if err := SaveSyncState(path, state); err != nil {
+		t.Fatalf("SaveSyncState: %v", err)
+	}
+	loaded, err := LoadSyncState(path)
+	if err != nil {
+		t.Fatalf("LoadSyncState: %v", err)
+	}
+	if !loaded.HasToken() || len(loaded.Items) != 2 {
+		t.Fatalf("unexpected loaded state: %#v", loaded)
+	}
+	if active := loaded.ActiveItems(); len(active) != 1 || active[0].ID != "t1" {
+		t.Fatalf("unexpected active items: %#v", active)
+	}
+}
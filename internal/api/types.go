@@ -9,26 +9,40 @@ type Paginated[T any] struct {
 }
 
 type Task struct {
-	ID          string   `json:"id"`
-	Content     string   `json:"content"`
-	Description string   `json:"description"`
-	ProjectID   string   `json:"project_id"`
-	SectionID   string   `json:"section_id"`
-	ParentID    string   `json:"parent_id"`
-	Labels      []string `json:"labels"`
-	Priority    int      `json:"priority"`
-	Checked     bool     `json:"checked"`
-	Due         *Due     `json:"due"`
-	AddedAt     string   `json:"added_at"`
-	CompletedAt string   `json:"completed_at"`
-	UpdatedAt   string   `json:"updated_at"`
-	NoteCount   int      `json:"note_count"`
+	ID          string    `json:"id"`
+	Content     string    `json:"content"`
+	Description string    `json:"description"`
+	ProjectID   string    `json:"project_id"`
+	SectionID   string    `json:"section_id"`
+	ParentID    string    `json:"parent_id"`
+	Labels      []string  `json:"labels"`
+	Priority    int       `json:"priority"`
+	Checked     bool      `json:"checked"`
+	Due         *Due      `json:"due"`
+	Duration    *Duration `json:"duration,omitempty"`
+	Deadline    *Deadline `json:"deadline,omitempty"`
+	// ResponsibleUID is the assignee's user id, empty when unassigned.
+	ResponsibleUID string `json:"responsible_uid,omitempty"`
+	AddedAt        string `json:"added_at"`
+	CompletedAt    string `json:"completed_at"`
+	UpdatedAt      string `json:"updated_at"`
+	NoteCount      int    `json:"note_count"`
 }
 
 type Due struct {
-	Date     string `json:"date,omitempty"`
-	Datetime string `json:"datetime,omitempty"`
-	String   string `json:"string,omitempty"`
+	Date        string `json:"date,omitempty"`
+	Datetime    string `json:"datetime,omitempty"`
+	String      string `json:"string,omitempty"`
+	IsRecurring bool   `json:"is_recurring,omitempty"`
+}
+
+type Duration struct {
+	Amount int    `json:"amount"`
+	Unit   string `json:"unit"`
+}
+
+type Deadline struct {
+	Date string `json:"date"`
 }
 
 type Project struct {
@@ -10,6 +10,10 @@ import (
 	apptasks "github.com/agisilaos/todoist-cli/internal/app/tasks"
 )
 
+// ErrUnchanged means the action would leave its target as it is, so there is
+// no request to send.
+var ErrUnchanged = errors.New("action changes nothing")
+
 type ActionRequest struct {
 	Method string
 	Path   string
@@ -22,6 +26,7 @@ type ActionDeps struct {
 	BuildTaskMovePayload   func(projectID, projectRef, sectionID, sectionRef, parent string) (map[string]any, error)
 	ResolveProjectID       func(reference string) (string, error)
 	ResolveProjectSelector func(explicitID, reference string) (string, error)
+	LoadTaskLabels         func(taskID string) ([]string, error)
 }
 
 func BuildActionRequest(action coreagent.Action, deps ActionDeps) (ActionRequest, error) {
@@ -80,6 +85,21 @@ func BuildActionRequest(action coreagent.Action, deps ActionDeps) (ActionRequest
 		if action.Content != "" {
 			body["content"] = action.Content
 		}
+		if edit := (apptasks.LabelEdit{Add: action.AddLabels, Remove: action.RemoveLabels}); !edit.Empty() {
+			if deps.LoadTaskLabels == nil {
+				return ActionRequest{}, errors.New("task label loader is not configured")
+			}
+			current, err := deps.LoadTaskLabels(action.TaskID)
+			if err != nil {
+				return ActionRequest{}, err
+			}
+			if labels := edit.Apply(current); !apptasks.LabelsEqual(labels, current) {
+				body["labels"] = labels
+			}
+			if len(body) == 0 {
+				return ActionRequest{}, ErrUnchanged
+			}
+		}
 		return ActionRequest{Method: http.MethodPost, Path: "/tasks/" + action.TaskID, Body: body}, nil
 	case "task_move":
 		if action.TaskID == "" {
@@ -1,6 +1,7 @@
 package agent
 
 import (
+	"errors"
 	"net/http"
 	"testing"
 
@@ -40,3 +41,35 @@ func TestBuildActionRequestCommentUpdate(t *testing.T) {
 		t.Fatalf("unexpected request: %#v", req)
 	}
 }
+
+func TestBuildActionRequestTaskUpdateLabelEdits(t *testing.T) {
+	deps := ActionDeps{
+		BuildTaskUpdatePayload: func(in apptasks.MutationInput) (map[string]any, error) {
+			return map[string]any{}, nil
+		},
+		LoadTaskLabels: func(taskID string) ([]string, error) {
+			if taskID != "t1" {
+				t.Fatalf("unexpected task id %q", taskID)
+			}
+			return []string{"work", "waiting"}, nil
+		},
+	}
+	req, err := BuildActionRequest(coreagent.Action{Type: "task_update", TaskID: "t1", AddLabels: []string{"stale"}, RemoveLabels: []string{"Waiting"}}, deps)
+	if err != nil {
+		t.Fatalf("BuildActionRequest: %v", err)
+	}
+	labels, _ := req.Body["labels"].([]string)
+	if len(labels) != 2 || labels[0] != "work" || labels[1] != "stale" {
+		t.Fatalf("unexpected labels: %#v", req.Body)
+	}
+	req, err = BuildActionRequest(coreagent.Action{Type: "task_update", TaskID: "t1", Content: "Renamed", AddLabels: []string{"work"}}, deps)
+	if err != nil {
+		t.Fatalf("BuildActionRequest: %v", err)
+	}
+	if _, ok := req.Body["labels"]; ok || req.Body["content"] != "Renamed" {
+		t.Fatalf("expected unchanged labels to be omitted: %#v", req.Body)
+	}
+	if _, err := BuildActionRequest(coreagent.Action{Type: "task_update", TaskID: "t1", AddLabels: []string{"work"}}, deps); !errors.Is(err, ErrUnchanged) {
+		t.Fatalf("expected a no-op label edit to be unchanged, got %v", err)
+	}
+}
@@ -0,0 +1,390 @@
+package agent
+
+import (
+	"fmt"
+	"strings"
+
+	coreagent "github.com/agisilaos/todoist-cli/internal/agent"
+	"github.com/agisilaos/todoist-cli/internal/api"
+	apptasks "github.com/agisilaos/todoist-cli/internal/app/tasks"
+)
+
+// FieldChange is one field an action changes, rendered for review.
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// ActionDiff is what one plan action would change against live state.
+type ActionDiff struct {
+	Index    int           `json:"index"`
+	Type     string        `json:"type"`
+	Target   string        `json:"target,omitempty"`
+	Changes  []FieldChange `json:"changes,omitempty"`
+	Subtasks int           `json:"subtasks,omitempty"`
+	Comments int           `json:"comments,omitempty"`
+	Note     string        `json:"note,omitempty"`
+}
+
+// DiffState is the live state plans are diffed against.
+type DiffState struct {
+	Tasks    []api.Task
+	Projects []api.Project
+	Sections []api.Section
+}
+
+type diffIndex struct {
+	tasks    map[string]api.Task
+	children map[string][]string
+	projects map[string]string
+	sections map[string]string
+}
+
+// DiffPlan computes a field-level diff for every action of plan. Task actions
+// get before → after changes; other actions only name their target. Each
+// action is diffed against the state the earlier actions leave behind.
+func DiffPlan(plan coreagent.Plan, state DiffState) []ActionDiff {
+	idx := diffIndex{
+		tasks:    map[string]api.Task{},
+		children: map[string][]string{},
+		projects: map[string]string{},
+		sections: map[string]string{},
+	}
+	for _, task := range state.Tasks {
+		idx.tasks[task.ID] = task
+		if task.ParentID != "" {
+			idx.children[task.ParentID] = append(idx.children[task.ParentID], task.ID)
+		}
+	}
+	for _, project := range state.Projects {
+		idx.projects[project.ID] = project.Name
+	}
+	for _, section := range state.Sections {
+		idx.sections[section.ID] = section.Name
+	}
+	diffs := make([]ActionDiff, 0, len(plan.Actions))
+	for i, action := range plan.Actions {
+		diffs = append(diffs, idx.diffAction(i, action))
+		idx.apply(action)
+	}
+	return diffs
+}
+
+// apply updates the index with the effect of a task action. Tasks created
+// or reopened by the plan stay unknown.
+func (idx diffIndex) apply(a coreagent.Action) {
+	task, ok := idx.tasks[a.TaskID]
+	if !ok {
+		return
+	}
+	switch a.Type {
+	case "task_update":
+		if a.Content != "" {
+			task.Content = a.Content
+		}
+		if a.Description != "" {
+			task.Description = a.Description
+		}
+		task.Labels = updatedLabels(a, task.Labels)
+		if a.Priority != 0 {
+			task.Priority = a.Priority
+		}
+		if after := firstNonEmpty(a.Due, a.DueDatetime, a.DueDate); after != "" {
+			task.Due = &api.Due{String: after}
+		}
+		if a.Duration != 0 {
+			task.Duration = &api.Duration{Amount: a.Duration, Unit: firstNonEmpty(a.DurationUnit, "minute")}
+		}
+		if a.Deadline != "" {
+			task.Deadline = &api.Deadline{Date: a.Deadline}
+		}
+		if a.Assignee != "" {
+			task.ResponsibleUID = a.Assignee
+		}
+		idx.tasks[task.ID] = task
+	case "task_move":
+		idx.detach(task)
+		switch {
+		case a.Parent != "":
+			parent := idx.tasks[a.Parent]
+			task.ParentID = a.Parent
+			if parent.ID != "" {
+				task.ProjectID, task.SectionID = parent.ProjectID, parent.SectionID
+			}
+			idx.children[a.Parent] = append(idx.children[a.Parent], task.ID)
+		case a.Section != "" || a.SectionID != "":
+			task.ParentID = ""
+			task.SectionID = firstNonEmpty(a.SectionID, a.Section)
+			task.ProjectID = firstNonEmpty(a.ProjectID, a.Project, task.ProjectID)
+		default:
+			task.ParentID, task.SectionID = "", ""
+			task.ProjectID = firstNonEmpty(a.ProjectID, a.Project)
+		}
+		idx.tasks[task.ID] = task
+	case "task_complete", "task_delete":
+		idx.detach(task)
+		idx.remove(task.ID)
+	}
+}
+
+func (idx diffIndex) detach(task api.Task) {
+	siblings := idx.children[task.ParentID]
+	for i, id := range siblings {
+		if id == task.ID {
+			idx.children[task.ParentID] = append(siblings[:i:i], siblings[i+1:]...)
+			break
+		}
+	}
+}
+
+// remove drops a task and its subtasks, which complete and delete take along.
+func (idx diffIndex) remove(id string) {
+	for _, child := range idx.children[id] {
+		idx.remove(child)
+	}
+	delete(idx.children, id)
+	delete(idx.tasks, id)
+}
+
+func (idx diffIndex) diffAction(i int, a coreagent.Action) ActionDiff {
+	diff := ActionDiff{Index: i, Type: a.Type}
+	if !strings.HasPrefix(a.Type, "task_") || a.Type == "task_add" {
+		diff.Target = describeTarget(a)
+		return diff
+	}
+	if name, ok := coreagent.AliasRef(a.TaskID); ok {
+		diff.Target = "task $" + name
+		diff.Note = "task is created by this plan"
+		return diff
+	}
+	if a.Type == "task_reopen" {
+		// Reopen targets are completed, so they are normally missing from
+		// the active tasks the diff is computed against.
+		return idx.taskReopenDiff(diff, a.TaskID)
+	}
+	task, ok := idx.tasks[a.TaskID]
+	if !ok {
+		diff.Target = "task " + a.TaskID
+		diff.Note = "task not found among active tasks"
+		return diff
+	}
+	diff.Target = fmt.Sprintf("task %s %q", task.ID, task.Content)
+	switch a.Type {
+	case "task_update":
+		diff.Changes = taskUpdateChanges(a, task)
+	case "task_move":
+		diff.Changes = idx.taskMoveChanges(a, task)
+	case "task_complete":
+		diff.Changes = changed(nil, "status", "open", "completed")
+	case "task_delete":
+		diff.Subtasks = idx.countDescendants(task.ID)
+		diff.Comments = task.NoteCount
+		diff.Note = fmt.Sprintf("deletes %q with %d %s and %d %s", task.Content,
+			diff.Subtasks, plural(diff.Subtasks, "subtask"), diff.Comments, plural(diff.Comments, "comment"))
+		return diff
+	}
+	if len(diff.Changes) == 0 {
+		diff.Note = "no change"
+	}
+	return diff
+}
+
+func (idx diffIndex) taskReopenDiff(diff ActionDiff, id string) ActionDiff {
+	task, ok := idx.tasks[id]
+	if ok {
+		diff.Target = fmt.Sprintf("task %s %q", task.ID, task.Content)
+		diff.Note = "task is already open"
+		return diff
+	}
+	diff.Target = "task " + id
+	diff.Changes = changed(nil, "status", "completed", "open")
+	diff.Note = "task is not active; assumed completed"
+	return diff
+}
+
+func taskUpdateChanges(a coreagent.Action, task api.Task) []FieldChange {
+	var changes []FieldChange
+	if a.Content != "" {
+		changes = changed(changes, "content", task.Content, a.Content)
+	}
+	if a.Description != "" {
+		changes = changed(changes, "description", task.Description, a.Description)
+	}
+	changes = changed(changes, "labels", formatLabels(task.Labels), formatLabels(updatedLabels(a, task.Labels)))
+	if a.Priority != 0 {
+		changes = changed(changes, "priority", priorityName(task.Priority), priorityName(a.Priority))
+	}
+	if after := firstNonEmpty(a.Due, a.DueDatetime, a.DueDate); after != "" {
+		changes = changed(changes, "due", formatDue(task.Due), after)
+	}
+	if a.Duration != 0 {
+		before := ""
+		if task.Duration != nil {
+			before = fmt.Sprintf("%d %s", task.Duration.Amount, task.Duration.Unit)
+		}
+		unit := a.DurationUnit
+		if unit == "" {
+			unit = "minute"
+		}
+		changes = changed(changes, "duration", before, fmt.Sprintf("%d %s", a.Duration, unit))
+	}
+	if a.Deadline != "" {
+		before := ""
+		if task.Deadline != nil {
+			before = task.Deadline.Date
+		}
+		changes = changed(changes, "deadline", before, a.Deadline)
+	}
+	if a.Assignee != "" {
+		changes = changed(changes, "assignee", task.ResponsibleUID, a.Assignee)
+	}
+	return changes
+}
+
+func updatedLabels(a coreagent.Action, labels []string) []string {
+	switch {
+	case len(a.Labels) > 0:
+		return a.Labels
+	case len(a.AddLabels) > 0 || len(a.RemoveLabels) > 0:
+		return apptasks.LabelEdit{Add: a.AddLabels, Remove: a.RemoveLabels}.Apply(labels)
+	}
+	return labels
+}
+
+func (idx diffIndex) taskMoveChanges(a coreagent.Action, task api.Task) []FieldChange {
+	var changes []FieldChange
+	beforeProject := idx.projectName(task.ProjectID)
+	beforeSection := idx.sectionName(task.SectionID)
+	beforeParent := idx.taskName(task.ParentID)
+	switch {
+	case a.Parent != "":
+		parent := idx.tasks[a.Parent]
+		changes = changed(changes, "parent", beforeParent, idx.taskName(a.Parent))
+		if parent.ID != "" {
+			changes = changed(changes, "project", beforeProject, idx.projectName(parent.ProjectID))
+			changes = changed(changes, "section", beforeSection, idx.sectionName(parent.SectionID))
+		}
+	case a.Section != "" || a.SectionID != "":
+		section := a.Section
+		if a.SectionID != "" {
+			section = idx.sectionName(a.SectionID)
+		}
+		if project := firstNonEmpty(a.Project, idx.projectName(a.ProjectID)); project != "" {
+			changes = changed(changes, "project", beforeProject, project)
+		}
+		changes = changed(changes, "section", beforeSection, section)
+		changes = changed(changes, "parent", beforeParent, "")
+	default:
+		project := a.Project
+		if a.ProjectID != "" {
+			project = idx.projectName(a.ProjectID)
+		}
+		changes = changed(changes, "project", beforeProject, project)
+		changes = changed(changes, "section", beforeSection, "")
+		changes = changed(changes, "parent", beforeParent, "")
+	}
+	return changes
+}
+
+func (idx diffIndex) countDescendants(id string) int {
+	count := 0
+	for _, child := range idx.children[id] {
+		count += 1 + idx.countDescendants(child)
+	}
+	return count
+}
+
+func (idx diffIndex) projectName(id string) string {
+	if id == "" {
+		return ""
+	}
+	if name := idx.projects[id]; name != "" {
+		return name
+	}
+	return id
+}
+
+func (idx diffIndex) sectionName(id string) string {
+	if id == "" {
+		return ""
+	}
+	if name := idx.sections[id]; name != "" {
+		return name
+	}
+	return id
+}
+
+func (idx diffIndex) taskName(id string) string {
+	if id == "" {
+		return ""
+	}
+	if task, ok := idx.tasks[id]; ok {
+		return task.Content
+	}
+	return id
+}
+
+func describeTarget(a coreagent.Action) string {
+	switch {
+	case a.Type == "task_add":
+		return fmt.Sprintf("new task %q", a.Content)
+	case strings.HasSuffix(a.Type, "_add"):
+		name := firstNonEmpty(a.Name, a.Content)
+		return fmt.Sprintf("new %s %q", strings.TrimSuffix(a.Type, "_add"), name)
+	}
+	if id := targetID(a); id != "" {
+		return strings.SplitN(a.Type, "_", 2)[0] + " " + id
+	}
+	return ""
+}
+
+// changed appends a change unless before and after render the same.
+func changed(changes []FieldChange, field, before, after string) []FieldChange {
+	if before == after {
+		return changes
+	}
+	return append(changes, FieldChange{Field: field, Before: before, After: after})
+}
+
+func formatLabels(labels []string) string {
+	return strings.Join(labels, ", ")
+}
+
+func formatDue(due *api.Due) string {
+	if due == nil {
+		return ""
+	}
+	if due.IsRecurring && due.String != "" {
+		return due.String
+	}
+	return firstNonEmpty(due.Datetime, due.Date, due.String)
+}
+
+// priorityName maps API priority (4 is most urgent) to the p1-p4 names; an
+// unset priority is the default p4.
+func priorityName(priority int) string {
+	if priority < 1 {
+		priority = 1
+	}
+	if priority > 4 {
+		return fmt.Sprint(priority)
+	}
+	return fmt.Sprintf("p%d", 5-priority)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
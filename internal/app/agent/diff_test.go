@@ -0,0 +1,111 @@
+package agent
+
+import (
+	"reflect"
+	"testing"
+
+	coreagent "github.com/agisilaos/todoist-cli/internal/agent"
+	"github.com/agisilaos/todoist-cli/internal/api"
+)
+
+func testDiffState() DiffState {
+	return DiffState{
+		Tasks: []api.Task{
+			{ID: "t1", Content: "Deploy", ProjectID: "p1", SectionID: "s1", Labels: []string{"work"}, Priority: 1, Due: &api.Due{Date: "2026-10-20"}},
+			{ID: "t2", Content: "Check logs", ProjectID: "p1", SectionID: "s1", ParentID: "t1", NoteCount: 2},
+			{ID: "t3", Content: "Rollback plan", ProjectID: "p1", SectionID: "s1", ParentID: "t2"},
+			{ID: "t4", Content: "Water plants", ProjectID: "p2"},
+		},
+		Projects: []api.Project{{ID: "p1", Name: "Work"}, {ID: "p2", Name: "Home"}},
+		Sections: []api.Section{{ID: "s1", ProjectID: "p1", Name: "Backlog"}},
+	}
+}
+
+func TestDiffPlanTaskUpdate(t *testing.T) {
+	plan := coreagent.Plan{Actions: []coreagent.Action{
+		{Type: "task_update", TaskID: "t1", Content: "Deploy v2", AddLabels: []string{"urgent"}, Priority: 4, DueDate: "2026-10-21", Duration: 30},
+		{Type: "task_update", TaskID: "t4", Priority: 1, Labels: []string{}},
+	}}
+	diffs := DiffPlan(plan, testDiffState())
+	want := []FieldChange{
+		{Field: "content", Before: "Deploy", After: "Deploy v2"},
+		{Field: "labels", Before: "work", After: "work, urgent"},
+		{Field: "priority", Before: "p4", After: "p1"},
+		{Field: "due", Before: "2026-10-20", After: "2026-10-21"},
+		{Field: "duration", Before: "", After: "30 minute"},
+	}
+	if !reflect.DeepEqual(diffs[0].Changes, want) || diffs[0].Target != `task t1 "Deploy"` {
+		t.Fatalf("unexpected update diff: %#v", diffs[0])
+	}
+	if diffs[1].Changes != nil || diffs[1].Note != "no change" {
+		t.Fatalf("expected no change: %#v", diffs[1])
+	}
+}
+
+func TestDiffPlanMoveAndDelete(t *testing.T) {
+	plan := coreagent.Plan{Actions: []coreagent.Action{
+		{Type: "task_move", TaskID: "t2", Project: "Home"},
+		{Type: "task_move", TaskID: "t4", Parent: "t1"},
+		{Type: "task_move", TaskID: "t4", SectionID: "s1", ProjectID: "p1"},
+		{Type: "task_delete", TaskID: "t1"},
+		{Type: "task_complete", TaskID: "$new"},
+		{Type: "task_complete", TaskID: "t9"},
+		{Type: "task_reopen", TaskID: "t9"},
+		{Type: "task_reopen", TaskID: "t1"},
+		{Type: "project_add", Name: "Errands"},
+		{Type: "label_delete", LabelID: "l1"},
+	}}
+	diffs := DiffPlan(plan, testDiffState())
+	checks := []struct {
+		changes []FieldChange
+		note    string
+		target  string
+	}{
+		{changes: []FieldChange{{"project", "Work", "Home"}, {"section", "Backlog", ""}, {"parent", "Deploy", ""}}, target: `task t2 "Check logs"`},
+		{changes: []FieldChange{{"parent", "", "Deploy"}, {"project", "Home", "Work"}, {"section", "", "Backlog"}}, target: `task t4 "Water plants"`},
+		{changes: []FieldChange{{"parent", "Deploy", ""}}, target: `task t4 "Water plants"`},
+		{note: `deletes "Deploy" with 0 subtasks and 0 comments`, target: `task t1 "Deploy"`},
+		{note: "task is created by this plan", target: "task $new"},
+		{note: "task not found among active tasks", target: "task t9"},
+		{changes: []FieldChange{{"status", "completed", "open"}}, note: "task is not active; assumed completed", target: "task t9"},
+		{changes: []FieldChange{{"status", "completed", "open"}}, note: "task is not active; assumed completed", target: "task t1"},
+		{target: `new project "Errands"`},
+		{target: "label l1"},
+	}
+	for i, check := range checks {
+		if !reflect.DeepEqual(diffs[i].Changes, check.changes) || diffs[i].Note != check.note || diffs[i].Target != check.target || diffs[i].Index != i {
+			t.Fatalf("unexpected diff %d: %#v", i, diffs[i])
+		}
+	}
+	if diffs[3].Subtasks != 0 || diffs[3].Comments != 0 {
+		t.Fatalf("unexpected delete counts: %#v", diffs[3])
+	}
+	if got := DiffPlan(coreagent.Plan{Actions: []coreagent.Action{{Type: "task_delete", TaskID: "t2"}}}, testDiffState())[0].Note; got != `deletes "Check logs" with 1 subtask and 2 comments` {
+		t.Fatalf("unexpected delete note %q", got)
+	}
+	if got := DiffPlan(coreagent.Plan{Actions: []coreagent.Action{{Type: "task_reopen", TaskID: "t1"}}}, testDiffState())[0].Note; got != "task is already open" {
+		t.Fatalf("unexpected reopen note %q", got)
+	}
+}
+
+func TestDiffPlanFollowsEarlierActions(t *testing.T) {
+	state := testDiffState()
+	state.Tasks[3].ResponsibleUID = "u1"
+	plan := coreagent.Plan{Actions: []coreagent.Action{
+		{Type: "task_update", TaskID: "t4", Content: "Water ferns", Assignee: "u2"},
+		{Type: "task_update", TaskID: "t4", Content: "Water cacti", Assignee: "u3"},
+		{Type: "task_complete", TaskID: "t2"},
+		{Type: "task_update", TaskID: "t3", Priority: 4},
+	}}
+	diffs := DiffPlan(plan, state)
+	want := []FieldChange{{"content", "Water ferns", "Water cacti"}, {"assignee", "u2", "u3"}}
+	if !reflect.DeepEqual(diffs[1].Changes, want) || diffs[1].Target != `task t4 "Water ferns"` {
+		t.Fatalf("expected the second update diffed against the first: %#v", diffs[1])
+	}
+	if diffs[0].Changes[1] != (FieldChange{"assignee", "u1", "u2"}) {
+		t.Fatalf("expected the current assignee as before: %#v", diffs[0].Changes)
+	}
+	if diffs[3].Note != "task not found among active tasks" {
+		t.Fatalf("expected the completed parent to take its subtask along: %#v", diffs[3])
+	}
+}
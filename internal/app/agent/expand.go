@@ -0,0 +1,158 @@
+package agent
+
+import (
+	"fmt"
+	"strings"
+
+	coreagent "github.com/agisilaos/todoist-cli/internal/agent"
+	"github.com/agisilaos/todoist-cli/internal/api"
+)
+
+// MaxExpandedActions bounds how many actions a version 2 plan may expand to.
+const MaxExpandedActions = 500
+
+// ExpandNote records how one version 2 action expanded.
+type ExpandNote struct {
+	Index int    `json:"index"`
+	Type  string `json:"type"`
+	Count int    `json:"count"`
+	Note  string `json:"note"`
+}
+
+type ExpandDeps struct {
+	// Query returns the active tasks matching a Todoist filter query.
+	Query func(query string) ([]api.Task, error)
+}
+
+// ExpandPlan resolves a version 2 plan into a version 1 plan of literal
+// actions: vars are substituted, foreach actions repeat for every matching
+// task, and when guards drop actions (or foreach items) their query does not
+// match. Actions that reference an alias of a dropped action are dropped too.
+// Version 1 plans are returned unchanged.
+func ExpandPlan(plan coreagent.Plan, deps ExpandDeps) (coreagent.Plan, []ExpandNote, error) {
+	if plan.Version < 2 {
+		return plan, nil, nil
+	}
+	if deps.Query == nil {
+		return coreagent.Plan{}, nil, fmt.Errorf("filter query is not configured")
+	}
+	results := map[string][]api.Task{}
+	query := func(q string) ([]api.Task, error) {
+		if tasks, ok := results[q]; ok {
+			return tasks, nil
+		}
+		tasks, err := deps.Query(q)
+		if err != nil {
+			return nil, fmt.Errorf("filter %q: %w", q, err)
+		}
+		results[q] = tasks
+		return tasks, nil
+	}
+	var actions []coreagent.Action
+	var notes []ExpandNote
+	dropped := map[string]bool{}
+	for i, a := range plan.Actions {
+		note := ExpandNote{Index: i, Type: a.Type}
+		if ref := droppedAliasRef(a, dropped); ref != "" {
+			note.Note = fmt.Sprintf("skipped: $%s was not created", ref)
+			notes = append(notes, note)
+			markDropped(a, dropped)
+			continue
+		}
+		foreach, err := coreagent.RenderTemplate(a.Foreach, plan.Vars, nil)
+		if err != nil {
+			return coreagent.Plan{}, nil, fmt.Errorf("action %d (%s): %w", i+1, a.Type, err)
+		}
+		when, err := coreagent.RenderTemplate(a.When, plan.Vars, nil)
+		if err != nil {
+			return coreagent.Plan{}, nil, fmt.Errorf("action %d (%s): %w", i+1, a.Type, err)
+		}
+		var guard map[string]bool
+		if strings.TrimSpace(when) != "" {
+			matches, err := query(when)
+			if err != nil {
+				return coreagent.Plan{}, nil, fmt.Errorf("action %d (%s) when: %w", i+1, a.Type, err)
+			}
+			guard = map[string]bool{}
+			for _, task := range matches {
+				guard[task.ID] = true
+			}
+		}
+		a.Foreach, a.When = "", ""
+		if strings.TrimSpace(foreach) == "" {
+			if guard != nil && len(guard) == 0 {
+				note.Note = fmt.Sprintf("skipped: when %q matched no tasks", when)
+				notes = append(notes, note)
+				markDropped(a, dropped)
+				continue
+			}
+			rendered, err := coreagent.RenderAction(a, plan.Vars, nil)
+			if err != nil {
+				return coreagent.Plan{}, nil, fmt.Errorf("action %d (%s): %w", i+1, a.Type, err)
+			}
+			actions = append(actions, rendered)
+			note.Count = 1
+			if guard != nil {
+				note.Note = fmt.Sprintf("when %q matched %d %s", when, len(guard), plural(len(guard), "task"))
+				notes = append(notes, note)
+			}
+			continue
+		}
+		items, err := query(foreach)
+		if err != nil {
+			return coreagent.Plan{}, nil, fmt.Errorf("action %d (%s) foreach: %w", i+1, a.Type, err)
+		}
+		for _, item := range items {
+			if guard != nil && !guard[item.ID] {
+				continue
+			}
+			rendered, err := coreagent.RenderAction(a, plan.Vars, itemFields(item))
+			if err != nil {
+				return coreagent.Plan{}, nil, fmt.Errorf("action %d (%s) item %s: %w", i+1, a.Type, item.ID, err)
+			}
+			actions = append(actions, rendered)
+			note.Count++
+		}
+		note.Note = fmt.Sprintf("foreach %q matched %d %s", foreach, len(items), plural(len(items), "task"))
+		if guard != nil {
+			note.Note += fmt.Sprintf(", %d passed when %q", note.Count, when)
+		}
+		notes = append(notes, note)
+		if len(actions) > MaxExpandedActions {
+			return coreagent.Plan{}, nil, fmt.Errorf("plan expands to more than %d actions; narrow the foreach queries", MaxExpandedActions)
+		}
+	}
+	expanded := plan
+	expanded.Version = 1
+	expanded.Vars = nil
+	expanded.Actions = actions
+	expanded.Summary = coreagent.SummarizeActions(actions)
+	return expanded, notes, nil
+}
+
+func droppedAliasRef(a coreagent.Action, dropped map[string]bool) string {
+	for _, field := range coreagent.AliasFields(&a) {
+		if name, ok := coreagent.AliasRef(*field.Value); ok && dropped[name] {
+			return name
+		}
+	}
+	return ""
+}
+
+func markDropped(a coreagent.Action, dropped map[string]bool) {
+	if a.Alias != "" {
+		dropped[a.Alias] = true
+	}
+}
+
+func itemFields(task api.Task) map[string]string {
+	return map[string]string{
+		"id":          task.ID,
+		"content":     task.Content,
+		"description": task.Description,
+		"project_id":  task.ProjectID,
+		"section_id":  task.SectionID,
+		"parent_id":   task.ParentID,
+		"due":         formatDue(task.Due),
+	}
+}
@@ -0,0 +1,88 @@
+package agent
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	coreagent "github.com/agisilaos/todoist-cli/internal/agent"
+	"github.com/agisilaos/todoist-cli/internal/api"
+)
+
+func testExpandQuery(queries *[]string) func(string) ([]api.Task, error) {
+	return func(q string) ([]api.Task, error) {
+		*queries = append(*queries, q)
+		switch q {
+		case "overdue & @waiting":
+			return []api.Task{{ID: "t1", Content: "Call back"}, {ID: "t2", Content: "Email"}}, nil
+		case "p1":
+			return []api.Task{{ID: "t2", Content: "Email"}}, nil
+		case "today":
+			return nil, nil
+		}
+		return nil, errors.New("invalid filter")
+	}
+}
+
+func TestExpandPlanForeachWhenAndVars(t *testing.T) {
+	plan := coreagent.Plan{Version: 2, ConfirmToken: "abcd", Vars: map[string]string{"label": "stale", "filter": "overdue & @waiting"}, Actions: []coreagent.Action{
+		{Type: "task_update", Foreach: "{{filter}}", TaskID: "{{item.id}}", AddLabels: []string{"{{label}}"}},
+		{Type: "comment_add", Foreach: "{{filter}}", When: "p1", TaskID: "{{item.id}}", Content: "Still waiting on {{item.content}}"},
+		{Type: "task_add", When: "today", Alias: "review", Content: "Review today"},
+		{Type: "task_complete", TaskID: "$review"},
+		{Type: "task_add", Content: "Tidy {{label}} tasks"},
+	}}
+	var queries []string
+	expanded, notes, err := ExpandPlan(plan, ExpandDeps{Query: testExpandQuery(&queries)})
+	if err != nil {
+		t.Fatalf("ExpandPlan: %v", err)
+	}
+	want := []coreagent.Action{
+		{Type: "task_update", TaskID: "t1", AddLabels: []string{"stale"}},
+		{Type: "task_update", TaskID: "t2", AddLabels: []string{"stale"}},
+		{Type: "comment_add", TaskID: "t2", Content: "Still waiting on Email"},
+		{Type: "task_add", Content: "Tidy stale tasks"},
+	}
+	if !reflect.DeepEqual(expanded.Actions, want) {
+		t.Fatalf("unexpected actions: %#v", expanded.Actions)
+	}
+	if expanded.Version != 1 || expanded.Vars != nil || expanded.ConfirmToken != "abcd" || expanded.Summary.Tasks != 3 || expanded.Summary.Comments != 1 {
+		t.Fatalf("unexpected expanded plan: %#v", expanded)
+	}
+	if strings.Join(queries, "|") != "overdue & @waiting|p1|today" {
+		t.Fatalf("expected each query once, got %v", queries)
+	}
+	gotNotes := []string{}
+	for _, note := range notes {
+		gotNotes = append(gotNotes, note.Note)
+	}
+	wantNotes := []string{
+		`foreach "overdue & @waiting" matched 2 tasks`,
+		`foreach "overdue & @waiting" matched 2 tasks, 1 passed when "p1"`,
+		`skipped: when "today" matched no tasks`,
+		"skipped: $review was not created",
+	}
+	if !reflect.DeepEqual(gotNotes, wantNotes) {
+		t.Fatalf("unexpected notes: %#v", gotNotes)
+	}
+	if err := coreagent.ValidatePlan(expanded, 0, false); err != nil {
+		t.Fatalf("expanded plan invalid: %v", err)
+	}
+}
+
+func TestExpandPlanErrors(t *testing.T) {
+	var queries []string
+	deps := ExpandDeps{Query: testExpandQuery(&queries)}
+	v1 := coreagent.Plan{Version: 1, Actions: []coreagent.Action{{Type: "task_add", Content: "{{x}}"}}}
+	if got, notes, err := ExpandPlan(v1, deps); err != nil || notes != nil || !reflect.DeepEqual(got, v1) {
+		t.Fatalf("expected v1 plan unchanged: %#v %v", got, err)
+	}
+	bad := coreagent.Plan{Version: 2, Actions: []coreagent.Action{{Type: "task_complete", Foreach: "nonsense", TaskID: "{{item.id}}"}}}
+	if _, _, err := ExpandPlan(bad, deps); err == nil || !strings.Contains(err.Error(), `action 1 (task_complete) foreach: filter "nonsense": invalid filter`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, err := ExpandPlan(coreagent.Plan{Version: 2}, ExpandDeps{}); err == nil {
+		t.Fatal("expected missing query error")
+	}
+}
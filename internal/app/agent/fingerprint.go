@@ -0,0 +1,113 @@
+package agent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	coreagent "github.com/agisilaos/todoist-cli/internal/agent"
+)
+
+// StaleTarget is an entity a plan action targets that changed, or is gone,
+// since the plan was made.
+type StaleTarget struct {
+	Index   int    `json:"index"`
+	Type    string `json:"type"`
+	Entity  string `json:"entity"`
+	Planned string `json:"planned"`
+	Current string `json:"current,omitempty"`
+}
+
+// StaleError reports the plan actions whose targets changed since planning.
+type StaleError struct {
+	Targets []StaleTarget
+}
+
+func (e *StaleError) Error() string {
+	seen := map[string]bool{}
+	var parts []string
+	for _, target := range e.Targets {
+		if seen[target.Entity] {
+			continue
+		}
+		seen[target.Entity] = true
+		if target.Current == "" {
+			parts = append(parts, target.Entity+" is gone")
+		} else {
+			parts = append(parts, target.Entity+" changed")
+		}
+	}
+	return fmt.Sprintf("plan is stale: %s", strings.Join(parts, ", "))
+}
+
+// FingerprintKey names an entity in Plan.Fingerprints, e.g. "task:123".
+func FingerprintKey(entity, id string) string {
+	return entity + ":" + strings.TrimPrefix(strings.TrimSpace(id), "id:")
+}
+
+// Fingerprint identifies one version of an entity: its updated_at when the
+// API reports one, else a hash of its fields.
+func Fingerprint(updatedAt string, entity any) string {
+	if strings.TrimSpace(updatedAt) != "" {
+		return updatedAt
+	}
+	data, _ := json.Marshal(entity)
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])[:16]
+}
+
+// ReferencedEntities returns the fingerprint keys of the existing entities
+// action targets. Aliases, templates and name references are left out: they
+// are created or resolved at apply time.
+func ReferencedEntities(action coreagent.Action) []string {
+	var keys []string
+	for _, field := range coreagent.AliasFields(&action) {
+		id := strings.TrimSpace(*field.Value)
+		if id == "" {
+			continue
+		}
+		if _, ok := coreagent.AliasRef(id); ok || strings.Contains(id, "{{") {
+			continue
+		}
+		if field.Name == "parent" && action.Type == "project_add" && !strings.HasPrefix(id, "id:") {
+			continue
+		}
+		keys = append(keys, FingerprintKey(field.Entity, id))
+	}
+	return keys
+}
+
+// PlanFingerprints picks the fingerprints of the entities actions reference
+// out of known; entities without a known fingerprint are not tracked.
+func PlanFingerprints(actions []coreagent.Action, known map[string]string) map[string]string {
+	out := map[string]string{}
+	for _, action := range actions {
+		for _, key := range ReferencedEntities(action) {
+			if fingerprint, ok := known[key]; ok {
+				out[key] = fingerprint
+			}
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// StaleTargets compares the plan's fingerprints with current ones and lists,
+// per action, the targets that changed or no longer exist.
+func StaleTargets(plan coreagent.Plan, current map[string]string) []StaleTarget {
+	var stale []StaleTarget
+	for i, action := range plan.Actions {
+		for _, key := range ReferencedEntities(action) {
+			planned, ok := plan.Fingerprints[key]
+			if !ok || current[key] == planned {
+				continue
+			}
+			stale = append(stale, StaleTarget{Index: i, Type: action.Type, Entity: key, Planned: planned, Current: current[key]})
+		}
+	}
+	return stale
+}
@@ -0,0 +1,71 @@
+package agent
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	coreagent "github.com/agisilaos/todoist-cli/internal/agent"
+	"github.com/agisilaos/todoist-cli/internal/api"
+)
+
+func TestReferencedEntities(t *testing.T) {
+	cases := []struct {
+		action coreagent.Action
+		want   []string
+	}{
+		{coreagent.Action{Type: "task_move", TaskID: "t1", SectionID: "s1", Parent: "t2"}, []string{"task:t1", "section:s1", "task:t2"}},
+		{coreagent.Action{Type: "task_add", Content: "x", ProjectID: "$inbox", Project: "Home"}, nil},
+		{coreagent.Action{Type: "project_add", Name: "x", Parent: "Work"}, nil},
+		{coreagent.Action{Type: "project_add", Name: "x", Parent: "id:p1"}, []string{"project:p1"}},
+		{coreagent.Action{Type: "label_update", LabelID: "l1"}, []string{"label:l1"}},
+	}
+	for _, tc := range cases {
+		if got := ReferencedEntities(tc.action); !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("ReferencedEntities(%#v) = %v, want %v", tc.action, got, tc.want)
+		}
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	if got := Fingerprint("2026-10-01T10:00:00Z", nil); got != "2026-10-01T10:00:00Z" {
+		t.Fatalf("expected updated_at, got %q", got)
+	}
+	a := Fingerprint("", api.Project{ID: "p1", Name: "Work"})
+	b := Fingerprint("", api.Project{ID: "p1", Name: "Work!"})
+	if !strings.HasPrefix(a, "sha256:") || a == b || a != Fingerprint("", api.Project{ID: "p1", Name: "Work"}) {
+		t.Fatalf("unexpected hashes %q %q", a, b)
+	}
+}
+
+func TestStaleTargets(t *testing.T) {
+	actions := []coreagent.Action{
+		{Type: "task_update", TaskID: "t1", Priority: 4},
+		{Type: "task_move", TaskID: "t2", ProjectID: "p1"},
+		{Type: "task_complete", TaskID: "t3"},
+		{Type: "task_add", Content: "x", ProjectID: "p9"},
+	}
+	plan := coreagent.Plan{Actions: actions, Fingerprints: PlanFingerprints(actions, map[string]string{
+		"task:t1": "a", "task:t2": "b", "task:t3": "c", "project:p1": "d", "task:t9": "unused",
+	})}
+	if len(plan.Fingerprints) != 4 {
+		t.Fatalf("unexpected fingerprints: %#v", plan.Fingerprints)
+	}
+	if stale := StaleTargets(plan, map[string]string{"task:t1": "a", "task:t2": "b", "task:t3": "c", "project:p1": "d"}); stale != nil {
+		t.Fatalf("expected fresh plan, got %#v", stale)
+	}
+	stale := StaleTargets(plan, map[string]string{"task:t1": "a2", "task:t2": "b", "project:p1": "d"})
+	want := []StaleTarget{
+		{Index: 0, Type: "task_update", Entity: "task:t1", Planned: "a", Current: "a2"},
+		{Index: 2, Type: "task_complete", Entity: "task:t3", Planned: "c"},
+	}
+	if !reflect.DeepEqual(stale, want) {
+		t.Fatalf("unexpected stale targets: %#v", stale)
+	}
+	if msg := (&StaleError{Targets: stale}).Error(); msg != "plan is stale: task:t1 changed, task:t3 is gone" {
+		t.Fatalf("unexpected message %q", msg)
+	}
+	if PlanFingerprints(actions, nil) != nil {
+		t.Fatal("expected nil fingerprints without known state")
+	}
+}
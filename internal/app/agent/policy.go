@@ -0,0 +1,530 @@
+package agent
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	coreagent "github.com/agisilaos/todoist-cli/internal/agent"
+	"github.com/agisilaos/todoist-cli/internal/api"
+)
+
+// PolicyRule is one scoped rule of agent_policy.json. Exactly one of Deny,
+// Require and Max is set; Actions limits the rule to matching action types
+// (globs such as "task_*"), all actions when empty.
+type PolicyRule struct {
+	Name    string       `json:"name,omitempty"`
+	Actions []string     `json:"actions,omitempty"`
+	Deny    *PolicyMatch `json:"deny,omitempty"`
+	Require *PolicyMatch `json:"require,omitempty"`
+	Max     int          `json:"max,omitempty"`
+}
+
+// PolicyMatch holds conditions on an action and the entities it touches; a
+// match needs every set condition to hold.
+type PolicyMatch struct {
+	Project      string `json:"project,omitempty"`
+	ProjectUnder string `json:"project_under,omitempty"`
+	Priority     string `json:"priority,omitempty"`
+	Label        string `json:"label,omitempty"`
+	HasComments  *bool  `json:"has_comments,omitempty"`
+	HasSubtasks  *bool  `json:"has_subtasks,omitempty"`
+}
+
+// PolicyState is the live state rules are evaluated against; nil means
+// offline, where conditions on existing entities cannot be decided. Tasks are
+// the active tasks; Completed and Comments hold the other tasks and the
+// comments the plan targets, which only tell where those live.
+type PolicyState struct {
+	Tasks     []api.Task
+	Completed []api.Task
+	Comments  []PolicyComment
+	Projects  []api.Project
+	Sections  []api.Section
+}
+
+// PolicyComment is an existing comment and the task or project it is on.
+type PolicyComment struct {
+	ID        string `json:"id"`
+	TaskID    string `json:"task_id"`
+	ProjectID string `json:"project_id"`
+}
+
+// PolicyFinding is a rule that fired, or could not be decided, for one
+// action (Action is 1-based) or for the whole plan (Action is 0).
+type PolicyFinding struct {
+	Rule       string `json:"rule"`
+	Action     int    `json:"action,omitempty"`
+	ActionType string `json:"action_type,omitempty"`
+	Reason     string `json:"reason"`
+	Limit      int    `json:"limit,omitempty"`
+	Count      int    `json:"count,omitempty"`
+}
+
+// PolicyReport is the outcome of evaluating rules against a plan.
+type PolicyReport struct {
+	Violations []PolicyFinding `json:"violations"`
+	Unchecked  []PolicyFinding `json:"unchecked,omitempty"`
+}
+
+// RuleName is the name findings report for rules[i].
+func (r PolicyRule) RuleName(i int) string {
+	if strings.TrimSpace(r.Name) != "" {
+		return r.Name
+	}
+	return fmt.Sprintf("rules[%d]", i)
+}
+
+// ValidatePolicyRules rejects rules that cannot be evaluated.
+func ValidatePolicyRules(rules []PolicyRule) error {
+	for i, rule := range rules {
+		name := rule.RuleName(i)
+		kinds := 0
+		for _, set := range []bool{rule.Deny != nil, rule.Require != nil, rule.Max != 0} {
+			if set {
+				kinds++
+			}
+		}
+		if kinds != 1 {
+			return fmt.Errorf("rule %s: set exactly one of deny, require or max", name)
+		}
+		if rule.Max < 0 {
+			return fmt.Errorf("rule %s: max must be positive", name)
+		}
+		for _, pattern := range rule.Actions {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("rule %s: invalid action pattern %q", name, pattern)
+			}
+		}
+		for _, m := range []*PolicyMatch{rule.Deny, rule.Require} {
+			if m == nil {
+				continue
+			}
+			if m.String() == "" {
+				return fmt.Errorf("rule %s: no conditions", name)
+			}
+			if m.Priority != "" && priorityValue(m.Priority) == 0 {
+				return fmt.Errorf("rule %s: priority must be p1-p4", name)
+			}
+		}
+	}
+	return nil
+}
+
+func (m PolicyMatch) String() string {
+	var parts []string
+	add := func(key, value string) {
+		if value != "" {
+			parts = append(parts, key+"="+value)
+		}
+	}
+	add("project", m.Project)
+	add("project_under", m.ProjectUnder)
+	add("priority", m.Priority)
+	add("label", m.Label)
+	if m.HasComments != nil {
+		add("has_comments", fmt.Sprint(*m.HasComments))
+	}
+	if m.HasSubtasks != nil {
+		add("has_subtasks", fmt.Sprint(*m.HasSubtasks))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// EvaluatePolicyRules checks every action of plan against rules. Conditions
+// that cannot be decided (offline, or entities missing from state) are
+// reported as unchecked rather than passing.
+func EvaluatePolicyRules(plan coreagent.Plan, rules []PolicyRule, state *PolicyState) PolicyReport {
+	idx := newPolicyIndex(state)
+	report := PolicyReport{}
+	counts := make([]int, len(rules))
+	for i, action := range plan.Actions {
+		touched := idx.touchedProjects(action)
+		for r, rule := range rules {
+			if !rule.appliesTo(action.Type) {
+				continue
+			}
+			counts[r]++
+			finding := PolicyFinding{Rule: rule.RuleName(r), Action: i + 1, ActionType: action.Type}
+			switch {
+			case rule.Deny != nil:
+				switch idx.match(*rule.Deny, action, touched, false) {
+				case matchYes:
+					finding.Reason = "matches deny: " + rule.Deny.String()
+					report.Violations = append(report.Violations, finding)
+				case matchUnknown:
+					finding.Reason = "cannot verify deny: " + rule.Deny.String()
+					report.Unchecked = append(report.Unchecked, finding)
+				}
+			case rule.Require != nil:
+				switch idx.match(*rule.Require, action, touched, true) {
+				case matchNo:
+					finding.Reason = "does not satisfy require: " + rule.Require.String()
+					report.Violations = append(report.Violations, finding)
+				case matchUnknown:
+					finding.Reason = "cannot verify require: " + rule.Require.String()
+					report.Unchecked = append(report.Unchecked, finding)
+				}
+			}
+		}
+		idx.recordAlias(action, touched)
+	}
+	for r, rule := range rules {
+		if rule.Max > 0 && counts[r] > rule.Max {
+			report.Violations = append(report.Violations, PolicyFinding{
+				Rule:   rule.RuleName(r),
+				Reason: fmt.Sprintf("%d matching actions exceed max %d", counts[r], rule.Max),
+				Limit:  rule.Max,
+				Count:  counts[r],
+			})
+		}
+	}
+	return report
+}
+
+func (r PolicyRule) appliesTo(actionType string) bool {
+	if len(r.Actions) == 0 {
+		return true
+	}
+	for _, pattern := range r.Actions {
+		if ok, _ := path.Match(pattern, actionType); ok {
+			return true
+		}
+	}
+	return false
+}
+
+type matchResult int
+
+const (
+	matchNo matchResult = iota
+	matchYes
+	matchUnknown
+)
+
+// and combines condition results: any no wins, then any unknown.
+func and(results ...matchResult) matchResult {
+	out := matchYes
+	for _, r := range results {
+		if r == matchNo {
+			return matchNo
+		}
+		if r == matchUnknown {
+			out = matchUnknown
+		}
+	}
+	return out
+}
+
+func boolMatch(ok bool) matchResult {
+	if ok {
+		return matchYes
+	}
+	return matchNo
+}
+
+// policyProject is a project an action touches. resolved means its parent
+// chain is known, so "not under X" can be decided.
+type policyProject struct {
+	id       string
+	name     string
+	parent   *policyProject
+	resolved bool
+}
+
+type policyIndex struct {
+	tasks         map[string]api.Task
+	children      map[string]int
+	comments      map[string]PolicyComment
+	projects      map[string]api.Project
+	projectByName map[string]api.Project
+	sections      map[string]api.Section
+	inboxID       string
+	aliases       map[string]*policyProject
+}
+
+func newPolicyIndex(state *PolicyState) *policyIndex {
+	idx := &policyIndex{
+		tasks:         map[string]api.Task{},
+		children:      map[string]int{},
+		comments:      map[string]PolicyComment{},
+		projects:      map[string]api.Project{},
+		projectByName: map[string]api.Project{},
+		sections:      map[string]api.Section{},
+		aliases:       map[string]*policyProject{},
+	}
+	if state == nil {
+		return idx
+	}
+	for _, task := range state.Tasks {
+		idx.tasks[task.ID] = task
+		if task.ParentID != "" {
+			idx.children[task.ParentID]++
+		}
+	}
+	for _, task := range state.Completed {
+		if _, ok := idx.tasks[task.ID]; !ok {
+			idx.tasks[task.ID] = task
+		}
+	}
+	for _, comment := range state.Comments {
+		idx.comments[comment.ID] = comment
+	}
+	for _, project := range state.Projects {
+		idx.projects[project.ID] = project
+		idx.projectByName[strings.ToLower(project.Name)] = project
+		if project.IsInbox {
+			idx.inboxID = project.ID
+		}
+	}
+	for _, section := range state.Sections {
+		idx.sections[section.ID] = section
+	}
+	return idx
+}
+
+func (idx *policyIndex) match(m PolicyMatch, a coreagent.Action, touched []*policyProject, require bool) matchResult {
+	var results []matchResult
+	if m.Project != "" {
+		results = append(results, projectsMatch(touched, require, func(p *policyProject) matchResult { return p.is(m.Project) }))
+	}
+	if m.ProjectUnder != "" {
+		results = append(results, projectsMatch(touched, require, func(p *policyProject) matchResult { return p.under(m.ProjectUnder) }))
+	}
+	if m.Priority != "" {
+		results = append(results, boolMatch(a.Priority != 0 && a.Priority == priorityValue(m.Priority)))
+	}
+	if m.Label != "" {
+		results = append(results, boolMatch(containsFold(a.Labels, m.Label) || containsFold(a.AddLabels, m.Label)))
+	}
+	if m.HasComments != nil {
+		results = append(results, idx.taskFact(a, *m.HasComments, func(task api.Task) bool { return task.NoteCount > 0 }))
+	}
+	if m.HasSubtasks != nil {
+		results = append(results, idx.taskFact(a, *m.HasSubtasks, func(task api.Task) bool { return idx.children[task.ID] > 0 }))
+	}
+	return and(results...)
+}
+
+// projectsMatch decides a project condition over every touched project: a
+// deny matches if any does, a require holds only if all do. Actions that
+// touch no project (label actions) therefore never match a deny's project
+// condition and always satisfy a require's.
+func projectsMatch(touched []*policyProject, all bool, check func(*policyProject) matchResult) matchResult {
+	if len(touched) == 0 {
+		return boolMatch(all)
+	}
+	if all {
+		results := make([]matchResult, 0, len(touched))
+		for _, p := range touched {
+			results = append(results, check(p))
+		}
+		return and(results...)
+	}
+	out := matchNo
+	for _, p := range touched {
+		switch check(p) {
+		case matchYes:
+			return matchYes
+		case matchUnknown:
+			out = matchUnknown
+		}
+	}
+	return out
+}
+
+// taskFact evaluates a fact about the existing task an action targets.
+// Tasks the plan creates have neither comments nor subtasks yet.
+func (idx *policyIndex) taskFact(a coreagent.Action, want bool, fact func(api.Task) bool) matchResult {
+	if !strings.HasPrefix(a.Type, "task_") || a.Type == "task_add" {
+		return boolMatch(!want)
+	}
+	if _, ok := coreagent.AliasRef(a.TaskID); ok {
+		return boolMatch(!want)
+	}
+	task, ok := idx.tasks[a.TaskID]
+	if !ok {
+		return matchUnknown
+	}
+	return boolMatch(fact(task) == want)
+}
+
+func (p *policyProject) is(ref string) matchResult {
+	if id := strings.TrimPrefix(ref, "id:"); p.id != "" && p.id == id {
+		return matchYes
+	}
+	if p.name == "" {
+		return matchUnknown
+	}
+	return boolMatch(strings.EqualFold(p.name, ref))
+}
+
+func (p *policyProject) under(ref string) matchResult {
+	for depth, cur := 0, p; cur != nil && depth < 64; depth, cur = depth+1, cur.parent {
+		if cur.is(ref) == matchYes {
+			return matchYes
+		}
+		if !cur.resolved {
+			return matchUnknown
+		}
+	}
+	return matchNo
+}
+
+// touchedProjects lists the projects action reads from or writes to: the
+// target's project, plus the destination for moves.
+func (idx *policyIndex) touchedProjects(a coreagent.Action) []*policyProject {
+	switch {
+	case a.Type == "task_add":
+		return []*policyProject{idx.destination(a)}
+	case a.Type == "task_move":
+		return []*policyProject{idx.taskProject(a.TaskID), idx.destination(a)}
+	case strings.HasPrefix(a.Type, "task_"):
+		return []*policyProject{idx.taskProject(a.TaskID)}
+	case a.Type == "project_add":
+		project := &policyProject{name: a.Name, resolved: true}
+		if _, alias := coreagent.AliasRef(a.Parent); alias || strings.HasPrefix(a.Parent, "id:") {
+			project.parent = idx.projectRef(strings.TrimPrefix(a.Parent, "id:"), "")
+		} else if a.Parent != "" {
+			project.parent = idx.projectRef("", a.Parent)
+		}
+		return []*policyProject{project}
+	case strings.HasPrefix(a.Type, "project_"):
+		return []*policyProject{idx.projectRef(a.ProjectID, "")}
+	case a.Type == "section_add":
+		return []*policyProject{idx.projectRef(a.ProjectID, a.Project)}
+	case strings.HasPrefix(a.Type, "section_"):
+		return []*policyProject{idx.sectionProject(a.SectionID)}
+	case a.Type == "comment_add" && a.TaskID != "":
+		return []*policyProject{idx.taskProject(a.TaskID)}
+	case a.Type == "comment_add":
+		return []*policyProject{idx.projectRef(a.ProjectID, "")}
+	case strings.HasPrefix(a.Type, "comment_"):
+		return []*policyProject{idx.commentProject(a.CommentID)}
+	}
+	return nil
+}
+
+func (idx *policyIndex) commentProject(commentID string) *policyProject {
+	if p, ok := idx.aliased(commentID); ok {
+		return p
+	}
+	comment, ok := idx.comments[commentID]
+	switch {
+	case !ok:
+		return &policyProject{}
+	case comment.TaskID != "":
+		return idx.taskProject(comment.TaskID)
+	}
+	return idx.projectRef(comment.ProjectID, "")
+}
+
+func (idx *policyIndex) destination(a coreagent.Action) *policyProject {
+	switch {
+	case a.Parent != "":
+		return idx.taskProject(a.Parent)
+	case a.SectionID != "":
+		return idx.sectionProject(a.SectionID)
+	case a.ProjectID != "" || a.Project != "":
+		return idx.projectRef(a.ProjectID, a.Project)
+	case idx.inboxID != "":
+		return idx.project(idx.inboxID, 0)
+	}
+	return &policyProject{}
+}
+
+// recordAlias remembers where an aliased entity lives so later "$alias"
+// references resolve to it.
+func (idx *policyIndex) recordAlias(a coreagent.Action, touched []*policyProject) {
+	if a.Alias == "" || len(touched) == 0 {
+		return
+	}
+	idx.aliases[a.Alias] = touched[len(touched)-1]
+}
+
+func (idx *policyIndex) aliased(ref string) (*policyProject, bool) {
+	name, ok := coreagent.AliasRef(ref)
+	if !ok {
+		return nil, false
+	}
+	if project := idx.aliases[name]; project != nil {
+		return project, true
+	}
+	return &policyProject{}, true
+}
+
+func (idx *policyIndex) taskProject(taskID string) *policyProject {
+	if p, ok := idx.aliased(taskID); ok {
+		return p
+	}
+	if task, ok := idx.tasks[taskID]; ok {
+		return idx.project(task.ProjectID, 0)
+	}
+	return &policyProject{}
+}
+
+func (idx *policyIndex) sectionProject(sectionID string) *policyProject {
+	if p, ok := idx.aliased(sectionID); ok {
+		return p
+	}
+	if section, ok := idx.sections[sectionID]; ok {
+		return idx.project(section.ProjectID, 0)
+	}
+	return &policyProject{}
+}
+
+// projectRef resolves a project by id, else by name; offline, a name is
+// known but its place in the hierarchy is not.
+func (idx *policyIndex) projectRef(id, name string) *policyProject {
+	if id != "" {
+		if p, ok := idx.aliased(id); ok {
+			return p
+		}
+		if _, ok := idx.projects[id]; ok {
+			return idx.project(id, 0)
+		}
+		if name == "" {
+			return &policyProject{id: id}
+		}
+	}
+	if project, ok := idx.projectByName[strings.ToLower(name)]; ok {
+		return idx.project(project.ID, 0)
+	}
+	return &policyProject{name: name}
+}
+
+func (idx *policyIndex) project(id string, depth int) *policyProject {
+	project, ok := idx.projects[id]
+	if !ok || depth > 64 {
+		return &policyProject{id: id}
+	}
+	p := &policyProject{id: project.ID, name: project.Name, resolved: true}
+	if project.ParentID != "" {
+		p.parent = idx.project(project.ParentID, depth+1)
+	}
+	return p
+}
+
+// priorityValue maps "p1".."p4" to the API priority (p1 is 4).
+func priorityValue(name string) int {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "p1":
+		return 4
+	case "p2":
+		return 3
+	case "p3":
+		return 2
+	case "p4":
+		return 1
+	}
+	return 0
+}
+
+func containsFold(values []string, want string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, want) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package agent
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	coreagent "github.com/agisilaos/todoist-cli/internal/agent"
+	"github.com/agisilaos/todoist-cli/internal/api"
+)
+
+func testPolicyState() *PolicyState {
+	return &PolicyState{
+		Projects: []api.Project{
+			{ID: "inbox", Name: "Inbox", IsInbox: true},
+			{ID: "p1", Name: "Work"},
+			{ID: "p2", Name: "Clients", ParentID: "p1"},
+			{ID: "p3", Name: "Home"},
+		},
+		Sections: []api.Section{{ID: "s3", ProjectID: "p3", Name: "Garden"}},
+		Tasks: []api.Task{
+			{ID: "t1", Content: "Deploy", ProjectID: "p2", NoteCount: 2},
+			{ID: "t2", Content: "Check logs", ProjectID: "p2", ParentID: "t1"},
+			{ID: "t3", Content: "Water plants", ProjectID: "p3"},
+		},
+	}
+}
+
+func TestEvaluatePolicyRulesScopes(t *testing.T) {
+	yes := true
+	rules := []PolicyRule{
+		{Name: "work-only", Actions: []string{"task_*"}, Require: &PolicyMatch{ProjectUnder: "Work"}},
+		{Name: "no-p1", Actions: []string{"task_add", "task_update"}, Deny: &PolicyMatch{Priority: "p1"}},
+		{Name: "keep-discussed", Actions: []string{"task_delete"}, Deny: &PolicyMatch{HasComments: &yes}},
+		{Name: "few-adds", Actions: []string{"task_add"}, Max: 1},
+	}
+	plan := coreagent.Plan{Actions: []coreagent.Action{
+		{Type: "task_update", TaskID: "t2", Priority: 3},
+		{Type: "task_update", TaskID: "t1", Priority: 4},
+		{Type: "task_move", TaskID: "t1", ProjectID: "p3"},
+		{Type: "task_delete", TaskID: "t1"},
+		{Type: "task_delete", TaskID: "t2"},
+		{Type: "task_add", Alias: "n", Content: "x", Project: "Clients"},
+		{Type: "task_complete", TaskID: "$n"},
+		{Type: "task_add", Content: "y"},
+		{Type: "label_add", Name: "later"},
+	}}
+	report := EvaluatePolicyRules(plan, rules, testPolicyState())
+	type hit struct {
+		rule   string
+		action int
+	}
+	var got []hit
+	for _, v := range report.Violations {
+		got = append(got, hit{v.Rule, v.Action})
+	}
+	want := []hit{{"no-p1", 2}, {"work-only", 3}, {"keep-discussed", 4}, {"work-only", 8}, {"few-adds", 0}}
+	if !reflect.DeepEqual(got, want) || len(report.Unchecked) != 0 {
+		t.Fatalf("unexpected report: %#v", report)
+	}
+	if v := report.Violations[4]; v.Limit != 1 || v.Count != 2 {
+		t.Fatalf("unexpected max finding: %#v", v)
+	}
+	if v := report.Violations[1]; v.Reason != "does not satisfy require: project_under=Work" || v.ActionType != "task_move" {
+		t.Fatalf("unexpected require finding: %#v", v)
+	}
+}
+
+func TestEvaluatePolicyRulesResolvesCommentsAndCompletedTasks(t *testing.T) {
+	rules := []PolicyRule{
+		{Name: "work-only", Require: &PolicyMatch{ProjectUnder: "Work"}},
+		{Name: "not-home", Deny: &PolicyMatch{Project: "Home"}},
+	}
+	state := testPolicyState()
+	state.Completed = []api.Task{{ID: "t8", Content: "Invoice", ProjectID: "p2", Checked: true}, {ID: "t9", ProjectID: "p3", Checked: true}}
+	state.Comments = []PolicyComment{{ID: "c1", TaskID: "t1"}, {ID: "c2", ProjectID: "p3"}}
+	plan := coreagent.Plan{Actions: []coreagent.Action{
+		{Type: "label_add", Name: "later"},
+		{Type: "task_reopen", TaskID: "t8"},
+		{Type: "comment_update", CommentID: "c1", Content: "done"},
+		{Type: "comment_delete", CommentID: "c2"},
+		{Type: "task_reopen", TaskID: "t9"},
+		{Type: "comment_add", Alias: "n", TaskID: "t1", Content: "note"},
+		{Type: "comment_update", CommentID: "$n", Content: "edited"},
+		{Type: "comment_delete", CommentID: "c404"},
+	}}
+	report := EvaluatePolicyRules(plan, rules, state)
+	var got []string
+	for _, v := range report.Violations {
+		got = append(got, fmt.Sprintf("%s@%d", v.Rule, v.Action))
+	}
+	if want := []string{"work-only@4", "not-home@4", "work-only@5", "not-home@5"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected violations %v", got)
+	}
+	if len(report.Unchecked) != 2 || report.Unchecked[0].Action != 8 || report.Unchecked[1].Action != 8 {
+		t.Fatalf("unexpected unchecked: %#v", report.Unchecked)
+	}
+}
+
+func TestEvaluatePolicyRulesOffline(t *testing.T) {
+	rules := []PolicyRule{
+		{Name: "work-only", Actions: []string{"task_*", "project_add"}, Require: &PolicyMatch{ProjectUnder: "Work"}},
+		{Name: "no-p1", Deny: &PolicyMatch{Priority: "p1"}},
+	}
+	plan := coreagent.Plan{Actions: []coreagent.Action{
+		{Type: "task_add", Content: "x", Project: "Work", Priority: 4},
+		{Type: "task_update", TaskID: "t1", Priority: 2},
+		{Type: "project_add", Alias: "c", Name: "Clients", Parent: "Work"},
+		{Type: "task_add", Content: "y", ProjectID: "$c"},
+		{Type: "project_add", Name: "Loose"},
+	}}
+	report := EvaluatePolicyRules(plan, rules, nil)
+	if len(report.Violations) != 2 || report.Violations[0].Rule != "no-p1" || report.Violations[0].Action != 1 ||
+		report.Violations[1].Rule != "work-only" || report.Violations[1].Action != 5 {
+		t.Fatalf("unexpected violations: %#v", report.Violations)
+	}
+	if len(report.Unchecked) != 1 || report.Unchecked[0].Action != 2 || report.Unchecked[0].Reason != "cannot verify require: project_under=Work" {
+		t.Fatalf("unexpected unchecked: %#v", report.Unchecked)
+	}
+}
+
+func TestValidatePolicyRules(t *testing.T) {
+	bad := []PolicyRule{
+		{Name: "both", Deny: &PolicyMatch{Priority: "p1"}, Max: 3},
+		{Name: "none"},
+		{Name: "empty", Deny: &PolicyMatch{}},
+		{Name: "prio", Require: &PolicyMatch{Priority: "urgent"}},
+		{Name: "glob", Actions: []string{"task_["}, Max: 1},
+		{Name: "neg", Max: -1},
+	}
+	for _, rule := range bad {
+		if err := ValidatePolicyRules([]PolicyRule{rule}); err == nil {
+			t.Fatalf("expected %s to be rejected", rule.Name)
+		}
+	}
+	if err := ValidatePolicyRules([]PolicyRule{{Actions: []string{"task_*"}, Max: 5}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
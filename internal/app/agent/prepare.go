@@ -7,13 +7,20 @@ import (
 	coreagent "github.com/agisilaos/todoist-cli/internal/agent"
 )
 
+// ErrConfirmMismatch means --confirm does not match the plan's confirm token.
+var ErrConfirmMismatch = errors.New("confirmation token does not match plan")
+
 type PrepareInput struct {
-	PlanPath        string
-	Instruction     string
+	PlanPath    string
+	Instruction string
+	// Plan is used as is when set, e.g. a plan synthesized by agent undo.
+	Plan            *coreagent.Plan
 	Confirm         string
 	ExpectedVersion int
 	Force           bool
 	DryRun          bool
+	// Preview validates and enforces policy without requiring --confirm.
+	Preview bool
 }
 
 type PrepareDeps struct {
@@ -21,12 +28,18 @@ type PrepareDeps struct {
 	Plan          func(instruction string) (coreagent.Plan, error)
 	ValidatePlan  func(plan coreagent.Plan, expectedVersion int, allowEmptyActions bool) error
 	EnforcePolicy func(plan coreagent.Plan) error
+	// Expand resolves a version 2 plan into literal actions before policy.
+	Expand func(plan coreagent.Plan) (coreagent.Plan, error)
+	// CheckStale rejects plans whose targets changed since planning.
+	CheckStale func(plan coreagent.Plan) error
 }
 
 func PreparePlan(in PrepareInput, deps PrepareDeps) (coreagent.Plan, error) {
 	var plan coreagent.Plan
 	var err error
-	if strings.TrimSpace(in.PlanPath) != "" {
+	if in.Plan != nil {
+		plan = *in.Plan
+	} else if strings.TrimSpace(in.PlanPath) != "" {
 		if deps.LoadPlan == nil {
 			return coreagent.Plan{}, errors.New("plan loader is not configured")
 		}
@@ -48,17 +61,32 @@ func PreparePlan(in PrepareInput, deps PrepareDeps) (coreagent.Plan, error) {
 			return coreagent.Plan{}, err
 		}
 	}
+	if deps.Expand != nil && plan.Version >= 2 {
+		if plan, err = deps.Expand(plan); err != nil {
+			return coreagent.Plan{}, err
+		}
+		if deps.ValidatePlan != nil {
+			if err := deps.ValidatePlan(plan, 0, true); err != nil {
+				return coreagent.Plan{}, err
+			}
+		}
+	}
 	if deps.EnforcePolicy != nil {
 		if err := deps.EnforcePolicy(plan); err != nil {
 			return coreagent.Plan{}, err
 		}
 	}
-	if !in.Force {
+	if !in.Force && !in.Preview {
 		if strings.TrimSpace(in.Confirm) == "" {
 			return coreagent.Plan{}, errors.New("--confirm is required (or use --force)")
 		}
 		if plan.ConfirmToken != "" && strings.TrimSpace(in.Confirm) != plan.ConfirmToken {
-			return coreagent.Plan{}, errors.New("confirmation token does not match plan")
+			return coreagent.Plan{}, ErrConfirmMismatch
+		}
+	}
+	if deps.CheckStale != nil {
+		if err := deps.CheckStale(plan); err != nil {
+			return coreagent.Plan{}, err
 		}
 	}
 	return plan, nil
@@ -47,3 +47,61 @@ func TestPreparePlanEnforcesPolicy(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestPreparePlanUsesGivenPlanAndPreview(t *testing.T) {
+	given := coreagent.Plan{ConfirmToken: "abcd", Actions: []coreagent.Action{{Type: "task_reopen", TaskID: "t1"}}}
+	deps := PrepareDeps{
+		LoadPlan: func(string) (coreagent.Plan, error) { return coreagent.Plan{}, errors.New("should not load") },
+		ValidatePlan: func(plan coreagent.Plan, expectedVersion int, allowEmptyActions bool) error {
+			return coreagent.ValidatePlan(plan, expectedVersion, allowEmptyActions)
+		},
+	}
+	plan, err := PreparePlan(PrepareInput{Plan: &given, Preview: true}, deps)
+	if err != nil || len(plan.Actions) != 1 {
+		t.Fatalf("unexpected preview result %#v: %v", plan, err)
+	}
+	if _, err := PreparePlan(PrepareInput{Plan: &given}, deps); err == nil || err.Error() != "--confirm is required (or use --force)" {
+		t.Fatalf("expected confirm error, got %v", err)
+	}
+	if _, err := PreparePlan(PrepareInput{Plan: &given, Confirm: "zzzz"}, deps); !errors.Is(err, ErrConfirmMismatch) {
+		t.Fatalf("expected confirm mismatch, got %v", err)
+	}
+}
+
+func TestPreparePlanChecksStaleAfterConfirm(t *testing.T) {
+	deps := PrepareDeps{
+		Plan: func(string) (coreagent.Plan, error) { return coreagent.Plan{ConfirmToken: "abcd"}, nil },
+		CheckStale: func(coreagent.Plan) error {
+			return errors.New("stale")
+		},
+	}
+	if _, err := PreparePlan(PrepareInput{Instruction: "x", Confirm: "zzzz"}, deps); !errors.Is(err, ErrConfirmMismatch) {
+		t.Fatalf("expected confirm mismatch first, got %v", err)
+	}
+	if _, err := PreparePlan(PrepareInput{Instruction: "x", Confirm: "abcd"}, deps); err == nil || err.Error() != "stale" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestPreparePlanExpandsVersion2BeforePolicy(t *testing.T) {
+	var policed coreagent.Plan
+	deps := PrepareDeps{
+		LoadPlan: func(string) (coreagent.Plan, error) {
+			return coreagent.Plan{Version: 2, ConfirmToken: "abcd", Actions: []coreagent.Action{{Type: "task_complete", Foreach: "today", TaskID: "{{item.id}}"}}}, nil
+		},
+		ValidatePlan: func(plan coreagent.Plan, expectedVersion int, allowEmptyActions bool) error {
+			return coreagent.ValidatePlan(plan, expectedVersion, allowEmptyActions)
+		},
+		Expand: func(plan coreagent.Plan) (coreagent.Plan, error) {
+			return coreagent.Plan{Version: 1, ConfirmToken: plan.ConfirmToken}, nil
+		},
+		EnforcePolicy: func(plan coreagent.Plan) error {
+			policed = plan
+			return nil
+		},
+	}
+	plan, err := PreparePlan(PrepareInput{PlanPath: "x.json", Confirm: "abcd"}, deps)
+	if err != nil || plan.Version != 1 || len(plan.Actions) != 0 || policed.Version != 1 {
+		t.Fatalf("unexpected plan %#v (policy saw %#v): %v", plan, policed, err)
+	}
+}
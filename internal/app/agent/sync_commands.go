@@ -0,0 +1,163 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	coreagent "github.com/agisilaos/todoist-cli/internal/agent"
+	"github.com/agisilaos/todoist-cli/internal/api"
+)
+
+// ResolveAliases replaces "$alias" references with the ids recorded for
+// earlier actions. Project parents are emitted as "id:<value>" so the project
+// resolver treats them as direct ids instead of names.
+func ResolveAliases(action coreagent.Action, aliases map[string]string) (coreagent.Action, error) {
+	resolved := action
+	resolved.Labels = append([]string(nil), action.Labels...)
+	resolved.AddLabels = append([]string(nil), action.AddLabels...)
+	resolved.RemoveLabels = append([]string(nil), action.RemoveLabels...)
+	for _, field := range coreagent.AliasFields(&resolved) {
+		name, ok := coreagent.AliasRef(*field.Value)
+		if !ok {
+			continue
+		}
+		id := strings.TrimSpace(aliases[name])
+		if id == "" {
+			return coreagent.Action{}, fmt.Errorf("%s: alias $%s has no created id (did the defining action fail?)", field.Name, name)
+		}
+		if field.Name == "parent" && resolved.Type == "project_add" {
+			id = "id:" + id
+		}
+		*field.Value = id
+	}
+	return resolved, nil
+}
+
+func BuildSyncCommand(action coreagent.Action, deps ActionDeps) (api.SyncCommand, error) {
+	req, err := BuildActionRequest(action, deps)
+	if err != nil {
+		return api.SyncCommand{}, err
+	}
+	switch action.Type {
+	case "task_add":
+		return api.NewSyncCommand("item_add", syncItemArgs(req.Body)), nil
+	case "task_update":
+		args := syncItemArgs(req.Body)
+		args["id"] = action.TaskID
+		return api.NewSyncCommand("item_update", args), nil
+	case "task_move":
+		args := map[string]any{"id": action.TaskID}
+		switch {
+		case req.Body["parent_id"] != nil:
+			args["parent_id"] = req.Body["parent_id"]
+		case req.Body["section_id"] != nil:
+			args["section_id"] = req.Body["section_id"]
+		case req.Body["project_id"] != nil:
+			args["project_id"] = req.Body["project_id"]
+		default:
+			return api.SyncCommand{}, errors.New("task_move requires a destination")
+		}
+		return api.NewSyncCommand("item_move", args), nil
+	case "task_complete":
+		return api.NewSyncCommand("item_close", map[string]any{"id": action.TaskID}), nil
+	case "task_reopen":
+		return api.NewSyncCommand("item_uncomplete", map[string]any{"id": action.TaskID}), nil
+	case "task_delete":
+		return api.NewSyncCommand("item_delete", map[string]any{"id": action.TaskID}), nil
+	case "project_add":
+		return api.NewSyncCommand("project_add", req.Body), nil
+	case "project_update":
+		args := copyArgs(req.Body)
+		args["id"] = action.ProjectID
+		return api.NewSyncCommand("project_update", args), nil
+	case "project_archive", "project_unarchive", "project_delete":
+		return api.NewSyncCommand(action.Type, map[string]any{"id": action.ProjectID}), nil
+	case "section_add":
+		args := copyArgs(req.Body)
+		renameArg(args, "order", "section_order")
+		return api.NewSyncCommand("section_add", args), nil
+	case "section_update":
+		args := copyArgs(req.Body)
+		args["id"] = action.SectionID
+		return api.NewSyncCommand("section_update", args), nil
+	case "section_delete":
+		return api.NewSyncCommand("section_delete", map[string]any{"id": action.SectionID}), nil
+	case "label_add":
+		args := copyArgs(req.Body)
+		renameArg(args, "order", "item_order")
+		return api.NewSyncCommand("label_add", args), nil
+	case "label_update":
+		args := copyArgs(req.Body)
+		renameArg(args, "order", "item_order")
+		args["id"] = action.LabelID
+		return api.NewSyncCommand("label_update", args), nil
+	case "label_delete":
+		return api.NewSyncCommand("label_delete", map[string]any{"id": action.LabelID}), nil
+	case "comment_add":
+		args := map[string]any{"content": req.Body["content"]}
+		if taskID, ok := req.Body["task_id"]; ok {
+			args["item_id"] = taskID
+			return api.NewSyncCommand("note_add", args), nil
+		}
+		if projectID, ok := req.Body["project_id"]; ok {
+			args["project_id"] = projectID
+			return api.NewSyncCommand("project_note_add", args), nil
+		}
+		return api.SyncCommand{}, errors.New("comment_add requires task_id or project")
+	case "comment_update":
+		return api.NewSyncCommand("note_update", map[string]any{"id": action.CommentID, "content": action.Content}), nil
+	case "comment_delete":
+		return api.NewSyncCommand("note_delete", map[string]any{"id": action.CommentID}), nil
+	default:
+		return api.SyncCommand{}, fmt.Errorf("unsupported action type: %s", action.Type)
+	}
+}
+
+func syncItemArgs(body map[string]any) map[string]any {
+	args := map[string]any{}
+	due := map[string]any{}
+	duration := map[string]any{}
+	for key, value := range body {
+		switch key {
+		case "due_string":
+			due["string"] = value
+		case "due_date", "due_datetime":
+			due["date"] = value
+		case "due_lang":
+			due["lang"] = value
+		case "duration":
+			duration["amount"] = value
+		case "duration_unit":
+			duration["unit"] = value
+		case "deadline_date":
+			args["deadline"] = map[string]any{"date": value}
+		case "assignee_id":
+			args["responsible_uid"] = value
+		default:
+			args[key] = value
+		}
+	}
+	if len(due) > 0 {
+		args["due"] = due
+	}
+	if len(duration) > 0 {
+		args["duration"] = duration
+	}
+	return args
+}
+
+func copyArgs(body map[string]any) map[string]any {
+	args := make(map[string]any, len(body))
+	for key, value := range body {
+		args[key] = value
+	}
+	return args
+}
+
+func renameArg(args map[string]any, from, to string) {
+	if value, ok := args[from]; ok {
+		delete(args, from)
+		args[to] = value
+	}
+}
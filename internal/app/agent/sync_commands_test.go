@@ -0,0 +1,63 @@
+package agent
+
+import (
+	"strings"
+	"testing"
+
+	coreagent "github.com/agisilaos/todoist-cli/internal/agent"
+	apptasks "github.com/agisilaos/todoist-cli/internal/app/tasks"
+)
+
+func TestResolveAliasesSubstitutesCreatedIDs(t *testing.T) {
+	aliases := map[string]string{"launch": "tmp-p", "draft": "tmp-t"}
+	action, err := ResolveAliases(coreagent.Action{Type: "task_add", Content: "x", ProjectID: "$launch", Parent: "$draft"}, aliases)
+	if err != nil {
+		t.Fatalf("ResolveAliases: %v", err)
+	}
+	if action.ProjectID != "tmp-p" || action.Parent != "tmp-t" {
+		t.Fatalf("unexpected action: %#v", action)
+	}
+	project, err := ResolveAliases(coreagent.Action{Type: "project_add", Name: "Child", Parent: "$launch"}, aliases)
+	if err != nil {
+		t.Fatalf("ResolveAliases project: %v", err)
+	}
+	if project.Parent != "id:tmp-p" {
+		t.Fatalf("expected direct id parent, got %q", project.Parent)
+	}
+	if _, err := ResolveAliases(coreagent.Action{Type: "task_complete", TaskID: "$gone"}, aliases); err == nil || !strings.Contains(err.Error(), "$gone") {
+		t.Fatalf("expected unresolved alias error, got %v", err)
+	}
+}
+
+func TestBuildSyncCommandTaskAddTranslatesPayload(t *testing.T) {
+	cmd, err := BuildSyncCommand(coreagent.Action{Type: "task_add", Content: "Ship", Due: "tomorrow", Duration: 30, DurationUnit: "minute"}, ActionDeps{
+		BuildTaskCreatePayload: func(in apptasks.MutationInput) (map[string]any, error) {
+			return map[string]any{"content": in.Content, "due_string": in.DueString, "duration": in.Duration, "duration_unit": in.DurationUnit, "assignee_id": "u1"}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("BuildSyncCommand: %v", err)
+	}
+	if cmd.Type != "item_add" || cmd.UUID == "" {
+		t.Fatalf("unexpected command: %#v", cmd)
+	}
+	due, _ := cmd.Args["due"].(map[string]any)
+	duration, _ := cmd.Args["duration"].(map[string]any)
+	if due["string"] != "tomorrow" || duration["amount"] != 30 || duration["unit"] != "minute" || cmd.Args["responsible_uid"] != "u1" {
+		t.Fatalf("unexpected args: %#v", cmd.Args)
+	}
+}
+
+func TestBuildSyncCommandMovePrefersMostSpecificDestination(t *testing.T) {
+	cmd, err := BuildSyncCommand(coreagent.Action{Type: "task_move", TaskID: "t1", ProjectID: "p1", SectionID: "s1"}, ActionDeps{
+		BuildTaskMovePayload: func(projectID, projectRef, sectionID, sectionRef, parent string) (map[string]any, error) {
+			return map[string]any{"project_id": projectID, "section_id": sectionID}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("BuildSyncCommand: %v", err)
+	}
+	if cmd.Type != "item_move" || cmd.Args["section_id"] != "s1" || cmd.Args["project_id"] != nil {
+		t.Fatalf("unexpected command: %#v", cmd)
+	}
+}
@@ -0,0 +1,366 @@
+package agent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	coreagent "github.com/agisilaos/todoist-cli/internal/agent"
+	"github.com/agisilaos/todoist-cli/internal/api"
+	apptasks "github.com/agisilaos/todoist-cli/internal/app/tasks"
+)
+
+// JournalAction is one applied action as kept in the apply journal: the
+// action with aliases resolved to real ids, the id it created, and the entity
+// it touched as it was just before the action ran.
+type JournalAction struct {
+	Index     int              `json:"index"`
+	Action    coreagent.Action `json:"action"`
+	CreatedID string           `json:"created_id,omitempty"`
+	Before    json.RawMessage  `json:"before,omitempty"`
+}
+
+// UndoSkip is an applied action the undo plan cannot (fully) revert.
+type UndoSkip struct {
+	Index  int    `json:"index"`
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+type UndoInput struct {
+	ApplyID     string
+	Instruction string
+	Applied     []JournalAction
+	Now         time.Time
+}
+
+// PreStatePath returns the REST path of the entity action changes, or "" when
+// there is nothing to capture: adds, and ids that are aliases for entities
+// created earlier in the same plan.
+func PreStatePath(action coreagent.Action) string {
+	var collection, id string
+	switch action.Type {
+	case "task_update", "task_move", "task_complete":
+		collection, id = "tasks", action.TaskID
+	case "project_update":
+		collection, id = "projects", action.ProjectID
+	case "section_update":
+		collection, id = "sections", action.SectionID
+	case "label_update":
+		collection, id = "labels", action.LabelID
+	case "comment_update":
+		collection, id = "comments", action.CommentID
+	default:
+		return ""
+	}
+	id = strings.TrimSpace(id)
+	if _, alias := coreagent.AliasRef(id); alias || id == "" {
+		return ""
+	}
+	return "/" + collection + "/" + id
+}
+
+// BuildUndoPlan returns the plan that reverts applied, newest action first,
+// and the actions it leaves alone. The confirm token is derived from the apply
+// id and the inverse actions, so previewing and confirming agree.
+func BuildUndoPlan(in UndoInput) (coreagent.Plan, []UndoSkip) {
+	created := map[string]bool{}
+	for _, applied := range in.Applied {
+		if applied.CreatedID != "" {
+			created[applied.CreatedID] = true
+		}
+	}
+	ordered := append([]JournalAction(nil), in.Applied...)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Index > ordered[j].Index })
+	var actions []coreagent.Action
+	var skipped []UndoSkip
+	for _, applied := range ordered {
+		// Entities the apply created are deleted outright; edits to them
+		// need no reverting first.
+		if created[targetID(applied.Action)] {
+			continue
+		}
+		inverse, reason := InverseAction(applied)
+		if inverse != nil {
+			inverse.Reason = fmt.Sprintf("undo #%d %s", applied.Index+1, applied.Action.Type)
+			actions = append(actions, *inverse)
+		}
+		if reason != "" {
+			skipped = append(skipped, UndoSkip{Index: applied.Index, Type: applied.Action.Type, Reason: reason})
+		}
+	}
+	instruction := "undo " + in.ApplyID
+	if strings.TrimSpace(in.Instruction) != "" {
+		instruction += ": " + strings.TrimSpace(in.Instruction)
+	}
+	plan := coreagent.Plan{
+		Version:      1,
+		Instruction:  instruction,
+		CreatedAt:    in.Now.UTC().Format(time.RFC3339),
+		ConfirmToken: undoConfirmToken(in.ApplyID, actions),
+		Summary:      coreagent.SummarizeActions(actions),
+		Actions:      actions,
+	}
+	return plan, skipped
+}
+
+// InverseAction returns the action that reverts applied. A non-empty reason
+// explains what cannot be reverted; with a nil action nothing can.
+func InverseAction(applied JournalAction) (*coreagent.Action, string) {
+	a := applied.Action
+	switch a.Type {
+	case "task_add", "project_add", "section_add", "label_add", "comment_add":
+		if applied.CreatedID == "" {
+			return nil, "created id was not recorded"
+		}
+		inverse := coreagent.Action{Type: strings.TrimSuffix(a.Type, "_add") + "_delete"}
+		setTargetID(&inverse, applied.CreatedID)
+		return &inverse, ""
+	case "task_complete":
+		return inverseTaskComplete(a, applied.Before)
+	case "task_reopen":
+		return &coreagent.Action{Type: "task_complete", TaskID: a.TaskID}, ""
+	case "project_archive":
+		return &coreagent.Action{Type: "project_unarchive", ProjectID: a.ProjectID}, ""
+	case "project_unarchive":
+		return &coreagent.Action{Type: "project_archive", ProjectID: a.ProjectID}, ""
+	case "task_delete", "project_delete", "section_delete", "label_delete", "comment_delete":
+		return nil, "deleted entities cannot be restored"
+	}
+	if len(applied.Before) == 0 {
+		return nil, "no pre-state was recorded"
+	}
+	switch a.Type {
+	case "task_update":
+		var before api.Task
+		if err := json.Unmarshal(applied.Before, &before); err != nil {
+			return nil, "unreadable pre-state: " + err.Error()
+		}
+		return inverseTaskUpdate(a, before)
+	case "task_move":
+		var before api.Task
+		if err := json.Unmarshal(applied.Before, &before); err != nil {
+			return nil, "unreadable pre-state: " + err.Error()
+		}
+		inverse := coreagent.Action{Type: "task_move", TaskID: a.TaskID}
+		switch {
+		case before.ParentID != "":
+			inverse.Parent = before.ParentID
+		case before.SectionID != "":
+			inverse.SectionID = before.SectionID
+		default:
+			inverse.ProjectID = before.ProjectID
+		}
+		return &inverse, ""
+	case "project_update":
+		var before api.Project
+		if err := json.Unmarshal(applied.Before, &before); err != nil {
+			return nil, "unreadable pre-state: " + err.Error()
+		}
+		inverse := coreagent.Action{Type: "project_update", ProjectID: a.ProjectID}
+		var lost []string
+		if a.Name != "" {
+			inverse.Name = before.Name
+		}
+		if a.Description != "" {
+			inverse.Description = before.Description
+			if before.Description == "" {
+				lost = append(lost, "description")
+			}
+		}
+		if a.Color != "" {
+			lost = append(lost, "color")
+		}
+		if a.Favorite != nil {
+			favorite := before.IsFavorite
+			inverse.Favorite = &favorite
+		}
+		return partialInverse(inverse, lost)
+	case "section_update":
+		var before api.Section
+		if err := json.Unmarshal(applied.Before, &before); err != nil {
+			return nil, "unreadable pre-state: " + err.Error()
+		}
+		return &coreagent.Action{Type: "section_update", SectionID: a.SectionID, Name: before.Name}, ""
+	case "label_update":
+		var before api.Label
+		if err := json.Unmarshal(applied.Before, &before); err != nil {
+			return nil, "unreadable pre-state: " + err.Error()
+		}
+		inverse := coreagent.Action{Type: "label_update", LabelID: a.LabelID}
+		if a.Name != "" {
+			inverse.Name = before.Name
+		}
+		if a.Color != "" {
+			inverse.Color = before.Color
+		}
+		if a.Order > 0 {
+			inverse.Order = before.Order
+		}
+		if a.Favorite != nil {
+			favorite := before.IsFavorite
+			inverse.Favorite = &favorite
+		}
+		return partialInverse(inverse, nil)
+	case "comment_update":
+		var before api.Comment
+		if err := json.Unmarshal(applied.Before, &before); err != nil {
+			return nil, "unreadable pre-state: " + err.Error()
+		}
+		return &coreagent.Action{Type: "comment_update", CommentID: a.CommentID, Content: before.Content}, ""
+	}
+	return nil, "unsupported action type"
+}
+
+// inverseTaskComplete reopens a completed task. Completing a recurring task
+// only moves its due to the next occurrence, so the inverse puts the recorded
+// due back instead; the due string is sent along to keep the recurrence.
+func inverseTaskComplete(a coreagent.Action, raw json.RawMessage) (*coreagent.Action, string) {
+	reopen := &coreagent.Action{Type: "task_reopen", TaskID: a.TaskID}
+	if len(raw) == 0 {
+		return reopen, "no pre-state was recorded; a recurring task's due is not restored"
+	}
+	var before api.Task
+	if err := json.Unmarshal(raw, &before); err != nil {
+		return nil, "unreadable pre-state: " + err.Error()
+	}
+	if before.Due == nil || !apptasks.IsRecurringDue(*before.Due) {
+		return reopen, ""
+	}
+	inverse := &coreagent.Action{Type: "task_update", TaskID: a.TaskID, Due: before.Due.String}
+	if before.Due.Datetime != "" {
+		inverse.DueDatetime = before.Due.Datetime
+	} else {
+		inverse.DueDate = before.Due.Date
+	}
+	return inverse, ""
+}
+
+func inverseTaskUpdate(a coreagent.Action, before api.Task) (*coreagent.Action, string) {
+	inverse := coreagent.Action{Type: "task_update", TaskID: a.TaskID}
+	var lost []string
+	if a.Content != "" {
+		inverse.Content = before.Content
+	}
+	if a.Description != "" {
+		inverse.Description = before.Description
+		if before.Description == "" {
+			lost = append(lost, "description")
+		}
+	}
+	if len(a.Labels) > 0 || len(a.AddLabels) > 0 || len(a.RemoveLabels) > 0 {
+		had := map[string]bool{}
+		for _, label := range before.Labels {
+			had[label] = true
+		}
+		inverse.AddLabels = append([]string(nil), before.Labels...)
+		for _, label := range append(append([]string(nil), a.Labels...), a.AddLabels...) {
+			if !had[label] {
+				inverse.RemoveLabels = append(inverse.RemoveLabels, label)
+			}
+		}
+	}
+	if a.Priority != 0 {
+		inverse.Priority = before.Priority
+	}
+	if a.Due != "" || a.DueDate != "" || a.DueDatetime != "" {
+		switch {
+		case before.Due == nil:
+			inverse.Due = "no date"
+		case before.Due.IsRecurring && before.Due.String != "":
+			inverse.Due = before.Due.String
+		case before.Due.Datetime != "":
+			inverse.DueDatetime = before.Due.Datetime
+		default:
+			inverse.DueDate = before.Due.Date
+		}
+	}
+	if a.Duration != 0 {
+		if before.Duration != nil {
+			inverse.Duration = before.Duration.Amount
+			inverse.DurationUnit = before.Duration.Unit
+		} else {
+			lost = append(lost, "duration")
+		}
+	}
+	if a.Deadline != "" {
+		if before.Deadline != nil {
+			inverse.Deadline = before.Deadline.Date
+		} else {
+			lost = append(lost, "deadline")
+		}
+	}
+	if a.Assignee != "" {
+		lost = append(lost, "assignee")
+	}
+	return partialInverse(inverse, lost)
+}
+
+// partialInverse drops an inverse update that restores nothing and reports the
+// fields that cannot be put back (fields that were empty before cannot be
+// cleared through a plan action).
+func partialInverse(inverse coreagent.Action, lost []string) (*coreagent.Action, string) {
+	reason := ""
+	if len(lost) > 0 {
+		reason = "cannot restore " + strings.Join(lost, ", ")
+	}
+	probe := inverse
+	probe.Type, probe.TaskID, probe.ProjectID, probe.LabelID = "", "", "", ""
+	probe.SectionID, probe.CommentID = "", ""
+	if isZeroAction(probe) {
+		if reason == "" {
+			reason = "nothing to restore"
+		}
+		return nil, reason
+	}
+	return &inverse, reason
+}
+
+func isZeroAction(a coreagent.Action) bool {
+	data, _ := json.Marshal(a)
+	return string(data) == `{"type":""}`
+}
+
+func targetID(a coreagent.Action) string {
+	switch {
+	case strings.HasPrefix(a.Type, "task_"):
+		return a.TaskID
+	case strings.HasPrefix(a.Type, "project_"):
+		return a.ProjectID
+	case strings.HasPrefix(a.Type, "section_"):
+		return a.SectionID
+	case strings.HasPrefix(a.Type, "label_"):
+		return a.LabelID
+	case strings.HasPrefix(a.Type, "comment_"):
+		return a.CommentID
+	}
+	return ""
+}
+
+func setTargetID(a *coreagent.Action, id string) {
+	switch {
+	case strings.HasPrefix(a.Type, "task_"):
+		a.TaskID = id
+	case strings.HasPrefix(a.Type, "project_"):
+		a.ProjectID = id
+	case strings.HasPrefix(a.Type, "section_"):
+		a.SectionID = id
+	case strings.HasPrefix(a.Type, "label_"):
+		a.LabelID = id
+	case strings.HasPrefix(a.Type, "comment_"):
+		a.CommentID = id
+	}
+}
+
+func undoConfirmToken(applyID string, actions []coreagent.Action) string {
+	data, _ := json.Marshal(struct {
+		ApplyID string             `json:"apply_id"`
+		Actions []coreagent.Action `json:"actions"`
+	}{applyID, actions})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:4]
+}
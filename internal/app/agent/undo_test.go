@@ -0,0 +1,133 @@
+package agent
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	coreagent "github.com/agisilaos/todoist-cli/internal/agent"
+	"github.com/agisilaos/todoist-cli/internal/api"
+)
+
+func mustJSON(t *testing.T, v any) json.RawMessage {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestBuildUndoPlanInvertsNewestFirst(t *testing.T) {
+	before := api.Task{
+		ID: "t1", Content: "Old", ProjectID: "p1", SectionID: "s1", Labels: []string{"home"}, Priority: 1,
+		Due: &api.Due{Date: "2026-10-17", String: "every day", IsRecurring: true},
+	}
+	plan, skipped := BuildUndoPlan(UndoInput{
+		ApplyID:     "a1",
+		Instruction: "triage",
+		Now:         time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC),
+		Applied: []JournalAction{
+			{Index: 0, Action: coreagent.Action{Type: "task_complete", TaskID: "t2"}, Before: mustJSON(t, api.Task{ID: "t2"})},
+			{Index: 1, Action: coreagent.Action{Type: "task_update", TaskID: "t1", Content: "New", Labels: []string{"work"}, Priority: 4, Due: "tomorrow"}, Before: mustJSON(t, before)},
+			{Index: 2, Action: coreagent.Action{Type: "task_move", TaskID: "t1", ProjectID: "p2"}, Before: mustJSON(t, before)},
+			{Index: 3, Action: coreagent.Action{Type: "task_add", Content: "Call", Alias: "c"}, CreatedID: "t9"},
+			{Index: 4, Action: coreagent.Action{Type: "task_complete", TaskID: "t9"}},
+			{Index: 5, Action: coreagent.Action{Type: "task_delete", TaskID: "t3"}},
+		},
+	})
+	want := []coreagent.Action{
+		{Type: "task_delete", TaskID: "t9", Reason: "undo #4 task_add"},
+		{Type: "task_move", TaskID: "t1", SectionID: "s1", Reason: "undo #3 task_move"},
+		{Type: "task_update", TaskID: "t1", Content: "Old", AddLabels: []string{"home"}, RemoveLabels: []string{"work"}, Priority: 1, Due: "every day", Reason: "undo #2 task_update"},
+		{Type: "task_reopen", TaskID: "t2", Reason: "undo #1 task_complete"},
+	}
+	if !reflect.DeepEqual(plan.Actions, want) {
+		t.Fatalf("unexpected actions:\n%#v", plan.Actions)
+	}
+	if plan.Instruction != "undo a1: triage" || plan.CreatedAt != "2026-10-17T09:00:00Z" || plan.Summary.Tasks != 4 {
+		t.Fatalf("unexpected plan: %#v", plan)
+	}
+	if len(skipped) != 1 || skipped[0].Index != 5 || skipped[0].Reason != "deleted entities cannot be restored" {
+		t.Fatalf("unexpected skipped: %#v", skipped)
+	}
+	if err := coreagent.ValidatePlan(plan, 1, false); err != nil {
+		t.Fatalf("undo plan does not validate: %v", err)
+	}
+	again, _ := BuildUndoPlan(UndoInput{ApplyID: "a1", Applied: []JournalAction{{Index: 0, Action: coreagent.Action{Type: "task_complete", TaskID: "t2"}}}})
+	same, _ := BuildUndoPlan(UndoInput{ApplyID: "a1", Applied: []JournalAction{{Index: 0, Action: coreagent.Action{Type: "task_complete", TaskID: "t2"}}}})
+	if again.ConfirmToken == "" || again.ConfirmToken != same.ConfirmToken || again.ConfirmToken == plan.ConfirmToken {
+		t.Fatalf("confirm token should follow the inverse actions: %q %q %q", again.ConfirmToken, same.ConfirmToken, plan.ConfirmToken)
+	}
+}
+
+func TestInverseActionPartialAndMissingState(t *testing.T) {
+	before := api.Task{ID: "t1", ProjectID: "p1", ParentID: "t0"}
+	inverse, reason := InverseAction(JournalAction{
+		Action: coreagent.Action{Type: "task_update", TaskID: "t1", Description: "notes", DueDate: "2026-10-20", Duration: 30, DurationUnit: "minute"},
+		Before: mustJSON(t, before),
+	})
+	want := &coreagent.Action{Type: "task_update", TaskID: "t1", Due: "no date"}
+	if !reflect.DeepEqual(inverse, want) || reason != "cannot restore description, duration" {
+		t.Fatalf("unexpected inverse %#v (%q)", inverse, reason)
+	}
+	if inverse, _ := InverseAction(JournalAction{Action: coreagent.Action{Type: "task_move", TaskID: "t1", ProjectID: "p2"}, Before: mustJSON(t, before)}); inverse.Parent != "t0" || inverse.ProjectID != "" {
+		t.Fatalf("expected a move back under the parent: %#v", inverse)
+	}
+	if inverse, reason := InverseAction(JournalAction{Action: coreagent.Action{Type: "task_update", TaskID: "t1", Priority: 4}}); inverse != nil || reason != "no pre-state was recorded" {
+		t.Fatalf("unexpected inverse without pre-state %#v (%q)", inverse, reason)
+	}
+	if inverse, reason := InverseAction(JournalAction{Action: coreagent.Action{Type: "task_update", TaskID: "t1", Assignee: "u1"}, Before: mustJSON(t, before)}); inverse != nil || reason != "cannot restore assignee" {
+		t.Fatalf("unexpected assignee inverse %#v (%q)", inverse, reason)
+	}
+	favorite := true
+	label := api.Label{ID: "l1", Name: "home", Color: "red"}
+	inverse, _ = InverseAction(JournalAction{Action: coreagent.Action{Type: "label_update", LabelID: "l1", Name: "house", Favorite: &favorite}, Before: mustJSON(t, label)})
+	if inverse.Name != "home" || inverse.Favorite == nil || *inverse.Favorite || inverse.Color != "" {
+		t.Fatalf("unexpected label inverse: %#v", inverse)
+	}
+	if inverse, _ := InverseAction(JournalAction{Action: coreagent.Action{Type: "project_add", Name: "X"}, CreatedID: "p9"}); inverse.Type != "project_delete" || inverse.ProjectID != "p9" {
+		t.Fatalf("unexpected add inverse: %#v", inverse)
+	}
+}
+
+func TestInverseActionRestoresRecurringDue(t *testing.T) {
+	complete := coreagent.Action{Type: "task_complete", TaskID: "t1"}
+	recurring := api.Task{ID: "t1", Due: &api.Due{Date: "2026-10-17", Datetime: "2026-10-17T09:00:00", String: "every day at 9", IsRecurring: true}}
+	inverse, reason := InverseAction(JournalAction{Action: complete, Before: mustJSON(t, recurring)})
+	want := &coreagent.Action{Type: "task_update", TaskID: "t1", Due: "every day at 9", DueDatetime: "2026-10-17T09:00:00"}
+	if !reflect.DeepEqual(inverse, want) || reason != "" {
+		t.Fatalf("unexpected recurring inverse %#v (%q)", inverse, reason)
+	}
+	oneOff := api.Task{ID: "t1", Due: &api.Due{Date: "2026-10-17"}}
+	if inverse, reason := InverseAction(JournalAction{Action: complete, Before: mustJSON(t, oneOff)}); inverse.Type != "task_reopen" || reason != "" {
+		t.Fatalf("unexpected one-off inverse %#v (%q)", inverse, reason)
+	}
+	if inverse, reason := InverseAction(JournalAction{Action: complete}); inverse.Type != "task_reopen" || !strings.Contains(reason, "recurring task's due is not restored") {
+		t.Fatalf("unexpected inverse without pre-state %#v (%q)", inverse, reason)
+	}
+}
+
+func TestPreStatePath(t *testing.T) {
+	cases := map[string]coreagent.Action{
+		"/tasks/t1":     {Type: "task_update", TaskID: "t1"},
+		"/sections/s1":  {Type: "section_update", SectionID: "s1"},
+		"/comments/c1":  {Type: "comment_update", CommentID: "c1"},
+		"":              {Type: "task_update", TaskID: "$t"},
+		"/projects/p1":  {Type: "project_update", ProjectID: "p1"},
+		"/labels/l1":    {Type: "label_update", LabelID: "l1"},
+		"/tasks/t2":     {Type: "task_move", TaskID: "t2", ProjectID: "p1"},
+		"/tasks/t3":     {Type: "task_complete", TaskID: "t3"},
+		"none-for-adds": {Type: "task_add", Content: "x"},
+	}
+	for want, action := range cases {
+		if want == "none-for-adds" {
+			want = ""
+		}
+		if got := PreStatePath(action); got != want {
+			t.Fatalf("PreStatePath(%#v) = %q, want %q", action, got, want)
+		}
+	}
+}
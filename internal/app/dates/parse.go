@@ -0,0 +1,549 @@
+package dates
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Due is a due expression resolved against a reference time. Date is the
+// first occurrence; its clock is meaningful only when HasTime is set.
+type Due struct {
+	Input      string
+	Date       time.Time
+	HasTime    bool
+	Recurrence *Recurrence
+}
+
+// Preview is the JSON-friendly form of a resolved due: Date and Datetime in
+// the Todoist API's formats (Datetime is floating, without a zone), and the
+// next occurrences in the same format.
+type Preview struct {
+	Input       string   `json:"input"`
+	Date        string   `json:"date"`
+	Datetime    string   `json:"datetime,omitempty"`
+	IsRecurring bool     `json:"is_recurring"`
+	Description string   `json:"description"`
+	Rule        string   `json:"rule,omitempty"`
+	Occurrences []string `json:"occurrences,omitempty"`
+}
+
+const floatingDatetime = "2006-01-02T15:04:05"
+
+func (d Due) Preview(occurrences int) Preview {
+	out := Preview{
+		Input:       d.Input,
+		Date:        d.Date.Format(time.DateOnly),
+		IsRecurring: d.Recurrence != nil,
+		Description: d.Describe(),
+	}
+	if d.HasTime {
+		out.Datetime = d.Date.Format(floatingDatetime)
+	}
+	if d.Recurrence != nil {
+		out.Rule = d.Recurrence.RRule()
+	}
+	for _, at := range d.Occurrences(occurrences) {
+		if d.HasTime {
+			out.Occurrences = append(out.Occurrences, at.Format(floatingDatetime))
+		} else {
+			out.Occurrences = append(out.Occurrences, at.Format(time.DateOnly))
+		}
+	}
+	return out
+}
+
+// Describe restates the due in canonical form: the rule for recurring dues,
+// otherwise the resolved day such as "Mon 2026-10-19 09:00".
+func (d Due) Describe() string {
+	if d.Recurrence != nil {
+		return d.Recurrence.Describe()
+	}
+	return FormatDay(d.Date, d.HasTime)
+}
+
+func FormatDay(t time.Time, hasTime bool) string {
+	if hasTime {
+		return t.Format("Mon 2006-01-02 15:04")
+	}
+	return t.Format("Mon 2006-01-02")
+}
+
+type clock struct {
+	hour   int
+	minute int
+}
+
+// Parse resolves a due expression such as "tomorrow at 9", "next friday",
+// "in 3 days", "mar 10" or "every other weekday at 9:30" relative to now.
+// Days are calendar days in now's location. It covers the common English
+// grammar only; anything else is an error so callers can fall back to
+// sending the raw string to Todoist.
+func Parse(expr string, now time.Time) (Due, error) {
+	input := strings.TrimSpace(expr)
+	if input == "" {
+		return Due{}, errors.New("due expression is empty")
+	}
+	p := &parser{toks: tokenize(input), now: now, today: midnight(now)}
+	due, ok, err := p.recurring()
+	if err != nil {
+		return Due{}, err
+	}
+	if !ok {
+		if due, err = p.single(); err != nil {
+			return Due{}, err
+		}
+	}
+	due.Input = input
+	return due, nil
+}
+
+type parser struct {
+	toks  []string
+	pos   int
+	now   time.Time
+	today time.Time
+}
+
+func tokenize(input string) []string {
+	input = strings.ToLower(input)
+	input = strings.ReplaceAll(input, ",", " , ")
+	return strings.Fields(input)
+}
+
+func (p *parser) peek(offset int) string {
+	if p.pos+offset < len(p.toks) {
+		return p.toks[p.pos+offset]
+	}
+	return ""
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.toks)
+}
+
+func (p *parser) accept(words ...string) bool {
+	tok := p.peek(0)
+	for _, word := range words {
+		if tok == word {
+			p.pos++
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) unexpected() error {
+	if p.done() {
+		return errors.New("incomplete due expression")
+	}
+	return fmt.Errorf("unrecognized %q in due expression", strings.Join(p.toks[p.pos:], " "))
+}
+
+func (p *parser) single() (Due, error) {
+	var day time.Time
+	var at *clock
+	for !p.done() {
+		if p.accept("on", "due") {
+			continue
+		}
+		if at == nil {
+			if c, ok := p.clock(); ok {
+				at = c
+				continue
+			}
+		}
+		if day.IsZero() {
+			d, c, ok, err := p.date()
+			if err != nil {
+				return Due{}, err
+			}
+			if ok {
+				day = d
+				if c != nil {
+					if at != nil {
+						return Due{}, errors.New("due expression has two times")
+					}
+					at = c
+				}
+				continue
+			}
+		}
+		return Due{}, p.unexpected()
+	}
+	if day.IsZero() && at == nil {
+		return Due{}, p.unexpected()
+	}
+	if day.IsZero() {
+		day = p.today
+	}
+	due := Due{Date: day}
+	if at != nil {
+		due.Date = at.on(day)
+		due.HasTime = true
+	}
+	return due, nil
+}
+
+// date reads one calendar date. Relative hour and minute offsets also carry
+// the resulting clock.
+func (p *parser) date() (time.Time, *clock, bool, error) {
+	tok := p.peek(0)
+	switch tok {
+	case "today", "tod":
+		p.pos++
+		return p.today, nil, true, nil
+	case "tomorrow", "tom", "tmr":
+		p.pos++
+		return p.today.AddDate(0, 0, 1), nil, true, nil
+	case "yesterday":
+		p.pos++
+		return p.today.AddDate(0, 0, -1), nil, true, nil
+	case "tonight":
+		p.pos++
+		return p.today, &clock{hour: 19}, true, nil
+	case "weekend":
+		p.pos++
+		return onOrAfter(p.today, time.Saturday), nil, true, nil
+	case "eom":
+		p.pos++
+		return endOfMonth(p.today), nil, true, nil
+	case "end":
+		if p.peek(1) == "of" && p.peek(2) == "month" {
+			p.pos += 3
+			return endOfMonth(p.today), nil, true, nil
+		}
+		if p.peek(1) == "of" && p.peek(2) == "the" && p.peek(3) == "month" {
+			p.pos += 4
+			return endOfMonth(p.today), nil, true, nil
+		}
+	case "this":
+		next := p.peek(1)
+		if next == "weekend" {
+			p.pos += 2
+			return onOrAfter(p.today, time.Saturday), nil, true, nil
+		}
+		if wd, ok := weekdayByName(next); ok {
+			p.pos += 2
+			return onOrAfter(p.today, wd), nil, true, nil
+		}
+	case "next":
+		nextMonday := after(p.today, time.Monday)
+		next := p.peek(1)
+		switch next {
+		case "week":
+			p.pos += 2
+			return nextMonday, nil, true, nil
+		case "weekend":
+			p.pos += 2
+			return nextMonday.AddDate(0, 0, 5), nil, true, nil
+		case "month":
+			p.pos += 2
+			return time.Date(p.today.Year(), p.today.Month()+1, 1, 0, 0, 0, 0, p.today.Location()), nil, true, nil
+		case "year":
+			p.pos += 2
+			return time.Date(p.today.Year()+1, time.January, 1, 0, 0, 0, 0, p.today.Location()), nil, true, nil
+		}
+		if wd, ok := weekdayByName(next); ok {
+			p.pos += 2
+			return nextMonday.AddDate(0, 0, mondayOffset(wd)), nil, true, nil
+		}
+	case "in":
+		return p.relative()
+	}
+	if wd, ok := weekdayByName(tok); ok {
+		p.pos++
+		return after(p.today, wd), nil, true, nil
+	}
+	if day, err := time.ParseInLocation(time.DateOnly, tok, p.today.Location()); err == nil {
+		p.pos++
+		return day, nil, true, nil
+	}
+	if month, day, ok := p.monthDay(); ok {
+		year, hasYear := p.year()
+		if !hasYear {
+			// The next such day on or after today; Feb 29 waits for a
+			// leap year.
+			year = p.today.Year()
+			for i := 0; i < 8 && (day > daysIn(year, month) || time.Date(year, month, day, 0, 0, 0, 0, p.today.Location()).Before(p.today)); i++ {
+				year++
+			}
+		}
+		if day > daysIn(year, month) {
+			return time.Time{}, nil, false, fmt.Errorf("%s has no day %d", month, day)
+		}
+		return time.Date(year, month, day, 0, 0, 0, 0, p.today.Location()), nil, true, nil
+	}
+	start := p.pos
+	p.accept("the")
+	if day, ok := ordinal(p.peek(0)); ok {
+		p.pos++
+		if p.peek(0) == "of" && p.peek(1) == "month" {
+			p.pos += 2
+		} else if p.peek(0) == "of" && p.peek(1) == "the" && p.peek(2) == "month" {
+			p.pos += 3
+		}
+		date := clampDay(p.today.Year(), p.today.Month(), day, p.today.Location())
+		if date.Before(p.today) {
+			date = clampDay(p.today.Year(), p.today.Month()+1, day, p.today.Location())
+		}
+		return date, nil, true, nil
+	}
+	p.pos = start
+	return time.Time{}, nil, false, nil
+}
+
+// relative reads "in 3 days", "in a week" or "in 2 hours".
+func (p *parser) relative() (time.Time, *clock, bool, error) {
+	n, ok := count(p.peek(1))
+	if !ok {
+		return time.Time{}, nil, false, nil
+	}
+	unit := singular(p.peek(2))
+	switch unit {
+	case "day":
+		p.pos += 3
+		return p.today.AddDate(0, 0, n), nil, true, nil
+	case "week":
+		p.pos += 3
+		return p.today.AddDate(0, 0, 7*n), nil, true, nil
+	case "month":
+		p.pos += 3
+		return p.today.AddDate(0, n, 0), nil, true, nil
+	case "year":
+		p.pos += 3
+		return p.today.AddDate(n, 0, 0), nil, true, nil
+	case "hour", "minute", "min":
+		p.pos += 3
+		step := time.Hour
+		if unit != "hour" {
+			step = time.Minute
+		}
+		at := p.now.Add(time.Duration(n) * step).Truncate(time.Minute)
+		return midnight(at), &clock{hour: at.Hour(), minute: at.Minute()}, true, nil
+	}
+	return time.Time{}, nil, false, fmt.Errorf("unknown unit %q after \"in\"", p.peek(2))
+}
+
+// monthDay reads "mar 10", "march 10th", "10 march" or "10th of march".
+func (p *parser) monthDay() (time.Month, int, bool) {
+	if month, ok := monthByName(p.peek(0)); ok {
+		if day, ok := dayNumber(p.peek(1)); ok {
+			p.pos += 2
+			return month, day, true
+		}
+		return 0, 0, false
+	}
+	day, ok := dayNumber(p.peek(0))
+	if !ok {
+		return 0, 0, false
+	}
+	offset := 1
+	if p.peek(1) == "of" {
+		offset = 2
+	}
+	if month, ok := monthByName(p.peek(offset)); ok {
+		p.pos += offset + 1
+		return month, day, true
+	}
+	return 0, 0, false
+}
+
+func (p *parser) year() (int, bool) {
+	tok := p.peek(0)
+	if len(tok) != 4 {
+		return 0, false
+	}
+	year, err := strconv.Atoi(tok)
+	if err != nil || year < 1970 {
+		return 0, false
+	}
+	p.pos++
+	return year, true
+}
+
+var clockPattern = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm|a|p)?$`)
+
+// clock reads a time of day: "at 9", "9am", "9:30 pm", "14:00", "noon".
+// A bare hour needs a leading "at" so it is not mistaken for a day.
+func (p *parser) clock() (*clock, bool) {
+	start := p.pos
+	at := p.accept("at", "@")
+	switch p.peek(0) {
+	case "noon", "midday":
+		p.pos++
+		return &clock{hour: 12}, true
+	case "midnight":
+		p.pos++
+		return &clock{}, true
+	case "morning":
+		p.pos++
+		return &clock{hour: 9}, true
+	case "evening":
+		p.pos++
+		return &clock{hour: 19}, true
+	}
+	m := clockPattern.FindStringSubmatch(p.peek(0))
+	if m == nil {
+		p.pos = start
+		return nil, false
+	}
+	suffix := m[3]
+	width := 1
+	if suffix == "" && (p.peek(1) == "am" || p.peek(1) == "pm") {
+		suffix = p.peek(1)
+		width = 2
+	}
+	if suffix == "" && m[2] == "" && !at {
+		p.pos = start
+		return nil, false
+	}
+	hour, _ := strconv.Atoi(m[1])
+	minute := 0
+	if m[2] != "" {
+		minute, _ = strconv.Atoi(m[2])
+	}
+	if minute > 59 || hour > 23 || (suffix != "" && (hour == 0 || hour > 12)) {
+		p.pos = start
+		return nil, false
+	}
+	switch suffix {
+	case "am", "a":
+		if hour == 12 {
+			hour = 0
+		}
+	case "pm", "p":
+		if hour != 12 {
+			hour += 12
+		}
+	}
+	p.pos += width
+	return &clock{hour: hour, minute: minute}, true
+}
+
+func (c clock) on(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), c.hour, c.minute, 0, 0, day.Location())
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "weds": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+	"sun": time.Sunday, "sunday": time.Sunday,
+}
+
+// weekdayByName also accepts plurals ("mondays") for recurrences.
+func weekdayByName(tok string) (time.Weekday, bool) {
+	if wd, ok := weekdayNames[tok]; ok {
+		return wd, true
+	}
+	wd, ok := weekdayNames[strings.TrimSuffix(tok, "s")]
+	return wd, ok && strings.HasSuffix(tok, "days")
+}
+
+var monthNames = map[string]time.Month{
+	"jan": time.January, "january": time.January,
+	"feb": time.February, "february": time.February,
+	"mar": time.March, "march": time.March,
+	"apr": time.April, "april": time.April,
+	"may": time.May,
+	"jun": time.June, "june": time.June,
+	"jul": time.July, "july": time.July,
+	"aug": time.August, "august": time.August,
+	"sep": time.September, "sept": time.September, "september": time.September,
+	"oct": time.October, "october": time.October,
+	"nov": time.November, "november": time.November,
+	"dec": time.December, "december": time.December,
+}
+
+func monthByName(tok string) (time.Month, bool) {
+	month, ok := monthNames[tok]
+	return month, ok
+}
+
+var ordinalPattern = regexp.MustCompile(`^(\d{1,2})(st|nd|rd|th)$`)
+
+// ordinal reads "1st", "22nd", "15th".
+func ordinal(tok string) (int, bool) {
+	m := ordinalPattern.FindStringSubmatch(tok)
+	if m == nil {
+		return 0, false
+	}
+	n, _ := strconv.Atoi(m[1])
+	return n, n >= 1 && n <= 31
+}
+
+// dayNumber reads a day of month with or without an ordinal suffix.
+func dayNumber(tok string) (int, bool) {
+	if n, ok := ordinal(tok); ok {
+		return n, true
+	}
+	if len(tok) > 2 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(tok)
+	return n, err == nil && n >= 1 && n <= 31
+}
+
+func count(tok string) (int, bool) {
+	switch tok {
+	case "a", "an", "one":
+		return 1, true
+	case "two":
+		return 2, true
+	case "three":
+		return 3, true
+	}
+	n, err := strconv.Atoi(tok)
+	return n, err == nil && n > 0
+}
+
+func singular(tok string) string {
+	if len(tok) > 3 && strings.HasSuffix(tok, "s") {
+		return strings.TrimSuffix(tok, "s")
+	}
+	return tok
+}
+
+func midnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// after returns the first wd strictly after day; onOrAfter includes day.
+func after(day time.Time, wd time.Weekday) time.Time {
+	return onOrAfter(day.AddDate(0, 0, 1), wd)
+}
+
+func onOrAfter(day time.Time, wd time.Weekday) time.Time {
+	return day.AddDate(0, 0, (int(wd)-int(day.Weekday())+7)%7)
+}
+
+// mondayOffset counts days from Monday, so weeks run Monday to Sunday.
+func mondayOffset(wd time.Weekday) int {
+	return (int(wd) + 6) % 7
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func endOfMonth(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, day.Location())
+}
+
+// clampDay moves days past the end of the month onto its last day, so "31st"
+// lands on Feb 28.
+func clampDay(year int, month time.Month, day int, loc *time.Location) time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, loc)
+	last := daysIn(first.Year(), first.Month())
+	if day < 0 || day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
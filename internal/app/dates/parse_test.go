@@ -0,0 +1,63 @@
+package dates
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// refNow is Saturday 2026-10-17 10:30.
+var refNow = time.Date(2026, 10, 17, 10, 30, 0, 0, time.UTC)
+
+func TestParseSingleDates(t *testing.T) {
+	cases := []struct {
+		expr     string
+		date     string
+		datetime string
+	}{
+		{"today", "2026-10-17", ""},
+		{"Tomorrow at 9", "2026-10-18", "2026-10-18T09:00:00"},
+		{"at 9", "2026-10-17", "2026-10-17T09:00:00"},
+		{"9:30 pm", "2026-10-17", "2026-10-17T21:30:00"},
+		{"noon tomorrow", "2026-10-18", "2026-10-18T12:00:00"},
+		{"friday", "2026-10-23", ""},
+		{"sat", "2026-10-24", ""},
+		{"this saturday", "2026-10-17", ""},
+		{"next friday", "2026-10-23", ""},
+		{"next week", "2026-10-19", ""},
+		{"next month", "2026-11-01", ""},
+		{"in 3 days", "2026-10-20", ""},
+		{"in a week", "2026-10-24", ""},
+		{"in 2 hours", "2026-10-17", "2026-10-17T12:30:00"},
+		{"mar 10", "2027-03-10", ""},
+		{"10th of march 2028", "2028-03-10", ""},
+		{"feb 29", "2028-02-29", ""},
+		{"oct 20 at 14:00", "2026-10-20", "2026-10-20T14:00:00"},
+		{"15th", "2026-11-15", ""},
+		{"1st of the month", "2026-11-01", ""},
+		{"end of month", "2026-10-31", ""},
+		{"2026-12-24", "2026-12-24", ""},
+	}
+	for _, tc := range cases {
+		due, err := Parse(tc.expr, refNow)
+		if err != nil {
+			t.Fatalf("%q: %v", tc.expr, err)
+		}
+		preview := due.Preview(1)
+		if preview.Date != tc.date || preview.Datetime != tc.datetime || preview.IsRecurring {
+			t.Fatalf("%q: got date=%q datetime=%q recurring=%v", tc.expr, preview.Date, preview.Datetime, preview.IsRecurring)
+		}
+	}
+}
+
+func TestParseRejectsUnknownInput(t *testing.T) {
+	for _, expr := range []string{"", "someday", "every blah", "at 25", "feb 30", "feb 29 2027", "in 3 fortnights"} {
+		if _, err := Parse(expr, refNow); err == nil {
+			t.Fatalf("expected error for %q", expr)
+		}
+	}
+	_, err := Parse("friday maybe", refNow)
+	if err == nil || !strings.Contains(err.Error(), `"maybe"`) {
+		t.Fatalf("expected error naming the leftover words, got %v", err)
+	}
+}
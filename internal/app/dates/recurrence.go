@@ -0,0 +1,602 @@
+package dates
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	Daily    Frequency = "daily"
+	Weekdays Frequency = "weekdays"
+	Weekly   Frequency = "weekly"
+	Monthly  Frequency = "monthly"
+	Yearly   Frequency = "yearly"
+)
+
+// Recurrence is a repeating due rule. MonthDays uses -1 for the last day of
+// the month; days past a month's end fall on its last day. A monthly rule
+// with WeekdayPos repeats on that week of the month (-1 for the last) of its
+// single weekday, as in "every 3rd friday".
+type Recurrence struct {
+	Frequency      Frequency
+	Interval       int
+	Weekdays       []time.Weekday
+	WeekdayPos     int
+	MonthDays      []int
+	Month          time.Month
+	FromCompletion bool
+	Start          time.Time
+	Until          time.Time
+
+	at            *clock
+	explicitStart bool
+}
+
+// recurring reads "every ..." rules and the daily/weekly/monthly/yearly
+// shorthands, followed by an optional time, "starting <date>" and
+// "until <date>".
+func (p *parser) recurring() (Due, bool, error) {
+	rec := &Recurrence{Interval: 1}
+	switch tok := p.peek(0); tok {
+	case "every", "ev", "every!", "ev!":
+		rec.FromCompletion = strings.HasSuffix(tok, "!")
+		p.pos++
+		if err := p.recurrenceSpec(rec); err != nil {
+			return Due{}, false, err
+		}
+	case "daily":
+		p.pos++
+		rec.Frequency = Daily
+	case "weekly":
+		p.pos++
+		rec.Frequency = Weekly
+	case "monthly":
+		p.pos++
+		rec.Frequency = Monthly
+	case "yearly", "annually":
+		p.pos++
+		rec.Frequency = Yearly
+	default:
+		return Due{}, false, nil
+	}
+	rec.Start = p.today
+	for !p.done() {
+		if rec.at == nil {
+			if c, ok := p.clock(); ok {
+				rec.at = c
+				continue
+			}
+		}
+		if !rec.explicitStart && p.accept("starting", "from", "beginning") {
+			p.accept("on")
+			day, err := p.boundary("starting")
+			if err != nil {
+				return Due{}, false, err
+			}
+			rec.Start = day
+			rec.explicitStart = true
+			continue
+		}
+		if rec.Until.IsZero() && p.accept("until", "ending", "till") {
+			p.accept("on")
+			day, err := p.boundary("until")
+			if err != nil {
+				return Due{}, false, err
+			}
+			rec.Until = day
+			continue
+		}
+		return Due{}, false, p.unexpected()
+	}
+	rec.fillDefaults()
+	// A time already past today starts the rule tomorrow.
+	if !rec.explicitStart && rec.at != nil && rec.at.on(p.today).Before(p.now) {
+		rec.Start = p.today.AddDate(0, 0, 1)
+	}
+	due := Due{Recurrence: rec, HasTime: rec.at != nil}
+	first := rec.next(rec.Start, 1, nil)
+	if len(first) == 0 {
+		return Due{}, false, errors.New("recurrence has no occurrences")
+	}
+	due.Date = first[0]
+	return due, true, nil
+}
+
+func (p *parser) boundary(keyword string) (time.Time, error) {
+	day, at, ok, err := p.date()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !ok || at != nil {
+		return time.Time{}, fmt.Errorf("%q needs a date", keyword)
+	}
+	return day, nil
+}
+
+func (p *parser) recurrenceSpec(rec *Recurrence) error {
+	if p.accept("other") {
+		rec.Interval = 2
+	} else if n, err := strconv.Atoi(p.peek(0)); err == nil && n > 0 && !p.isMonthDay() {
+		rec.Interval = n
+		p.pos++
+	}
+	switch singular(p.peek(0)) {
+	case "day":
+		p.pos++
+		rec.Frequency = Daily
+		return nil
+	case "weekday", "workday":
+		p.pos++
+		rec.Frequency = Weekdays
+		return nil
+	case "weekend":
+		p.pos++
+		rec.Frequency = Weekly
+		rec.Weekdays = []time.Weekday{time.Saturday, time.Sunday}
+		return nil
+	case "week":
+		p.pos++
+		rec.Frequency = Weekly
+		if p.accept("on") {
+			return p.weekdayList(rec)
+		}
+		return nil
+	case "month":
+		p.pos++
+		rec.Frequency = Monthly
+		if p.accept("on") {
+			return p.monthDayList(rec)
+		}
+		return nil
+	case "year":
+		p.pos++
+		rec.Frequency = Yearly
+		if p.accept("on") {
+			return p.yearDay(rec)
+		}
+		return nil
+	}
+	if _, ok := weekdayByName(p.peek(0)); ok {
+		rec.Frequency = Weekly
+		return p.weekdayList(rec)
+	}
+	if p.isMonthDay() {
+		rec.Frequency = Yearly
+		return p.yearDay(rec)
+	}
+	rec.Frequency = Monthly
+	return p.monthDayList(rec)
+}
+
+func (p *parser) isMonthDay() bool {
+	start := p.pos
+	_, _, ok := p.monthDay()
+	p.pos = start
+	return ok
+}
+
+// weekdayList reads "mon", "mon, wed" or "monday and friday".
+func (p *parser) weekdayList(rec *Recurrence) error {
+	for {
+		wd, ok := weekdayByName(p.peek(0))
+		if !ok {
+			return p.unexpected()
+		}
+		p.pos++
+		rec.Weekdays = appendWeekday(rec.Weekdays, wd)
+		if !p.listContinues(func(tok string) bool { _, ok := weekdayByName(tok); return ok }) {
+			return nil
+		}
+	}
+}
+
+// monthDayList reads "15th", "the 1st and 15th", "last day" or a single
+// weekday of the month such as "3rd friday" or "last monday".
+func (p *parser) monthDayList(rec *Recurrence) error {
+	for {
+		p.accept("the")
+		if len(rec.MonthDays) == 0 && p.nthWeekday(rec) {
+			return nil
+		}
+		if p.accept("last") {
+			p.accept("day")
+			if p.peek(0) == "of" && p.peek(1) == "the" && p.peek(2) == "month" {
+				p.pos += 3
+			}
+			rec.MonthDays = appendInt(rec.MonthDays, -1)
+		} else if day, ok := ordinal(p.peek(0)); ok {
+			p.pos++
+			rec.MonthDays = appendInt(rec.MonthDays, day)
+		} else {
+			return p.unexpected()
+		}
+		if !p.listContinues(func(tok string) bool { _, ok := ordinal(tok); return ok || tok == "last" || tok == "the" }) {
+			return nil
+		}
+	}
+}
+
+func (p *parser) nthWeekday(rec *Recurrence) bool {
+	pos, ok := ordinal(p.peek(0))
+	if p.peek(0) == "last" {
+		pos, ok = -1, true
+	}
+	wd, isWeekday := weekdayByName(p.peek(1))
+	if !ok || !isWeekday || pos > 5 {
+		return false
+	}
+	p.pos += 2
+	rec.WeekdayPos = pos
+	rec.Weekdays = []time.Weekday{wd}
+	return true
+}
+
+func (p *parser) yearDay(rec *Recurrence) error {
+	month, day, ok := p.monthDay()
+	if !ok {
+		return p.unexpected()
+	}
+	rec.Month = month
+	rec.MonthDays = []int{day}
+	return nil
+}
+
+// listContinues consumes a "," or "and" separator when the next item matches.
+func (p *parser) listContinues(item func(string) bool) bool {
+	tok := p.peek(0)
+	if tok != "," && tok != "and" {
+		return false
+	}
+	next := p.peek(1)
+	if next == "and" && tok == "," {
+		next = p.peek(2)
+		if !item(next) {
+			return false
+		}
+		p.pos += 2
+		return true
+	}
+	if !item(next) {
+		return false
+	}
+	p.pos++
+	return true
+}
+
+func appendWeekday(list []time.Weekday, wd time.Weekday) []time.Weekday {
+	for _, existing := range list {
+		if existing == wd {
+			return list
+		}
+	}
+	list = append(list, wd)
+	sort.Slice(list, func(i, j int) bool { return mondayOffset(list[i]) < mondayOffset(list[j]) })
+	return list
+}
+
+func appendInt(list []int, n int) []int {
+	for _, existing := range list {
+		if existing == n {
+			return list
+		}
+	}
+	return append(list, n)
+}
+
+// LooksRecurring reports whether a due string starts like a recurrence,
+// including forms Parse does not fully understand ("every last workday").
+func LooksRecurring(value string) bool {
+	fields := strings.Fields(strings.ToLower(value))
+	if len(fields) == 0 {
+		return false
+	}
+	switch fields[0] {
+	case "every", "every!", "ev", "ev!", "daily", "weekly", "monthly", "yearly", "annually":
+		return true
+	}
+	return false
+}
+
+// fillDefaults anchors rules without explicit days on the start date, so
+// "every week" repeats on the start's weekday.
+func (r *Recurrence) fillDefaults() {
+	switch r.Frequency {
+	case Weekly:
+		if len(r.Weekdays) == 0 {
+			r.Weekdays = []time.Weekday{r.Start.Weekday()}
+		}
+	case Monthly:
+		if len(r.MonthDays) == 0 && r.WeekdayPos == 0 {
+			r.MonthDays = []int{r.Start.Day()}
+		}
+	case Yearly:
+		if r.Month == 0 {
+			r.Month = r.Start.Month()
+			r.MonthDays = []int{r.Start.Day()}
+		}
+	}
+}
+
+// Occurrences lists the next n due dates, starting with Date.
+func (d Due) Occurrences(n int) []time.Time {
+	if n <= 0 {
+		return nil
+	}
+	if d.Recurrence == nil {
+		return []time.Time{d.Date}
+	}
+	return d.Recurrence.next(midnight(d.Date), n, func(t time.Time) bool { return !t.Before(d.Date) })
+}
+
+// next scans forward day by day from `from`, collecting up to n days that
+// match the rule and pass keep.
+func (r *Recurrence) next(from time.Time, n int, keep func(time.Time) bool) []time.Time {
+	var out []time.Time
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+	weekdaysSeen := 0
+	limit := 400 * interval * (n + 1)
+	for day, i := midnight(from), 0; len(out) < n && i < limit; day, i = day.AddDate(0, 0, 1), i+1 {
+		if !r.Until.IsZero() && day.After(r.Until) {
+			break
+		}
+		match := false
+		switch r.Frequency {
+		case Daily:
+			match = daysBetween(r.Start, day)%interval == 0
+		case Weekdays:
+			if day.Weekday() != time.Saturday && day.Weekday() != time.Sunday && !day.Before(r.Start) {
+				match = weekdaysSeen%interval == 0
+				weekdaysSeen++
+			}
+		case Weekly:
+			week := daysBetween(weekStart(r.Start), weekStart(day)) / 7
+			match = week%interval == 0 && containsWeekday(r.Weekdays, day.Weekday())
+		case Monthly:
+			months := (day.Year()-r.Start.Year())*12 + int(day.Month()-r.Start.Month())
+			if r.WeekdayPos != 0 {
+				match = months%interval == 0 && containsWeekday(r.Weekdays, day.Weekday()) && weekOfMonth(day, r.WeekdayPos < 0) == r.WeekdayPos
+			} else {
+				match = months%interval == 0 && matchesMonthDay(r.MonthDays, day)
+			}
+		case Yearly:
+			match = (day.Year()-r.Start.Year())%interval == 0 && day.Month() == r.Month && matchesMonthDay(r.MonthDays, day)
+		}
+		if !match || day.Before(r.Start) {
+			continue
+		}
+		at := day
+		if r.at != nil {
+			at = r.at.on(day)
+		}
+		if keep == nil || keep(at) {
+			out = append(out, at)
+		}
+	}
+	return out
+}
+
+func daysBetween(a, b time.Time) int {
+	ua := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	ub := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(ub.Sub(ua).Hours() / 24)
+}
+
+func weekStart(day time.Time) time.Time {
+	return day.AddDate(0, 0, -mondayOffset(day.Weekday()))
+}
+
+func containsWeekday(list []time.Weekday, wd time.Weekday) bool {
+	for _, item := range list {
+		if item == wd {
+			return true
+		}
+	}
+	return false
+}
+
+// weekOfMonth numbers day's weekday within its month from 1, or with
+// fromEnd returns -1 when it is the month's last such weekday.
+func weekOfMonth(day time.Time, fromEnd bool) int {
+	if fromEnd {
+		if day.Day()+7 > daysIn(day.Year(), day.Month()) {
+			return -1
+		}
+		return 0
+	}
+	return (day.Day()-1)/7 + 1
+}
+
+func matchesMonthDay(days []int, day time.Time) bool {
+	last := daysIn(day.Year(), day.Month())
+	for _, want := range days {
+		if want < 0 || want > last {
+			want = last
+		}
+		if day.Day() == want {
+			return true
+		}
+	}
+	return false
+}
+
+var rruleDays = map[time.Weekday]string{
+	time.Monday: "MO", time.Tuesday: "TU", time.Wednesday: "WE", time.Thursday: "TH",
+	time.Friday: "FR", time.Saturday: "SA", time.Sunday: "SU",
+}
+
+// RRule renders the rule as an RFC 5545 RRULE, anchored on the first
+// occurrence as DTSTART. Rules RRULE cannot express exactly, such as every
+// third weekday, return "".
+func (r *Recurrence) RRule() string {
+	var parts []string
+	switch r.Frequency {
+	case Daily:
+		parts = append(parts, "FREQ=DAILY")
+	case Weekdays:
+		switch r.Interval {
+		case 1:
+			parts = append(parts, "FREQ=WEEKLY", "BYDAY=MO,TU,WE,TH,FR")
+		case 2:
+			// A weekend is two days, so every other weekday is every
+			// other day that is a weekday.
+			parts = append(parts, "FREQ=DAILY", "BYDAY=MO,TU,WE,TH,FR")
+		default:
+			return ""
+		}
+	case Weekly:
+		days := make([]string, 0, len(r.Weekdays))
+		for _, wd := range r.Weekdays {
+			days = append(days, rruleDays[wd])
+		}
+		parts = append(parts, "FREQ=WEEKLY", "BYDAY="+strings.Join(days, ","))
+	case Monthly:
+		parts = append(parts, "FREQ=MONTHLY")
+		if r.WeekdayPos != 0 {
+			parts = append(parts, "BYDAY="+strconv.Itoa(r.WeekdayPos)+rruleDays[r.Weekdays[0]])
+			break
+		}
+		days, ok := rruleMonthDays(r.MonthDays, 28)
+		if !ok {
+			return ""
+		}
+		parts = append(parts, days...)
+	case Yearly:
+		days, ok := rruleMonthDays(r.MonthDays, daysIn(2001, r.Month))
+		if !ok {
+			return ""
+		}
+		parts = append(parts, "FREQ=YEARLY", "BYMONTH="+strconv.Itoa(int(r.Month)))
+		parts = append(parts, days...)
+	}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.at != nil {
+		parts = append(parts, "BYHOUR="+strconv.Itoa(r.at.hour), "BYMINUTE="+strconv.Itoa(r.at.minute))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+	}
+	return strings.Join(parts, ";")
+}
+
+// rruleMonthDays renders month days for months of at least shortest days.
+// BYMONTHDAY skips months too short for a day, where the rule falls on the
+// last day instead, so a day past shortest becomes "the last of shortest
+// through that day" with BYSETPOS. More than one such day cannot be
+// expressed.
+func rruleMonthDays(days []int, shortest int) ([]string, bool) {
+	var fixed []int
+	clamped := 0
+	for _, day := range days {
+		switch {
+		case day <= shortest:
+			fixed = append(fixed, day)
+		case clamped != 0:
+			return nil, false
+		default:
+			clamped = day
+		}
+	}
+	if clamped == 0 {
+		return []string{"BYMONTHDAY=" + joinInts(days)}, true
+	}
+	for _, day := range fixed {
+		if day < 0 {
+			return nil, false
+		}
+	}
+	sort.Ints(fixed)
+	positions := make([]int, 0, len(fixed)+1)
+	for i := range fixed {
+		positions = append(positions, i+1)
+	}
+	positions = append(positions, -1)
+	set := fixed
+	for day := shortest; day <= clamped; day++ {
+		if len(fixed) == 0 || day > fixed[len(fixed)-1] {
+			set = append(set, day)
+		}
+	}
+	return []string{"BYMONTHDAY=" + joinInts(set), "BYSETPOS=" + joinInts(positions)}, true
+}
+
+func joinInts(values []int) string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		out = append(out, strconv.Itoa(v))
+	}
+	return strings.Join(out, ",")
+}
+
+// Describe restates the rule in canonical form, e.g. "every 2 weekdays at
+// 09:00".
+func (r *Recurrence) Describe() string {
+	var b strings.Builder
+	b.WriteString("every")
+	if r.FromCompletion {
+		b.WriteString("!")
+	}
+	unit := map[Frequency]string{Daily: "day", Weekdays: "weekday", Weekly: "week", Monthly: "month", Yearly: "year"}[r.Frequency]
+	if r.Interval > 1 {
+		fmt.Fprintf(&b, " %d %ss", r.Interval, unit)
+	} else {
+		b.WriteString(" " + unit)
+	}
+	switch r.Frequency {
+	case Weekly:
+		names := make([]string, 0, len(r.Weekdays))
+		for _, wd := range r.Weekdays {
+			names = append(names, strings.ToLower(wd.String()[:3]))
+		}
+		b.WriteString(" on " + strings.Join(names, ", "))
+	case Monthly:
+		if r.WeekdayPos != 0 {
+			fmt.Fprintf(&b, " on the %s %s", strings.TrimSuffix(ordinalName(r.WeekdayPos), " day"), strings.ToLower(r.Weekdays[0].String()[:3]))
+			break
+		}
+		names := make([]string, 0, len(r.MonthDays))
+		for _, day := range r.MonthDays {
+			names = append(names, ordinalName(day))
+		}
+		b.WriteString(" on the " + strings.Join(names, ", "))
+	case Yearly:
+		fmt.Fprintf(&b, " on %s %d", strings.ToLower(r.Month.String()[:3]), r.MonthDays[0])
+	}
+	if r.at != nil {
+		fmt.Fprintf(&b, " at %02d:%02d", r.at.hour, r.at.minute)
+	}
+	if r.explicitStart {
+		b.WriteString(" starting " + r.Start.Format(time.DateOnly))
+	}
+	if !r.Until.IsZero() {
+		b.WriteString(" until " + r.Until.Format(time.DateOnly))
+	}
+	return b.String()
+}
+
+func ordinalName(day int) string {
+	if day < 0 {
+		return "last day"
+	}
+	suffix := "th"
+	switch {
+	case day%100 >= 11 && day%100 <= 13:
+	case day%10 == 1:
+		suffix = "st"
+	case day%10 == 2:
+		suffix = "nd"
+	case day%10 == 3:
+		suffix = "rd"
+	}
+	return strconv.Itoa(day) + suffix
+}
@@ -0,0 +1,127 @@
+package dates
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRecurrences(t *testing.T) {
+	cases := []struct {
+		expr        string
+		description string
+		rule        string
+		next        []string
+	}{
+		{
+			expr:        "every other weekday at 9",
+			description: "every 2 weekdays at 09:00",
+			rule:        "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR;INTERVAL=2;BYHOUR=9;BYMINUTE=0",
+			next:        []string{"2026-10-19T09:00:00", "2026-10-21T09:00:00", "2026-10-23T09:00:00", "2026-10-27T09:00:00"},
+		},
+		{
+			expr:        "every day at 9",
+			description: "every day at 09:00",
+			rule:        "FREQ=DAILY;BYHOUR=9;BYMINUTE=0",
+			next:        []string{"2026-10-18T09:00:00", "2026-10-19T09:00:00", "2026-10-20T09:00:00", "2026-10-21T09:00:00"},
+		},
+		{
+			expr:        "every mon, wed and fri",
+			description: "every week on mon, wed, fri",
+			rule:        "FREQ=WEEKLY;BYDAY=MO,WE,FR",
+			next:        []string{"2026-10-19", "2026-10-21", "2026-10-23", "2026-10-26"},
+		},
+		{
+			expr:        "every other monday starting nov 2",
+			description: "every 2 weeks on mon starting 2026-11-02",
+			rule:        "FREQ=WEEKLY;BYDAY=MO;INTERVAL=2",
+			next:        []string{"2026-11-02", "2026-11-16", "2026-11-30", "2026-12-14"},
+		},
+		{
+			expr:        "every 3 weekdays",
+			description: "every 3 weekdays",
+			next:        []string{"2026-10-19", "2026-10-22", "2026-10-27", "2026-10-30"},
+		},
+		{
+			expr:        "every 31st",
+			description: "every month on the 31st",
+			rule:        "FREQ=MONTHLY;BYMONTHDAY=28,29,30,31;BYSETPOS=-1",
+			next:        []string{"2026-10-31", "2026-11-30", "2026-12-31", "2027-01-31"},
+		},
+		{
+			expr:        "every month on the 31st",
+			description: "every month on the 31st",
+			rule:        "FREQ=MONTHLY;BYMONTHDAY=28,29,30,31;BYSETPOS=-1",
+			next:        []string{"2026-10-31", "2026-11-30", "2026-12-31", "2027-01-31"},
+		},
+		{
+			expr:        "every 1st and 30th",
+			description: "every month on the 1st, 30th",
+			rule:        "FREQ=MONTHLY;BYMONTHDAY=1,28,29,30;BYSETPOS=1,-1",
+			next:        []string{"2026-10-30", "2026-11-01", "2026-11-30", "2026-12-01"},
+		},
+		{
+			expr:        "every 3rd friday",
+			description: "every month on the 3rd fri",
+			rule:        "FREQ=MONTHLY;BYDAY=3FR",
+			next:        []string{"2026-11-20", "2026-12-18", "2027-01-15", "2027-02-19"},
+		},
+		{
+			expr:        "every last friday",
+			description: "every month on the last fri",
+			rule:        "FREQ=MONTHLY;BYDAY=-1FR",
+			next:        []string{"2026-10-30", "2026-11-27", "2026-12-25", "2027-01-29"},
+		},
+		{
+			expr:        "every feb 29",
+			description: "every year on feb 29",
+			rule:        "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=28,29;BYSETPOS=-1",
+			next:        []string{"2027-02-28", "2028-02-29", "2029-02-28", "2030-02-28"},
+		},
+		{
+			expr:        "every month on the 1st and 15th",
+			description: "every month on the 1st, 15th",
+			rule:        "FREQ=MONTHLY;BYMONTHDAY=1,15",
+			next:        []string{"2026-11-01", "2026-11-15", "2026-12-01", "2026-12-15"},
+		},
+		{
+			expr:        "every jan 5",
+			description: "every year on jan 5",
+			rule:        "FREQ=YEARLY;BYMONTH=1;BYMONTHDAY=5",
+			next:        []string{"2027-01-05", "2028-01-05", "2029-01-05", "2030-01-05"},
+		},
+		{
+			expr:        "every! 3 days",
+			description: "every! 3 days",
+			rule:        "FREQ=DAILY;INTERVAL=3",
+			next:        []string{"2026-10-17", "2026-10-20", "2026-10-23", "2026-10-26"},
+		},
+		{
+			expr:        "daily until oct 19",
+			description: "every day until 2026-10-19",
+			rule:        "FREQ=DAILY;UNTIL=20261019",
+			next:        []string{"2026-10-17", "2026-10-18", "2026-10-19"},
+		},
+	}
+	for _, tc := range cases {
+		due, err := Parse(tc.expr, refNow)
+		if err != nil {
+			t.Fatalf("%q: %v", tc.expr, err)
+		}
+		preview := due.Preview(4)
+		if !preview.IsRecurring || preview.Description != tc.description || preview.Rule != tc.rule {
+			t.Fatalf("%q: got recurring=%v description=%q rule=%q", tc.expr, preview.IsRecurring, preview.Description, preview.Rule)
+		}
+		if !reflect.DeepEqual(preview.Occurrences, tc.next) {
+			t.Fatalf("%q: got occurrences %v, want %v", tc.expr, preview.Occurrences, tc.next)
+		}
+		if preview.Occurrences[0] != preview.Date && preview.Occurrences[0] != preview.Datetime {
+			t.Fatalf("%q: first occurrence %q does not match date %q/%q", tc.expr, preview.Occurrences[0], preview.Date, preview.Datetime)
+		}
+	}
+}
+
+func TestParseRecurrenceWithoutOccurrences(t *testing.T) {
+	if _, err := Parse("every day starting oct 20 until oct 18", refNow); err == nil {
+		t.Fatal("expected error for a rule that never occurs")
+	}
+}
@@ -0,0 +1,40 @@
+package search
+
+import "github.com/agisilaos/todoist-cli/internal/api"
+
+// Documents turns tasks into searchable documents, attaching each task's
+// comments and the names of its project and section.
+func Documents(tasks []api.Task, notes []api.SyncNote, projects []api.Project, sections []api.Section) []Document {
+	projectNames := make(map[string]string, len(projects))
+	for _, project := range projects {
+		projectNames[project.ID] = project.Name
+	}
+	sectionNames := make(map[string]string, len(sections))
+	for _, section := range sections {
+		sectionNames[section.ID] = section.Name
+	}
+	comments := map[string][]string{}
+	for _, note := range notes {
+		if note.ItemID != "" && note.Content != "" {
+			comments[note.ItemID] = append(comments[note.ItemID], note.Content)
+		}
+	}
+	docs := make([]Document, 0, len(tasks))
+	for _, task := range tasks {
+		fields := map[Field][]string{
+			FieldTitle:   {task.Content},
+			FieldComment: comments[task.ID],
+		}
+		if task.Description != "" {
+			fields[FieldDesc] = []string{task.Description}
+		}
+		if name := projectNames[task.ProjectID]; name != "" {
+			fields[FieldProject] = []string{name}
+		}
+		if name := sectionNames[task.SectionID]; name != "" {
+			fields[FieldSection] = []string{name}
+		}
+		docs = append(docs, Document{ID: task.ID, ProjectID: task.ProjectID, ProjectName: projectNames[task.ProjectID], Fields: fields})
+	}
+	return docs
+}
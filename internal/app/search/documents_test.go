@@ -0,0 +1,30 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+)
+
+func TestDocuments(t *testing.T) {
+	docs := Documents(
+		[]api.Task{{ID: "t1", Content: "Fix boiler", Description: "call first", ProjectID: "p1", SectionID: "s1"}, {ID: "t2", Content: "Plain"}},
+		[]api.SyncNote{{Comment: api.Comment{ID: "n1", Content: "part ordered"}, ItemID: "t1"}, {Comment: api.Comment{ID: "n2", Content: "project note"}, ProjectID: "p1"}},
+		[]api.Project{{ID: "p1", Name: "Home"}},
+		[]api.Section{{ID: "s1", Name: "Repairs"}},
+	)
+	want := Document{ID: "t1", ProjectID: "p1", ProjectName: "Home", Fields: map[Field][]string{
+		FieldTitle:   {"Fix boiler"},
+		FieldDesc:    {"call first"},
+		FieldComment: {"part ordered"},
+		FieldProject: {"Home"},
+		FieldSection: {"Repairs"},
+	}}
+	if len(docs) != 2 || !reflect.DeepEqual(docs[0], want) {
+		t.Fatalf("unexpected documents: %#v", docs)
+	}
+	if docs[1].Fields[FieldComment] != nil || docs[1].ProjectName != "" {
+		t.Fatalf("unexpected second document: %#v", docs[1])
+	}
+}
@@ -0,0 +1,210 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// Document is one searchable task. Every text in a field is indexed; phrase
+// matches never span two texts (two comments, say).
+type Document struct {
+	ID          string
+	ProjectID   string
+	ProjectName string
+	Fields      map[Field][]string
+}
+
+// Hit is a matching document with its relevance score and the fields where
+// the terms were found.
+type Hit struct {
+	ID     string  `json:"id"`
+	Score  float64 `json:"score"`
+	Fields []Field `json:"matched_fields"`
+}
+
+// fieldWeights favour the title over the body text and the body over the
+// project and section names.
+var fieldWeights = map[Field]float64{
+	FieldTitle:   3,
+	FieldDesc:    1.5,
+	FieldComment: 1,
+	FieldProject: 0.75,
+	FieldSection: 0.75,
+}
+
+// textGap separates the positions of consecutive texts in one field so a
+// phrase cannot match across them.
+const textGap = 1000
+
+type posting struct {
+	doc       int
+	field     Field
+	positions []int
+}
+
+// Index is an inverted index from word to the documents, fields and
+// positions where it occurs.
+type Index struct {
+	docs     []Document
+	postings map[string][]posting
+	vocab    []string
+}
+
+func NewIndex(docs []Document) *Index {
+	ix := &Index{docs: docs, postings: map[string][]posting{}}
+	for docIdx, doc := range docs {
+		for _, field := range Fields {
+			positions := map[string][]int{}
+			var order []string
+			offset := 0
+			for _, text := range doc.Fields[field] {
+				words := Tokenize(text)
+				for i, word := range words {
+					if _, seen := positions[word]; !seen {
+						order = append(order, word)
+					}
+					positions[word] = append(positions[word], offset+i)
+				}
+				offset += len(words) + textGap
+			}
+			for _, word := range order {
+				ix.postings[word] = append(ix.postings[word], posting{doc: docIdx, field: field, positions: positions[word]})
+			}
+		}
+	}
+	ix.vocab = make([]string, 0, len(ix.postings))
+	for word := range ix.postings {
+		ix.vocab = append(ix.vocab, word)
+	}
+	sort.Strings(ix.vocab)
+	return ix
+}
+
+// Len returns the number of indexed documents.
+func (ix *Index) Len() int {
+	return len(ix.docs)
+}
+
+// Search returns documents matching every term of q, best first. Each term
+// scores weight(field) * idf * tf/(tf+1.2), summed over fields, so rare words
+// and title matches rank highest; ties keep index order.
+func (ix *Index) Search(q Query) []Hit {
+	allowed := map[Field]bool{}
+	for _, field := range q.Fields {
+		allowed[field] = true
+	}
+	project := strings.ToLower(strings.TrimSpace(q.Project))
+	scores := map[int]float64{}
+	matched := map[int]map[Field]bool{}
+	for i, term := range q.Terms {
+		counts := ix.termCounts(term)
+		found := map[int]map[Field]int{}
+		for key, tf := range counts {
+			if len(allowed) > 0 && !allowed[key.field] {
+				continue
+			}
+			doc := ix.docs[key.doc]
+			if project != "" && strings.ToLower(doc.ProjectName) != project && strings.ToLower(doc.ProjectID) != project {
+				continue
+			}
+			if found[key.doc] == nil {
+				found[key.doc] = map[Field]int{}
+			}
+			found[key.doc][key.field] = tf
+		}
+		idf := math.Log(1 + (float64(len(ix.docs))-float64(len(found))+0.5)/(float64(len(found))+0.5))
+		next := map[int]float64{}
+		for docIdx, fields := range found {
+			if _, ok := scores[docIdx]; i > 0 && !ok {
+				continue
+			}
+			score := scores[docIdx]
+			for field, tf := range fields {
+				score += fieldWeights[field] * idf * float64(tf) / (float64(tf) + 1.2)
+				if matched[docIdx] == nil {
+					matched[docIdx] = map[Field]bool{}
+				}
+				matched[docIdx][field] = true
+			}
+			next[docIdx] = score
+		}
+		scores = next
+	}
+	hits := make([]Hit, 0, len(scores))
+	order := make([]int, 0, len(scores))
+	for docIdx := range scores {
+		order = append(order, docIdx)
+	}
+	sort.Ints(order)
+	for _, docIdx := range order {
+		hit := Hit{ID: ix.docs[docIdx].ID, Score: math.Round(scores[docIdx]*1000) / 1000}
+		for _, field := range Fields {
+			if matched[docIdx][field] {
+				hit.Fields = append(hit.Fields, field)
+			}
+		}
+		hits = append(hits, hit)
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].Score > hits[j].Score })
+	return hits
+}
+
+type docField struct {
+	doc   int
+	field Field
+}
+
+// termCounts returns how often term occurs in each document field: once per
+// position for words, per expanded word for prefixes and per run of
+// consecutive positions for phrases.
+func (ix *Index) termCounts(term Term) map[docField]int {
+	counts := map[docField]int{}
+	switch {
+	case term.Prefix:
+		prefix := term.Words[0]
+		start := sort.SearchStrings(ix.vocab, prefix)
+		for _, word := range ix.vocab[start:] {
+			if !strings.HasPrefix(word, prefix) {
+				break
+			}
+			for _, p := range ix.postings[word] {
+				counts[docField{p.doc, p.field}] += len(p.positions)
+			}
+		}
+	case len(term.Words) == 1:
+		for _, p := range ix.postings[term.Words[0]] {
+			counts[docField{p.doc, p.field}] += len(p.positions)
+		}
+	default:
+		for _, first := range ix.postings[term.Words[0]] {
+			key := docField{first.doc, first.field}
+			for _, start := range first.positions {
+				if ix.phraseAt(key, term.Words[1:], start+1) {
+					counts[key]++
+				}
+			}
+		}
+	}
+	return counts
+}
+
+func (ix *Index) phraseAt(key docField, words []string, pos int) bool {
+	for i, word := range words {
+		if !ix.hasPosition(word, key, pos+i) {
+			return false
+		}
+	}
+	return true
+}
+
+func (ix *Index) hasPosition(word string, key docField, pos int) bool {
+	for _, p := range ix.postings[word] {
+		if p.doc != key.doc || p.field != key.field {
+			continue
+		}
+		idx := sort.SearchInts(p.positions, pos)
+		return idx < len(p.positions) && p.positions[idx] == pos
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testIndex() *Index {
+	return NewIndex([]Document{
+		{ID: "t1", ProjectID: "p1", ProjectName: "Work", Fields: map[Field][]string{
+			FieldTitle: {"Deploy the release"},
+			FieldDesc:  {"Checklist: release notes, smoke tests"},
+		}},
+		{ID: "t2", ProjectID: "p1", ProjectName: "Work", Fields: map[Field][]string{
+			FieldTitle:   {"Write notes"},
+			FieldComment: {"draft the release", "notes are in the wiki"},
+		}},
+		{ID: "t3", ProjectID: "p2", ProjectName: "Home", Fields: map[Field][]string{
+			FieldTitle:   {"Buy paint"},
+			FieldProject: {"Home"},
+			FieldSection: {"Deployment"},
+		}},
+	})
+}
+
+func hitIDs(hits []Hit) []string {
+	ids := make([]string, 0, len(hits))
+	for _, hit := range hits {
+		ids = append(ids, hit.ID)
+	}
+	return ids
+}
+
+func search(t *testing.T, ix *Index, input string) []Hit {
+	t.Helper()
+	q, err := ParseQuery(input)
+	if err != nil {
+		t.Fatalf("parse %q: %v", input, err)
+	}
+	return ix.Search(q)
+}
+
+func TestSearchRanksTitleMatchesFirst(t *testing.T) {
+	hits := search(t, testIndex(), "release")
+	if got := hitIDs(hits); !reflect.DeepEqual(got, []string{"t1", "t2"}) {
+		t.Fatalf("unexpected hits: %v", got)
+	}
+	if !reflect.DeepEqual(hits[0].Fields, []Field{FieldTitle, FieldDesc}) || !reflect.DeepEqual(hits[1].Fields, []Field{FieldComment}) {
+		t.Fatalf("unexpected matched fields: %#v", hits)
+	}
+	if hits[0].Score <= hits[1].Score {
+		t.Fatalf("expected title match to score higher: %#v", hits)
+	}
+}
+
+func TestSearchPhrasePrefixAndQualifiers(t *testing.T) {
+	ix := testIndex()
+	cases := map[string][]string{
+		`"release notes"`:         {"t1"},
+		`"the release notes"`:     {},
+		`"release notes" in:desc`: {"t1"},
+		"notes in:comment":        {"t2"},
+		"notes in:title":          {"t2"},
+		"deplo*":                  {"t1", "t3"},
+		"deplo* project:home":     {"t3"},
+		"deplo* project:p1":       {"t1"},
+		"deploy":                  {"t1"},
+		"notes wiki":              {"t2"},
+		"notes paint":             {},
+	}
+	for input, want := range cases {
+		if got := hitIDs(search(t, ix, input)); !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s: expected %v, got %v", input, want, got)
+		}
+	}
+	if ix.Len() != 3 {
+		t.Fatalf("expected 3 documents, got %d", ix.Len())
+	}
+}
@@ -0,0 +1,171 @@
+// Package search is a small in-memory full-text index over tasks, their
+// comments and the names of their project and section.
+package search
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type Field string
+
+const (
+	FieldTitle   Field = "title"
+	FieldDesc    Field = "desc"
+	FieldComment Field = "comment"
+	FieldProject Field = "project"
+	FieldSection Field = "section"
+)
+
+// Fields lists every indexed field in ranking order.
+var Fields = []Field{FieldTitle, FieldDesc, FieldComment, FieldProject, FieldSection}
+
+var fieldAliases = map[string]Field{
+	"title":       FieldTitle,
+	"content":     FieldTitle,
+	"desc":        FieldDesc,
+	"description": FieldDesc,
+	"comment":     FieldComment,
+	"comments":    FieldComment,
+	"project":     FieldProject,
+	"section":     FieldSection,
+}
+
+// Term is one required match: a single word, a phrase of consecutive words,
+// or a word prefix ("deplo*").
+type Term struct {
+	Words  []string
+	Prefix bool
+}
+
+func (t Term) String() string {
+	text := strings.Join(t.Words, " ")
+	switch {
+	case t.Prefix:
+		return text + "*"
+	case len(t.Words) > 1:
+		return `"` + text + `"`
+	}
+	return text
+}
+
+// Query holds the terms every hit must match, the fields to look in (all
+// when empty) and an optional project name or ID the hit must belong to.
+type Query struct {
+	Terms   []Term
+	Fields  []Field
+	Project string
+}
+
+// ParseQuery reads words, "quoted phrases", prefix* words and the
+// qualifiers in:<field>[,<field>] and project:<name>.
+func ParseQuery(input string) (Query, error) {
+	var q Query
+	tokens, err := splitQuery(input)
+	if err != nil {
+		return Query{}, err
+	}
+	for _, tok := range tokens {
+		if !tok.quoted {
+			if key, value, ok := strings.Cut(tok.text, ":"); ok && value != "" {
+				switch strings.ToLower(key) {
+				case "in":
+					for _, name := range strings.Split(value, ",") {
+						field, ok := fieldAliases[strings.ToLower(strings.TrimSpace(name))]
+						if !ok {
+							return Query{}, fmt.Errorf("unknown field in:%s (use title, desc, comment, project or section)", name)
+						}
+						q.Fields = appendField(q.Fields, field)
+					}
+					continue
+				case "project":
+					q.Project = value
+					continue
+				}
+			}
+		}
+		prefix := !tok.quoted && strings.HasSuffix(tok.text, "*")
+		words := Tokenize(strings.TrimSuffix(tok.text, "*"))
+		if len(words) == 0 {
+			continue
+		}
+		if prefix && len(words) == 1 {
+			q.Terms = append(q.Terms, Term{Words: words, Prefix: true})
+			continue
+		}
+		if tok.quoted {
+			q.Terms = append(q.Terms, Term{Words: words})
+			continue
+		}
+		// "e-mail" and similar tokenize to several words; they must all
+		// match but need not be adjacent.
+		for _, word := range words {
+			q.Terms = append(q.Terms, Term{Words: []string{word}})
+		}
+	}
+	if len(q.Terms) == 0 {
+		return Query{}, errors.New("search query needs at least one word")
+	}
+	return q, nil
+}
+
+type queryToken struct {
+	text   string
+	quoted bool
+}
+
+// splitQuery splits on spaces, keeping "quoted phrases" together; a quote
+// right after a qualifier (project:"Home Office") quotes the value.
+func splitQuery(input string) ([]queryToken, error) {
+	var out []queryToken
+	var cur strings.Builder
+	inQuote := false
+	quotedValue := false
+	flush := func() {
+		if cur.Len() > 0 {
+			out = append(out, queryToken{text: cur.String(), quoted: inQuote && !quotedValue})
+		}
+		cur.Reset()
+		quotedValue = false
+	}
+	for _, r := range input {
+		switch {
+		case r == '"' && inQuote:
+			flush()
+			inQuote = false
+		case r == '"':
+			quotedValue = strings.HasSuffix(cur.String(), ":")
+			if !quotedValue {
+				flush()
+			}
+			inQuote = true
+		case unicode.IsSpace(r) && !inQuote:
+			flush()
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if inQuote {
+		return nil, errors.New("unterminated quote in search query")
+	}
+	flush()
+	return out, nil
+}
+
+// Tokenize lowercases text and splits it into letter/digit runs.
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func appendField(fields []Field, field Field) []Field {
+	for _, existing := range fields {
+		if existing == field {
+			return fields
+		}
+	}
+	return append(fields, field)
+}
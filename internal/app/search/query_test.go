@@ -0,0 +1,47 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	q, err := ParseQuery(`deplo* "release notes" in:desc,comment project:"Home Office" e-mail`)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	want := Query{
+		Terms: []Term{
+			{Words: []string{"deplo"}, Prefix: true},
+			{Words: []string{"release", "notes"}},
+			{Words: []string{"e"}},
+			{Words: []string{"mail"}},
+		},
+		Fields:  []Field{FieldDesc, FieldComment},
+		Project: "Home Office",
+	}
+	if !reflect.DeepEqual(q, want) {
+		t.Fatalf("unexpected query:\n got %#v\nwant %#v", q, want)
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	for _, input := range []string{"", "in:desc", `"open`, "in:body x", "!!!"} {
+		if _, err := ParseQuery(input); err == nil {
+			t.Fatalf("expected error for %q", input)
+		}
+	}
+}
+
+func TestTermString(t *testing.T) {
+	cases := map[string]Term{
+		"plan":           {Words: []string{"plan"}},
+		"pla*":           {Words: []string{"pla"}, Prefix: true},
+		`"release plan"`: {Words: []string{"release", "plan"}},
+	}
+	for want, term := range cases {
+		if got := term.String(); got != want {
+			t.Fatalf("expected %s, got %s", want, got)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package tasks
+
+import (
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+)
+
+// Blocking links live in a fenced block at the end of the description:
+//
+//	```todoist-cli
+//	blocked_by: 123, 456
+//	```
+//
+// Other "key: value" lines in the block are kept when the links change.
+const (
+	metadataFence = "```todoist-cli"
+	blockedByKey  = "blocked_by"
+)
+
+var metadataBlockPattern = regexp.MustCompile("(?s)\\n*```todoist-cli\\n(.*?)```[ \\t]*\\n?")
+
+// BlockedBy returns the blocker IDs recorded in description.
+func BlockedBy(description string) []string {
+	_, lines := splitMetadata(description)
+	for _, line := range lines {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(key) != blockedByKey {
+			continue
+		}
+		var ids []string
+		for _, id := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' }) {
+			if !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+		return ids
+	}
+	return nil
+}
+
+// WithBlockedBy rewrites description so its metadata block lists ids. The
+// block is dropped when nothing else is left in it.
+func WithBlockedBy(description string, ids []string) string {
+	text, lines := splitMetadata(description)
+	kept := make([]string, 0, len(lines)+1)
+	for _, line := range lines {
+		if key, _, ok := strings.Cut(line, ":"); ok && strings.TrimSpace(key) == blockedByKey {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	if len(ids) > 0 {
+		kept = append(kept, blockedByKey+": "+strings.Join(ids, ", "))
+	}
+	if len(kept) == 0 {
+		return text
+	}
+	block := metadataFence + "\n" + strings.Join(kept, "\n") + "\n```"
+	if text == "" {
+		return block
+	}
+	return text + "\n\n" + block
+}
+
+func splitMetadata(description string) (string, []string) {
+	loc := metadataBlockPattern.FindStringSubmatchIndex(description)
+	if loc == nil {
+		return strings.TrimRight(description, "\n"), nil
+	}
+	text := strings.TrimRight(description[:loc[0]]+description[loc[1]:], "\n")
+	var lines []string
+	for _, line := range strings.Split(description[loc[2]:loc[3]], "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return text, lines
+}
+
+// OpenBlockers maps every task in active to the blockers that are still open,
+// that is, recorded blockers that are themselves in active and unchecked.
+// Tasks without open blockers are left out, so they are the actionable ones.
+func OpenBlockers(active []api.Task) map[string][]string {
+	open := make(map[string]bool, len(active))
+	for _, task := range active {
+		if !task.Checked {
+			open[task.ID] = true
+		}
+	}
+	out := map[string][]string{}
+	for _, task := range active {
+		for _, id := range BlockedBy(task.Description) {
+			if open[id] && id != task.ID {
+				out[task.ID] = append(out[task.ID], id)
+			}
+		}
+	}
+	return out
+}
+
+// Unblocked lists the tasks in active that were waiting on one of completed
+// and have no open blocker left once those are done.
+func Unblocked(active []api.Task, completed []string) []api.Task {
+	done := make(map[string]bool, len(completed))
+	for _, id := range completed {
+		done[id] = true
+	}
+	before := OpenBlockers(active)
+	var remaining []api.Task
+	for _, task := range active {
+		if !done[task.ID] {
+			remaining = append(remaining, task)
+		}
+	}
+	after := OpenBlockers(remaining)
+	var out []api.Task
+	for _, task := range remaining {
+		if len(before[task.ID]) > 0 && len(after[task.ID]) == 0 {
+			out = append(out, task)
+		}
+	}
+	return out
+}
+
+// BlockingPath reports whether making taskID wait on blockerID would close a
+// cycle, returning the existing chain from blockerID back to taskID.
+func BlockingPath(active []api.Task, taskID, blockerID string) []string {
+	edges := map[string][]string{}
+	for _, task := range active {
+		edges[task.ID] = BlockedBy(task.Description)
+	}
+	seen := map[string]bool{}
+	var walk func(id string, path []string) []string
+	walk = func(id string, path []string) []string {
+		path = append(path, id)
+		if id == taskID {
+			return path
+		}
+		if seen[id] {
+			return nil
+		}
+		seen[id] = true
+		next := append([]string(nil), edges[id]...)
+		sort.Strings(next)
+		for _, dep := range next {
+			if found := walk(dep, path); found != nil {
+				return found
+			}
+		}
+		return nil
+	}
+	return walk(blockerID, nil)
+}
@@ -0,0 +1,82 @@
+package tasks
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+)
+
+func TestWithBlockedByRoundTrip(t *testing.T) {
+	desc := WithBlockedBy("Ship the release", []string{"1", "2"})
+	want := "Ship the release\n\n```todoist-cli\nblocked_by: 1, 2\n```"
+	if desc != want {
+		t.Fatalf("unexpected description:\n%q\nwant\n%q", desc, want)
+	}
+	if got := BlockedBy(desc); !reflect.DeepEqual(got, []string{"1", "2"}) {
+		t.Fatalf("unexpected blockers: %#v", got)
+	}
+	desc = WithBlockedBy(desc, []string{"2"})
+	if got := BlockedBy(desc); !reflect.DeepEqual(got, []string{"2"}) {
+		t.Fatalf("unexpected blockers after edit: %#v", got)
+	}
+	if got := WithBlockedBy(desc, nil); got != "Ship the release" {
+		t.Fatalf("expected block removed, got %q", got)
+	}
+	if got := WithBlockedBy("", []string{"7"}); got != "```todoist-cli\nblocked_by: 7\n```" {
+		t.Fatalf("unexpected block for empty description: %q", got)
+	}
+}
+
+func TestWithBlockedByKeepsOtherMetadata(t *testing.T) {
+	desc := "notes\n\n```todoist-cli\nowner: ops\nblocked_by: 1\n```\n"
+	got := WithBlockedBy(desc, nil)
+	if got != "notes\n\n```todoist-cli\nowner: ops\n```" {
+		t.Fatalf("unexpected description: %q", got)
+	}
+	if got := BlockedBy("no block here"); got != nil {
+		t.Fatalf("expected no blockers, got %#v", got)
+	}
+	if got := BlockedBy("```todoist-cli\nblocked_by: 3,3 4\n```"); !reflect.DeepEqual(got, []string{"3", "4"}) {
+		t.Fatalf("expected duplicates dropped: %#v", got)
+	}
+}
+
+func blockedTask(id string, blockers ...string) api.Task {
+	return api.Task{ID: id, Content: "task " + id, Description: WithBlockedBy("", blockers)}
+}
+
+func TestOpenBlockersAndUnblocked(t *testing.T) {
+	active := []api.Task{
+		blockedTask("a"),
+		blockedTask("b", "a"),
+		blockedTask("c", "a", "b"),
+		blockedTask("d", "gone"),
+	}
+	open := OpenBlockers(active)
+	want := map[string][]string{"b": {"a"}, "c": {"a", "b"}}
+	if !reflect.DeepEqual(open, want) {
+		t.Fatalf("unexpected open blockers: %#v", open)
+	}
+	unblocked := Unblocked(active, []string{"a"})
+	if len(unblocked) != 1 || unblocked[0].ID != "b" {
+		t.Fatalf("expected only b unblocked, got %#v", unblocked)
+	}
+	if unblocked := Unblocked(active, []string{"a", "b"}); len(unblocked) != 1 || unblocked[0].ID != "c" {
+		t.Fatalf("expected c unblocked, got %#v", unblocked)
+	}
+}
+
+func TestBlockingPath(t *testing.T) {
+	active := []api.Task{blockedTask("a"), blockedTask("b", "a"), blockedTask("c", "b")}
+	if path := BlockingPath(active, "a", "c"); strings.Join(path, ">") != "c>b>a" {
+		t.Fatalf("expected cycle through c>b>a, got %#v", path)
+	}
+	if path := BlockingPath(active, "c", "a"); path != nil {
+		t.Fatalf("expected no cycle, got %#v", path)
+	}
+	if path := BlockingPath(active, "a", "a"); len(path) != 1 {
+		t.Fatalf("expected self block to be a cycle, got %#v", path)
+	}
+}
@@ -0,0 +1,72 @@
+package tasks
+
+import (
+	"strings"
+	"time"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+)
+
+// DuplicatePayload builds the POST /tasks body for a copy of task. A copy
+// goes under parentID when one is given, otherwise into projectID (leaving
+// the section behind), otherwise next to the original.
+func DuplicatePayload(task api.Task, projectID, parentID string) map[string]any {
+	body := map[string]any{"content": task.Content}
+	if task.Description != "" {
+		body["description"] = task.Description
+	}
+	switch {
+	case parentID != "":
+		body["parent_id"] = parentID
+	case projectID != "":
+		body["project_id"] = projectID
+	case task.ParentID != "":
+		body["parent_id"] = task.ParentID
+	case task.SectionID != "":
+		body["section_id"] = task.SectionID
+	case task.ProjectID != "":
+		body["project_id"] = task.ProjectID
+	}
+	if len(task.Labels) > 0 {
+		body["labels"] = append([]string(nil), task.Labels...)
+	}
+	if task.Priority > 0 {
+		body["priority"] = task.Priority
+	}
+	if task.Due != nil {
+		for key, value := range duplicateDue(*task.Due) {
+			body[key] = value
+		}
+	}
+	if task.Duration != nil && task.Duration.Amount > 0 {
+		body["duration"] = task.Duration.Amount
+		body["duration_unit"] = task.Duration.Unit
+	}
+	if task.Deadline != nil && task.Deadline.Date != "" {
+		body["deadline_date"] = task.Deadline.Date
+	}
+	return body
+}
+
+// duplicateDue picks the due field that reproduces due: the string for a
+// recurring due so the copy keeps recurring, otherwise the exact date. The
+// REST API only takes UTC datetimes, so a floating one is sent as a string.
+func duplicateDue(due api.Due) map[string]any {
+	switch {
+	case IsRecurringDue(due):
+		return map[string]any{"due_string": due.String}
+	case due.Datetime != "":
+		if _, err := time.Parse(time.RFC3339, due.Datetime); err == nil {
+			return map[string]any{"due_datetime": due.Datetime}
+		}
+		if at, err := time.Parse(floatingDatetime, due.Datetime); err == nil {
+			return map[string]any{"due_string": at.Format("2006-01-02 15:04")}
+		}
+		return map[string]any{"due_string": strings.Replace(due.Datetime, "T", " ", 1)}
+	case due.Date != "":
+		return map[string]any{"due_date": due.Date}
+	case due.String != "":
+		return map[string]any{"due_string": due.String}
+	}
+	return nil
+}
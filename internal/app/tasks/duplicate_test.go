@@ -0,0 +1,81 @@
+package tasks
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+)
+
+func TestDuplicatePayloadCopiesFields(t *testing.T) {
+	task := api.Task{
+		ID:          "t1",
+		Content:     "Review",
+		Description: "notes",
+		ProjectID:   "p1",
+		SectionID:   "s1",
+		Labels:      []string{"work"},
+		Priority:    4,
+		Due:         &api.Due{Date: "2026-10-20"},
+		Duration:    &api.Duration{Amount: 45, Unit: "minute"},
+		Deadline:    &api.Deadline{Date: "2026-10-30"},
+	}
+	got := DuplicatePayload(task, "", "")
+	want := map[string]any{
+		"content":       "Review",
+		"description":   "notes",
+		"section_id":    "s1",
+		"labels":        []string{"work"},
+		"priority":      4,
+		"due_date":      "2026-10-20",
+		"duration":      45,
+		"duration_unit": "minute",
+		"deadline_date": "2026-10-30",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected payload:\n got %#v\nwant %#v", got, want)
+	}
+}
+
+func TestDuplicatePayloadPlacement(t *testing.T) {
+	task := api.Task{Content: "x", ProjectID: "p1", SectionID: "s1", ParentID: "t0"}
+	cases := []struct {
+		project, parent string
+		key, value      string
+	}{
+		{"", "", "parent_id", "t0"},
+		{"p2", "", "project_id", "p2"},
+		{"p2", "n1", "parent_id", "n1"},
+	}
+	for _, tc := range cases {
+		got := DuplicatePayload(task, tc.project, tc.parent)
+		if got[tc.key] != tc.value {
+			t.Fatalf("project=%q parent=%q: expected %s=%s, got %#v", tc.project, tc.parent, tc.key, tc.value, got)
+		}
+		for _, key := range []string{"project_id", "section_id", "parent_id"} {
+			if _, ok := got[key]; ok && key != tc.key {
+				t.Fatalf("project=%q parent=%q: unexpected %s in %#v", tc.project, tc.parent, key, got)
+			}
+		}
+	}
+}
+
+func TestDuplicatePayloadDue(t *testing.T) {
+	cases := []struct {
+		due  api.Due
+		key  string
+		want string
+	}{
+		{api.Due{Date: "2026-10-19", String: "every monday", IsRecurring: true}, "due_string", "every monday"},
+		{api.Due{Date: "2026-10-19", String: "every day"}, "due_string", "every day"},
+		{api.Due{Date: "2026-10-19", Datetime: "2026-10-19T09:00:00Z"}, "due_datetime", "2026-10-19T09:00:00Z"},
+		{api.Due{Date: "2026-10-19", Datetime: "2026-10-19T09:30:00"}, "due_string", "2026-10-19 09:30"},
+		{api.Due{Date: "2026-10-19", String: "oct 19"}, "due_date", "2026-10-19"},
+	}
+	for _, tc := range cases {
+		got := DuplicatePayload(api.Task{Content: "x", Due: &tc.due}, "", "")
+		if got[tc.key] != tc.want {
+			t.Fatalf("%#v: expected %s=%q, got %#v", tc.due, tc.key, tc.want, got)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package tasks
 
-import "strings"
+import (
+	"slices"
+	"strings"
+)
 
 type MutationInput struct {
 	Content      string
@@ -136,3 +139,56 @@ func applyMutationPayload(body map[string]any, in MutationInput, resolver Select
 	}
 	return nil
 }
+
+// LabelEdit changes a task's labels relative to its current set instead of
+// replacing them. Clear runs first, then Remove, then Add.
+type LabelEdit struct {
+	Add    []string
+	Remove []string
+	Clear  bool
+}
+
+func (e LabelEdit) Empty() bool {
+	return len(e.Add) == 0 && len(e.Remove) == 0 && !e.Clear
+}
+
+// Apply returns the edited label set. Labels are compared case-insensitively,
+// as Todoist does, and existing labels keep their order.
+func (e LabelEdit) Apply(current []string) []string {
+	out := []string{}
+	if !e.Clear {
+		for _, label := range current {
+			if !containsLabel(e.Remove, label) {
+				out = append(out, label)
+			}
+		}
+	}
+	for _, label := range e.Add {
+		label = strings.TrimSpace(label)
+		if label == "" || containsLabel(out, label) {
+			continue
+		}
+		out = append(out, label)
+	}
+	return out
+}
+
+// LabelsEqual reports whether a and b hold the same labels in the same order.
+func LabelsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func containsLabel(labels []string, label string) bool {
+	label = strings.TrimSpace(label)
+	return slices.ContainsFunc(labels, func(existing string) bool {
+		return strings.EqualFold(strings.TrimSpace(existing), label)
+	})
+}
@@ -2,6 +2,7 @@ package tasks
 
 import (
 	"errors"
+	"strings"
 	"testing"
 )
 
@@ -73,3 +74,29 @@ func TestBuildUpdatePayloadResolverError(t *testing.T) {
 		t.Fatalf("expected boom error, got %v", err)
 	}
 }
+
+func TestLabelEditApply(t *testing.T) {
+	cases := []struct {
+		name string
+		edit LabelEdit
+		want string
+	}{
+		{"add keeps existing", LabelEdit{Add: []string{"waiting", "stale", " ", "stale"}}, "work,Waiting,stale"},
+		{"remove case-insensitive", LabelEdit{Remove: []string{"waiting"}}, "work"},
+		{"clear then add", LabelEdit{Clear: true, Add: []string{"fresh"}}, "fresh"},
+		{"remove and add same", LabelEdit{Remove: []string{"work"}, Add: []string{"work"}}, "Waiting,work"},
+		{"clear only", LabelEdit{Clear: true}, ""},
+	}
+	for _, tc := range cases {
+		got := tc.edit.Apply([]string{"work", "Waiting"})
+		if strings.Join(got, ",") != tc.want {
+			t.Fatalf("%s: got %v, want %s", tc.name, got, tc.want)
+		}
+	}
+	if !(LabelEdit{}).Empty() || (LabelEdit{Clear: true}).Empty() {
+		t.Fatalf("unexpected Empty result")
+	}
+	if !LabelsEqual([]string{"a", "b"}, []string{"a", "b"}) || LabelsEqual([]string{"a"}, []string{"b"}) {
+		t.Fatalf("unexpected LabelsEqual result")
+	}
+}
@@ -0,0 +1,151 @@
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	appdates "github.com/agisilaos/todoist-cli/internal/app/dates"
+)
+
+// Postpone moves a task's due date either by a relative shift (Days plus a
+// sub-day Offset) or onto an absolute target (To). Recurring dues keep their
+// due string so Todoist keeps the recurrence.
+type Postpone struct {
+	Days   int
+	Offset time.Duration
+	To     *appdates.Due
+}
+
+var shiftPattern = regexp.MustCompile(`^([+-]?)(\d+)\s*(m|min|mins|h|hr|hrs|d|day|days|w|wk|week|weeks)?$`)
+
+// ParsePostpone reads --by ("2d", "1w", "3h", "-1d") or --to (any due
+// expression the dates package understands, resolved against now).
+func ParsePostpone(by, to string, now time.Time) (Postpone, error) {
+	by, to = strings.TrimSpace(by), strings.TrimSpace(to)
+	if (by == "") == (to == "") {
+		return Postpone{}, errors.New("postpone requires exactly one of --by or --to")
+	}
+	if to != "" {
+		due, err := appdates.Parse(to, now)
+		if err != nil {
+			return Postpone{}, fmt.Errorf("invalid --to: %w", err)
+		}
+		if due.Recurrence != nil {
+			return Postpone{}, errors.New("--to needs a single date, not a recurrence")
+		}
+		return Postpone{To: &due}, nil
+	}
+	m := shiftPattern.FindStringSubmatch(strings.ToLower(by))
+	if m == nil {
+		return Postpone{}, fmt.Errorf("invalid --by %q (use 2d, 1w, 3h or 30m)", by)
+	}
+	n, err := strconv.Atoi(m[2])
+	if err != nil {
+		return Postpone{}, fmt.Errorf("invalid --by %q", by)
+	}
+	if m[1] == "-" {
+		n = -n
+	}
+	var out Postpone
+	switch m[3] {
+	case "", "d", "day", "days":
+		out.Days = n
+	case "w", "wk", "week", "weeks":
+		out.Days = 7 * n
+	case "h", "hr", "hrs":
+		out.Offset = time.Duration(n) * time.Hour
+	default:
+		out.Offset = time.Duration(n) * time.Minute
+	}
+	if out.Days == 0 && out.Offset == 0 {
+		return Postpone{}, errors.New("--by must not be zero")
+	}
+	return out, nil
+}
+
+const floatingDatetime = "2006-01-02T15:04:05"
+
+// Apply returns the postponed due. The time of day survives a date-only
+// target, a datetime in UTC ("Z") stays in UTC, and a target time is read in
+// the zone --to was resolved in.
+func (p Postpone) Apply(due *api.Due) (api.Due, error) {
+	var current time.Time
+	hasTime := false
+	utc := false
+	if due != nil && due.Datetime != "" {
+		var err error
+		if current, err = time.Parse(time.RFC3339, due.Datetime); err == nil {
+			utc = true
+		} else if current, err = time.Parse(floatingDatetime, due.Datetime); err != nil {
+			return api.Due{}, fmt.Errorf("unsupported due datetime %q", due.Datetime)
+		}
+		hasTime = true
+	} else if due != nil && due.Date != "" {
+		day, err := time.Parse(time.DateOnly, due.Date)
+		if err != nil {
+			return api.Due{}, fmt.Errorf("unsupported due date %q", due.Date)
+		}
+		current = day
+	}
+
+	var next time.Time
+	switch {
+	case p.To != nil:
+		target := p.To.Date
+		switch {
+		case p.To.HasTime && utc:
+			next = target.UTC()
+		case p.To.HasTime:
+			next = time.Date(target.Year(), target.Month(), target.Day(), target.Hour(), target.Minute(), 0, 0, time.UTC)
+		case hasTime:
+			next = time.Date(target.Year(), target.Month(), target.Day(), current.Hour(), current.Minute(), current.Second(), 0, current.Location())
+		default:
+			next = time.Date(target.Year(), target.Month(), target.Day(), 0, 0, 0, 0, time.UTC)
+		}
+		hasTime = hasTime || p.To.HasTime
+	case current.IsZero():
+		return api.Due{}, errors.New("task has no due date to postpone")
+	case p.Offset != 0 && !hasTime:
+		return api.Due{}, errors.New("task has no due time; postpone by days or weeks")
+	default:
+		next = current.AddDate(0, 0, p.Days).Add(p.Offset)
+	}
+
+	out := api.Due{Date: next.Format(time.DateOnly)}
+	if hasTime {
+		if utc {
+			out.Datetime = next.UTC().Format(time.RFC3339)
+		} else {
+			out.Datetime = next.Format(floatingDatetime)
+		}
+	}
+	if due != nil && IsRecurringDue(*due) {
+		out.String = due.String
+		out.IsRecurring = true
+	}
+	return out, nil
+}
+
+func IsRecurringDue(due api.Due) bool {
+	return due.IsRecurring || appdates.LooksRecurring(due.String)
+}
+
+// PostponeSyncArgs builds item_update arguments. The Sync API takes the date
+// or datetime in due.date; passing the current due string along keeps a
+// recurring task's recurrence.
+func PostponeSyncArgs(taskID string, due api.Due) map[string]any {
+	value := due.Date
+	if due.Datetime != "" {
+		value = due.Datetime
+	}
+	dueArgs := map[string]any{"date": value}
+	if due.String != "" {
+		dueArgs["string"] = due.String
+	}
+	return map[string]any{"id": taskID, "due": dueArgs}
+}
@@ -0,0 +1,95 @@
+package tasks
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+)
+
+func TestParsePostpone(t *testing.T) {
+	now := time.Date(2026, 10, 17, 10, 30, 0, 0, time.UTC)
+	cases := []struct {
+		by     string
+		days   int
+		offset time.Duration
+	}{
+		{"2d", 2, 0},
+		{"+1w", 7, 0},
+		{"-1d", -1, 0},
+		{"3", 3, 0},
+		{"3h", 0, 3 * time.Hour},
+		{"30m", 0, 30 * time.Minute},
+	}
+	for _, tc := range cases {
+		got, err := ParsePostpone(tc.by, "", now)
+		if err != nil {
+			t.Fatalf("%q: %v", tc.by, err)
+		}
+		if got.Days != tc.days || got.Offset != tc.offset || got.To != nil {
+			t.Fatalf("%q: unexpected postpone %#v", tc.by, got)
+		}
+	}
+	to, err := ParsePostpone("", "monday", now)
+	if err != nil || to.To == nil || to.To.Date.Format(time.DateOnly) != "2026-10-19" {
+		t.Fatalf("unexpected --to result: %#v, %v", to, err)
+	}
+	for _, bad := range [][2]string{{"", ""}, {"2d", "monday"}, {"soon", ""}, {"0d", ""}, {"", "every day"}} {
+		if _, err := ParsePostpone(bad[0], bad[1], now); err == nil {
+			t.Fatalf("expected error for by=%q to=%q", bad[0], bad[1])
+		}
+	}
+}
+
+func TestPostponeApply(t *testing.T) {
+	now := time.Date(2026, 10, 17, 10, 30, 0, 0, time.UTC)
+	byTwoDays, _ := ParsePostpone("2d", "", now)
+	byThreeHours, _ := ParsePostpone("3h", "", now)
+	toMonday, _ := ParsePostpone("", "monday", now)
+	toMondayAtNine, _ := ParsePostpone("", "monday at 9", now)
+
+	cases := []struct {
+		name     string
+		postpone Postpone
+		due      *api.Due
+		want     api.Due
+	}{
+		{"date", byTwoDays, &api.Due{Date: "2026-10-16", String: "Oct 16"}, api.Due{Date: "2026-10-18"}},
+		{"recurring keeps string", byTwoDays, &api.Due{Date: "2026-10-16", String: "every day"}, api.Due{Date: "2026-10-18", String: "every day", IsRecurring: true}},
+		{"floating datetime", byThreeHours, &api.Due{Date: "2026-10-16", Datetime: "2026-10-16T22:00:00"}, api.Due{Date: "2026-10-17", Datetime: "2026-10-17T01:00:00"}},
+		{"utc datetime", byTwoDays, &api.Due{Date: "2026-10-16", Datetime: "2026-10-16T09:00:00Z"}, api.Due{Date: "2026-10-18", Datetime: "2026-10-18T09:00:00Z"}},
+		{"to keeps time of day", toMonday, &api.Due{Date: "2026-10-16", Datetime: "2026-10-16T08:15:00", String: "every weekday at 8:15", IsRecurring: true}, api.Due{Date: "2026-10-19", Datetime: "2026-10-19T08:15:00", String: "every weekday at 8:15", IsRecurring: true}},
+		{"to with time", toMondayAtNine, &api.Due{Date: "2026-10-16"}, api.Due{Date: "2026-10-19", Datetime: "2026-10-19T09:00:00"}},
+		{"to without due", toMonday, nil, api.Due{Date: "2026-10-19"}},
+	}
+	for _, tc := range cases {
+		got, err := tc.postpone.Apply(tc.due)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if got != tc.want {
+			t.Fatalf("%s: got %#v, want %#v", tc.name, got, tc.want)
+		}
+	}
+
+	if _, err := byTwoDays.Apply(nil); err == nil || !strings.Contains(err.Error(), "no due date") {
+		t.Fatalf("expected no due date error, got %v", err)
+	}
+	if _, err := byThreeHours.Apply(&api.Due{Date: "2026-10-16"}); err == nil {
+		t.Fatal("expected error shifting a date-only due by hours")
+	}
+}
+
+func TestPostponeSyncArgs(t *testing.T) {
+	args := PostponeSyncArgs("t1", api.Due{Date: "2026-10-19", Datetime: "2026-10-19T09:00:00", String: "every monday at 9"})
+	due, _ := args["due"].(map[string]any)
+	if args["id"] != "t1" || due["date"] != "2026-10-19T09:00:00" || due["string"] != "every monday at 9" {
+		t.Fatalf("unexpected args: %#v", args)
+	}
+	args = PostponeSyncArgs("t2", api.Due{Date: "2026-10-19"})
+	due, _ = args["due"].(map[string]any)
+	if _, ok := due["string"]; ok || due["date"] != "2026-10-19" {
+		t.Fatalf("unexpected args: %#v", args)
+	}
+}
@@ -56,104 +56,108 @@ type ResolveMoveResult struct {
 	Filter string
 }
 
+type ResolveBulkInput struct {
+	Action string
+	ID     string
+	Ref    string
+	Filter string
+	Yes    bool
+	Force  bool
+}
+
+// ResolveBulkResult carries the matched tasks as well as their IDs so callers
+// can preview them and compute per-task payloads.
+type ResolveBulkResult struct {
+	Mode   string
+	ID     string
+	IDs    []string
+	Tasks  []api.Task
+	Filter string
+}
+
 type ResolveTaskTargetInput struct {
 	ID  string
 	Ref string
 }
 
 func (s Service) ResolveCompletionTargets(ctx context.Context, in ResolveCompletionInput) (ResolveCompletionResult, error) {
-	id, err := normalizeTaskID(in.ID)
+	res, err := s.resolveTargets(ctx, ResolveBulkInput{Action: "complete", ID: in.ID, Ref: in.Ref, Filter: in.Filter, Yes: in.Yes, Force: in.Force},
+		errors.New("task complete requires --id or a reference"))
 	if err != nil {
 		return ResolveCompletionResult{}, err
 	}
-	ref := strings.TrimSpace(in.Ref)
-	filter := strings.TrimSpace(in.Filter)
+	return ResolveCompletionResult{Mode: res.Mode, ID: res.ID, IDs: res.IDs, Filter: res.Filter}, nil
+}
 
-	if filter != "" {
-		if id != "" || ref != "" {
-			return ResolveCompletionResult{}, errors.New("--filter cannot be combined with --id or positional task reference")
-		}
-		if !in.Yes && !in.Force {
-			return ResolveCompletionResult{}, errors.New("bulk complete with --filter requires --yes (or --force)")
-		}
-		if s.Lister == nil {
-			return ResolveCompletionResult{}, errors.New("task filter lister is not configured")
-		}
-		tasks, err := s.Lister.ListByFilter(ctx, filter)
-		if err != nil {
-			return ResolveCompletionResult{}, err
-		}
-		ids := make([]string, 0, len(tasks))
-		for _, task := range tasks {
-			ids = append(ids, task.ID)
-		}
-		return ResolveCompletionResult{Mode: "bulk", IDs: ids, Filter: filter}, nil
+func (s Service) ResolveMoveTargets(ctx context.Context, in ResolveMoveInput) (ResolveMoveResult, error) {
+	if _, err := normalizeTaskID(in.ID); err != nil {
+		return ResolveMoveResult{}, err
 	}
-
-	if id == "" && ref != "" {
-		if s.Resolver == nil {
-			return ResolveCompletionResult{}, errors.New("task resolver is not configured")
-		}
-		task, err := s.Resolver.ResolveTaskRef(ctx, ref)
-		if err != nil {
-			return ResolveCompletionResult{}, err
-		}
-		id = task.ID
+	if strings.TrimSpace(in.Project) == "" && strings.TrimSpace(in.Section) == "" && strings.TrimSpace(in.Parent) == "" {
+		return ResolveMoveResult{}, errors.New("at least one of --project, --section, or --parent is required")
 	}
-
-	if id == "" {
-		return ResolveCompletionResult{}, errors.New("task complete requires --id or a reference")
+	res, err := s.resolveTargets(ctx, ResolveBulkInput{Action: "move", ID: in.ID, Ref: in.Ref, Filter: in.Filter, Yes: in.Yes, Force: in.Force},
+		errors.New("--id is required (or pass a text reference)"))
+	if err != nil {
+		return ResolveMoveResult{}, err
 	}
-	return ResolveCompletionResult{Mode: "single", ID: id, IDs: []string{id}}, nil
+	return ResolveMoveResult{Mode: res.Mode, ID: res.ID, IDs: res.IDs, Filter: res.Filter}, nil
 }
 
-func (s Service) ResolveMoveTargets(ctx context.Context, in ResolveMoveInput) (ResolveMoveResult, error) {
+// ResolveBulkTargets applies the --filter guardrails shared by bulk task
+// commands: a filter excludes --id and references and requires --yes or
+// --force.
+func (s Service) ResolveBulkTargets(ctx context.Context, in ResolveBulkInput) (ResolveBulkResult, error) {
+	return s.resolveTargets(ctx, in, fmt.Errorf("task %s requires --id or a reference", in.Action))
+}
+
+// resolveTargets is ResolveBulkTargets with the caller's error for a missing
+// target.
+func (s Service) resolveTargets(ctx context.Context, in ResolveBulkInput, missing error) (ResolveBulkResult, error) {
 	id, err := normalizeTaskID(in.ID)
 	if err != nil {
-		return ResolveMoveResult{}, err
+		return ResolveBulkResult{}, err
 	}
 	ref := strings.TrimSpace(in.Ref)
 	filter := strings.TrimSpace(in.Filter)
 
-	if strings.TrimSpace(in.Project) == "" && strings.TrimSpace(in.Section) == "" && strings.TrimSpace(in.Parent) == "" {
-		return ResolveMoveResult{}, errors.New("at least one of --project, --section, or --parent is required")
-	}
-
 	if filter != "" {
 		if id != "" || ref != "" {
-			return ResolveMoveResult{}, errors.New("--filter cannot be combined with --id or positional task reference")
+			return ResolveBulkResult{}, errors.New("--filter cannot be combined with --id or positional task reference")
 		}
 		if !in.Yes && !in.Force {
-			return ResolveMoveResult{}, errors.New("bulk move with --filter requires --yes (or --force)")
+			return ResolveBulkResult{}, fmt.Errorf("bulk %s with --filter requires --yes (or --force)", in.Action)
 		}
 		if s.Lister == nil {
-			return ResolveMoveResult{}, errors.New("task filter lister is not configured")
+			return ResolveBulkResult{}, errors.New("task filter lister is not configured")
 		}
 		tasks, err := s.Lister.ListByFilter(ctx, filter)
 		if err != nil {
-			return ResolveMoveResult{}, err
+			return ResolveBulkResult{}, err
 		}
 		ids := make([]string, 0, len(tasks))
 		for _, task := range tasks {
 			ids = append(ids, task.ID)
 		}
-		return ResolveMoveResult{Mode: "bulk", IDs: ids, Filter: filter}, nil
+		return ResolveBulkResult{Mode: "bulk", IDs: ids, Tasks: tasks, Filter: filter}, nil
 	}
 
+	var tasks []api.Task
 	if id == "" && ref != "" {
 		if s.Resolver == nil {
-			return ResolveMoveResult{}, errors.New("task resolver is not configured")
+			return ResolveBulkResult{}, errors.New("task resolver is not configured")
 		}
 		task, err := s.Resolver.ResolveTaskRef(ctx, ref)
 		if err != nil {
-			return ResolveMoveResult{}, err
+			return ResolveBulkResult{}, err
 		}
 		id = task.ID
+		tasks = []api.Task{task}
 	}
 	if id == "" {
-		return ResolveMoveResult{}, errors.New("--id is required (or pass a text reference)")
+		return ResolveBulkResult{}, missing
 	}
-	return ResolveMoveResult{Mode: "single", ID: id, IDs: []string{id}}, nil
+	return ResolveBulkResult{Mode: "single", ID: id, IDs: []string{id}, Tasks: tasks}, nil
 }
 
 func (s Service) ResolveTaskTarget(ctx context.Context, in ResolveTaskTargetInput) (string, error) {
@@ -164,3 +164,23 @@ func TestResolveTaskTargetRejectsMismatchedURLType(t *testing.T) {
 		t.Fatalf("expected error")
 	}
 }
+
+func TestResolveBulkTargetsGuardrails(t *testing.T) {
+	svc := Service{Lister: fakeLister{tasks: []api.Task{{ID: "a", Content: "A"}, {ID: "b", Content: "B"}}}}
+	if _, err := svc.ResolveBulkTargets(context.Background(), ResolveBulkInput{Action: "update", Filter: "today"}); err == nil || err.Error() != "bulk update with --filter requires --yes (or --force)" {
+		t.Fatalf("expected --yes error, got %v", err)
+	}
+	if _, err := svc.ResolveBulkTargets(context.Background(), ResolveBulkInput{Action: "delete", Filter: "today", ID: "a", Yes: true}); err == nil {
+		t.Fatalf("expected error combining --filter and --id")
+	}
+	out, err := svc.ResolveBulkTargets(context.Background(), ResolveBulkInput{Action: "delete", Filter: "today", Force: true})
+	if err != nil {
+		t.Fatalf("ResolveBulkTargets: %v", err)
+	}
+	if out.Mode != "bulk" || len(out.IDs) != 2 || out.Tasks[1].Content != "B" {
+		t.Fatalf("unexpected output: %#v", out)
+	}
+	if _, err := svc.ResolveBulkTargets(context.Background(), ResolveBulkInput{Action: "update"}); err == nil || err.Error() != "task update requires --id or a reference" {
+		t.Fatalf("expected missing target error, got %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package tasks
+
+import "github.com/agisilaos/todoist-cli/internal/api"
+
+// TreeNode is a task together with its subtasks. It marshals as the task's
+// own fields plus a "children" array.
+type TreeNode struct {
+	api.Task
+	Children []*TreeNode `json:"children"`
+}
+
+// BuildTree nests tasks under their parents, keeping input order among
+// siblings. Tasks whose parent is not in the input, or whose parent_id chain
+// loops back to themselves, become roots.
+func BuildTree(tasks []api.Task) []*TreeNode {
+	nodes := make(map[string]*TreeNode, len(tasks))
+	ordered := make([]*TreeNode, 0, len(tasks))
+	for _, task := range tasks {
+		if _, ok := nodes[task.ID]; ok {
+			continue
+		}
+		node := &TreeNode{Task: task, Children: []*TreeNode{}}
+		nodes[task.ID] = node
+		ordered = append(ordered, node)
+	}
+	roots := make([]*TreeNode, 0, len(ordered))
+	for _, node := range ordered {
+		parent, ok := nodes[node.ParentID]
+		if node.ParentID == "" || !ok || inParentCycle(nodes, node) {
+			roots = append(roots, node)
+			continue
+		}
+		parent.Children = append(parent.Children, node)
+	}
+	return roots
+}
+
+// inParentCycle reports whether following parent_id from node leads back to
+// node.
+func inParentCycle(nodes map[string]*TreeNode, node *TreeNode) bool {
+	seen := map[string]bool{}
+	for current := node; current.ParentID != ""; {
+		if seen[current.ID] {
+			return false
+		}
+		seen[current.ID] = true
+		parent, ok := nodes[current.ParentID]
+		if !ok {
+			return false
+		}
+		if parent == node {
+			return true
+		}
+		current = parent
+	}
+	return false
+}
+
+// SplitCascaded separates matched tasks that have another matched task as an
+// ancestor, and so go with it when it is completed or deleted, from the ones
+// to act on. all supplies the unmatched tasks between them; both results keep
+// input order.
+func SplitCascaded(matched, all []api.Task) (act, cascaded []api.Task) {
+	parents := make(map[string]string, len(all)+len(matched))
+	for _, task := range all {
+		parents[task.ID] = task.ParentID
+	}
+	isMatched := make(map[string]bool, len(matched))
+	for _, task := range matched {
+		parents[task.ID] = task.ParentID
+		isMatched[task.ID] = true
+	}
+	for _, task := range matched {
+		covered, cyclic := false, false
+		seen := map[string]bool{}
+		for id := parents[task.ID]; id != "" && !seen[id]; id = parents[id] {
+			if id == task.ID {
+				cyclic = true
+				break
+			}
+			seen[id] = true
+			covered = covered || isMatched[id]
+		}
+		if covered && !cyclic {
+			cascaded = append(cascaded, task)
+		} else {
+			act = append(act, task)
+		}
+	}
+	return act, cascaded
+}
+
+// FlatNode is one entry of a depth-first walk over a tree.
+type FlatNode struct {
+	Task  api.Task
+	Depth int
+}
+
+// Flatten walks roots depth-first with parents before their children.
+func Flatten(roots []*TreeNode) []FlatNode {
+	var out []FlatNode
+	var walk func(nodes []*TreeNode, depth int)
+	walk = func(nodes []*TreeNode, depth int) {
+		for _, node := range nodes {
+			out = append(out, FlatNode{Task: node.Task, Depth: depth})
+			walk(node.Children, depth+1)
+		}
+	}
+	walk(roots, 0)
+	return out
+}
+
+// Subtree returns the node for rootID with all of its descendants found in
+// tasks, or nil when rootID is not among them.
+func Subtree(tasks []api.Task, rootID string) *TreeNode {
+	var find func(nodes []*TreeNode) *TreeNode
+	find = func(nodes []*TreeNode) *TreeNode {
+		for _, node := range nodes {
+			if node.ID == rootID {
+				return node
+			}
+			if found := find(node.Children); found != nil {
+				return found
+			}
+		}
+		return nil
+	}
+	return find(BuildTree(tasks))
+}
+
+// PostOrder lists a subtree with every child before its parent, the order in
+// which completing or deleting nodes leaves no parent acting on its children.
+func PostOrder(root *TreeNode) []FlatNode {
+	var out []FlatNode
+	var walk func(node *TreeNode, depth int)
+	walk = func(node *TreeNode, depth int) {
+		for _, child := range node.Children {
+			walk(child, depth+1)
+		}
+		out = append(out, FlatNode{Task: node.Task, Depth: depth})
+	}
+	if root != nil {
+		walk(root, 0)
+	}
+	return out
+}
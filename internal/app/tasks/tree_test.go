@@ -0,0 +1,101 @@
+package tasks
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+)
+
+func TestBuildTreeNestsChildrenAndKeepsOrphansAsRoots(t *testing.T) {
+	roots := BuildTree([]api.Task{
+		{ID: "1", Content: "parent"},
+		{ID: "2", Content: "child", ParentID: "1"},
+		{ID: "3", Content: "grandchild", ParentID: "2"},
+		{ID: "4", Content: "orphan", ParentID: "missing"},
+		{ID: "5", Content: "second child", ParentID: "1"},
+	})
+	if len(roots) != 2 || roots[0].ID != "1" || roots[1].ID != "4" {
+		t.Fatalf("unexpected roots: %#v", roots)
+	}
+	if len(roots[0].Children) != 2 || roots[0].Children[0].Children[0].ID != "3" {
+		t.Fatalf("unexpected children: %#v", roots[0].Children)
+	}
+	flat := Flatten(roots)
+	var got []string
+	for _, node := range flat {
+		got = append(got, node.Task.ID)
+	}
+	if want := "1,2,3,5,4"; strings.Join(got, ",") != want {
+		t.Fatalf("flatten order=%s, want %s", strings.Join(got, ","), want)
+	}
+	if flat[2].Depth != 2 {
+		t.Fatalf("expected grandchild depth 2, got %d", flat[2].Depth)
+	}
+}
+
+func TestBuildTreeBreaksParentCycles(t *testing.T) {
+	roots := BuildTree([]api.Task{
+		{ID: "a", ParentID: "b"},
+		{ID: "b", ParentID: "a"},
+		{ID: "c", ParentID: "a"},
+		{ID: "d", ParentID: "d"},
+	})
+	var got []string
+	for _, node := range Flatten(roots) {
+		got = append(got, node.Task.ID)
+	}
+	if want := "a,c,b,d"; strings.Join(got, ",") != want {
+		t.Fatalf("flatten order=%s, want %s", strings.Join(got, ","), want)
+	}
+	if len(roots) != 3 {
+		t.Fatalf("expected cycle members as roots, got %d roots", len(roots))
+	}
+}
+
+func TestSplitCascadedFollowsUnmatchedAncestors(t *testing.T) {
+	all := []api.Task{
+		{ID: "1"},
+		{ID: "2", ParentID: "1"},
+		{ID: "3", ParentID: "2"},
+		{ID: "4"},
+		{ID: "5", ParentID: "6"},
+		{ID: "6", ParentID: "5"},
+	}
+	matched := []api.Task{all[2], all[0], all[3], all[4], all[5]}
+	act, cascaded := SplitCascaded(matched, all)
+	ids := func(tasks []api.Task) string {
+		var out []string
+		for _, task := range tasks {
+			out = append(out, task.ID)
+		}
+		return strings.Join(out, ",")
+	}
+	if ids(act) != "1,4,5,6" || ids(cascaded) != "3" {
+		t.Fatalf("act=%s cascaded=%s", ids(act), ids(cascaded))
+	}
+}
+
+func TestSubtreePostOrderVisitsChildrenFirst(t *testing.T) {
+	tasks := []api.Task{
+		{ID: "1"},
+		{ID: "2", ParentID: "1"},
+		{ID: "3", ParentID: "2"},
+		{ID: "4", ParentID: "1"},
+		{ID: "5"},
+	}
+	node := Subtree(tasks, "1")
+	if node == nil {
+		t.Fatalf("expected subtree")
+	}
+	var got []string
+	for _, n := range PostOrder(node) {
+		got = append(got, n.Task.ID)
+	}
+	if want := "3,2,4,1"; strings.Join(got, ",") != want {
+		t.Fatalf("post order=%s, want %s", strings.Join(got, ","), want)
+	}
+	if Subtree(tasks, "missing") != nil {
+		t.Fatalf("expected nil for unknown root")
+	}
+}
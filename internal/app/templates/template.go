@@ -0,0 +1,317 @@
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	coreagent "github.com/agisilaos/todoist-cli/internal/agent"
+	"github.com/agisilaos/todoist-cli/internal/api"
+	apptasks "github.com/agisilaos/todoist-cli/internal/app/tasks"
+)
+
+const Version = 1
+
+// Template is a reusable project skeleton. Tasks outside any section come
+// first, then each section with its tasks. Due dates are stored as offsets
+// from the day the template is applied.
+type Template struct {
+	Version  int               `json:"version"`
+	Name     string            `json:"name,omitempty"`
+	Vars     map[string]string `json:"vars,omitempty"`
+	Tasks    []Task            `json:"tasks,omitempty"`
+	Sections []Section         `json:"sections,omitempty"`
+}
+
+type Section struct {
+	Name  string `json:"name"`
+	Tasks []Task `json:"tasks,omitempty"`
+}
+
+type Task struct {
+	Content     string   `json:"content"`
+	Description string   `json:"description,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+	Priority    int      `json:"priority,omitempty"`
+	DueOffset   string   `json:"due_offset,omitempty"`
+	Children    []Task   `json:"children,omitempty"`
+}
+
+type ExportInput struct {
+	Name     string
+	Sections []api.Section
+	Tasks    []api.Task
+}
+
+// Export captures a project's sections and open tasks. Due offsets are
+// relative to the earliest due date among the exported tasks, so applying
+// the template anchors that task on the apply date.
+func Export(in ExportInput) Template {
+	base, hasBase := earliestDue(in.Tasks)
+	bySection := map[string][]api.Task{}
+	known := map[string]bool{}
+	for _, section := range in.Sections {
+		known[section.ID] = true
+	}
+	for _, task := range in.Tasks {
+		key := task.SectionID
+		if !known[key] {
+			key = ""
+		}
+		bySection[key] = append(bySection[key], task)
+	}
+	var walk func(nodes []*apptasks.TreeNode) []Task
+	walk = func(nodes []*apptasks.TreeNode) []Task {
+		var level []Task
+		for _, node := range nodes {
+			level = append(level, exportTask(node.Task, base, hasBase, walk(node.Children)))
+		}
+		return level
+	}
+	convert := func(tasks []api.Task) []Task {
+		return walk(apptasks.BuildTree(tasks))
+	}
+	tpl := Template{Version: Version, Name: in.Name}
+	tpl.Tasks = convert(bySection[""])
+	for _, section := range in.Sections {
+		tpl.Sections = append(tpl.Sections, Section{Name: section.Name, Tasks: convert(bySection[section.ID])})
+	}
+	return tpl
+}
+
+func exportTask(task api.Task, base time.Time, hasBase bool, children []Task) Task {
+	out := Task{
+		Content:     task.Content,
+		Description: task.Description,
+		Labels:      append([]string(nil), task.Labels...),
+		Children:    children,
+	}
+	if task.Priority > 1 {
+		out.Priority = task.Priority
+	}
+	if due, ok := dueDay(task); ok && hasBase {
+		out.DueOffset = FormatOffset(int(due.Sub(base).Hours() / 24))
+	}
+	return out
+}
+
+func dueDay(task api.Task) (time.Time, bool) {
+	if task.Due == nil {
+		return time.Time{}, false
+	}
+	value := task.Due.Date
+	if value == "" && len(task.Due.Datetime) >= len(time.DateOnly) {
+		value = task.Due.Datetime[:len(time.DateOnly)]
+	}
+	if len(value) > len(time.DateOnly) {
+		value = value[:len(time.DateOnly)]
+	}
+	day, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return day, true
+}
+
+func earliestDue(tasks []api.Task) (time.Time, bool) {
+	var base time.Time
+	found := false
+	for _, task := range tasks {
+		day, ok := dueDay(task)
+		if !ok {
+			continue
+		}
+		if !found || day.Before(base) {
+			base = day
+			found = true
+		}
+	}
+	return base, found
+}
+
+var offsetPattern = regexp.MustCompile(`^([+-]?)(\d+)([dw]?)$`)
+
+// ParseOffset reads a day offset such as "+3d", "-1d", "2w" or "0".
+func ParseOffset(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	m := offsetPattern.FindStringSubmatch(value)
+	if m == nil {
+		return 0, fmt.Errorf("invalid offset %q (use +Nd, -Nd or +Nw)", value)
+	}
+	n, err := strconv.Atoi(m[2])
+	if err != nil {
+		return 0, fmt.Errorf("invalid offset %q", value)
+	}
+	if m[3] == "w" {
+		n *= 7
+	}
+	if m[1] == "-" {
+		n = -n
+	}
+	return n, nil
+}
+
+func FormatOffset(days int) string {
+	if days < 0 {
+		return fmt.Sprintf("-%dd", -days)
+	}
+	return fmt.Sprintf("+%dd", days)
+}
+
+var varPattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
+
+// Expand replaces {{name}} placeholders. Every placeholder must have a value.
+func Expand(value string, vars map[string]string) (string, error) {
+	var missing []string
+	out := varPattern.ReplaceAllStringFunc(value, func(match string) string {
+		name := varPattern.FindStringSubmatch(match)[1]
+		v, ok := vars[name]
+		if !ok {
+			missing = append(missing, name)
+			return match
+		}
+		return v
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("template variable %q is not set (use --var %s=...)", missing[0], missing[0])
+	}
+	return out, nil
+}
+
+// Placeholders lists the distinct variable names a template references.
+func (t Template) Placeholders() []string {
+	seen := map[string]bool{}
+	add := func(value string) {
+		for _, m := range varPattern.FindAllStringSubmatch(value, -1) {
+			seen[m[1]] = true
+		}
+	}
+	var walk func(tasks []Task)
+	walk = func(tasks []Task) {
+		for _, task := range tasks {
+			add(task.Content)
+			add(task.Description)
+			for _, label := range task.Labels {
+				add(label)
+			}
+			walk(task.Children)
+		}
+	}
+	walk(t.Tasks)
+	for _, section := range t.Sections {
+		add(section.Name)
+		walk(section.Tasks)
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type ApplyInput struct {
+	ProjectID string
+	// Vars override the template's own defaults.
+	Vars map[string]string
+	// Anchor is the day offset "+0d" lands on.
+	Anchor time.Time
+}
+
+// Actions turns the template into agent plan actions: section_add for each
+// section and task_add for each task, linked through plan-local aliases so
+// the regular plan executor can create them in order.
+func (t Template) Actions(in ApplyInput) ([]coreagent.Action, error) {
+	if t.Version != 0 && t.Version != Version {
+		return nil, fmt.Errorf("unsupported template version %d (expected %d)", t.Version, Version)
+	}
+	if strings.TrimSpace(in.ProjectID) == "" {
+		return nil, errors.New("target project is required")
+	}
+	vars := map[string]string{}
+	for k, v := range t.Vars {
+		vars[k] = v
+	}
+	for k, v := range in.Vars {
+		vars[k] = v
+	}
+	b := &actionBuilder{projectID: in.ProjectID, vars: vars, anchor: in.Anchor}
+	if err := b.tasks(t.Tasks, "", ""); err != nil {
+		return nil, err
+	}
+	for i, section := range t.Sections {
+		name, err := Expand(section.Name, vars)
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("section %d has no name", i+1)
+		}
+		alias := fmt.Sprintf("s%d", i+1)
+		b.actions = append(b.actions, coreagent.Action{Type: "section_add", Alias: alias, Name: name, ProjectID: in.ProjectID})
+		if err := b.tasks(section.Tasks, "$"+alias, ""); err != nil {
+			return nil, err
+		}
+	}
+	return b.actions, nil
+}
+
+type actionBuilder struct {
+	projectID string
+	vars      map[string]string
+	anchor    time.Time
+	count     int
+	actions   []coreagent.Action
+}
+
+func (b *actionBuilder) tasks(tasks []Task, sectionRef, parentRef string) error {
+	for _, task := range tasks {
+		b.count++
+		alias := fmt.Sprintf("t%d", b.count)
+		action := coreagent.Action{Type: "task_add", Alias: alias, ProjectID: b.projectID, Priority: task.Priority}
+		if parentRef != "" {
+			action.Parent = parentRef
+		} else {
+			action.SectionID = sectionRef
+		}
+		var err error
+		if action.Content, err = Expand(task.Content, b.vars); err != nil {
+			return err
+		}
+		if strings.TrimSpace(action.Content) == "" {
+			return fmt.Errorf("task %d has no content", b.count)
+		}
+		if action.Description, err = Expand(task.Description, b.vars); err != nil {
+			return err
+		}
+		for _, label := range task.Labels {
+			expanded, err := Expand(label, b.vars)
+			if err != nil {
+				return err
+			}
+			action.Labels = append(action.Labels, expanded)
+		}
+		if task.Priority < 0 || task.Priority > 4 {
+			return fmt.Errorf("task %q: priority must be between 1 and 4", task.Content)
+		}
+		if strings.TrimSpace(task.DueOffset) != "" {
+			days, err := ParseOffset(task.DueOffset)
+			if err != nil {
+				return fmt.Errorf("task %q: %w", task.Content, err)
+			}
+			action.DueDate = b.anchor.AddDate(0, 0, days).Format(time.DateOnly)
+		}
+		b.actions = append(b.actions, action)
+		if err := b.tasks(task.Children, sectionRef, "$"+alias); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+)
+
+func TestExportNestsTasksAndComputesOffsets(t *testing.T) {
+	tpl := Export(ExportInput{
+		Name:     "Release",
+		Sections: []api.Section{{ID: "s1", Name: "QA"}},
+		Tasks: []api.Task{
+			{ID: "a", Content: "Kickoff", Priority: 1, Due: &api.Due{Date: "2026-03-02"}},
+			{ID: "b", Content: "Test", SectionID: "s1", Priority: 4, Labels: []string{"qa"}, Due: &api.Due{Datetime: "2026-03-05T10:00:00Z"}},
+			{ID: "c", Content: "Smoke", SectionID: "s1", ParentID: "b"},
+			{ID: "d", Content: "Stray", SectionID: "gone"},
+		},
+	})
+	if tpl.Version != Version || tpl.Name != "Release" {
+		t.Fatalf("unexpected header: %#v", tpl)
+	}
+	if len(tpl.Tasks) != 2 || tpl.Tasks[0].DueOffset != "+0d" || tpl.Tasks[0].Priority != 0 || tpl.Tasks[1].Content != "Stray" {
+		t.Fatalf("unexpected root tasks: %#v", tpl.Tasks)
+	}
+	qa := tpl.Sections[0]
+	if qa.Name != "QA" || len(qa.Tasks) != 1 {
+		t.Fatalf("unexpected section: %#v", qa)
+	}
+	test := qa.Tasks[0]
+	if test.DueOffset != "+3d" || test.Priority != 4 || test.Labels[0] != "qa" || len(test.Children) != 1 || test.Children[0].Content != "Smoke" {
+		t.Fatalf("unexpected section task: %#v", test)
+	}
+}
+
+func TestParseOffset(t *testing.T) {
+	cases := map[string]int{"": 0, "0": 0, "+3d": 3, "-1d": -1, "2w": 14, "+1w": 7, "5": 5}
+	for input, want := range cases {
+		got, err := ParseOffset(input)
+		if err != nil || got != want {
+			t.Fatalf("ParseOffset(%q) = %d, %v; want %d", input, got, err, want)
+		}
+	}
+	if _, err := ParseOffset("3 days"); err == nil {
+		t.Fatalf("expected error for invalid offset")
+	}
+}
+
+func TestActionsLinksSectionsAndSubtasks(t *testing.T) {
+	tpl := Template{
+		Version: 1,
+		Vars:    map[string]string{"version": "0.0"},
+		Tasks:   []Task{{Content: "Plan {{version}}", DueOffset: "+0d"}},
+		Sections: []Section{{
+			Name: "Ship {{ version }}",
+			Tasks: []Task{{
+				Content:   "Tag",
+				Labels:    []string{"release-{{version}}"},
+				Priority:  4,
+				DueOffset: "+2d",
+				Children:  []Task{{Content: "Push tag"}},
+			}},
+		}},
+	}
+	anchor := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	actions, err := tpl.Actions(ApplyInput{ProjectID: "p9", Vars: map[string]string{"version": "1.4"}, Anchor: anchor})
+	if err != nil {
+		t.Fatalf("Actions: %v", err)
+	}
+	if len(actions) != 4 {
+		t.Fatalf("expected 4 actions, got %#v", actions)
+	}
+	if a := actions[0]; a.Type != "task_add" || a.Content != "Plan 1.4" || a.DueDate != "2026-03-10" || a.ProjectID != "p9" || a.SectionID != "" {
+		t.Fatalf("unexpected root task: %#v", a)
+	}
+	if a := actions[1]; a.Type != "section_add" || a.Name != "Ship 1.4" || a.Alias != "s1" || a.ProjectID != "p9" {
+		t.Fatalf("unexpected section: %#v", a)
+	}
+	if a := actions[2]; a.SectionID != "$s1" || a.Labels[0] != "release-1.4" || a.DueDate != "2026-03-12" || a.Priority != 4 || a.Alias != "t2" {
+		t.Fatalf("unexpected section task: %#v", a)
+	}
+	if a := actions[3]; a.Parent != "$t2" || a.SectionID != "" || a.Content != "Push tag" {
+		t.Fatalf("unexpected subtask: %#v", a)
+	}
+}
+
+func TestActionsRequiresVariables(t *testing.T) {
+	tpl := Template{Tasks: []Task{{Content: "Release {{version}}"}}}
+	_, err := tpl.Actions(ApplyInput{ProjectID: "p1"})
+	if err == nil || !strings.Contains(err.Error(), `"version" is not set`) {
+		t.Fatalf("expected missing variable error, got %v", err)
+	}
+	if got := tpl.Placeholders(); len(got) != 1 || got[0] != "version" {
+		t.Fatalf("unexpected placeholders: %v", got)
+	}
+}
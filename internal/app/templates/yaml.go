@@ -0,0 +1,529 @@
+package templates
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Templates are read and written as a small YAML subset: block mappings and
+// sequences, "- key: value" items, quoted or plain scalars, [a, b] flow lists
+// and # comments. That covers everything Encode produces and what people
+// write by hand, without pulling in a YAML dependency. Input starting with
+// "{" is read as JSON.
+
+// Encode writes t as YAML.
+func Encode(t Template) []byte {
+	var buf bytes.Buffer
+	if t.Version == 0 {
+		t.Version = Version
+	}
+	fmt.Fprintf(&buf, "version: %d\n", t.Version)
+	if t.Name != "" {
+		fmt.Fprintf(&buf, "name: %s\n", quote(t.Name))
+	}
+	if len(t.Vars) > 0 {
+		buf.WriteString("vars:\n")
+		keys := make([]string, 0, len(t.Vars))
+		for k := range t.Vars {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(&buf, "  %s: %s\n", k, quote(t.Vars[k]))
+		}
+	}
+	if len(t.Tasks) > 0 {
+		buf.WriteString("tasks:\n")
+		encodeTasks(&buf, t.Tasks, "  ")
+	}
+	if len(t.Sections) > 0 {
+		buf.WriteString("sections:\n")
+		for _, section := range t.Sections {
+			fmt.Fprintf(&buf, "  - name: %s\n", quote(section.Name))
+			if len(section.Tasks) > 0 {
+				buf.WriteString("    tasks:\n")
+				encodeTasks(&buf, section.Tasks, "      ")
+			}
+		}
+	}
+	return buf.Bytes()
+}
+
+func encodeTasks(buf *bytes.Buffer, tasks []Task, indent string) {
+	for _, task := range tasks {
+		fmt.Fprintf(buf, "%s- content: %s\n", indent, quote(task.Content))
+		field := indent + "  "
+		if task.Description != "" {
+			fmt.Fprintf(buf, "%sdescription: %s\n", field, quote(task.Description))
+		}
+		if len(task.Labels) > 0 {
+			quoted := make([]string, 0, len(task.Labels))
+			for _, label := range task.Labels {
+				quoted = append(quoted, quote(label))
+			}
+			fmt.Fprintf(buf, "%slabels: [%s]\n", field, strings.Join(quoted, ", "))
+		}
+		if task.Priority > 0 {
+			fmt.Fprintf(buf, "%spriority: %d\n", field, task.Priority)
+		}
+		if task.DueOffset != "" {
+			fmt.Fprintf(buf, "%sdue_offset: %s\n", field, quote(task.DueOffset))
+		}
+		if len(task.Children) > 0 {
+			fmt.Fprintf(buf, "%schildren:\n", field)
+			encodeTasks(buf, task.Children, field+"  ")
+		}
+	}
+}
+
+func quote(value string) string {
+	return strconv.Quote(value)
+}
+
+// Decode reads a template from YAML or JSON.
+func Decode(data []byte) (Template, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return Template{}, errors.New("template is empty")
+	}
+	var root any
+	if trimmed[0] == '{' {
+		if err := json.Unmarshal(trimmed, &root); err != nil {
+			return Template{}, fmt.Errorf("invalid template JSON: %w", err)
+		}
+	} else {
+		var err error
+		if root, err = parseYAML(data); err != nil {
+			return Template{}, err
+		}
+	}
+	return templateFromNode(root)
+}
+
+type yamlLine struct {
+	num    int
+	indent int
+	text   string
+}
+
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+func parseYAML(data []byte) (any, error) {
+	var lines []yamlLine
+	for i, raw := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		text := strings.TrimRight(stripComment(raw), " \t")
+		if strings.TrimSpace(text) == "" || strings.TrimSpace(text) == "---" {
+			continue
+		}
+		body := strings.TrimLeft(text, " ")
+		if strings.HasPrefix(body, "\t") {
+			return nil, fmt.Errorf("line %d: tabs are not allowed for indentation", i+1)
+		}
+		lines = append(lines, yamlLine{num: i + 1, indent: len(text) - len(body), text: body})
+	}
+	if len(lines) == 0 {
+		return nil, errors.New("template is empty")
+	}
+	p := &yamlParser{lines: lines}
+	node, err := p.parseBlock(lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.lines) {
+		return nil, fmt.Errorf("line %d: unexpected indentation", p.lines[p.pos].num)
+	}
+	return node, nil
+}
+
+func isSeqItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+func (p *yamlParser) parseBlock(indent int) (any, error) {
+	if isSeqItem(p.lines[p.pos].text) {
+		return p.parseSeq(indent)
+	}
+	return p.parseMap(indent)
+}
+
+func (p *yamlParser) parseSeq(indent int) ([]any, error) {
+	items := []any{}
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if line.indent != indent || !isSeqItem(line.text) {
+			break
+		}
+		rest := strings.TrimLeft(strings.TrimPrefix(line.text, "-"), " ")
+		if rest == "" {
+			p.pos++
+			if p.pos >= len(p.lines) || p.lines[p.pos].indent <= indent {
+				items = append(items, nil)
+				continue
+			}
+			item, err := p.parseBlock(p.lines[p.pos].indent)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+			continue
+		}
+		if _, _, ok := splitKey(rest); ok {
+			// "- key: value" opens a mapping whose keys line up with "key".
+			p.lines[p.pos] = yamlLine{num: line.num, indent: indent + len(line.text) - len(rest), text: rest}
+			item, err := p.parseMap(p.lines[p.pos].indent)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+			continue
+		}
+		value, err := parseScalar(rest, line.num)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, value)
+		p.pos++
+	}
+	return items, nil
+}
+
+func (p *yamlParser) parseMap(indent int) (map[string]any, error) {
+	out := map[string]any{}
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+		if line.indent < indent {
+			break
+		}
+		if line.indent > indent {
+			return nil, fmt.Errorf("line %d: unexpected indentation", line.num)
+		}
+		if isSeqItem(line.text) {
+			break
+		}
+		key, value, ok := splitKey(line.text)
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected \"key: value\"", line.num)
+		}
+		if _, exists := out[key]; exists {
+			return nil, fmt.Errorf("line %d: duplicate key %q", line.num, key)
+		}
+		p.pos++
+		if value != "" {
+			parsed, err := parseScalar(value, line.num)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = parsed
+			continue
+		}
+		if p.pos < len(p.lines) {
+			next := p.lines[p.pos]
+			if next.indent > indent || (next.indent == indent && isSeqItem(next.text)) {
+				child, err := p.parseBlock(next.indent)
+				if err != nil {
+					return nil, err
+				}
+				out[key] = child
+				continue
+			}
+		}
+		out[key] = nil
+	}
+	return out, nil
+}
+
+func splitKey(text string) (string, string, bool) {
+	idx := strings.Index(text, ":")
+	if idx <= 0 {
+		return "", "", false
+	}
+	key := text[:idx]
+	for _, r := range key {
+		if !(r == '_' || r == '-' || r == '.' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return "", "", false
+		}
+	}
+	rest := text[idx+1:]
+	if rest != "" && rest[0] != ' ' {
+		return "", "", false
+	}
+	return key, strings.TrimSpace(rest), true
+}
+
+// stripComment drops a trailing "# ..." that is not inside quotes.
+func stripComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote == '"' && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+func parseScalar(value string, num int) (any, error) {
+	switch {
+	case strings.HasPrefix(value, `"`):
+		s, err := strconv.Unquote(value)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid quoted string %s", num, value)
+		}
+		return s, nil
+	case strings.HasPrefix(value, "'"):
+		if len(value) < 2 || !strings.HasSuffix(value, "'") {
+			return nil, fmt.Errorf("line %d: unterminated string %s", num, value)
+		}
+		return strings.ReplaceAll(value[1:len(value)-1], "''", "'"), nil
+	case strings.HasPrefix(value, "["):
+		if !strings.HasSuffix(value, "]") {
+			return nil, fmt.Errorf("line %d: unterminated list %s", num, value)
+		}
+		items := []any{}
+		for _, part := range splitFlow(value[1 : len(value)-1]) {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			item, err := parseScalar(part, num)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case value == "{}":
+		return map[string]any{}, nil
+	case value == "~" || value == "null":
+		return nil, nil
+	}
+	return value, nil
+}
+
+func splitFlow(value string) []string {
+	var parts []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case quote == '"' && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == ',':
+			parts = append(parts, value[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, value[start:])
+}
+
+func templateFromNode(node any) (Template, error) {
+	root, ok := node.(map[string]any)
+	if !ok {
+		return Template{}, errors.New("template must be a mapping")
+	}
+	if err := checkKeys("template", root, "version", "name", "vars", "tasks", "sections"); err != nil {
+		return Template{}, err
+	}
+	var tpl Template
+	var err error
+	if tpl.Version, err = intField("version", root["version"]); err != nil {
+		return Template{}, err
+	}
+	if tpl.Name, err = stringField("name", root["name"]); err != nil {
+		return Template{}, err
+	}
+	if raw, ok := root["vars"]; ok && raw != nil {
+		vars, ok := raw.(map[string]any)
+		if !ok {
+			return Template{}, errors.New("vars must be a mapping")
+		}
+		tpl.Vars = map[string]string{}
+		for k, v := range vars {
+			if tpl.Vars[k], err = stringField("vars."+k, v); err != nil {
+				return Template{}, err
+			}
+		}
+	}
+	if tpl.Tasks, err = tasksFromNode("tasks", root["tasks"]); err != nil {
+		return Template{}, err
+	}
+	sections, err := listField("sections", root["sections"])
+	if err != nil {
+		return Template{}, err
+	}
+	for i, raw := range sections {
+		where := fmt.Sprintf("sections[%d]", i)
+		m, ok := raw.(map[string]any)
+		if !ok {
+			return Template{}, fmt.Errorf("%s must be a mapping", where)
+		}
+		if err := checkKeys(where, m, "name", "tasks"); err != nil {
+			return Template{}, err
+		}
+		var section Section
+		if section.Name, err = stringField(where+".name", m["name"]); err != nil {
+			return Template{}, err
+		}
+		if section.Tasks, err = tasksFromNode(where+".tasks", m["tasks"]); err != nil {
+			return Template{}, err
+		}
+		tpl.Sections = append(tpl.Sections, section)
+	}
+	return tpl, nil
+}
+
+func tasksFromNode(where string, node any) ([]Task, error) {
+	items, err := listField(where, node)
+	if err != nil {
+		return nil, err
+	}
+	var tasks []Task
+	for i, raw := range items {
+		at := fmt.Sprintf("%s[%d]", where, i)
+		m, ok := raw.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%s must be a mapping", at)
+		}
+		if err := checkKeys(at, m, "content", "description", "labels", "priority", "due_offset", "children"); err != nil {
+			return nil, err
+		}
+		var task Task
+		if task.Content, err = stringField(at+".content", m["content"]); err != nil {
+			return nil, err
+		}
+		if task.Description, err = stringField(at+".description", m["description"]); err != nil {
+			return nil, err
+		}
+		labels, err := listField(at+".labels", m["labels"])
+		if err != nil {
+			return nil, err
+		}
+		for j, label := range labels {
+			value, err := stringField(fmt.Sprintf("%s.labels[%d]", at, j), label)
+			if err != nil {
+				return nil, err
+			}
+			task.Labels = append(task.Labels, value)
+		}
+		if task.Priority, err = priorityField(at+".priority", m["priority"]); err != nil {
+			return nil, err
+		}
+		if task.DueOffset, err = stringField(at+".due_offset", m["due_offset"]); err != nil {
+			return nil, err
+		}
+		if _, err := ParseOffset(task.DueOffset); err != nil {
+			return nil, fmt.Errorf("%s.due_offset: %w", at, err)
+		}
+		if task.Children, err = tasksFromNode(at+".children", m["children"]); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+func checkKeys(where string, m map[string]any, allowed ...string) error {
+	for key := range m {
+		known := false
+		for _, a := range allowed {
+			if key == a {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("%s: unknown field %q", where, key)
+		}
+	}
+	return nil
+}
+
+func listField(where string, node any) ([]any, error) {
+	if node == nil {
+		return nil, nil
+	}
+	items, ok := node.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a list", where)
+	}
+	return items, nil
+}
+
+func stringField(where string, node any) (string, error) {
+	switch v := node.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	return "", fmt.Errorf("%s must be a string", where)
+}
+
+func intField(where string, node any) (int, error) {
+	switch v := node.(type) {
+	case nil:
+		return 0, nil
+	case float64:
+		return int(v), nil
+	case string:
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, fmt.Errorf("%s must be a number", where)
+		}
+		return n, nil
+	}
+	return 0, fmt.Errorf("%s must be a number", where)
+}
+
+// priorityField accepts the API value (1-4, 4 is most urgent) or the app's
+// p1-p4 labels (p1 is most urgent), as task add --priority does.
+func priorityField(where string, node any) (int, error) {
+	if s, ok := node.(string); ok {
+		switch strings.ToLower(strings.TrimSpace(s)) {
+		case "p1":
+			return 4, nil
+		case "p2":
+			return 3, nil
+		case "p3":
+			return 2, nil
+		case "p4":
+			return 1, nil
+		}
+	}
+	n, err := intField(where, node)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be 1-4 or p1-p4", where)
+	}
+	if n < 0 || n > 4 {
+		return 0, fmt.Errorf("%s must be 1-4 or p1-p4", where)
+	}
+	return n, nil
+}
@@ -0,0 +1,89 @@
+package templates
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	tpl := Template{
+		Version: 1,
+		Name:    `Release "train"`,
+		Vars:    map[string]string{"version": ""},
+		Tasks:   []Task{{Content: "Plan # not a comment", Description: "line1\nline2"}},
+		Sections: []Section{
+			{Name: "QA", Tasks: []Task{{
+				Content:   "Test {{version}}",
+				Labels:    []string{"qa", "needs, review"},
+				Priority:  4,
+				DueOffset: "+3d",
+				Children:  []Task{{Content: "Smoke", Children: []Task{{Content: "Deep"}}}},
+			}}},
+			{Name: "Empty"},
+		},
+	}
+	got, err := Decode(Encode(tpl))
+	if err != nil {
+		t.Fatalf("Decode: %v\n%s", err, Encode(tpl))
+	}
+	if !reflect.DeepEqual(got, tpl) {
+		t.Fatalf("round trip mismatch:\n got %#v\nwant %#v", got, tpl)
+	}
+}
+
+func TestDecodeHandWrittenYAML(t *testing.T) {
+	src := `# release checklist
+version: 1
+name: Release
+tasks:
+- content: Write notes   # trailing comment
+  priority: p1
+sections:
+  - name: 'Ship it'
+    tasks:
+      -
+        content: Tag
+        labels: [release, "ops"]
+        due_offset: +1w
+`
+	tpl, err := Decode([]byte(src))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if tpl.Name != "Release" || tpl.Tasks[0].Content != "Write notes" || tpl.Tasks[0].Priority != 4 {
+		t.Fatalf("unexpected tasks: %#v", tpl)
+	}
+	task := tpl.Sections[0].Tasks[0]
+	if tpl.Sections[0].Name != "Ship it" || task.Content != "Tag" || !reflect.DeepEqual(task.Labels, []string{"release", "ops"}) || task.DueOffset != "+1w" {
+		t.Fatalf("unexpected section: %#v", tpl.Sections)
+	}
+}
+
+func TestDecodeJSON(t *testing.T) {
+	tpl, err := Decode([]byte(`{"version":1,"tasks":[{"content":"A","priority":3,"children":[{"content":"B"}]}]}`))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if tpl.Tasks[0].Priority != 3 || tpl.Tasks[0].Children[0].Content != "B" {
+		t.Fatalf("unexpected template: %#v", tpl)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	cases := map[string]string{
+		"unknown field": "tasks:\n  - content: A\n    childern: []\n",
+		"bad priority":  "tasks:\n  - content: A\n    priority: 7\n",
+		"bad offset":    "tasks:\n  - content: A\n    due_offset: soon\n",
+		"indentation":   "name: A\n   version: 1\n",
+		"tabs":          "tasks:\n\t- content: A\n",
+		"empty":         "# nothing\n",
+	}
+	for name, src := range cases {
+		if _, err := Decode([]byte(src)); err == nil {
+			t.Fatalf("%s: expected error", name)
+		} else if strings.TrimSpace(err.Error()) == "" {
+			t.Fatalf("%s: empty error", name)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// Package tui holds the terminal UI state machine and its rendering. It does
+// no I/O: the cli package feeds it keys, runs the commands it returns and
+// loads the rows it asks for.
+package tui
+
+import "unicode/utf8"
+
+// Key is a named key ("up", "enter", "ctrl-c", ...) or a single printable
+// character.
+type Key string
+
+const (
+	KeyUp        Key = "up"
+	KeyDown      Key = "down"
+	KeyLeft      Key = "left"
+	KeyRight     Key = "right"
+	KeyHome      Key = "home"
+	KeyEnd       Key = "end"
+	KeyPageUp    Key = "pgup"
+	KeyPageDown  Key = "pgdn"
+	KeyEnter     Key = "enter"
+	KeyBackspace Key = "backspace"
+	KeyEsc       Key = "esc"
+	KeyTab       Key = "tab"
+	KeyBackTab   Key = "backtab"
+	KeyCtrlC     Key = "ctrl-c"
+	KeyCtrlR     Key = "ctrl-r"
+)
+
+var escapeKeys = map[string]Key{
+	"[A": KeyUp, "[B": KeyDown, "[C": KeyRight, "[D": KeyLeft,
+	"OA": KeyUp, "OB": KeyDown, "OC": KeyRight, "OD": KeyLeft,
+	"[H": KeyHome, "[F": KeyEnd, "OH": KeyHome, "OF": KeyEnd,
+	"[1~": KeyHome, "[4~": KeyEnd, "[5~": KeyPageUp, "[6~": KeyPageDown,
+	"[Z": KeyBackTab,
+}
+
+// ParseKeys decodes one read from a terminal in cbreak mode. A read may hold
+// several keys when input is pasted or typed quickly.
+func ParseKeys(data []byte) []Key {
+	var keys []Key
+	for len(data) > 0 {
+		switch b := data[0]; {
+		case b == 0x1b:
+			key, n := parseEscape(data)
+			keys = append(keys, key)
+			data = data[n:]
+			continue
+		case b == '\r' || b == '\n':
+			keys = append(keys, KeyEnter)
+			// A CR LF pair is one Enter.
+			if b == '\r' && len(data) > 1 && data[1] == '\n' {
+				data = data[1:]
+			}
+		case b == 0x7f || b == 0x08:
+			keys = append(keys, KeyBackspace)
+		case b == '\t':
+			keys = append(keys, KeyTab)
+		case b == 0x03:
+			keys = append(keys, KeyCtrlC)
+		case b == 0x12:
+			keys = append(keys, KeyCtrlR)
+		case b < 0x20:
+			// Other control characters have no binding.
+		default:
+			r, n := utf8.DecodeRune(data)
+			if r != utf8.RuneError {
+				keys = append(keys, Key(string(r)))
+			}
+			data = data[n:]
+			continue
+		}
+		data = data[1:]
+	}
+	return keys
+}
+
+func parseEscape(data []byte) (Key, int) {
+	for seq, key := range escapeKeys {
+		if len(data) > len(seq) && string(data[1:1+len(seq)]) == seq {
+			return key, 1 + len(seq)
+		}
+	}
+	return KeyEsc, 1
+}
+
+// Printable reports whether k is a single character that can be typed into a
+// prompt.
+func (k Key) Printable() bool {
+	return utf8.RuneCountInString(string(k)) == 1 && string(k) >= " "
+}
@@ -0,0 +1,36 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseKeys(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []Key
+	}{
+		{"j", []Key{"j"}},
+		{"\x1b[A\x1b[B", []Key{KeyUp, KeyDown}},
+		{"\x1bOC", []Key{KeyRight}},
+		{"\x1b[5~\x1b[Z", []Key{KeyPageUp, KeyBackTab}},
+		{"\x1b", []Key{KeyEsc}},
+		{"ab\r\n", []Key{"a", "b", KeyEnter}},
+		{"é\x7f", []Key{"é", KeyBackspace}},
+		{"\x03\x12\t", []Key{KeyCtrlC, KeyCtrlR, KeyTab}},
+		{"\x01x", []Key{"x"}},
+	}
+	for _, tt := range tests {
+		if got := ParseKeys([]byte(tt.in)); !reflect.DeepEqual(got, tt.want) {
+			t.Fatalf("ParseKeys(%q) = %#v, want %#v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestKeyPrintable(t *testing.T) {
+	for key, want := range map[Key]bool{"a": true, " ": true, "é": true, KeyUp: false, KeyEnter: false} {
+		if got := key.Printable(); got != want {
+			t.Fatalf("%q.Printable() = %v, want %v", key, got, want)
+		}
+	}
+}
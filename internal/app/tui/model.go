@@ -0,0 +1,388 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	apptasks "github.com/agisilaos/todoist-cli/internal/app/tasks"
+)
+
+type View string
+
+const (
+	ViewProjects View = "projects"
+	ViewSections View = "sections"
+	ViewTasks    View = "tasks"
+	ViewToday    View = "today"
+	ViewUpcoming View = "upcoming"
+)
+
+// RootViews are the top-level panes, in Tab order.
+var RootViews = []View{ViewProjects, ViewToday, ViewUpcoming}
+
+type RowKind string
+
+const (
+	RowProject RowKind = "project"
+	RowSection RowKind = "section"
+	RowTask    RowKind = "task"
+)
+
+// Row is one line of a screen. For sections, an empty ID stands for the
+// tasks outside any section.
+type Row struct {
+	Kind      RowKind
+	ID        string
+	ProjectID string
+	SectionID string
+	Text      string
+	Detail    string
+	Depth     int
+	Priority  int
+	Done      bool
+}
+
+// Screen is one level of the navigation stack.
+type Screen struct {
+	View      View
+	Title     string
+	ProjectID string
+	SectionID string
+	Rows      []Row
+	Cursor    int
+	Offset    int
+}
+
+// Selected returns the row under the cursor, if any.
+func (s *Screen) Selected() (Row, bool) {
+	if s == nil || s.Cursor < 0 || s.Cursor >= len(s.Rows) {
+		return Row{}, false
+	}
+	return s.Rows[s.Cursor], true
+}
+
+type Action string
+
+const (
+	ActionNone     Action = ""
+	ActionQuit     Action = "quit"
+	ActionOpen     Action = "open"
+	ActionShow     Action = "show"
+	ActionRefresh  Action = "refresh"
+	ActionComplete Action = "complete"
+	ActionReopen   Action = "reopen"
+	ActionPostpone Action = "postpone"
+	ActionMove     Action = "move"
+	ActionLabels   Action = "labels"
+	ActionPriority Action = "priority"
+	ActionAdd      Action = "add"
+)
+
+// Command is what a key asks the caller to do. Open loads the screen below
+// Row; Show loads the root View; task actions carry the prompt answer in
+// Input and, for Add, the placement of the current screen.
+type Command struct {
+	Action    Action
+	View      View
+	Row       Row
+	Input     string
+	ProjectID string
+	SectionID string
+}
+
+// Prompt is an open one-line input in the status bar.
+type Prompt struct {
+	Label  string
+	Input  string
+	Action Action
+	Row    Row
+}
+
+// Model is the navigation stack plus the status line and any open prompt.
+type Model struct {
+	Stack    []Screen
+	Status   string
+	Prompt   *Prompt
+	ShowHelp bool
+	Height   int
+}
+
+var taskPrompts = map[string]struct {
+	action Action
+	label  string
+}{
+	"p": {ActionPostpone, "Postpone by (2d, 1w) or to (monday, mar 10)"},
+	"m": {ActionMove, "Move to project"},
+	"l": {ActionLabels, "Labels (+add -remove, none to clear)"},
+}
+
+// Current returns the screen on top of the stack.
+func (m *Model) Current() *Screen {
+	if len(m.Stack) == 0 {
+		return nil
+	}
+	return &m.Stack[len(m.Stack)-1]
+}
+
+// Push opens screen on top of the current one.
+func (m *Model) Push(screen Screen) {
+	m.Stack = append(m.Stack, screen)
+	m.clamp()
+}
+
+// Reset makes screen the only one on the stack (a root pane switch).
+func (m *Model) Reset(screen Screen) {
+	m.Stack = []Screen{screen}
+	m.clamp()
+}
+
+// SetRows replaces the rows of the current screen after a reload, keeping
+// the cursor on the same row ID when it is still there.
+func (m *Model) SetRows(rows []Row) {
+	screen := m.Current()
+	if screen == nil {
+		return
+	}
+	selected, ok := screen.Selected()
+	screen.Rows = rows
+	if ok {
+		for i, row := range rows {
+			if row.Kind == selected.Kind && row.ID == selected.ID {
+				screen.Cursor = i
+				break
+			}
+		}
+	}
+	m.clamp()
+}
+
+// MarkDone flips the done state of a row on the current screen, so a
+// completed task stays visible (and can be reopened) until the next reload.
+func (m *Model) MarkDone(id string, done bool) {
+	if screen := m.Current(); screen != nil {
+		for i := range screen.Rows {
+			if screen.Rows[i].Kind == RowTask && screen.Rows[i].ID == id {
+				screen.Rows[i].Done = done
+			}
+		}
+	}
+}
+
+// Select moves the cursor to row index i.
+func (m *Model) Select(i int) bool {
+	screen := m.Current()
+	if screen == nil || i < 0 || i >= len(screen.Rows) {
+		return false
+	}
+	screen.Cursor = i
+	m.clamp()
+	return true
+}
+
+// Handle applies key and returns the command the caller should run, if any.
+func (m *Model) Handle(key Key) Command {
+	if m.Prompt != nil {
+		return m.handlePrompt(key)
+	}
+	screen := m.Current()
+	if screen == nil {
+		return Command{Action: ActionQuit}
+	}
+	m.Status = ""
+	switch key {
+	case "q", KeyCtrlC:
+		return Command{Action: ActionQuit}
+	case "?":
+		m.ShowHelp = !m.ShowHelp
+		return Command{}
+	case KeyUp, "k":
+		screen.Cursor--
+	case KeyDown, "j":
+		screen.Cursor++
+	case KeyPageUp:
+		screen.Cursor -= m.pageSize()
+	case KeyPageDown:
+		screen.Cursor += m.pageSize()
+	case KeyHome, "g":
+		screen.Cursor = 0
+	case KeyEnd, "G":
+		screen.Cursor = len(screen.Rows) - 1
+	case KeyLeft, KeyBackspace, KeyEsc, "h":
+		if m.ShowHelp {
+			m.ShowHelp = false
+		} else if len(m.Stack) > 1 {
+			m.Stack = m.Stack[:len(m.Stack)-1]
+		}
+	case KeyEnter, KeyRight:
+		if row, ok := screen.Selected(); ok && row.Kind != RowTask {
+			return Command{Action: ActionOpen, Row: row}
+		}
+	case KeyTab, KeyBackTab:
+		return Command{Action: ActionShow, View: m.nextRoot(key == KeyBackTab)}
+	case "b":
+		return Command{Action: ActionShow, View: ViewProjects}
+	case "t":
+		return Command{Action: ActionShow, View: ViewToday}
+	case "u":
+		return Command{Action: ActionShow, View: ViewUpcoming}
+	case "R", KeyCtrlR:
+		return Command{Action: ActionRefresh}
+	case "a":
+		m.Prompt = &Prompt{Label: "New task", Action: ActionAdd}
+	case "c", "r", "p", "m", "l", "1", "2", "3", "4":
+		return m.taskKey(screen, string(key))
+	}
+	m.clamp()
+	return Command{}
+}
+
+func (m *Model) taskKey(screen *Screen, key string) Command {
+	row, ok := screen.Selected()
+	if !ok || row.Kind != RowTask {
+		m.Status = "select a task first"
+		return Command{}
+	}
+	switch key {
+	case "c":
+		if row.Done {
+			m.Status = "already completed; press r to reopen"
+			return Command{}
+		}
+		return Command{Action: ActionComplete, Row: row}
+	case "r":
+		if !row.Done {
+			m.Status = "task is open; only tasks completed here can be reopened"
+			return Command{}
+		}
+		return Command{Action: ActionReopen, Row: row}
+	}
+	if row.Done {
+		m.Status = "task is completed; press r to reopen it first"
+		return Command{}
+	}
+	if prompt, ok := taskPrompts[key]; ok {
+		m.Prompt = &Prompt{Label: prompt.label, Action: prompt.action, Row: row}
+		return Command{}
+	}
+	return Command{Action: ActionPriority, Row: row, Input: "p" + key}
+}
+
+func (m *Model) handlePrompt(key Key) Command {
+	prompt := m.Prompt
+	switch {
+	case key == KeyEnter:
+		m.Prompt = nil
+		input := strings.TrimSpace(prompt.Input)
+		if input == "" {
+			m.Status = "cancelled"
+			return Command{}
+		}
+		cmd := Command{Action: prompt.Action, Row: prompt.Row, Input: input}
+		if screen := m.Current(); prompt.Action == ActionAdd && screen != nil {
+			cmd.View = screen.View
+			cmd.ProjectID = screen.ProjectID
+			cmd.SectionID = screen.SectionID
+		}
+		return cmd
+	case key == KeyEsc || key == KeyCtrlC:
+		m.Prompt = nil
+		m.Status = "cancelled"
+	case key == KeyBackspace:
+		if runes := []rune(prompt.Input); len(runes) > 0 {
+			prompt.Input = string(runes[:len(runes)-1])
+		}
+	case key.Printable():
+		prompt.Input += string(key)
+	}
+	return Command{}
+}
+
+func (m *Model) nextRoot(back bool) View {
+	current := ViewProjects
+	if len(m.Stack) > 0 {
+		current = m.Stack[0].View
+	}
+	for i, view := range RootViews {
+		if view != current {
+			continue
+		}
+		if back {
+			return RootViews[(i+len(RootViews)-1)%len(RootViews)]
+		}
+		return RootViews[(i+1)%len(RootViews)]
+	}
+	return RootViews[0]
+}
+
+func (m *Model) pageSize() int {
+	if size := m.listHeight(); size > 1 {
+		return size - 1
+	}
+	return 1
+}
+
+// listHeight is the number of rows that fit between the header and footer.
+func (m *Model) listHeight() int {
+	if m.Height <= 0 {
+		return 0
+	}
+	if size := m.Height - 4; size > 0 {
+		return size
+	}
+	return 1
+}
+
+func (m *Model) clamp() {
+	screen := m.Current()
+	if screen == nil {
+		return
+	}
+	if screen.Cursor >= len(screen.Rows) {
+		screen.Cursor = len(screen.Rows) - 1
+	}
+	if screen.Cursor < 0 {
+		screen.Cursor = 0
+	}
+	size := m.listHeight()
+	if size == 0 {
+		screen.Offset = 0
+		return
+	}
+	if screen.Cursor < screen.Offset {
+		screen.Offset = screen.Cursor
+	}
+	if screen.Cursor >= screen.Offset+size {
+		screen.Offset = screen.Cursor - size + 1
+	}
+}
+
+// Breadcrumb names the stack, root first ("Projects > Work > Backlog").
+func (m *Model) Breadcrumb() string {
+	parts := make([]string, 0, len(m.Stack))
+	for _, screen := range m.Stack {
+		parts = append(parts, screen.Title)
+	}
+	return strings.Join(parts, " > ")
+}
+
+// ParseLabelEdit reads a labels prompt answer: "+name" or a bare name adds,
+// "-name" removes and "none" clears.
+func ParseLabelEdit(input string) (apptasks.LabelEdit, error) {
+	var edit apptasks.LabelEdit
+	for _, token := range strings.Fields(input) {
+		switch {
+		case strings.EqualFold(token, "none"):
+			edit.Clear = true
+		case strings.HasPrefix(token, "-"):
+			edit.Remove = append(edit.Remove, strings.TrimPrefix(strings.TrimPrefix(token, "-"), "@"))
+		default:
+			edit.Add = append(edit.Add, strings.TrimPrefix(strings.TrimPrefix(token, "+"), "@"))
+		}
+	}
+	for _, label := range append(append([]string{}, edit.Add...), edit.Remove...) {
+		if label == "" {
+			return apptasks.LabelEdit{}, fmt.Errorf("empty label in %q", input)
+		}
+	}
+	return edit, nil
+}
@@ -0,0 +1,143 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+
+	apptasks "github.com/agisilaos/todoist-cli/internal/app/tasks"
+)
+
+func testModel() *Model {
+	m := &Model{}
+	m.Reset(Screen{View: ViewProjects, Title: "Projects", Rows: []Row{
+		{Kind: RowProject, ID: "p1", Text: "Home"},
+		{Kind: RowProject, ID: "p2", Text: "Work"},
+	}})
+	return m
+}
+
+func TestModelNavigation(t *testing.T) {
+	m := testModel()
+	m.Handle(KeyUp)
+	if m.Current().Cursor != 0 {
+		t.Fatalf("cursor moved above first row: %d", m.Current().Cursor)
+	}
+	m.Handle("j")
+	cmd := m.Handle(KeyEnter)
+	if cmd.Action != ActionOpen || cmd.Row.ID != "p2" {
+		t.Fatalf("unexpected open command: %#v", cmd)
+	}
+	m.Push(Screen{View: ViewTasks, Title: "Work", ProjectID: "p2", Rows: []Row{{Kind: RowTask, ID: "t1", Text: "Deploy"}}})
+	if got := m.Breadcrumb(); got != "Projects > Work" {
+		t.Fatalf("unexpected breadcrumb %q", got)
+	}
+	if cmd := m.Handle(KeyEnter); cmd.Action != ActionNone {
+		t.Fatalf("enter on a task should do nothing: %#v", cmd)
+	}
+	m.Handle(KeyBackspace)
+	if len(m.Stack) != 1 || m.Current().Cursor != 1 {
+		t.Fatalf("back should restore the project list: %#v", m.Stack)
+	}
+	m.Handle(KeyLeft)
+	if len(m.Stack) != 1 {
+		t.Fatalf("back on the root screen should stay: %#v", m.Stack)
+	}
+	if cmd := m.Handle(KeyTab); cmd.Action != ActionShow || cmd.View != ViewToday {
+		t.Fatalf("unexpected tab command: %#v", cmd)
+	}
+	if cmd := m.Handle(KeyBackTab); cmd.View != ViewUpcoming {
+		t.Fatalf("unexpected back-tab command: %#v", cmd)
+	}
+	if cmd := m.Handle("q"); cmd.Action != ActionQuit {
+		t.Fatalf("unexpected quit command: %#v", cmd)
+	}
+}
+
+func TestModelTaskActions(t *testing.T) {
+	m := &Model{}
+	m.Reset(Screen{View: ViewTasks, Title: "Work", ProjectID: "p2", SectionID: "s1", Rows: []Row{
+		{Kind: RowTask, ID: "t1", Text: "Deploy"},
+		{Kind: RowTask, ID: "t2", Text: "Announce"},
+	}})
+	if cmd := m.Handle("r"); cmd.Action != ActionNone || m.Status == "" {
+		t.Fatalf("reopen of an open task should be refused: %#v %q", cmd, m.Status)
+	}
+	if cmd := m.Handle("c"); cmd.Action != ActionComplete || cmd.Row.ID != "t1" {
+		t.Fatalf("unexpected complete command: %#v", cmd)
+	}
+	m.MarkDone("t1", true)
+	if cmd := m.Handle("c"); cmd.Action != ActionNone {
+		t.Fatalf("complete of a done task should be refused: %#v", cmd)
+	}
+	if cmd := m.Handle("1"); cmd.Action != ActionNone {
+		t.Fatalf("priority on a done task should be refused: %#v", cmd)
+	}
+	if cmd := m.Handle("r"); cmd.Action != ActionReopen {
+		t.Fatalf("unexpected reopen command: %#v", cmd)
+	}
+	m.Handle(KeyDown)
+	if cmd := m.Handle("2"); cmd.Action != ActionPriority || cmd.Input != "p2" || cmd.Row.ID != "t2" {
+		t.Fatalf("unexpected priority command: %#v", cmd)
+	}
+
+	m.Handle("p")
+	if m.Prompt == nil || m.Prompt.Action != ActionPostpone {
+		t.Fatalf("expected postpone prompt: %#v", m.Prompt)
+	}
+	for _, key := range []Key{"2", "x", KeyBackspace, "d"} {
+		m.Handle(key)
+	}
+	if cmd := m.Handle(KeyEnter); cmd.Action != ActionPostpone || cmd.Input != "2d" || cmd.Row.ID != "t2" {
+		t.Fatalf("unexpected postpone command: %#v", cmd)
+	}
+
+	m.Handle("m")
+	m.Handle(KeyEsc)
+	if m.Prompt != nil || m.Status != "cancelled" {
+		t.Fatalf("esc should cancel the prompt: %#v %q", m.Prompt, m.Status)
+	}
+
+	m.Handle("a")
+	for _, key := range []Key{"N", "e", "w"} {
+		m.Handle(key)
+	}
+	cmd := m.Handle(KeyEnter)
+	want := Command{Action: ActionAdd, View: ViewTasks, Input: "New", ProjectID: "p2", SectionID: "s1"}
+	if !reflect.DeepEqual(cmd, want) {
+		t.Fatalf("unexpected add command: %#v", cmd)
+	}
+}
+
+func TestModelTaskKeysNeedATask(t *testing.T) {
+	m := testModel()
+	if cmd := m.Handle("c"); cmd.Action != ActionNone || m.Status != "select a task first" {
+		t.Fatalf("unexpected result: %#v %q", cmd, m.Status)
+	}
+}
+
+func TestModelSetRowsKeepsSelection(t *testing.T) {
+	m := testModel()
+	m.Handle(KeyDown)
+	m.SetRows([]Row{{Kind: RowProject, ID: "p0"}, {Kind: RowProject, ID: "p1"}, {Kind: RowProject, ID: "p2"}})
+	if row, _ := m.Current().Selected(); row.ID != "p2" {
+		t.Fatalf("selection moved to %q", row.ID)
+	}
+	m.SetRows([]Row{{Kind: RowProject, ID: "p0"}})
+	if m.Current().Cursor != 0 {
+		t.Fatalf("cursor not clamped: %d", m.Current().Cursor)
+	}
+}
+
+func TestParseLabelEdit(t *testing.T) {
+	edit, err := ParseLabelEdit("+home -@work errand none")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := apptasks.LabelEdit{Add: []string{"home", "errand"}, Remove: []string{"work"}, Clear: true}
+	if !reflect.DeepEqual(edit, want) {
+		t.Fatalf("unexpected edit: %#v", edit)
+	}
+	if _, err := ParseLabelEdit("+ -"); err == nil {
+		t.Fatal("expected error for empty labels")
+	}
+}
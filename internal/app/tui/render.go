@@ -0,0 +1,201 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	sgrReset   = "\x1b[0m"
+	sgrBold    = "\x1b[1m"
+	sgrDim     = "\x1b[2m"
+	sgrReverse = "\x1b[7m"
+)
+
+// priorityColors follow Todoist: API priority 4 is p1 (red).
+var priorityColors = map[int]string{4: "\x1b[31m", 3: "\x1b[33m", 2: "\x1b[34m"}
+
+// HelpLines describe every key binding.
+var HelpLines = []string{
+	"up/down, j/k     move          pgup/pgdn, g/G   page, first/last",
+	"enter, right     open          left, backspace  back",
+	"tab, b/t/u       projects, today, upcoming",
+	"c                complete      r                reopen (tasks completed here)",
+	"p                postpone      m                move to project",
+	"l                edit labels   1-4              priority p1-p4",
+	"a                add task      R, ctrl-r        refresh",
+	"?                toggle help   q, ctrl-c        quit",
+}
+
+const keyHint = "enter open  c complete  p postpone  m move  l labels  1-4 priority  a add  ? help  q quit"
+
+// Render draws the full screen as height lines of at most width cells. With
+// color off the selection is shown by the "> " marker alone.
+func Render(m *Model, width, height int, color bool) []string {
+	if width < 20 {
+		width = 20
+	}
+	m.Height = height
+	m.clamp()
+	screen := m.Current()
+	lines := make([]string, 0, height)
+	header := fit("todoist  "+m.Breadcrumb(), width)
+	if color {
+		header = sgrBold + header + sgrReset
+	}
+	lines = append(lines, header, "")
+	body := m.listHeight()
+	switch {
+	case m.ShowHelp:
+		for _, line := range HelpLines {
+			lines = append(lines, fit("  "+line, width))
+		}
+	case screen == nil || len(screen.Rows) == 0:
+		lines = append(lines, "  (empty)")
+	default:
+		end := screen.Offset + body
+		if end > len(screen.Rows) {
+			end = len(screen.Rows)
+		}
+		for i := screen.Offset; i < end; i++ {
+			lines = append(lines, renderRow(screen.Rows[i], i == screen.Cursor, width, color))
+		}
+	}
+	for len(lines) < height-2 {
+		lines = append(lines, "")
+	}
+	if len(lines) > height-2 {
+		lines = lines[:height-2]
+	}
+	lines = append(lines, fit(m.statusLine(), width))
+	hint := fit(keyHint, width)
+	if color {
+		hint = sgrDim + hint + sgrReset
+	}
+	return append(lines, hint)
+}
+
+func renderRow(row Row, selected bool, width int, color bool) string {
+	marker := "  "
+	if selected {
+		marker = "> "
+	}
+	text := marker + rowText(row)
+	if row.Detail != "" {
+		text += "  " + row.Detail
+	}
+	text = fit(text, width)
+	if !color {
+		return text
+	}
+	switch {
+	case selected:
+		return sgrReverse + text + sgrReset
+	case row.Done:
+		return sgrDim + text + sgrReset
+	case priorityColors[row.Priority] != "":
+		return priorityColors[row.Priority] + text + sgrReset
+	}
+	return text
+}
+
+func rowText(row Row) string {
+	indent := strings.Repeat("  ", row.Depth)
+	switch row.Kind {
+	case RowTask:
+		box := "[ ]"
+		if row.Done {
+			box = "[x]"
+		}
+		text := indent + box + " " + row.Text
+		if p := priorityLabel(row.Priority); p != "" {
+			text += " (" + p + ")"
+		}
+		return text
+	case RowSection:
+		return indent + "§ " + row.Text
+	}
+	return indent + "# " + row.Text
+}
+
+func (m *Model) statusLine() string {
+	if m.Prompt != nil {
+		return m.Prompt.Label + ": " + m.Prompt.Input + "_"
+	}
+	return m.Status
+}
+
+// Describe renders the current screen as plain numbered lines for screen
+// readers and dumb terminals: no escape codes, no redraw.
+func Describe(m *Model) []string {
+	screen := m.Current()
+	if screen == nil {
+		return nil
+	}
+	if m.ShowHelp {
+		return append([]string{"Keys:"}, HelpLines...)
+	}
+	lines := []string{fmt.Sprintf("%s (%d %s)", m.Breadcrumb(), len(screen.Rows), plural(len(screen.Rows), "item"))}
+	for i, row := range screen.Rows {
+		line := fmt.Sprintf("%d. %s", i+1, describeRow(row))
+		if i == screen.Cursor {
+			line += " (selected)"
+		}
+		lines = append(lines, line)
+	}
+	if m.Status != "" {
+		lines = append(lines, m.Status)
+	}
+	return lines
+}
+
+func describeRow(row Row) string {
+	parts := []string{}
+	switch row.Kind {
+	case RowProject:
+		parts = append(parts, "project "+row.Text)
+	case RowSection:
+		parts = append(parts, "section "+row.Text)
+	default:
+		status := "task"
+		if row.Depth > 0 {
+			status = "subtask"
+		}
+		if row.Done {
+			status = "completed " + status
+		}
+		parts = append(parts, status+" "+row.Text)
+		if p := priorityLabel(row.Priority); p != "" {
+			parts = append(parts, "priority "+p)
+		}
+	}
+	if row.Detail != "" {
+		parts = append(parts, row.Detail)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// priorityLabel maps API priority to the p1-p4 name; p4 (the default) is
+// left out.
+func priorityLabel(priority int) string {
+	if priority < 2 || priority > 4 {
+		return ""
+	}
+	return fmt.Sprintf("p%d", 5-priority)
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
+
+func fit(text string, width int) string {
+	if utf8.RuneCountInString(text) <= width {
+		return text
+	}
+	runes := []rune(text)
+	return string(runes[:width-1]) + "…"
+}
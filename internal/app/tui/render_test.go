@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRenderScrollsAndMarksSelection(t *testing.T) {
+	m := &Model{}
+	var rows []Row
+	for i := 1; i <= 30; i++ {
+		rows = append(rows, Row{Kind: RowTask, ID: fmt.Sprint(i), Text: fmt.Sprintf("task %d", i), Priority: 4})
+	}
+	m.Reset(Screen{View: ViewToday, Title: "Today", Rows: rows})
+	m.Height = 10
+	m.Handle(KeyEnd)
+	lines := Render(m, 40, 10, false)
+	if len(lines) != 10 {
+		t.Fatalf("expected 10 lines, got %d: %q", len(lines), lines)
+	}
+	if lines[0] != "todoist  Today" {
+		t.Fatalf("unexpected header %q", lines[0])
+	}
+	if lines[7] != "> [ ] task 30 (p1)" || lines[2] != "  [ ] task 25 (p1)" {
+		t.Fatalf("unexpected rows: %q", lines[2:8])
+	}
+	for _, line := range lines {
+		if strings.Contains(line, "\x1b") {
+			t.Fatalf("escape code without color: %q", line)
+		}
+		if len([]rune(line)) > 40 {
+			t.Fatalf("line wider than 40: %q", line)
+		}
+	}
+
+	colored := Render(m, 40, 10, true)
+	if !strings.HasPrefix(colored[7], sgrReverse) || !strings.HasPrefix(colored[6], priorityColors[4]) {
+		t.Fatalf("expected reverse selection and p1 color: %q", colored[6:8])
+	}
+}
+
+func TestRenderPromptAndHelp(t *testing.T) {
+	m := &Model{}
+	m.Reset(Screen{View: ViewTasks, Title: "Work", Rows: []Row{{Kind: RowTask, ID: "t1", Text: "Deploy"}}})
+	m.Handle("m")
+	m.Handle("X")
+	lines := Render(m, 80, 8, false)
+	if lines[6] != "Move to project: X_" {
+		t.Fatalf("unexpected status line %q", lines[6])
+	}
+	m.Handle(KeyEsc)
+	m.Handle("?")
+	lines = Render(m, 100, 14, false)
+	if !strings.Contains(strings.Join(lines, "\n"), "complete") || strings.Contains(strings.Join(lines, "\n"), "Deploy") {
+		t.Fatalf("expected help instead of rows: %q", lines)
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	m := &Model{}
+	m.Reset(Screen{View: ViewTasks, Title: "Work", Rows: []Row{
+		{Kind: RowTask, ID: "t1", Text: "Deploy", Priority: 4, Detail: "due 2026-10-17"},
+		{Kind: RowTask, ID: "t2", Text: "Check logs", Depth: 1, Done: true},
+	}})
+	m.Status = "completed t2"
+	got := strings.Join(Describe(m), "\n")
+	want := "Work (2 items)\n1. task Deploy, priority p1, due 2026-10-17 (selected)\n2. completed subtask Check logs\ncompleted t2"
+	if got != want {
+		t.Fatalf("unexpected description:\n%s", got)
+	}
+}
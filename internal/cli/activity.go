@@ -75,11 +75,9 @@ func activityCommand(ctx *Context, args []string) error {
 	if err != nil {
 		return &CodeError{Code: exitUsage, Err: err}
 	}
-	events, next, err := fetchPaginated[api.ActivityEvent](ctx, "/activities", appactivities.BuildQuery(in), in.All)
-	if err != nil {
-		return err
-	}
-	return writeActivityList(ctx, events, next)
+	return listPaginated(ctx, "/activities", appactivities.BuildQuery(in), in.All, 0, func(events []api.ActivityEvent, next string) error {
+		return writeActivityList(ctx, events, next)
+	})
 }
 
 func writeActivityList(ctx *Context, events []api.ActivityEvent, cursor string) error {
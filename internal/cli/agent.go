@@ -24,6 +24,10 @@ func agentCommand(ctx *Context, args []string) error {
 		return agentPlan(ctx, args[1:])
 	case "apply":
 		return agentApply(ctx, args[1:])
+	case "undo":
+		return agentUndo(ctx, args[1:])
+	case "policy":
+		return agentPolicyCommand(ctx, args[1:])
 	case "status":
 		return agentStatus(ctx)
 	case "run":
@@ -47,13 +51,15 @@ func agentPlan(ctx *Context, args []string) error {
 	var contextProjects multiValue
 	var contextLabels multiValue
 	var contextCompleted string
+	var diff bool
 	var help bool
 	fs.StringVar(&outPath, "out", "", "Output plan file")
 	fs.StringVar(&planner, "planner", "", "Planner command")
-	fs.IntVar(&expectedVersion, "plan-version", 1, "Expected plan version")
+	fs.IntVar(&expectedVersion, "plan-version", 0, "Expected plan version (0 accepts any supported version)")
 	fs.Var(&contextProjects, "context-project", "Project context (repeatable)")
 	fs.Var(&contextLabels, "context-label", "Label context (repeatable)")
 	fs.StringVar(&contextCompleted, "context-completed", "", "Include completed tasks from last Nd (e.g. 7d)")
+	fs.BoolVar(&diff, "diff", false, "Show what each action changes against live state")
 	bindHelpFlag(fs, &help)
 	if err := parseFlagSetInterspersed(fs, args); err != nil {
 		return &CodeError{Code: exitUsage, Err: err}
@@ -83,6 +89,15 @@ func agentPlan(ctx *Context, args []string) error {
 	if err := writePlanFile(lastPlanPath(ctx), plan); err != nil {
 		return err
 	}
+	if diff {
+		// Diffs show what the plan would do now, so version 2 plans are
+		// expanded first; the saved plan keeps its templates.
+		preview, err := expandAgentPlan(ctx, plan)
+		if err != nil {
+			return err
+		}
+		return writePlanDiffPreview(ctx, preview, false)
+	}
 	return writePlanOutput(ctx, plan)
 }
 
@@ -93,6 +108,9 @@ func agentApply(ctx *Context, args []string) error {
 	var planner string
 	var policyPath string
 	var onError string
+	var batch bool
+	var diff bool
+	var allowStale bool
 	var expectedVersion int
 	var contextProjects multiValue
 	var contextLabels multiValue
@@ -103,7 +121,10 @@ func agentApply(ctx *Context, args []string) error {
 	fs.StringVar(&planner, "planner", "", "Planner command")
 	fs.StringVar(&policyPath, "policy", "", "Policy file path")
 	fs.StringVar(&onError, "on-error", "fail", "On error: fail|continue")
-	fs.IntVar(&expectedVersion, "plan-version", 1, "Expected plan version")
+	fs.BoolVar(&batch, "batch", false, "Apply actions as batched Sync API commands")
+	fs.BoolVar(&diff, "diff", false, "With --dry-run, show what each action changes against live state")
+	fs.BoolVar(&allowStale, "allow-stale", false, "Apply even if targets changed since planning (warns instead)")
+	fs.IntVar(&expectedVersion, "plan-version", 0, "Expected plan version (0 accepts any supported version)")
 	fs.Var(&contextProjects, "context-project", "Project context (repeatable)")
 	fs.Var(&contextLabels, "context-label", "Label context (repeatable)")
 	fs.StringVar(&contextCompleted, "context-completed", "", "Include completed tasks from last Nd (e.g. 7d)")
@@ -118,6 +139,9 @@ func agentApply(ctx *Context, args []string) error {
 	if onError != "fail" && onError != "continue" {
 		return &CodeError{Code: exitUsage, Err: errors.New("invalid --on-error; must be fail or continue")}
 	}
+	if diff && !ctx.Global.DryRun {
+		return &CodeError{Code: exitUsage, Err: errors.New("--diff requires --dry-run")}
+	}
 	emitProgress(ctx, "agent_apply_start", map[string]any{
 		"command": "agent apply",
 	})
@@ -144,11 +168,16 @@ func agentApply(ctx *Context, args []string) error {
 			return validatePlan(plan, expectedVersion, allowEmptyActions)
 		},
 		EnforcePolicy: func(plan Plan) error {
-			policy, err := loadAgentPolicy(ctx, policyPath)
-			if err != nil {
-				return err
+			return enforcePolicyFile(ctx, plan, policyPath)
+		},
+		Expand: func(plan Plan) (Plan, error) {
+			return expandAgentPlan(ctx, plan)
+		},
+		CheckStale: func(plan Plan) error {
+			if strings.TrimSpace(planPath) == "" || ctx.Global.DryRun {
+				return nil
 			}
-			return enforceAgentPolicy(plan, policy)
+			return checkPlanFresh(ctx, plan, allowStale)
 		},
 	})
 	if err != nil {
@@ -166,13 +195,16 @@ func agentApply(ctx *Context, args []string) error {
 	if ctx.Global.DryRun {
 		emitAgentApplySummary(ctx, "agent apply", nil, true, nil)
 		emitProgress(ctx, "agent_apply_complete", map[string]any{"dry_run": true, "action_count": len(plan.Actions)})
+		if diff {
+			return writePlanDiffPreview(ctx, plan, true)
+		}
 		return writePlanPreview(ctx, plan, true)
 	}
 	if err := ensureClient(ctx); err != nil {
 		emitProgress(ctx, "agent_apply_error", map[string]any{"error": err.Error()})
 		return err
 	}
-	results, err := applyActionsWithMode(ctx, plan.ConfirmToken, plan.Actions, onError)
+	results, err := applyPlanActions(ctx, &plan, onError, batch)
 	if err != nil && onError == "fail" {
 		emitAgentApplySummary(ctx, "agent apply", results, false, err)
 		emitProgress(ctx, "agent_apply_error", map[string]any{"error": err.Error()})
@@ -216,7 +248,7 @@ func runPlanner(ctx *Context, plannerCmd string, instruction string, expectedVer
 	if err := ensureClient(ctx); err != nil {
 		return Plan{}, err
 	}
-	plannerContext, err := buildPlannerContext(ctx, ctxOpts)
+	plannerContext, known, err := buildPlannerContext(ctx, ctxOpts)
 	if err != nil {
 		return Plan{}, err
 	}
@@ -252,6 +284,7 @@ func runPlanner(ctx *Context, plannerCmd string, instruction string, expectedVer
 	if err := normalizeAndValidatePlan(&plan, instruction, ctx.Now, expectedVersion); err != nil {
 		return Plan{}, err
 	}
+	plan.Fingerprints = appagent.PlanFingerprints(plan.Actions, known)
 	emitProgress(ctx, "agent_planner_complete", map[string]any{"action_count": len(plan.Actions)})
 	return plan, nil
 }
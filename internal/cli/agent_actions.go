@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -10,7 +11,15 @@ import (
 )
 
 func applyAction(ctx *Context, action Action) error {
-	req, err := appagent.BuildActionRequest(action, appagent.ActionDeps{
+	_, err := dispatchAction(ctx, action)
+	if errors.Is(err, appagent.ErrUnchanged) {
+		return nil
+	}
+	return err
+}
+
+func agentActionDeps(ctx *Context) appagent.ActionDeps {
+	return appagent.ActionDeps{
 		BuildTaskCreatePayload: func(in apptasks.MutationInput) (map[string]any, error) {
 			return buildTaskCreatePayload(ctx, in)
 		},
@@ -26,21 +35,41 @@ func applyAction(ctx *Context, action Action) error {
 		ResolveProjectSelector: func(explicitID, reference string) (string, error) {
 			return resolveProjectSelector(ctx, explicitID, reference)
 		},
-	})
+		LoadTaskLabels: func(taskID string) ([]string, error) {
+			task, err := fetchTask(ctx, taskID)
+			if err != nil {
+				return nil, err
+			}
+			return task.Labels, nil
+		},
+	}
+}
+
+func dispatchAction(ctx *Context, action Action) (string, error) {
+	req, err := appagent.BuildActionRequest(action, agentActionDeps(ctx))
 	if err != nil {
-		return err
+		return "", err
 	}
 	reqCtx, cancel := requestContext(ctx)
 	defer cancel()
 	switch req.Method {
 	case http.MethodPost:
-		_, err = ctx.Client.Post(reqCtx, req.Path, nil, req.Body, nil, true)
-		return err
+		if coreagent.AliasEntity(action.Type) == "" {
+			_, err = ctx.Client.Post(reqCtx, req.Path, nil, req.Body, nil, true)
+			return "", err
+		}
+		var created struct {
+			ID string `json:"id"`
+		}
+		if _, err = ctx.Client.Post(reqCtx, req.Path, nil, req.Body, &created, true); err != nil {
+			return "", err
+		}
+		return created.ID, nil
 	case http.MethodDelete:
 		_, err = ctx.Client.Delete(reqCtx, req.Path, nil)
-		return err
+		return "", err
 	default:
-		return &CodeError{Code: exitError, Err: fmt.Errorf("unsupported method: %s", req.Method)}
+		return "", &CodeError{Code: exitError, Err: fmt.Errorf("unsupported method: %s", req.Method)}
 	}
 }
 
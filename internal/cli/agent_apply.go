@@ -1,6 +1,63 @@
 package cli
 
-import "time"
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	coreagent "github.com/agisilaos/todoist-cli/internal/agent"
+	"github.com/agisilaos/todoist-cli/internal/api"
+	appagent "github.com/agisilaos/todoist-cli/internal/app/agent"
+)
+
+// applyPlanActions applies plan and journals what took effect; plan.ApplyID
+// is set to the journal entry that `agent undo` reverts.
+func applyPlanActions(ctx *Context, plan *Plan, onError string, batch bool) ([]applyResult, error) {
+	var results []applyResult
+	var err error
+	if batch {
+		results, err = applyActionsBatched(ctx, plan.ConfirmToken, plan.Actions, onError)
+	} else {
+		results, err = applyActionsWithMode(ctx, plan.ConfirmToken, plan.Actions, onError)
+	}
+	invalidateLookupsForResults(ctx, results)
+	applyID, journalErr := recordApply(ctx, *plan, results)
+	plan.ApplyID = applyID
+	if err == nil {
+		err = journalErr
+	}
+	return results, err
+}
+
+func invalidateLookupsForResults(ctx *Context, results []applyResult) {
+	seen := map[string]bool{}
+	var resources []string
+	for _, result := range results {
+		if result.Error != nil || result.SkippedReplay {
+			continue
+		}
+		resource := ""
+		switch {
+		case strings.HasPrefix(result.Action.Type, "project_"):
+			resource = "projects"
+		case strings.HasPrefix(result.Action.Type, "section_"):
+			resource = "sections"
+		case strings.HasPrefix(result.Action.Type, "label_"):
+			resource = "labels"
+		case len(result.Action.Labels) > 0 || len(result.Action.AddLabels) > 0:
+			// Task adds and updates create labels that do not exist yet.
+			resource = "labels"
+		}
+		if resource != "" && !seen[resource] {
+			seen[resource] = true
+			resources = append(resources, resource)
+		}
+	}
+	if len(resources) > 0 {
+		invalidateLookupCache(ctx, resources...)
+	}
+}
 
 func applyActionsWithMode(ctx *Context, confirmToken string, actions []Action, onError string) ([]applyResult, error) {
 	if onError == "" {
@@ -10,28 +67,39 @@ func applyActionsWithMode(ctx *Context, confirmToken string, actions []Action, o
 	if err != nil {
 		return nil, err
 	}
+	aliases := map[string]string{}
 	results := make([]applyResult, 0, len(actions))
 	for idx, action := range actions {
 		emitProgress(ctx, "agent_action_start", map[string]any{"index": idx, "action_type": action.Type})
 		emitProgress(ctx, "agent_action_validated", map[string]any{"index": idx, "action_type": action.Type})
 		replayKey := makeReplayKey(confirmToken, idx, action)
 		if _, ok := journal.Applied[replayKey]; ok {
-			results = append(results, applyResult{Action: action, SkippedReplay: true})
+			createdID := journal.Created[replayKey]
+			recordAlias(aliases, action, createdID)
+			results = append(results, applyResult{Action: action, CreatedID: createdID, SkippedReplay: true})
 			emitProgress(ctx, "agent_action_skipped_replay", map[string]any{"index": idx, "action_type": action.Type})
 			continue
 		}
 		emitProgress(ctx, "agent_action_dispatched", map[string]any{"index": idx, "action_type": action.Type})
-		err := applyAction(ctx, action)
-		results = append(results, applyResult{Action: action, Error: err})
+		createdID := ""
+		var before json.RawMessage
+		resolved, err := appagent.ResolveAliases(action, aliases)
+		if err == nil {
+			before = capturePreState(ctx, action, resolved)
+			createdID, err = dispatchAction(ctx, resolved)
+		}
+		unchanged := errors.Is(err, appagent.ErrUnchanged)
+		if unchanged {
+			err = nil
+		}
+		results = append(results, applyResult{Action: action, Resolved: resolved, CreatedID: createdID, Before: before, Error: err, Unchanged: unchanged})
 		if err != nil {
 			emitProgress(ctx, "agent_action_error", map[string]any{"index": idx, "action_type": action.Type, "error": err.Error()})
 			emitProgress(ctx, "agent_action_failed", map[string]any{"index": idx, "action_type": action.Type, "error": err.Error()})
 		} else {
-			nowFn := time.Now
-			if ctx != nil && ctx.Now != nil {
-				nowFn = ctx.Now
-			}
-			markReplayApplied(&journal, replayKey, nowFn())
+			recordAlias(aliases, action, createdID)
+			markReplayApplied(&journal, replayKey, applyNow(ctx))
+			markReplayCreated(&journal, replayKey, createdID)
 			emitProgress(ctx, "agent_action_complete", map[string]any{"index": idx, "action_type": action.Type})
 			emitProgress(ctx, "agent_action_succeeded", map[string]any{"index": idx, "action_type": action.Type})
 		}
@@ -45,3 +113,210 @@ func applyActionsWithMode(ctx *Context, confirmToken string, actions []Action, o
 	}
 	return results, nil
 }
+
+type pendingSyncCommand struct {
+	index     int
+	replayKey string
+	tempID    string
+	preState  string
+	command   api.SyncCommand
+}
+
+// applyActionsBatched sends actions as Sync API commands, up to
+// api.MaxSyncCommands per request. Aliases resolve to temp ids inside a batch
+// and to real ids (via temp_id_mapping) in later batches; an alias whose
+// defining command failed is dropped, so later actions on it fail locally.
+// Sync runs a whole batch, so with onError "fail" the commands sent after a
+// failed one in the same batch still take effect; no further batch is sent.
+func applyActionsBatched(ctx *Context, confirmToken string, actions []Action, onError string) ([]applyResult, error) {
+	if onError == "" {
+		onError = "fail"
+	}
+	journal, journalPath, err := loadReplayJournal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deps := agentActionDeps(ctx)
+	// Label edits read a task's current labels; for a temp id, or a task
+	// relabeled earlier in the run, only batchLabels knows them.
+	batchLabels := map[string][]string{}
+	loadLabels := deps.LoadTaskLabels
+	deps.LoadTaskLabels = func(taskID string) ([]string, error) {
+		if labels, ok := batchLabels[taskID]; ok {
+			return labels, nil
+		}
+		return loadLabels(taskID)
+	}
+	aliases := map[string]string{}
+	results := make([]applyResult, len(actions))
+	var firstErr error
+	fail := func(idx int, err error) {
+		results[idx].Error = err
+		emitProgress(ctx, "agent_action_error", map[string]any{"index": idx, "action_type": actions[idx].Type, "error": err.Error()})
+		emitProgress(ctx, "agent_action_failed", map[string]any{"index": idx, "action_type": actions[idx].Type, "error": err.Error()})
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	batchNo := 0
+	next := 0
+	for next < len(actions) && (firstErr == nil || onError == "continue") {
+		pending := make([]pendingSyncCommand, 0, api.MaxSyncCommands)
+		for next < len(actions) && len(pending) < api.MaxSyncCommands {
+			idx := next
+			next++
+			action := actions[idx]
+			results[idx].Action = action
+			emitProgress(ctx, "agent_action_start", map[string]any{"index": idx, "action_type": action.Type})
+			emitProgress(ctx, "agent_action_validated", map[string]any{"index": idx, "action_type": action.Type})
+			replayKey := makeReplayKey(confirmToken, idx, action)
+			if _, ok := journal.Applied[replayKey]; ok {
+				createdID := journal.Created[replayKey]
+				recordAlias(aliases, action, createdID)
+				results[idx].CreatedID = createdID
+				results[idx].SkippedReplay = true
+				emitProgress(ctx, "agent_action_skipped_replay", map[string]any{"index": idx, "action_type": action.Type})
+				continue
+			}
+			resolved, err := appagent.ResolveAliases(action, aliases)
+			if err != nil {
+				fail(idx, err)
+				if onError == "fail" {
+					break
+				}
+				continue
+			}
+			cmd, err := appagent.BuildSyncCommand(resolved, deps)
+			if errors.Is(err, appagent.ErrUnchanged) {
+				results[idx].Unchanged = true
+				markReplayApplied(&journal, replayKey, applyNow(ctx))
+				emitProgress(ctx, "agent_action_complete", map[string]any{"index": idx, "action_type": action.Type})
+				emitProgress(ctx, "agent_action_succeeded", map[string]any{"index": idx, "action_type": action.Type})
+				continue
+			}
+			if err != nil {
+				fail(idx, err)
+				if onError == "fail" {
+					break
+				}
+				continue
+			}
+			// Command ids derive from the replay key, so a re-sent command is
+			// recognized by Sync and the request body is stable for cassettes.
+			cmd.UUID = replayKey[:32]
+			entry := pendingSyncCommand{index: idx, replayKey: replayKey, preState: preStatePath(action, resolved), command: cmd}
+			if coreagent.AliasEntity(action.Type) != "" {
+				entry.tempID = replayKey[32:]
+				entry.command.TempID = entry.tempID
+				recordAlias(aliases, action, entry.tempID)
+			}
+			recordBatchLabels(batchLabels, resolved, entry)
+			pending = append(pending, entry)
+		}
+		if len(pending) == 0 {
+			continue
+		}
+		batchNo++
+		paths := make([]string, 0, len(pending))
+		for _, entry := range pending {
+			paths = append(paths, entry.preState)
+		}
+		preStates := capturePreStates(ctx, paths)
+		commands := make([]api.SyncCommand, 0, len(pending))
+		for _, entry := range pending {
+			results[entry.index].Before = preStates[entry.preState]
+			commands = append(commands, entry.command)
+			emitProgress(ctx, "agent_action_dispatched", map[string]any{"index": entry.index, "action_type": actions[entry.index].Type, "batch": batchNo})
+		}
+		emitProgress(ctx, "agent_batch_dispatched", map[string]any{"batch": batchNo, "command_count": len(commands)})
+		reqCtx, cancel := requestContext(ctx)
+		syncResult, reqID, err := ctx.Client.ExecuteCommands(reqCtx, commands)
+		cancel()
+		if reqID != "" {
+			setRequestID(ctx, reqID)
+		}
+		for _, entry := range pending {
+			action := actions[entry.index]
+			cmdErr := err
+			if cmdErr == nil {
+				cmdErr = syncResult.CommandError(entry.command.UUID)
+			}
+			if cmdErr != nil {
+				fail(entry.index, cmdErr)
+				delete(batchLabels, batchLabelsKey(actions[entry.index], entry))
+				if alias := strings.TrimSpace(action.Alias); alias != "" && entry.tempID != "" && aliases[alias] == entry.tempID {
+					delete(aliases, alias)
+				}
+				continue
+			}
+			if entry.tempID != "" {
+				createdID := syncResult.ResolveTempID(entry.tempID)
+				results[entry.index].CreatedID = createdID
+				recordAlias(aliases, action, createdID)
+				markReplayCreated(&journal, entry.replayKey, createdID)
+			}
+			markReplayApplied(&journal, entry.replayKey, applyNow(ctx))
+			emitProgress(ctx, "agent_action_complete", map[string]any{"index": entry.index, "action_type": action.Type})
+			emitProgress(ctx, "agent_action_succeeded", map[string]any{"index": entry.index, "action_type": action.Type})
+		}
+	}
+	results = trimUnattemptedResults(results, next)
+	// Temp ids in a batch resolve to real ids only after it ran, so resolve
+	// the journaled form with the final aliases.
+	for idx := range results {
+		if results[idx].Error == nil && !results[idx].SkippedReplay {
+			results[idx].Resolved, _ = appagent.ResolveAliases(results[idx].Action, aliases)
+		}
+	}
+	if err := saveReplayJournal(journalPath, journal); err != nil && firstErr == nil {
+		return results, err
+	}
+	return results, firstErr
+}
+
+// recordBatchLabels remembers the labels a task_add or task_update command
+// leaves on its task.
+func recordBatchLabels(batchLabels map[string][]string, resolved Action, entry pendingSyncCommand) {
+	key := batchLabelsKey(resolved, entry)
+	if key == "" {
+		return
+	}
+	if labels, ok := entry.command.Args["labels"].([]string); ok {
+		batchLabels[key] = labels
+	} else if resolved.Type == "task_add" {
+		batchLabels[key] = []string{}
+	}
+}
+
+func batchLabelsKey(action Action, entry pendingSyncCommand) string {
+	switch action.Type {
+	case "task_add":
+		return entry.tempID
+	case "task_update":
+		id, _ := entry.command.Args["id"].(string)
+		return id
+	}
+	return ""
+}
+
+func trimUnattemptedResults(results []applyResult, attempted int) []applyResult {
+	if attempted < len(results) {
+		return results[:attempted]
+	}
+	return results
+}
+
+func recordAlias(aliases map[string]string, action Action, id string) {
+	alias := strings.TrimSpace(action.Alias)
+	if alias == "" || strings.TrimSpace(id) == "" {
+		return
+	}
+	aliases[alias] = id
+}
+
+func applyNow(ctx *Context) time.Time {
+	if ctx != nil && ctx.Now != nil {
+		return ctx.Now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,280 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	"github.com/agisilaos/todoist-cli/internal/config"
+)
+
+func TestApplyActionsResolvesAliasesSequentially(t *testing.T) {
+	var commentBody map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tasks":
+			_, _ = w.Write([]byte(`{"id":"t9","content":"Draft"}`))
+		case "/comments":
+			data, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(data, &commentBody)
+			_, _ = w.Write([]byte(`{"id":"c1"}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	ctx := &Context{
+		Stdout: &bytes.Buffer{},
+		Token:  "token",
+		Client: api.NewClient(ts.URL, "token", time.Second),
+		Config: config.Config{TimeoutSeconds: 2},
+	}
+	results, err := applyActionsWithMode(ctx, "abcd", []Action{
+		{Type: "task_add", Content: "Draft", ProjectID: "p1", Alias: "draft"},
+		{Type: "comment_add", Content: "context", TaskID: "$draft"},
+	}, "fail")
+	if err != nil {
+		t.Fatalf("applyActionsWithMode: %v", err)
+	}
+	if results[0].CreatedID != "t9" || results[1].CreatedID != "c1" {
+		t.Fatalf("unexpected results: %#v", results)
+	}
+	if commentBody["task_id"] != "t9" {
+		t.Fatalf("expected alias to resolve to created id, got %#v", commentBody)
+	}
+}
+
+func TestApplyActionsBatchedMapsTempIDs(t *testing.T) {
+	var commands []api.SyncCommand
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/tasks" && r.URL.Query().Get("ids") == "t404" {
+			// The pre-state read for task_complete finds no active task.
+			_, _ = w.Write([]byte(`{"results":[]}`))
+			return
+		}
+		if r.URL.Path != "/sync" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		data, _ := io.ReadAll(r.Body)
+		values, _ := url.ParseQuery(string(data))
+		if err := json.Unmarshal([]byte(values.Get("commands")), &commands); err != nil {
+			t.Fatalf("decode commands: %v", err)
+		}
+		status := map[string]any{}
+		mapping := map[string]string{}
+		for i, cmd := range commands {
+			status[cmd.UUID] = "ok"
+			if cmd.TempID != "" {
+				mapping[cmd.TempID] = "real-" + cmd.Type
+			}
+			if i == 2 {
+				status[cmd.UUID] = map[string]any{"error": "Item not found", "error_tag": "ITEM_NOT_FOUND", "http_code": 404}
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"sync_status": status, "temp_id_mapping": mapping})
+	}))
+	defer ts.Close()
+
+	ctx := &Context{
+		Stdout: &bytes.Buffer{},
+		Token:  "token",
+		Client: api.NewClient(ts.URL, "token", time.Second),
+		Config: config.Config{TimeoutSeconds: 2},
+	}
+	results, err := applyActionsBatched(ctx, "abcd", []Action{
+		{Type: "project_add", Name: "Launch", Alias: "launch"},
+		{Type: "task_add", Content: "Draft", ProjectID: "$launch", Alias: "draft"},
+		{Type: "task_complete", TaskID: "t404"},
+	}, "continue")
+	if err == nil || !strings.Contains(err.Error(), "ITEM_NOT_FOUND") {
+		t.Fatalf("expected per-command error, got %v", err)
+	}
+	if len(commands) != 3 || commands[0].Type != "project_add" || commands[1].Type != "item_add" || commands[2].Type != "item_close" {
+		t.Fatalf("unexpected commands: %#v", commands)
+	}
+	if commands[1].Args["project_id"] != commands[0].TempID {
+		t.Fatalf("expected task to reference project temp id, got %#v", commands[1].Args)
+	}
+	if results[0].CreatedID != "real-project_add" || results[1].CreatedID != "real-item_add" || results[2].Error == nil {
+		t.Fatalf("unexpected results: %#v", results)
+	}
+}
+
+func TestApplyActionsBatchedDropsAliasOfFailedCommand(t *testing.T) {
+	var requests [][]api.SyncCommand
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/sync" {
+			http.NotFound(w, r)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		values, _ := url.ParseQuery(string(data))
+		var commands []api.SyncCommand
+		if err := json.Unmarshal([]byte(values.Get("commands")), &commands); err != nil {
+			t.Fatalf("decode commands: %v", err)
+		}
+		requests = append(requests, commands)
+		http.Error(w, `{"error":"invalid request"}`, http.StatusBadRequest)
+	}))
+	defer ts.Close()
+
+	ctx := &Context{
+		Stdout: &bytes.Buffer{},
+		Token:  "token",
+		Client: api.NewClient(ts.URL, "token", time.Second),
+		Config: config.Config{TimeoutSeconds: 2},
+	}
+	actions := []Action{{Type: "project_add", Name: "Launch", Alias: "launch"}}
+	for len(actions) < api.MaxSyncCommands {
+		actions = append(actions, Action{Type: "task_complete", TaskID: "t1"})
+	}
+	actions = append(actions, Action{Type: "task_add", Content: "Draft", ProjectID: "$launch"})
+	for run := 0; run < 2; run++ {
+		results, err := applyActionsBatched(ctx, "abcd", actions, "continue")
+		if err == nil {
+			t.Fatal("expected the batch error")
+		}
+		if len(results) != len(actions) || results[len(actions)-1].Error == nil || !strings.Contains(results[len(actions)-1].Error.Error(), "alias $launch has no created id") {
+			t.Fatalf("expected the dependent action to fail locally: %#v", results[len(actions)-1])
+		}
+	}
+	if len(requests) != 2 {
+		t.Fatalf("expected one request per run, got %d", len(requests))
+	}
+	for i, cmd := range requests[0] {
+		if again := requests[1][i]; cmd.UUID != again.UUID || cmd.TempID != again.TempID {
+			t.Fatalf("command %d ids differ between runs: %#v vs %#v", i, cmd, again)
+		}
+	}
+}
+
+func TestApplyActionsBatchedEditsLabelsOfTasksAddedInTheBatch(t *testing.T) {
+	var commands []api.SyncCommand
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/sync" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		data, _ := io.ReadAll(r.Body)
+		values, _ := url.ParseQuery(string(data))
+		if err := json.Unmarshal([]byte(values.Get("commands")), &commands); err != nil {
+			t.Fatalf("decode commands: %v", err)
+		}
+		status := map[string]any{}
+		mapping := map[string]string{}
+		for _, cmd := range commands {
+			status[cmd.UUID] = "ok"
+			if cmd.TempID != "" {
+				mapping[cmd.TempID] = "t9"
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"sync_status": status, "temp_id_mapping": mapping})
+	}))
+	defer ts.Close()
+
+	ctx := &Context{
+		Stdout: &bytes.Buffer{},
+		Token:  "token",
+		Client: api.NewClient(ts.URL, "token", time.Second),
+		Config: config.Config{TimeoutSeconds: 2},
+	}
+	if _, err := applyActionsBatched(ctx, "abcd", []Action{
+		{Type: "task_add", Content: "Draft", ProjectID: "p1", Labels: []string{"draft"}, Alias: "draft"},
+		{Type: "task_update", TaskID: "$draft", AddLabels: []string{"review"}},
+		{Type: "task_update", TaskID: "$draft", RemoveLabels: []string{"draft"}},
+	}, "fail"); err != nil {
+		t.Fatalf("applyActionsBatched: %v", err)
+	}
+	if len(commands) != 3 {
+		t.Fatalf("unexpected commands: %#v", commands)
+	}
+	if got, _ := json.Marshal(commands[1].Args["labels"]); string(got) != `["draft","review"]` {
+		t.Fatalf("unexpected first label edit: %s", got)
+	}
+	if got, _ := json.Marshal(commands[2].Args["labels"]); string(got) != `["review"]` {
+		t.Fatalf("unexpected second label edit: %s", got)
+	}
+}
+
+func TestApplyActionsSkipsNoOpLabelEdit(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/tasks/t1" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"id":"t1","content":"Draft","labels":["work"]}`))
+	}))
+	defer ts.Close()
+
+	ctx := &Context{
+		Stdout: &bytes.Buffer{},
+		Token:  "token",
+		Client: api.NewClient(ts.URL, "token", time.Second),
+		Config: config.Config{TimeoutSeconds: 2},
+	}
+	actions := []Action{{Type: "task_update", TaskID: "t1", AddLabels: []string{"work"}}}
+	results, err := applyActionsWithMode(ctx, "abcd", actions, "fail")
+	if err != nil || len(results) != 1 || !results[0].Unchanged {
+		t.Fatalf("expected an unchanged result, got %#v (%v)", results, err)
+	}
+	results, err = applyActionsBatched(ctx, "efgh", actions, "fail")
+	if err != nil || len(results) != 1 || !results[0].Unchanged {
+		t.Fatalf("expected an unchanged batched result, got %#v (%v)", results, err)
+	}
+}
+
+func TestApplyActionsBatchedReadsPreStatesTogether(t *testing.T) {
+	var taskReads []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tasks":
+			taskReads = append(taskReads, r.URL.Query().Get("ids"))
+			_, _ = w.Write([]byte(`{"results":[{"id":"t1","content":"Draft"},{"id":"t2","content":"Review"}]}`))
+		case "/sync":
+			data, _ := io.ReadAll(r.Body)
+			values, _ := url.ParseQuery(string(data))
+			var commands []api.SyncCommand
+			if err := json.Unmarshal([]byte(values.Get("commands")), &commands); err != nil {
+				t.Fatalf("decode commands: %v", err)
+			}
+			status := map[string]any{}
+			for _, cmd := range commands {
+				status[cmd.UUID] = "ok"
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"sync_status": status})
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	ctx := &Context{
+		Stdout: &bytes.Buffer{},
+		Token:  "token",
+		Client: api.NewClient(ts.URL, "token", time.Second),
+		Config: config.Config{TimeoutSeconds: 2},
+	}
+	results, err := applyActionsBatched(ctx, "abcd", []Action{
+		{Type: "task_update", TaskID: "t1", Content: "Draft v2"},
+		{Type: "task_complete", TaskID: "t2"},
+		{Type: "task_update", TaskID: "t1", Content: "Draft v3"},
+	}, "fail")
+	if err != nil {
+		t.Fatalf("applyActionsBatched: %v", err)
+	}
+	if len(taskReads) != 1 || taskReads[0] != "t1,t2" {
+		t.Fatalf("expected one task read for the batch, got %q", taskReads)
+	}
+	for idx, want := range []string{"Draft", "Review", "Draft"} {
+		var before api.Task
+		if err := json.Unmarshal(results[idx].Before, &before); err != nil || before.Content != want {
+			t.Fatalf("result %d: unexpected pre-state %s", idx, results[idx].Before)
+		}
+	}
+}
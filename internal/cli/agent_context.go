@@ -33,30 +33,33 @@ func parseContextOptions(ctx *Context, projects, labels []string, completed stri
 	}, nil
 }
 
-func buildPlannerContext(ctx *Context, opts plannerContextOptions) (PlannerContext, error) {
-	projects, err := listAllProjects(ctx)
+// buildPlannerContext loads the planner's view of the account, plus the
+// fingerprints of everything it loaded so a plan records the state it was
+// built from.
+func buildPlannerContext(ctx *Context, opts plannerContextOptions) (PlannerContext, map[string]string, error) {
+	projects, err := fetchAllProjects(ctx)
 	if err != nil {
-		return PlannerContext{}, err
+		return PlannerContext{}, nil, err
 	}
 	projectIDs, err := filterProjectIDs(ctx, projects, opts.ProjectFilters)
 	if err != nil {
-		return PlannerContext{}, err
+		return PlannerContext{}, nil, err
 	}
 	filteredProjects := filterProjects(projects, projectIDs)
 
-	sections, err := listAllSections(ctx, "")
+	sections, err := fetchAllSections(ctx, "")
 	if err != nil {
-		return PlannerContext{}, err
+		return PlannerContext{}, nil, err
 	}
 	filteredSections := filterSections(sections, projectIDs)
 
-	labels, err := listAllLabels(ctx)
+	labels, err := fetchAllLabels(ctx)
 	if err != nil {
-		return PlannerContext{}, err
+		return PlannerContext{}, nil, err
 	}
 	filteredLabels, err := filterLabels(ctx, labels, opts.LabelFilters)
 	if err != nil {
-		return PlannerContext{}, err
+		return PlannerContext{}, nil, err
 	}
 
 	var completed []api.Task
@@ -64,12 +67,12 @@ func buildPlannerContext(ctx *Context, opts plannerContextOptions) (PlannerConte
 		since := ctx.Now().AddDate(0, 0, -opts.CompletedDays).UTC().Format(time.RFC3339)
 		completed, err = listCompletedTasks(ctx, since)
 		if err != nil {
-			return PlannerContext{}, err
+			return PlannerContext{}, nil, err
 		}
 	}
 	activeTasks, err := listAllActiveTasks(ctx)
 	if err != nil {
-		return PlannerContext{}, err
+		return PlannerContext{}, nil, err
 	}
 	filteredActiveTasks := filterActiveTasksForContext(activeTasks, projectIDs, opts.LabelFilters)
 
@@ -79,7 +82,7 @@ func buildPlannerContext(ctx *Context, opts plannerContextOptions) (PlannerConte
 		Labels:         toAnySlice(filteredLabels),
 		ActiveTasks:    toAnySlice(filteredActiveTasks),
 		CompletedTasks: toAnySlice(completed),
-	}, nil
+	}, fingerprintEntities(activeTasks, projects, sections, labels), nil
 }
 
 func parseDays(value string) (int, error) {
@@ -190,23 +193,8 @@ func listCompletedTasks(ctx *Context, since string) ([]api.Task, error) {
 	if since != "" {
 		query.Set("since", since)
 	}
-	var all []api.Task
-	for {
-		var page api.Paginated[api.Task]
-		reqCtx, cancel := requestContext(ctx)
-		reqID, err := ctx.Client.Get(reqCtx, "/tasks/completed/by_completion_date", query, &page)
-		cancel()
-		if err != nil {
-			return nil, err
-		}
-		setRequestID(ctx, reqID)
-		all = append(all, page.Results...)
-		if page.NextCursor == "" {
-			break
-		}
-		query.Set("cursor", page.NextCursor)
-	}
-	return all, nil
+	all, _, err := fetchPaginated[api.Task](ctx, "/tasks/completed/by_completion_date", query, true)
+	return all, err
 }
 
 func filterActiveTasksForContext(tasks []api.Task, projectIDs map[string]struct{}, labelFilters []string) []api.Task {
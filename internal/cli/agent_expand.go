@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	appagent "github.com/agisilaos/todoist-cli/internal/app/agent"
+	"github.com/agisilaos/todoist-cli/internal/output"
+)
+
+// expandAgentPlan resolves a version 2 plan against live filter results.
+// Expansion notes go to progress events and, in human mode, stderr.
+func expandAgentPlan(ctx *Context, plan Plan) (Plan, error) {
+	if plan.Version < 2 {
+		return plan, nil
+	}
+	if err := ensureClient(ctx); err != nil {
+		return Plan{}, err
+	}
+	expanded, notes, err := appagent.ExpandPlan(plan, appagent.ExpandDeps{
+		Query: func(query string) ([]api.Task, error) {
+			tasks, _, err := listTasksByFilter(ctx, query, "", 200, true)
+			return tasks, err
+		},
+	})
+	if err != nil {
+		return Plan{}, err
+	}
+	emitProgress(ctx, "agent_plan_expanded", map[string]any{"action_count": len(expanded.Actions), "notes": notes})
+	if ctx.Mode != output.ModeJSON {
+		for _, note := range notes {
+			fmt.Fprintf(ctx.Stderr, "note: #%d %s: %s\n", note.Index+1, note.Type, note.Note)
+		}
+	}
+	return expanded, nil
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	"github.com/agisilaos/todoist-cli/internal/mockserver"
+)
+
+func TestAgentApplyExpandsVersion2Plan(t *testing.T) {
+	run := newMockCLIRunner(t, mockserver.Fixture{
+		Projects: []api.Project{{ID: "p1", Name: "Work"}},
+		Tasks: []api.Task{
+			{ID: "t1", Content: "Call vendor", ProjectID: "p1", Labels: []string{"waiting"}},
+			{ID: "t2", Content: "Email legal", ProjectID: "p1", Labels: []string{"waiting"}},
+			{ID: "t3", Content: "Write notes", ProjectID: "p1"},
+		},
+	}).runWithStderr
+	planPath := filepath.Join(t.TempDir(), "plan.json")
+	data, _ := json.Marshal(Plan{Version: 2, ConfirmToken: "abcd", Vars: map[string]string{"label": "followup"}, Actions: []Action{
+		{Type: "task_update", Foreach: "@waiting", TaskID: "{{item.id}}", AddLabels: []string{"{{label}}"}},
+		{Type: "task_add", When: "p1", Alias: "review", Content: "Review {{label}} tasks", ProjectID: "p1"},
+		{Type: "comment_add", TaskID: "$review", Content: "Check {{label}}"},
+	}})
+	if err := os.WriteFile(planPath, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, stderr := run(exitOK, "agent", "apply", "--plan", planPath, "--confirm", "abcd")
+	for _, want := range []string{`note: #1 task_update: foreach "@waiting" matched 2 tasks`, `note: #2 task_add: skipped: when "p1" matched no tasks`, "note: #3 comment_add: skipped: $review was not created"} {
+		if !strings.Contains(stderr, want) {
+			t.Fatalf("missing %q in stderr: %s", want, stderr)
+		}
+	}
+	out, _ := run(exitOK, "--json", "task", "list", "--filter", "@followup")
+	var tasks []api.Task
+	if err := json.Unmarshal([]byte(out), &tasks); err != nil {
+		t.Fatalf("decode tasks: %v\n%s", err, out)
+	}
+	if len(tasks) != 2 || tasks[0].ID != "t1" || tasks[1].ID != "t2" {
+		t.Fatalf("expected t1 and t2 labeled followup, got %#v", tasks)
+	}
+
+	data, _ = json.Marshal(Plan{Version: 2, ConfirmToken: "abcd", Actions: []Action{{Type: "task_complete", TaskID: "{{item.id}}"}}})
+	if err := os.WriteFile(planPath, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, stderr = run(exitUsage, "agent", "apply", "--plan", planPath, "--confirm", "abcd")
+	if !strings.Contains(stderr, "only available in foreach actions") {
+		t.Fatalf("expected template error, got %s", stderr)
+	}
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	appagent "github.com/agisilaos/todoist-cli/internal/app/agent"
+)
+
+// entityFingerprints fingerprints the live active tasks, projects, sections
+// and labels a plan can target.
+func entityFingerprints(ctx *Context) (map[string]string, error) {
+	tasks, err := listAllActiveTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	projects, err := fetchAllProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sections, err := fetchAllSections(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	labels, err := fetchAllLabels(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return fingerprintEntities(tasks, projects, sections, labels), nil
+}
+
+// fingerprintEntities keys the given entities like Plan.Fingerprints.
+func fingerprintEntities(tasks []api.Task, projects []api.Project, sections []api.Section, labels []api.Label) map[string]string {
+	out := map[string]string{}
+	for _, task := range tasks {
+		out[appagent.FingerprintKey("task", task.ID)] = appagent.Fingerprint(task.UpdatedAt, task)
+	}
+	for _, project := range projects {
+		out[appagent.FingerprintKey("project", project.ID)] = appagent.Fingerprint("", project)
+	}
+	for _, section := range sections {
+		out[appagent.FingerprintKey("section", section.ID)] = appagent.Fingerprint("", section)
+	}
+	for _, label := range labels {
+		out[appagent.FingerprintKey("label", label.ID)] = appagent.Fingerprint("", label)
+	}
+	return out
+}
+
+// checkPlanFresh compares a plan's fingerprints with live state. Stale
+// targets fail with plan_stale unless allowStale, which only warns. Plans
+// without fingerprints (hand-written, templates, undo) are not checked.
+func checkPlanFresh(ctx *Context, plan Plan, allowStale bool) error {
+	if len(plan.Fingerprints) == 0 {
+		return nil
+	}
+	if err := ensureClient(ctx); err != nil {
+		return err
+	}
+	if cache := ctx.cache(); cache != nil {
+		cache.forget("projects", "sections", "labels")
+		cache.activeTasks = nil
+		cache.activeTasksLoaded = false
+	}
+	current, err := entityFingerprints(ctx)
+	if err != nil {
+		return err
+	}
+	stale := appagent.StaleTargets(plan, current)
+	if len(stale) == 0 {
+		return nil
+	}
+	staleErr := &appagent.StaleError{Targets: stale}
+	emitProgress(ctx, "agent_plan_stale", map[string]any{"target_count": len(stale), "allowed": allowStale})
+	if allowStale {
+		fmt.Fprintf(ctx.Stderr, "warning: %s; applying anyway (--allow-stale)\n", staleErr)
+		return nil
+	}
+	return &CodeError{Code: exitConflict, Kind: errCodePlanStale, Err: fmt.Errorf("%w; re-plan or pass --allow-stale", staleErr)}
+}
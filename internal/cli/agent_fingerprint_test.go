@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	"github.com/agisilaos/todoist-cli/internal/mockserver"
+)
+
+func TestAgentApplyRejectsStalePlan(t *testing.T) {
+	run := newMockCLIRunner(t, mockserver.Fixture{
+		Projects: []api.Project{{ID: "p1", Name: "Work"}, {ID: "p2", Name: "Home"}},
+		Tasks: []api.Task{
+			{ID: "t1", Content: "Deploy", ProjectID: "p1"},
+			{ID: "t3", Content: "Water plants", ProjectID: "p1"},
+		},
+	}).runWithStderr
+	dir := t.TempDir()
+	plannerOut := filepath.Join(dir, "planner.json")
+	data, _ := json.Marshal(Plan{Version: 1, ConfirmToken: "abcd", Actions: []Action{
+		{Type: "task_update", TaskID: "t1", Priority: 4},
+		{Type: "task_move", TaskID: "t3", ProjectID: "p2"},
+		{Type: "task_add", Alias: "new", Content: "Call bank", ProjectID: "p2"},
+		{Type: "task_complete", TaskID: "$new"},
+	}})
+	if err := os.WriteFile(plannerOut, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	planPath := filepath.Join(dir, "plan.json")
+	run(exitOK, "agent", "plan", "--planner", "cat "+plannerOut, "--out", planPath, "triage")
+	var plan Plan
+	raw, err := os.ReadFile(planPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(raw, &plan); err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Fingerprints) != 3 || plan.Fingerprints["task:t1"] == "" || plan.Fingerprints["task:t3"] == "" || plan.Fingerprints["project:p2"] == "" {
+		t.Fatalf("unexpected fingerprints: %#v", plan.Fingerprints)
+	}
+
+	run(exitOK, "task", "update", "--id", "t1", "--content", "Deploy now")
+	_, out := run(exitConflict, "--json", "agent", "apply", "--plan", planPath, "--confirm", plan.ConfirmToken)
+	var body struct {
+		Error struct {
+			Code    string         `json:"code"`
+			Message string         `json:"message"`
+			Details map[string]any `json:"details"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(out), &body); err != nil {
+		t.Fatalf("decode error: %v\n%s", err, out)
+	}
+	if body.Error.Code != errCodePlanStale || !strings.Contains(body.Error.Message, "task:t1 changed") || body.Error.Details["type"] != "plan_stale" {
+		t.Fatalf("unexpected stale error: %s", out)
+	}
+	if targets, _ := body.Error.Details["targets"].([]any); len(targets) != 1 {
+		t.Fatalf("expected one stale target: %s", out)
+	}
+
+	run(exitOK, "agent", "apply", "--plan", planPath, "--confirm", plan.ConfirmToken, "--dry-run")
+	out, warning := run(exitOK, "agent", "apply", "--plan", planPath, "--confirm", plan.ConfirmToken, "--allow-stale")
+	if !strings.Contains(out, "Outcome: success") || !strings.Contains(warning, "warning: plan is stale: task:t1 changed") {
+		t.Fatalf("unexpected apply output:\n%s%s", out, warning)
+	}
+}
+
+func TestAgentPlanFingerprintsPlannerSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	marker := filepath.Join(dir, "planner-ran")
+	srv := mockserver.New(mockserver.Fixture{
+		Projects: []api.Project{{ID: "p1", Name: "Work"}},
+		Tasks:    []api.Task{{ID: "t1", Content: "Deploy", ProjectID: "p1"}},
+	})
+	var lateReads []string
+	run := newMockCLIHandlerRunner(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := os.Stat(marker); err == nil && r.Method == http.MethodGet {
+			lateReads = append(lateReads, r.URL.Path)
+		}
+		srv.ServeHTTP(w, r)
+	})).run
+	plannerOut := filepath.Join(dir, "planner.json")
+	data, _ := json.Marshal(Plan{Version: 1, ConfirmToken: "abcd", Actions: []Action{{Type: "task_update", TaskID: "t1", Priority: 4}}})
+	if err := os.WriteFile(plannerOut, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	planPath := filepath.Join(dir, "plan.json")
+	run(exitOK, "agent", "plan", "--planner", "touch "+marker+" && cat "+plannerOut, "--out", planPath, "triage")
+	var plan Plan
+	raw, err := os.ReadFile(planPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(raw, &plan); err != nil {
+		t.Fatalf("decode plan: %v\n%s", err, raw)
+	}
+	if plan.Fingerprints["task:t1"] == "" {
+		t.Fatalf("expected task fingerprint: %#v", plan.Fingerprints)
+	}
+	if len(lateReads) > 0 {
+		t.Fatalf("fingerprints were read after the planner ran: %v", lateReads)
+	}
+}
@@ -0,0 +1,219 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	appagent "github.com/agisilaos/todoist-cli/internal/app/agent"
+)
+
+// maxApplyJournalEntries bounds agent_journal.json; older applies can no
+// longer be undone.
+const maxApplyJournalEntries = 50
+
+type applyJournal struct {
+	Entries []applyJournalEntry `json:"entries"`
+}
+
+type applyJournalEntry struct {
+	ApplyID      string                   `json:"apply_id"`
+	Instruction  string                   `json:"instruction"`
+	ConfirmToken string                   `json:"confirm_token"`
+	AppliedAt    string                   `json:"applied_at"`
+	UndoOf       string                   `json:"undo_of,omitempty"`
+	UndoneBy     string                   `json:"undone_by,omitempty"`
+	Actions      []appagent.JournalAction `json:"actions"`
+}
+
+func applyJournalPath(ctx *Context) string {
+	if ctx == nil || ctx.ConfigPath == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(ctx.ConfigPath), "agent_journal.json")
+}
+
+func loadApplyJournal(ctx *Context) (applyJournal, string, error) {
+	path := applyJournalPath(ctx)
+	if path == "" {
+		return applyJournal{}, "", nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return applyJournal{}, path, nil
+		}
+		return applyJournal{}, path, err
+	}
+	var j applyJournal
+	if err := json.Unmarshal(data, &j); err != nil {
+		return applyJournal{}, path, fmt.Errorf("invalid apply journal %s: %w", path, err)
+	}
+	return j, path, nil
+}
+
+func saveApplyJournal(path string, j applyJournal) error {
+	if path == "" {
+		return nil
+	}
+	if len(j.Entries) > maxApplyJournalEntries {
+		j.Entries = j.Entries[len(j.Entries)-maxApplyJournalEntries:]
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// recordApply journals the actions of plan that took effect, with their
+// pre-state, and returns the new apply id ("" when nothing was applied).
+func recordApply(ctx *Context, plan Plan, results []applyResult) (string, error) {
+	var actions []appagent.JournalAction
+	for idx, result := range results {
+		if result.Error != nil || result.SkippedReplay || result.Unchanged {
+			continue
+		}
+		actions = append(actions, appagent.JournalAction{
+			Index:     idx,
+			Action:    result.Resolved,
+			CreatedID: result.CreatedID,
+			Before:    result.Before,
+		})
+	}
+	if len(actions) == 0 {
+		return "", nil
+	}
+	j, path, err := loadApplyJournal(ctx)
+	if err != nil {
+		return "", err
+	}
+	entry := applyJournalEntry{
+		ApplyID:      newApplyID(),
+		Instruction:  plan.Instruction,
+		ConfirmToken: plan.ConfirmToken,
+		AppliedAt:    applyNow(ctx).UTC().Format(time.RFC3339),
+		Actions:      actions,
+	}
+	j.Entries = append(j.Entries, entry)
+	return entry.ApplyID, saveApplyJournal(path, j)
+}
+
+// findApplyEntry returns the entry with applyID or, when applyID is empty,
+// the latest apply that is neither undone nor itself an undo, so repeated
+// undos walk back through history.
+func findApplyEntry(j applyJournal, applyID string) (applyJournalEntry, bool) {
+	for i := len(j.Entries) - 1; i >= 0; i-- {
+		entry := j.Entries[i]
+		if applyID == "" && entry.UndoneBy == "" && entry.UndoOf == "" {
+			return entry, true
+		}
+		if applyID != "" && entry.ApplyID == applyID {
+			return entry, true
+		}
+	}
+	return applyJournalEntry{}, false
+}
+
+// linkUndo records that undoID reverted applyID.
+func linkUndo(ctx *Context, applyID, undoID string) error {
+	j, path, err := loadApplyJournal(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range j.Entries {
+		switch j.Entries[i].ApplyID {
+		case applyID:
+			j.Entries[i].UndoneBy = undoID
+		case undoID:
+			j.Entries[i].UndoOf = applyID
+		}
+	}
+	return saveApplyJournal(path, j)
+}
+
+// capturePreState fetches the entity action is about to change so undo can
+// restore it. It is best effort: without a pre-state the action has no undo.
+func capturePreState(ctx *Context, action, resolved Action) json.RawMessage {
+	path := preStatePath(action, resolved)
+	if path == "" {
+		return nil
+	}
+	var raw json.RawMessage
+	reqCtx, cancel := requestContext(ctx)
+	defer cancel()
+	if _, err := ctx.Client.Get(reqCtx, path, nil, &raw); err != nil {
+		return nil
+	}
+	return raw
+}
+
+// preStatePath is the path capturePreState reads; ids the plan wrote as
+// aliases have no pre-state even once resolved.
+func preStatePath(action, resolved Action) string {
+	if appagent.PreStatePath(action) == "" {
+		return ""
+	}
+	return appagent.PreStatePath(resolved)
+}
+
+// capturePreStates fetches the pre-states of a sync batch together, keyed by
+// path: active tasks in one listing by id, other entities once per path.
+// Every pre-state is read before the batch is sent, so an action on an entity
+// an earlier command in the batch changes records the state from before the
+// batch; undo reverts newest first and so still ends at that state.
+func capturePreStates(ctx *Context, paths []string) map[string]json.RawMessage {
+	out := map[string]json.RawMessage{}
+	var taskIDs []string
+	for _, path := range paths {
+		if _, seen := out[path]; seen || path == "" {
+			continue
+		}
+		out[path] = nil
+		if id, ok := strings.CutPrefix(path, "/tasks/"); ok {
+			taskIDs = append(taskIDs, id)
+			continue
+		}
+		var raw json.RawMessage
+		reqCtx, cancel := requestContext(ctx)
+		if _, err := ctx.Client.Get(reqCtx, path, nil, &raw); err == nil {
+			out[path] = raw
+		}
+		cancel()
+	}
+	if len(taskIDs) == 0 {
+		return out
+	}
+	query := url.Values{}
+	query.Set("ids", strings.Join(taskIDs, ","))
+	query.Set("limit", "200")
+	tasks, _, err := fetchPaginated[json.RawMessage](ctx, "/tasks", query, true)
+	if err != nil {
+		return out
+	}
+	for _, raw := range tasks {
+		var task struct {
+			ID string `json:"id"`
+		}
+		if json.Unmarshal(raw, &task) == nil && task.ID != "" {
+			out["/tasks/"+task.ID] = raw
+		}
+	}
+	return out
+}
+
+func newApplyID() string {
+	id := api.NewRequestID()
+	if len(id) >= 8 {
+		return id[:8]
+	}
+	return fmt.Sprintf("%x", time.Now().UnixNano())
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/agisilaos/todoist-cli/internal/api"
+	appagent "github.com/agisilaos/todoist-cli/internal/app/agent"
 	"github.com/agisilaos/todoist-cli/internal/output"
 
 	"io"
@@ -72,6 +73,29 @@ func isPlanFileNotFoundError(err error) bool {
 }
 
 func writePlanPreview(ctx *Context, plan Plan, dryRun bool) error {
+	return writePlanPreviewDiff(ctx, plan, dryRun, nil)
+}
+
+// writePlanDiffPreview previews plan with a diff of each action against the
+// live tasks, projects and sections.
+func writePlanDiffPreview(ctx *Context, plan Plan, dryRun bool) error {
+	tasks, err := listAllActiveTasks(ctx)
+	if err != nil {
+		return err
+	}
+	projects, err := fetchAllProjects(ctx)
+	if err != nil {
+		return err
+	}
+	sections, err := fetchAllSections(ctx, "")
+	if err != nil {
+		return err
+	}
+	diffs := appagent.DiffPlan(plan, appagent.DiffState{Tasks: tasks, Projects: projects, Sections: sections})
+	return writePlanPreviewDiff(ctx, plan, dryRun, diffs)
+}
+
+func writePlanPreviewDiff(ctx *Context, plan Plan, dryRun bool, diffs []appagent.ActionDiff) error {
 	if ctx.Mode == output.ModeJSON {
 		payload := map[string]any{
 			"plan":         plan,
@@ -79,6 +103,9 @@ func writePlanPreview(ctx *Context, plan Plan, dryRun bool) error {
 			"action_count": len(plan.Actions),
 			"summary":      plan.Summary,
 		}
+		if diffs != nil {
+			payload["diff"] = diffs
+		}
 		return output.WriteJSON(ctx.Stdout, payload, output.Meta{})
 	}
 	fmt.Fprintf(ctx.Stdout, "Plan: %s\n", plan.Instruction)
@@ -91,13 +118,41 @@ func writePlanPreview(ctx *Context, plan Plan, dryRun bool) error {
 	for i, action := range plan.Actions {
 		if strings.TrimSpace(action.Reason) != "" {
 			fmt.Fprintf(ctx.Stdout, "%d. %s (%s)\n", i+1, action.Type, strings.TrimSpace(action.Reason))
-			continue
+		} else {
+			fmt.Fprintf(ctx.Stdout, "%d. %s\n", i+1, action.Type)
+		}
+		if action.Foreach != "" {
+			fmt.Fprintf(ctx.Stdout, "   foreach: %s\n", action.Foreach)
+		}
+		if action.When != "" {
+			fmt.Fprintf(ctx.Stdout, "   when: %s\n", action.When)
+		}
+		if i < len(diffs) {
+			writeActionDiff(ctx.Stdout, diffs[i])
 		}
-		fmt.Fprintf(ctx.Stdout, "%d. %s\n", i+1, action.Type)
 	}
 	return nil
 }
 
+func writeActionDiff(out io.Writer, diff appagent.ActionDiff) {
+	if diff.Target != "" {
+		fmt.Fprintf(out, "   %s\n", diff.Target)
+	}
+	for _, change := range diff.Changes {
+		fmt.Fprintf(out, "   %s: %s → %s\n", change.Field, diffValue(change.Before), diffValue(change.After))
+	}
+	if diff.Note != "" {
+		fmt.Fprintf(out, "   %s\n", diff.Note)
+	}
+}
+
+func diffValue(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
+
 func normalizeAndValidatePlan(plan *Plan, instruction string, now func() time.Time, expectedVersion int) error {
 	if plan.Version == 0 {
 		plan.Version = 1
@@ -143,8 +198,10 @@ func toAnySlice[T any](items []T) []any {
 func writePlanApplyResult(ctx *Context, plan Plan, results []applyResult, applyErr error) error {
 	if ctx.Mode == output.ModeJSON {
 		type resultJSON struct {
-			Action Action `json:"action"`
-			Error  string `json:"error,omitempty"`
+			Action    Action `json:"action"`
+			CreatedID string `json:"created_id,omitempty"`
+			Unchanged bool   `json:"unchanged,omitempty"`
+			Error     string `json:"error,omitempty"`
 		}
 		out := struct {
 			Plan    Plan         `json:"plan"`
@@ -153,7 +210,7 @@ func writePlanApplyResult(ctx *Context, plan Plan, results []applyResult, applyE
 			Plan: plan,
 		}
 		for _, r := range results {
-			entry := resultJSON{Action: r.Action}
+			entry := resultJSON{Action: r.Action, CreatedID: r.CreatedID, Unchanged: r.Unchanged}
 			if r.SkippedReplay {
 				entry.Error = "skipped_replay"
 				out.Results = append(out.Results, entry)
@@ -167,6 +224,9 @@ func writePlanApplyResult(ctx *Context, plan Plan, results []applyResult, applyE
 		return output.WriteJSON(ctx.Stdout, out, output.Meta{RequestID: ctxRequestIDValue(ctx)})
 	}
 	fmt.Fprintf(ctx.Stdout, "Applied plan: %s\n", plan.Instruction)
+	if plan.ApplyID != "" {
+		fmt.Fprintf(ctx.Stdout, "Apply ID: %s (revert with: todoist agent undo --apply-id %s)\n", plan.ApplyID, plan.ApplyID)
+	}
 	okCount, failedCount, skippedReplay := summarizeApplyResults(results)
 	destructive := 0
 	byType := map[string]int{}
@@ -195,9 +255,15 @@ func writePlanApplyResult(ctx *Context, plan Plan, results []applyResult, applyE
 		if r.SkippedReplay {
 			status = "skipped (replay)"
 		}
+		if r.Unchanged {
+			status = "unchanged"
+		}
 		if r.Error != nil {
 			status = "error: " + r.Error.Error()
 		}
+		if r.CreatedID != "" && r.Error == nil {
+			status += " -> " + r.CreatedID
+		}
 		fmt.Fprintf(ctx.Stdout, "%d. %s [%s]\n", i+1, r.Action.Type, status)
 	}
 	if applyErr != nil {
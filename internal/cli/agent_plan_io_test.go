@@ -2,9 +2,16 @@ package cli
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	appagent "github.com/agisilaos/todoist-cli/internal/app/agent"
+	"github.com/agisilaos/todoist-cli/internal/mockserver"
 )
 
 func TestWritePlanApplyResultHumanSummary(t *testing.T) {
@@ -52,3 +59,50 @@ func TestWritePlanApplyResultHumanErrorOutcome(t *testing.T) {
 		t.Fatalf("unexpected output:\n%s", out.String())
 	}
 }
+
+func TestAgentApplyDryRunDiff(t *testing.T) {
+	run := newMockCLIRunner(t, mockserver.Fixture{
+		Projects: []api.Project{{ID: "p1", Name: "Work"}, {ID: "p2", Name: "Home"}},
+		Tasks: []api.Task{
+			{ID: "t1", Content: "Deploy", ProjectID: "p1", Priority: 1},
+			{ID: "t2", Content: "Check logs", ProjectID: "p1", ParentID: "t1", NoteCount: 1},
+		},
+	}).run
+	plan := Plan{Version: 1, Instruction: "tidy", ConfirmToken: "abcd", Actions: []Action{
+		{Type: "task_update", TaskID: "t1", Priority: 4, Content: "Deploy v2"},
+		{Type: "task_move", TaskID: "t1", Project: "Home"},
+		{Type: "task_delete", TaskID: "t1"},
+	}}
+	data, _ := json.Marshal(plan)
+	planPath := filepath.Join(t.TempDir(), "plan.json")
+	if err := os.WriteFile(planPath, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	out := run(exitOK, "agent", "apply", "--plan", planPath, "--confirm", "abcd", "--dry-run", "--diff")
+	want := `1. task_update
+   task t1 "Deploy"
+   content: Deploy → Deploy v2
+   priority: p4 → p1
+2. task_move
+   task t1 "Deploy v2"
+   project: Work → Home
+3. task_delete
+   task t1 "Deploy v2"
+   deletes "Deploy v2" with 1 subtask and 0 comments
+`
+	if !strings.Contains(out, want) {
+		t.Fatalf("unexpected diff preview:\n%s", out)
+	}
+
+	var payload struct {
+		DryRun bool                  `json:"dry_run"`
+		Diff   []appagent.ActionDiff `json:"diff"`
+	}
+	if err := json.Unmarshal([]byte(run(exitOK, "--json", "agent", "apply", "--plan", planPath, "--confirm", "abcd", "--dry-run", "--diff")), &payload); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !payload.DryRun || len(payload.Diff) != 3 || payload.Diff[2].Subtasks != 1 || payload.Diff[0].Changes[1].After != "p1" {
+		t.Fatalf("unexpected JSON diff: %#v", payload)
+	}
+	run(exitUsage, "agent", "apply", "--plan", planPath, "--confirm", "abcd", "--diff")
+}
@@ -5,19 +5,33 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+
+	coreagent "github.com/agisilaos/todoist-cli/internal/agent"
+	"github.com/agisilaos/todoist-cli/internal/api"
+	appagent "github.com/agisilaos/todoist-cli/internal/app/agent"
 )
 
 type agentPolicy struct {
-	AllowActionTypes      []string `json:"allow_action_types"`
-	DenyActionTypes       []string `json:"deny_action_types"`
-	MaxDestructiveActions int      `json:"max_destructive_actions"`
+	AllowActionTypes      []string              `json:"allow_action_types"`
+	DenyActionTypes       []string              `json:"deny_action_types"`
+	MaxDestructiveActions int                   `json:"max_destructive_actions"`
+	Rules                 []appagent.PolicyRule `json:"rules,omitempty"`
+}
+
+func defaultAgentPolicyPath(ctx *Context) string {
+	if ctx == nil || ctx.ConfigPath == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(ctx.ConfigPath), "agent_policy.json")
 }
 
 func loadAgentPolicy(ctx *Context, path string) (*agentPolicy, error) {
-	if path == "" && ctx != nil && ctx.ConfigPath != "" {
-		defaultPath := filepath.Join(filepath.Dir(ctx.ConfigPath), "agent_policy.json")
-		if _, err := os.Stat(defaultPath); err == nil {
-			path = defaultPath
+	if path == "" {
+		if defaultPath := defaultAgentPolicyPath(ctx); defaultPath != "" {
+			if _, err := os.Stat(defaultPath); err == nil {
+				path = defaultPath
+			}
 		}
 	}
 	if path == "" {
@@ -31,12 +45,61 @@ func loadAgentPolicy(ctx *Context, path string) (*agentPolicy, error) {
 	if err := json.Unmarshal(data, &policy); err != nil {
 		return nil, fmt.Errorf("parse policy: %w", err)
 	}
+	if err := appagent.ValidatePolicyRules(policy.Rules); err != nil {
+		return nil, fmt.Errorf("parse policy: %w", err)
+	}
 	return &policy, nil
 }
 
-func enforceAgentPolicy(plan Plan, policy *agentPolicy) error {
+// policyViolation records which policy rule rejected a plan; it is reported
+// as error.details in --json mode.
+type policyViolation struct {
+	Rule       string `json:"rule"`
+	Action     int    `json:"action,omitempty"`
+	ActionType string `json:"action_type,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+	Limit      int    `json:"limit,omitempty"`
+	Count      int    `json:"count,omitempty"`
+}
+
+func (v *policyViolation) Error() string {
+	switch {
+	case v.Reason != "" && v.Action > 0:
+		return fmt.Sprintf("policy rule %q denied action %d (%s): %s", v.Rule, v.Action, v.ActionType, v.Reason)
+	case v.Reason != "":
+		return fmt.Sprintf("policy rule %q denied plan: %s", v.Rule, v.Reason)
+	}
+	switch v.Rule {
+	case "allow_action_types":
+		return fmt.Sprintf("policy denied action type: %s (not in allow list)", v.ActionType)
+	case "max_destructive_actions":
+		return fmt.Sprintf("policy exceeded max destructive actions: %d > %d", v.Count, v.Limit)
+	default:
+		return fmt.Sprintf("policy denied action type: %s", v.ActionType)
+	}
+}
+
+func policyDenied(v *policyViolation) error {
+	return &CodeError{Code: exitUsage, Kind: errCodePolicyDenied, Err: v}
+}
+
+// enforceAgentPolicy rejects plan with the first policy violation. Rule
+// conditions that cannot be verified against state do not deny; they are
+// returned for the caller to warn about.
+func enforceAgentPolicy(plan Plan, policy *agentPolicy, state *appagent.PolicyState) ([]*policyViolation, error) {
+	violations, unchecked := checkAgentPolicy(plan, policy, state)
+	if len(violations) > 0 {
+		return nil, policyDenied(violations[0])
+	}
+	return unchecked, nil
+}
+
+// checkAgentPolicy collects every violation in plan: action type lists in
+// action order, the destructive-action limit, then scoped rules. Rule
+// conditions that cannot be decided are returned separately as unchecked.
+func checkAgentPolicy(plan Plan, policy *agentPolicy, state *appagent.PolicyState) (violations, unchecked []*policyViolation) {
 	if policy == nil {
-		return nil
+		return nil, nil
 	}
 	allow := map[string]struct{}{}
 	for _, a := range policy.AllowActionTypes {
@@ -50,20 +113,123 @@ func enforceAgentPolicy(plan Plan, policy *agentPolicy) error {
 	for _, action := range plan.Actions {
 		if len(allow) > 0 {
 			if _, ok := allow[action.Type]; !ok {
-				return &CodeError{Code: exitUsage, Err: fmt.Errorf("policy denied action type: %s (not in allow list)", action.Type)}
+				violations = append(violations, &policyViolation{Rule: "allow_action_types", ActionType: action.Type})
 			}
 		}
 		if _, ok := deny[action.Type]; ok {
-			return &CodeError{Code: exitUsage, Err: fmt.Errorf("policy denied action type: %s", action.Type)}
+			violations = append(violations, &policyViolation{Rule: "deny_action_types", ActionType: action.Type})
 		}
 		if isDestructiveActionType(action.Type) {
 			destructive++
 		}
 	}
 	if policy.MaxDestructiveActions > 0 && destructive > policy.MaxDestructiveActions {
-		return &CodeError{Code: exitUsage, Err: fmt.Errorf("policy exceeded max destructive actions: %d > %d", destructive, policy.MaxDestructiveActions)}
+		violations = append(violations, &policyViolation{Rule: "max_destructive_actions", Limit: policy.MaxDestructiveActions, Count: destructive})
+	}
+	if len(policy.Rules) == 0 {
+		return violations, nil
+	}
+	report := appagent.EvaluatePolicyRules(plan, policy.Rules, state)
+	for _, finding := range report.Violations {
+		violations = append(violations, violationFromFinding(finding))
+	}
+	for _, finding := range report.Unchecked {
+		unchecked = append(unchecked, violationFromFinding(finding))
+	}
+	return violations, unchecked
+}
+
+func violationFromFinding(f appagent.PolicyFinding) *policyViolation {
+	return &policyViolation{Rule: f.Rule, Action: f.Action, ActionType: f.ActionType, Reason: f.Reason, Limit: f.Limit, Count: f.Count}
+}
+
+// agentPolicyState loads the live state scoped rules are evaluated against;
+// policies without rules need none.
+func agentPolicyState(ctx *Context, policy *agentPolicy, plan Plan) (*appagent.PolicyState, error) {
+	if policy == nil || len(policy.Rules) == 0 {
+		return nil, nil
+	}
+	if err := ensureClient(ctx); err != nil {
+		return nil, err
+	}
+	tasks, err := listAllActiveTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	projects, err := fetchAllProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sections, err := fetchAllSections(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	state := &appagent.PolicyState{Tasks: tasks, Projects: projects, Sections: sections}
+	loadPolicyTargets(ctx, plan, state)
+	return state, nil
+}
+
+// loadPolicyTargets fetches what the active lists miss: the comments plan
+// edits and the inactive tasks it targets (reopens of completed tasks), so
+// rules can tell which project those live in. Lookups are best effort; an
+// entity that cannot be read leaves its conditions unchecked.
+func loadPolicyTargets(ctx *Context, plan Plan, state *appagent.PolicyState) {
+	known := map[string]bool{}
+	for _, task := range state.Tasks {
+		known[task.ID] = true
+	}
+	get := func(path string, out any) bool {
+		reqCtx, cancel := requestContext(ctx)
+		defer cancel()
+		_, err := ctx.Client.Get(reqCtx, path, nil, out)
+		return err == nil
+	}
+	loadTask := func(id string) {
+		if _, alias := coreagent.AliasRef(id); alias || id == "" || known[id] {
+			return
+		}
+		known[id] = true
+		var task api.Task
+		if get("/tasks/"+id, &task) {
+			state.Completed = append(state.Completed, task)
+		}
+	}
+	comments := map[string]bool{}
+	for _, action := range plan.Actions {
+		switch {
+		case strings.HasPrefix(action.Type, "task_") && action.Type != "task_add":
+			loadTask(action.TaskID)
+		case action.Type == "comment_update" || action.Type == "comment_delete":
+			id := action.CommentID
+			if _, alias := coreagent.AliasRef(id); alias || id == "" || comments[id] {
+				continue
+			}
+			comments[id] = true
+			var comment appagent.PolicyComment
+			if get("/comments/"+id, &comment) {
+				state.Comments = append(state.Comments, comment)
+				loadTask(comment.TaskID)
+			}
+		}
+	}
+}
+
+// enforcePolicyFile loads the policy at path (or the default policy file)
+// and enforces it on plan.
+func enforcePolicyFile(ctx *Context, plan Plan, path string) error {
+	policy, err := loadAgentPolicy(ctx, path)
+	if err != nil {
+		return err
+	}
+	state, err := agentPolicyState(ctx, policy, plan)
+	if err != nil {
+		return err
+	}
+	unchecked, err := enforceAgentPolicy(plan, policy, state)
+	for _, finding := range unchecked {
+		fmt.Fprintf(ctx.Stderr, "warning: policy rule %q not checked for action %d (%s): %s\n", finding.Rule, finding.Action, finding.ActionType, finding.Reason)
 	}
-	return nil
+	return err
 }
 
 func isDestructiveActionType(actionType string) bool {
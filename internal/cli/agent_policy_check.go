@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/agisilaos/todoist-cli/internal/output"
+)
+
+func agentPolicyCommand(ctx *Context, args []string) error {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printAgentHelp(ctx.Stdout)
+		return nil
+	}
+	switch args[0] {
+	case "check":
+		return agentPolicyCheck(ctx, args[1:])
+	default:
+		return &CodeError{Code: exitUsage, Err: fmt.Errorf("unknown agent policy subcommand: %s", args[0])}
+	}
+}
+
+// agentPolicyCheck lints a plan against the policy without calling the API.
+// Rule conditions on existing entities cannot be decided offline and are
+// reported as unchecked.
+func agentPolicyCheck(ctx *Context, args []string) error {
+	fs := newFlagSet("agent policy check")
+	var planPath string
+	var policyPath string
+	var expectedVersion int
+	var strict bool
+	var help bool
+	fs.StringVar(&planPath, "plan", "", "Plan file (or - for stdin)")
+	fs.StringVar(&policyPath, "policy", "", "Policy file path")
+	fs.IntVar(&expectedVersion, "plan-version", 0, "Expected plan version (0 accepts any supported version)")
+	fs.BoolVar(&strict, "strict", false, "Exit non-zero when rules cannot be checked offline")
+	bindHelpFlag(fs, &help)
+	if err := parseFlagSetInterspersed(fs, args); err != nil {
+		return &CodeError{Code: exitUsage, Err: err}
+	}
+	if help {
+		printAgentHelp(ctx.Stdout)
+		return nil
+	}
+	if len(fs.Args()) > 0 {
+		return &CodeError{Code: exitUsage, Err: fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))}
+	}
+	if strings.TrimSpace(planPath) == "" {
+		return &CodeError{Code: exitUsage, Err: errors.New("--plan is required")}
+	}
+	plan, err := readPlanFile(planPath, ctx.Stdin)
+	if err != nil {
+		return err
+	}
+	if err := validatePlan(plan, expectedVersion, true); err != nil {
+		return err
+	}
+	policy, err := loadAgentPolicy(ctx, policyPath)
+	if err != nil {
+		return err
+	}
+	if policy == nil {
+		return &CodeError{Code: exitUsage, Err: fmt.Errorf("no policy file; pass --policy or create %s", defaultAgentPolicyPath(ctx))}
+	}
+	if policyPath == "" {
+		policyPath = defaultAgentPolicyPath(ctx)
+	}
+	violations, unchecked := checkAgentPolicy(plan, policy, nil)
+	if err := writePolicyCheckReport(ctx, policyPath, plan, violations, unchecked); err != nil {
+		return err
+	}
+	if len(violations) > 0 || (strict && len(unchecked) > 0) {
+		return &CodeError{Code: exitError, Kind: errCodePolicyDenied, Err: fmt.Errorf("policy check failed (violations=%d unchecked=%d)", len(violations), len(unchecked))}
+	}
+	return nil
+}
+
+func writePolicyCheckReport(ctx *Context, policyPath string, plan Plan, violations, unchecked []*policyViolation) error {
+	if violations == nil {
+		violations = []*policyViolation{}
+	}
+	if unchecked == nil {
+		unchecked = []*policyViolation{}
+	}
+	if ctx.Mode == output.ModeJSON {
+		return output.WriteJSON(ctx.Stdout, map[string]any{
+			"policy":       policyPath,
+			"action_count": len(plan.Actions),
+			"ok":           len(violations) == 0,
+			"violations":   violations,
+			"unchecked":    unchecked,
+		}, output.Meta{})
+	}
+	fmt.Fprintf(ctx.Stdout, "Policy: %s\n", policyPath)
+	fmt.Fprintf(ctx.Stdout, "Actions: %d\n", len(plan.Actions))
+	if len(violations) == 0 {
+		fmt.Fprintln(ctx.Stdout, "Violations: none")
+	} else {
+		fmt.Fprintf(ctx.Stdout, "Violations: %d\n", len(violations))
+		for _, v := range violations {
+			fmt.Fprintf(ctx.Stdout, "  - %s\n", describePolicyFinding(v))
+		}
+	}
+	if len(unchecked) > 0 {
+		fmt.Fprintf(ctx.Stdout, "Unchecked (needs live state): %d\n", len(unchecked))
+		for _, v := range unchecked {
+			fmt.Fprintf(ctx.Stdout, "  - %s\n", describePolicyFinding(v))
+		}
+	}
+	return nil
+}
+
+func describePolicyFinding(v *policyViolation) string {
+	switch {
+	case v.Reason == "":
+		return v.Error()
+	case v.Action > 0:
+		return fmt.Sprintf("action %d (%s): rule %q: %s", v.Action, v.ActionType, v.Rule, v.Reason)
+	default:
+		return fmt.Sprintf("rule %q: %s", v.Rule, v.Reason)
+	}
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	"github.com/agisilaos/todoist-cli/internal/mockserver"
+)
+
+const testScopedPolicy = `{
+  "rules": [
+    {"name": "work-only", "actions": ["task_*"], "require": {"project_under": "Work"}},
+    {"name": "no-p1", "deny": {"priority": "p1"}}
+  ]
+}`
+
+func writeTestFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestAgentPolicyCheckOffline(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.json")
+	writeTestFile(t, dir, "agent_policy.json", testScopedPolicy)
+	data, _ := json.Marshal(Plan{Version: 1, ConfirmToken: "abcd", Actions: []Action{
+		{Type: "task_add", Content: "Ship", Project: "Work", Priority: 4},
+		{Type: "task_update", TaskID: "t1", Priority: 2},
+	}})
+	planPath := writeTestFile(t, dir, "plan.json", string(data))
+	run := func(args ...string) (int, string, string) {
+		var stdout, stderr bytes.Buffer
+		code := Execute(append([]string{"--config", configPath}, args...), &stdout, &stderr)
+		return code, stdout.String(), stderr.String()
+	}
+
+	code, out, _ := run("agent", "policy", "check", "--plan", planPath)
+	if code != exitError {
+		t.Fatalf("expected violations to fail, got %d:\n%s", code, out)
+	}
+	for _, want := range []string{"Violations: 1", `action 1 (task_add): rule "no-p1": matches deny: priority=p1`, "Unchecked (needs live state): 1", `action 2 (task_update): rule "work-only": cannot verify require: project_under=Work`} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("report missing %q:\n%s", want, out)
+		}
+	}
+
+	data, _ = json.Marshal(Plan{Version: 1, ConfirmToken: "abcd", Actions: []Action{{Type: "task_update", TaskID: "t1", Priority: 2}}})
+	writeTestFile(t, dir, "plan.json", string(data))
+	code, out, _ = run("--json", "agent", "policy", "check", "--plan", planPath)
+	var report struct {
+		OK        bool             `json:"ok"`
+		Unchecked []map[string]any `json:"unchecked"`
+	}
+	if err := json.Unmarshal([]byte(out), &report); err != nil || code != exitOK || !report.OK || len(report.Unchecked) != 1 {
+		t.Fatalf("unexpected json report (%d): %s", code, out)
+	}
+	if code, _, _ = run("agent", "policy", "check", "--plan", planPath, "--strict"); code != exitError {
+		t.Fatalf("expected --strict to fail on unchecked rules, got %d", code)
+	}
+	if code, _, _ = run("agent", "policy", "check"); code != exitUsage {
+		t.Fatalf("expected usage error without --plan, got %d", code)
+	}
+	if code, _, _ = run("agent", "policy", "check", "--plan", planPath, "--policy", writeTestFile(t, dir, "bad.json", `{"rules":[{"name":"x"}]}`)); code == exitOK {
+		t.Fatal("expected invalid rule to fail")
+	}
+}
+
+func TestAgentApplyEnforcesScopedPolicy(t *testing.T) {
+	cli := newMockCLIRunner(t, mockserver.Fixture{
+		Projects: []api.Project{{ID: "p1", Name: "Work"}, {ID: "p2", Name: "Clients", ParentID: "p1"}, {ID: "p3", Name: "Home"}},
+		Tasks: []api.Task{
+			{ID: "t1", Content: "Deploy", ProjectID: "p2"},
+			{ID: "t3", Content: "Water plants", ProjectID: "p3"},
+			{ID: "t4", Content: "Mow lawn", ProjectID: "p3", Checked: true},
+		},
+	})
+	dir := filepath.Dir(cli.configPath)
+	writeTestFile(t, dir, "agent_policy.json", testScopedPolicy)
+	apply := func(wantCode int, actions ...Action) string {
+		t.Helper()
+		data, _ := json.Marshal(Plan{Version: 1, ConfirmToken: "abcd", Actions: actions})
+		planPath := writeTestFile(t, dir, "plan.json", string(data))
+		_, stderr := cli.runWithStderr(wantCode, "--json", "agent", "apply", "--plan", planPath, "--confirm", "abcd")
+		return stderr
+	}
+
+	errOut := apply(exitUsage, Action{Type: "task_complete", TaskID: "t1"}, Action{Type: "task_complete", TaskID: "t3"})
+	var body struct {
+		Error errorBody `json:"error"`
+	}
+	// Usage-class errors print agent help before the JSON error envelope.
+	envelope := errOut[strings.Index(errOut, "\n{")+1:]
+	if err := json.Unmarshal([]byte(envelope), &body); err != nil {
+		t.Fatalf("expected policy denial: %s", errOut)
+	}
+	details := body.Error.Details
+	if body.Error.Code != errCodePolicyDenied || details["rule"] != "work-only" || details["action"] != float64(2) || details["reason"] != "does not satisfy require: project_under=Work" {
+		t.Fatalf("unexpected policy error: %s", errOut)
+	}
+	if errOut = apply(exitNotFound, Action{Type: "task_complete", TaskID: "t9"}); !strings.Contains(errOut, `warning: policy rule "work-only" not checked for action 1 (task_complete): cannot verify`) {
+		t.Fatalf("expected unverifiable action to be warned about and attempted: %s", errOut)
+	}
+	apply(exitOK, Action{Type: "task_complete", TaskID: "t1"}, Action{Type: "label_add", Name: "later"})
+	// Completed tasks are not listed as active; their project is still checked.
+	apply(exitOK, Action{Type: "task_reopen", TaskID: "t1"})
+	if errOut = apply(exitUsage, Action{Type: "task_reopen", TaskID: "t4"}); !strings.Contains(errOut, "does not satisfy require") {
+		t.Fatalf("expected the completed Home task to be denied: %s", errOut)
+	}
+}
@@ -12,14 +12,14 @@ func TestEnforceAgentPolicyAllowDeny(t *testing.T) {
 	policy := &agentPolicy{
 		AllowActionTypes: []string{"task_add"},
 	}
-	if err := enforceAgentPolicy(plan, policy); err == nil {
+	if _, err := enforceAgentPolicy(plan, policy, nil); err == nil {
 		t.Fatalf("expected allow-list enforcement error")
 	}
 
 	policy = &agentPolicy{
 		DenyActionTypes: []string{"task_delete"},
 	}
-	if err := enforceAgentPolicy(plan, policy); err == nil {
+	if _, err := enforceAgentPolicy(plan, policy, nil); err == nil {
 		t.Fatalf("expected deny-list enforcement error")
 	}
 }
@@ -32,7 +32,7 @@ func TestEnforceAgentPolicyMaxDestructive(t *testing.T) {
 		},
 	}
 	policy := &agentPolicy{MaxDestructiveActions: 1}
-	if err := enforceAgentPolicy(plan, policy); err == nil {
+	if _, err := enforceAgentPolicy(plan, policy, nil); err == nil {
 		t.Fatalf("expected max destructive enforcement error")
 	}
 }
@@ -11,6 +11,7 @@ import (
 
 type replayJournal struct {
 	Applied map[string]string `json:"applied"`
+	Created map[string]string `json:"created,omitempty"`
 }
 
 func loadReplayJournal(ctx *Context) (replayJournal, string, error) {
@@ -76,3 +77,13 @@ func markReplayApplied(j *replayJournal, key string, at time.Time) {
 	}
 	j.Applied[key] = at.UTC().Format(time.RFC3339)
 }
+
+func markReplayCreated(j *replayJournal, key, createdID string) {
+	if j == nil || createdID == "" {
+		return
+	}
+	if j.Created == nil {
+		j.Created = map[string]string{}
+	}
+	j.Created[key] = createdID
+}
@@ -14,6 +14,7 @@ type agentRunOptions struct {
 	Planner          string
 	Confirm          string
 	OnError          string
+	Batch            bool
 	ExpectedVersion  int
 	Force            bool
 	DryRun           bool
@@ -22,6 +23,7 @@ type agentRunOptions struct {
 	ContextLabels    []string
 	ContextCompleted string
 	PolicyPath       string
+	AllowStale       bool
 }
 
 func agentRun(ctx *Context, args []string) error {
@@ -32,9 +34,11 @@ func agentRun(ctx *Context, args []string) error {
 	fs.StringVar(&opts.Planner, "planner", "", "Planner command")
 	fs.StringVar(&opts.Confirm, "confirm", "", "Confirmation token")
 	fs.StringVar(&opts.OnError, "on-error", "fail", "On error: fail|continue")
-	fs.IntVar(&opts.ExpectedVersion, "plan-version", 1, "Expected plan version")
+	fs.BoolVar(&opts.Batch, "batch", false, "Apply actions as batched Sync API commands")
+	fs.IntVar(&opts.ExpectedVersion, "plan-version", 0, "Expected plan version (0 accepts any supported version)")
 	fs.StringVar(&opts.OutPath, "out", "", "Write plan output to file")
 	fs.StringVar(&opts.PolicyPath, "policy", "", "Policy file path")
+	fs.BoolVar(&opts.AllowStale, "allow-stale", false, "Apply even if targets changed since planning (warns instead)")
 	var contextProjects multiValue
 	var contextLabels multiValue
 	fs.Var(&contextProjects, "context-project", "Project context (repeatable)")
@@ -85,11 +89,16 @@ func agentRun(ctx *Context, args []string) error {
 			return validatePlan(plan, expectedVersion, allowEmptyActions)
 		},
 		EnforcePolicy: func(plan Plan) error {
-			policy, err := loadAgentPolicy(ctx, opts.PolicyPath)
-			if err != nil {
-				return err
+			return enforcePolicyFile(ctx, plan, opts.PolicyPath)
+		},
+		Expand: func(plan Plan) (Plan, error) {
+			return expandAgentPlan(ctx, plan)
+		},
+		CheckStale: func(plan Plan) error {
+			if strings.TrimSpace(opts.PlanPath) == "" || opts.DryRun {
+				return nil
 			}
-			return enforceAgentPolicy(plan, policy)
+			return checkPlanFresh(ctx, plan, opts.AllowStale)
 		},
 	})
 	if err != nil {
@@ -115,7 +124,7 @@ func agentRun(ctx *Context, args []string) error {
 		emitProgress(ctx, "agent_run_error", map[string]any{"error": err.Error()})
 		return err
 	}
-	results, applyErr := applyActionsWithMode(ctx, plan.ConfirmToken, plan.Actions, opts.OnError)
+	results, applyErr := applyPlanActions(ctx, &plan, opts.OnError, opts.Batch)
 	if applyErr != nil && opts.OnError == "fail" {
 		emitAgentApplySummary(ctx, "agent run", results, false, applyErr)
 		emitProgress(ctx, "agent_run_error", map[string]any{"error": applyErr.Error()})
@@ -56,7 +56,7 @@ func agentSchedulePrint(ctx *Context, args []string) error {
 	fs.BoolVar(&force, "force", false, "Skip confirmation prompts")
 	fs.BoolVar(&dryRun, "dry-run", false, "Preview only")
 	fs.StringVar(&onError, "on-error", "fail", "On error: fail|continue")
-	fs.IntVar(&expectedVersion, "plan-version", 1, "Expected plan version")
+	fs.IntVar(&expectedVersion, "plan-version", 0, "Expected plan version (0 accepts any supported version)")
 	fs.Var(&contextProjects, "context-project", "Project context (repeatable)")
 	fs.Var(&contextLabels, "context-label", "Label context (repeatable)")
 	fs.StringVar(&contextCompleted, "context-completed", "", "Include completed tasks from last Nd (e.g. 7d)")
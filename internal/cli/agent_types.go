@@ -1,6 +1,10 @@
 package cli
 
-import coreagent "github.com/agisilaos/todoist-cli/internal/agent"
+import (
+	"encoding/json"
+
+	coreagent "github.com/agisilaos/todoist-cli/internal/agent"
+)
 
 type PlannerRequest = coreagent.PlannerRequest
 
@@ -13,7 +17,13 @@ type PlanSummary = coreagent.PlanSummary
 type Action = coreagent.Action
 
 type applyResult struct {
-	Action        Action `json:"action"`
-	Error         error  `json:"-"`
-	SkippedReplay bool   `json:"skipped_replay,omitempty"`
+	Action        Action          `json:"action"`
+	Resolved      Action          `json:"-"`
+	CreatedID     string          `json:"created_id,omitempty"`
+	Before        json.RawMessage `json:"-"`
+	Error         error           `json:"-"`
+	SkippedReplay bool            `json:"skipped_replay,omitempty"`
+	// Unchanged is set when the action would not change its target and no
+	// request was sent.
+	Unchanged bool `json:"unchanged,omitempty"`
 }
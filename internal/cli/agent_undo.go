@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	appagent "github.com/agisilaos/todoist-cli/internal/app/agent"
+	"github.com/agisilaos/todoist-cli/internal/output"
+)
+
+// agentUndo reverts a journaled apply by synthesizing the inverse plan and
+// sending it through the same preview, policy and confirm steps as apply.
+func agentUndo(ctx *Context, args []string) error {
+	fs := newFlagSet("agent undo")
+	var applyID string
+	var confirm string
+	var policyPath string
+	var onError string
+	var batch bool
+	var help bool
+	fs.StringVar(&applyID, "apply-id", "", "Apply to revert (default: latest not undone)")
+	fs.StringVar(&confirm, "confirm", "", "Confirmation token")
+	fs.StringVar(&policyPath, "policy", "", "Policy file path")
+	fs.StringVar(&onError, "on-error", "fail", "On error: fail|continue")
+	fs.BoolVar(&batch, "batch", false, "Apply actions as batched Sync API commands")
+	bindHelpFlag(fs, &help)
+	if err := parseFlagSetInterspersed(fs, args); err != nil {
+		return &CodeError{Code: exitUsage, Err: err}
+	}
+	if help {
+		printAgentHelp(ctx.Stdout)
+		return nil
+	}
+	if fs.NArg() > 0 {
+		return &CodeError{Code: exitUsage, Err: fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))}
+	}
+	if onError != "fail" && onError != "continue" {
+		return &CodeError{Code: exitUsage, Err: errors.New("invalid --on-error; must be fail or continue")}
+	}
+	emitProgress(ctx, "agent_undo_start", map[string]any{"command": "agent undo"})
+	journal, _, err := loadApplyJournal(ctx)
+	if err != nil {
+		return err
+	}
+	applyID = strings.TrimSpace(applyID)
+	entry, ok := findApplyEntry(journal, applyID)
+	if !ok {
+		if applyID == "" {
+			return &CodeError{Code: exitNotFound, Err: errors.New("no applied plan to undo")}
+		}
+		return &CodeError{Code: exitNotFound, Err: fmt.Errorf("apply not found in journal: %s", applyID)}
+	}
+	if entry.UndoneBy != "" {
+		return &CodeError{Code: exitConflict, Err: fmt.Errorf("apply %s was already undone by %s", entry.ApplyID, entry.UndoneBy)}
+	}
+	plan, skipped := appagent.BuildUndoPlan(appagent.UndoInput{
+		ApplyID:     entry.ApplyID,
+		Instruction: entry.Instruction,
+		Applied:     entry.Actions,
+		Now:         applyNow(ctx),
+	})
+	if len(plan.Actions) == 0 {
+		return &CodeError{Code: exitError, Err: fmt.Errorf("apply %s has nothing to undo: %s", entry.ApplyID, describeUndoSkips(skipped))}
+	}
+	preview := strings.TrimSpace(confirm) == "" && !ctx.Global.Force
+	plan, err = appagent.PreparePlan(appagent.PrepareInput{
+		Plan:            &plan,
+		Confirm:         confirm,
+		ExpectedVersion: 1,
+		Force:           ctx.Global.Force,
+		DryRun:          ctx.Global.DryRun,
+		Preview:         preview,
+	}, appagent.PrepareDeps{
+		ValidatePlan: func(plan Plan, expectedVersion int, allowEmptyActions bool) error {
+			return validatePlan(plan, expectedVersion, allowEmptyActions)
+		},
+		EnforcePolicy: func(plan Plan) error {
+			return enforcePolicyFile(ctx, plan, policyPath)
+		},
+	})
+	if err != nil {
+		emitProgress(ctx, "agent_undo_error", map[string]any{"error": err.Error()})
+		return err
+	}
+	emitAgentPlanLoaded(ctx, "agent undo", len(plan.Actions), "apply_journal")
+	if preview || ctx.Global.DryRun {
+		emitAgentApplySummary(ctx, "agent undo", nil, true, nil)
+		emitProgress(ctx, "agent_undo_complete", map[string]any{"dry_run": true, "action_count": len(plan.Actions)})
+		return writeUndoPreview(ctx, entry.ApplyID, plan, skipped, ctx.Global.DryRun)
+	}
+	if err := ensureClient(ctx); err != nil {
+		emitProgress(ctx, "agent_undo_error", map[string]any{"error": err.Error()})
+		return err
+	}
+	results, err := applyPlanActions(ctx, &plan, onError, batch)
+	if plan.ApplyID != "" {
+		// Link partial undos too, so a later undo does not pick them up
+		// and revert the actions that did succeed.
+		if linkErr := linkUndo(ctx, entry.ApplyID, plan.ApplyID); linkErr != nil && err == nil {
+			err = linkErr
+		}
+	}
+	if err != nil && onError == "fail" {
+		emitAgentApplySummary(ctx, "agent undo", results, false, err)
+		emitProgress(ctx, "agent_undo_error", map[string]any{"error": err.Error()})
+		return err
+	}
+	plan.AppliedAt = applyNow(ctx).UTC().Format(time.RFC3339)
+	if err := writePlanFile(lastPlanPath(ctx), plan); err != nil {
+		emitAgentApplySummary(ctx, "agent undo", results, false, err)
+		emitProgress(ctx, "agent_undo_error", map[string]any{"error": err.Error()})
+		return err
+	}
+	emitAgentApplySummary(ctx, "agent undo", results, false, err)
+	emitProgress(ctx, "agent_undo_complete", map[string]any{"action_count": len(plan.Actions)})
+	return writePlanApplyResult(ctx, plan, results, err)
+}
+
+func writeUndoPreview(ctx *Context, applyID string, plan Plan, skipped []appagent.UndoSkip, dryRun bool) error {
+	if ctx.Mode == output.ModeJSON {
+		payload := map[string]any{
+			"apply_id":     applyID,
+			"plan":         plan,
+			"dry_run":      dryRun,
+			"action_count": len(plan.Actions),
+			"summary":      plan.Summary,
+			"skipped":      skipped,
+		}
+		return output.WriteJSON(ctx.Stdout, payload, output.Meta{})
+	}
+	if err := writePlanPreview(ctx, plan, dryRun); err != nil {
+		return err
+	}
+	if len(skipped) > 0 {
+		fmt.Fprintln(ctx.Stdout, "Not reverted:")
+		for _, skip := range skipped {
+			fmt.Fprintf(ctx.Stdout, "  - #%d %s: %s\n", skip.Index+1, skip.Type, skip.Reason)
+		}
+	}
+	fmt.Fprintf(ctx.Stdout, "Apply with: todoist agent undo --apply-id %s --confirm %s\n", applyID, plan.ConfirmToken)
+	return nil
+}
+
+func describeUndoSkips(skipped []appagent.UndoSkip) string {
+	parts := make([]string, 0, len(skipped))
+	for _, skip := range skipped {
+		parts = append(parts, fmt.Sprintf("#%d %s (%s)", skip.Index+1, skip.Type, skip.Reason))
+	}
+	if len(parts) == 0 {
+		return "no reversible actions"
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	"github.com/agisilaos/todoist-cli/internal/mockserver"
+)
+
+func newAgentUndoTestRunner(t *testing.T) (func(wantCode int, args ...string) string, func(id string) (api.Task, bool)) {
+	t.Helper()
+	run := newMockCLIRunner(t, mockserver.Fixture{
+		Projects: []api.Project{{ID: "p1", Name: "Work"}, {ID: "p2", Name: "Home"}},
+		Sections: []api.Section{{ID: "s1", ProjectID: "p1", Name: "Backlog"}},
+		Tasks: []api.Task{
+			{ID: "t1", Content: "Deploy", ProjectID: "p1"},
+			{ID: "t2", Content: "Write notes", ProjectID: "p1", Labels: []string{"home"}, Priority: 1, Due: &api.Due{Date: "2026-10-20"}},
+			{ID: "t3", Content: "Water plants", ProjectID: "p1", SectionID: "s1"},
+			{ID: "t4", Content: "Stretch", ProjectID: "p1", Due: &api.Due{Date: "2026-10-17", String: "every day", IsRecurring: true}},
+		},
+	}).run
+	taskOf := func(id string) (api.Task, bool) {
+		t.Helper()
+		var tasks []api.Task
+		if err := json.Unmarshal([]byte(run(exitOK, "--json", "task", "list", "--all-projects")), &tasks); err != nil {
+			t.Fatalf("decode tasks: %v", err)
+		}
+		for _, task := range tasks {
+			if task.ID == id || task.Content == id {
+				return task, true
+			}
+		}
+		return api.Task{}, false
+	}
+	return run, taskOf
+}
+
+func writeUndoTestPlan(t *testing.T) string {
+	t.Helper()
+	plan := Plan{Version: 1, Instruction: "triage", ConfirmToken: "abcd", Actions: []Action{
+		{Type: "task_complete", TaskID: "t1"},
+		{Type: "task_update", TaskID: "t2", Content: "Write report", Labels: []string{"work"}, Priority: 4, DueDate: "2026-10-25"},
+		{Type: "task_move", TaskID: "t3", ProjectID: "p2"},
+		{Type: "task_add", Alias: "call", Content: "Call bank", ProjectID: "p2"},
+		{Type: "task_update", TaskID: "$call", Priority: 3},
+		{Type: "task_complete", TaskID: "t4"},
+	}}
+	data, _ := json.Marshal(plan)
+	path := filepath.Join(t.TempDir(), "plan.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestAgentUndoRevertsApply(t *testing.T) {
+	for _, batch := range []bool{false, true} {
+		run, taskOf := newAgentUndoTestRunner(t)
+		applyArgs := []string{"--json", "agent", "apply", "--plan", writeUndoTestPlan(t), "--confirm", "abcd"}
+		undoFlags := []string{}
+		if batch {
+			applyArgs = append(applyArgs, "--batch")
+			undoFlags = append(undoFlags, "--batch")
+		}
+		var applied struct {
+			Plan Plan `json:"plan"`
+		}
+		if err := json.Unmarshal([]byte(run(exitOK, applyArgs...)), &applied); err != nil {
+			t.Fatalf("decode apply: %v", err)
+		}
+		applyID := applied.Plan.ApplyID
+		if applyID == "" {
+			t.Fatalf("apply did not report an apply id: %#v", applied.Plan)
+		}
+		if _, ok := taskOf("Call bank"); !ok {
+			t.Fatal("expected the added task")
+		}
+		if task, _ := taskOf("t4"); task.Due == nil || task.Due.Date != "2026-10-18" {
+			t.Fatalf("expected the recurring task moved to its next occurrence, batch=%v: %#v", batch, task.Due)
+		}
+
+		preview := run(exitOK, append([]string{"agent", "undo"}, undoFlags...)...)
+		for _, want := range []string{"Plan: undo " + applyID + ": triage", "Actions: 5", "1. task_update (undo #6 task_complete)", "2. task_delete (undo #4 task_add)", "5. task_reopen (undo #1 task_complete)"} {
+			if !strings.Contains(preview, want) {
+				t.Fatalf("preview missing %q:\n%s", want, preview)
+			}
+		}
+		token := regexp.MustCompile(`Confirm: (\w+)`).FindStringSubmatch(preview)
+		if token == nil {
+			t.Fatalf("no confirm token in preview:\n%s", preview)
+		}
+		run(exitError, append([]string{"agent", "undo", "--confirm", "zzzz"}, undoFlags...)...)
+		out := run(exitOK, append([]string{"agent", "undo", "--confirm", token[1]}, undoFlags...)...)
+		if !strings.Contains(out, "Outcome: success") {
+			t.Fatalf("unexpected undo output:\n%s", out)
+		}
+
+		if task, ok := taskOf("t1"); !ok || task.Checked {
+			t.Fatalf("expected t1 reopened, batch=%v: %#v", batch, task)
+		}
+		task, _ := taskOf("t2")
+		if task.Content != "Write notes" || task.Priority != 1 || strings.Join(task.Labels, ",") != "home" || task.Due == nil || task.Due.Date != "2026-10-20" {
+			t.Fatalf("expected t2 restored, batch=%v: %#v", batch, task)
+		}
+		if task, _ := taskOf("t4"); task.Due == nil || task.Due.Date != "2026-10-17" || !task.Due.IsRecurring {
+			t.Fatalf("expected t4 back on its completed occurrence, batch=%v: %#v", batch, task.Due)
+		}
+		if task, _ := taskOf("t3"); task.ProjectID != "p1" || task.SectionID != "s1" {
+			t.Fatalf("expected t3 moved back, batch=%v: %#v", batch, task)
+		}
+		if _, ok := taskOf("Call bank"); ok {
+			t.Fatalf("expected the added task deleted, batch=%v", batch)
+		}
+
+		run(exitConflict, "agent", "undo", "--apply-id", applyID)
+		run(exitNotFound, "agent", "undo")
+		run(exitNotFound, "agent", "undo", "--apply-id", "nope")
+	}
+}
+
+func TestAgentUndoUsageErrors(t *testing.T) {
+	run, _ := newAgentUndoTestRunner(t)
+	run(exitUsage, "agent", "undo", "extra")
+	run(exitUsage, "agent", "undo", "--on-error", "skip")
+	run(exitNotFound, "agent", "undo")
+}
+
+func TestAgentUndoLinksPartialUndo(t *testing.T) {
+	run, taskOf := newAgentUndoTestRunner(t)
+	var applied struct {
+		Plan Plan `json:"plan"`
+	}
+	if err := json.Unmarshal([]byte(run(exitOK, "--json", "agent", "apply", "--plan", writeUndoTestPlan(t), "--confirm", "abcd")), &applied); err != nil {
+		t.Fatalf("decode apply: %v", err)
+	}
+	run(exitOK, "task", "delete", "--id", "t1", "--yes")
+
+	run(exitNotFound, "agent", "undo", "--force")
+	if task, _ := taskOf("t2"); task.Content != "Write notes" {
+		t.Fatalf("expected t2 restored by the partial undo: %#v", task)
+	}
+	run(exitConflict, "agent", "undo", "--apply-id", applied.Plan.ApplyID)
+	run(exitNotFound, "agent", "undo", "--force")
+	if task, _ := taskOf("t2"); task.Content != "Write notes" {
+		t.Fatalf("second undo reverted the partial undo: %#v", task)
+	}
+}
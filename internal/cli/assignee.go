@@ -85,6 +85,9 @@ func listProjectCollaborators(ctx *Context, projectID string) ([]api.Collaborato
 			return cloneSlice(collaborators), nil
 		}
 	}
+	if collaborators, ok := persistedLookup[api.Collaborator](ctx, lookupCacheKey("collaborators", projectID)); ok {
+		return collaborators, nil
+	}
 	query := url.Values{}
 	query.Set("limit", "200")
 	all, _, err := fetchPaginated[api.Collaborator](ctx, "/projects/"+projectID+"/collaborators", query, true)
@@ -94,5 +97,6 @@ func listProjectCollaborators(ctx *Context, projectID string) ([]api.Collaborato
 	if cache := ctx.cache(); cache != nil {
 		cache.collaboratorsByProject[projectID] = cloneSlice(all)
 	}
+	storeLookup(ctx, lookupCacheKey("collaborators", projectID), all)
 	return all, nil
 }
@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	"github.com/agisilaos/todoist-cli/internal/output"
+)
+
+const (
+	defaultBulkConcurrency = 4
+	maxBulkConcurrency     = 8
+)
+
+func bulkConcurrency(ctx *Context, total int) int {
+	n := ctx.Config.BulkConcurrency
+	if n <= 0 {
+		n = defaultBulkConcurrency
+	}
+	if n > maxBulkConcurrency {
+		n = maxBulkConcurrency
+	}
+	if n > total {
+		n = total
+	}
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+// runBulk calls fn for every id on a bounded worker pool and returns the
+// per-id errors in input order. fn returns the request ID of its call; the
+// shared api.Client rate limiter keeps the workers under the API limits.
+func runBulk(ctx *Context, command string, ids []string, fn func(id string) (string, error)) []error {
+	return runBulkIndexed(ctx, command, len(ids), func(idx int) (string, error) {
+		return fn(ids[idx])
+	})
+}
+
+// runBulkIndexed is runBulk over the indexes 0..count-1, for callers whose
+// items may repeat an ID.
+func runBulkIndexed(ctx *Context, command string, count int, fn func(idx int) (string, error)) []error {
+	errs := make([]error, count)
+	reqIDs := make([]string, count)
+	workers := bulkConcurrency(ctx, count)
+	emitProgress(ctx, "bulk_start", map[string]any{"command": command, "count": count, "concurrency": workers})
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				reqIDs[idx], errs[idx] = fn(idx)
+			}
+		}()
+	}
+	for idx := 0; idx < count; idx++ {
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
+	failed := 0
+	for idx, err := range errs {
+		if err != nil {
+			failed++
+			continue
+		}
+		setRequestID(ctx, reqIDs[idx])
+	}
+	emitProgress(ctx, "bulk_complete", map[string]any{"command": command, "count": count, "succeeded": count - failed, "failed": failed})
+	return errs
+}
+
+type bulkItemResult struct {
+	ID        string `json:"id"`
+	Content   string `json:"content"`
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// runBulkReport is runBulk over tasks with a per-task result; successful
+// items get status.
+func runBulkReport(ctx *Context, command string, tasks []api.Task, status string, fn func(task api.Task) (string, error)) []bulkItemResult {
+	results := make([]bulkItemResult, len(tasks))
+	for i, task := range tasks {
+		results[i] = bulkItemResult{ID: task.ID, Content: task.Content}
+	}
+	errs := runBulkIndexed(ctx, command, len(tasks), func(idx int) (string, error) {
+		reqID, err := fn(tasks[idx])
+		results[idx].RequestID = reqID
+		return reqID, err
+	})
+	for i, err := range errs {
+		if err != nil {
+			results[i].Status = "failed"
+			results[i].Error = err.Error()
+			results[i].RequestID = ""
+			continue
+		}
+		results[i].Status = status
+	}
+	return results
+}
+
+func writeBulkResults(ctx *Context, action, filter string, results []bulkItemResult) error {
+	failed := 0
+	for _, result := range results {
+		if result.Status == "failed" {
+			failed++
+		}
+	}
+	succeeded := len(results) - failed
+	switch ctx.Mode {
+	case output.ModeJSON:
+		return output.WriteJSON(ctx.Stdout, map[string]any{
+			"action":    action,
+			"filter":    filter,
+			"results":   results,
+			"succeeded": succeeded,
+			"failed":    failed,
+			"count":     len(results),
+		}, output.Meta{RequestID: ctx.RequestID, Count: len(results)})
+	case output.ModeNDJSON:
+		return output.WriteNDJSONSlice(ctx.Stdout, results)
+	case output.ModePlain:
+		rows := make([][]string, 0, len(results))
+		for _, result := range results {
+			rows = append(rows, []string{result.Status, result.ID, result.Content, result.Error})
+		}
+		return output.WritePlain(ctx.Stdout, rows)
+	}
+	for _, result := range results {
+		line := fmt.Sprintf("%s %s %s", result.Status, result.ID, result.Content)
+		if result.Error != "" {
+			line += ": " + result.Error
+		}
+		fmt.Fprintln(ctx.Stdout, line)
+	}
+	fmt.Fprintf(ctx.Stdout, "bulk %s done: succeeded=%d failed=%d total=%d\n", action, succeeded, failed, len(results))
+	return nil
+}
+
+// writeBulkPreview is the --dry-run output of a bulk command: every matched
+// task plus the payload that would be sent.
+func writeBulkPreview(ctx *Context, action, filter string, tasks []api.Task, payload map[string]any) error {
+	if ctx.Mode == output.ModeJSON {
+		items := make([]map[string]any, 0, len(tasks))
+		ids := make([]string, 0, len(tasks))
+		for _, task := range tasks {
+			items = append(items, map[string]any{"id": task.ID, "content": task.Content, "labels": task.Labels})
+			ids = append(ids, task.ID)
+		}
+		body := map[string]any{"filter": filter, "count": len(tasks), "ids": ids, "tasks": items}
+		if payload != nil {
+			body["payload"] = payload
+		}
+		return writeDryRun(ctx, action, body)
+	}
+	if ctx.Mode == output.ModePlain {
+		rows := make([][]string, 0, len(tasks))
+		for _, task := range tasks {
+			rows = append(rows, []string{task.ID, task.Content})
+		}
+		return output.WritePlain(ctx.Stdout, rows)
+	}
+	fmt.Fprintf(ctx.Stdout, "dry run: %s (%d tasks matching %q)\n", action, len(tasks), filter)
+	for _, task := range tasks {
+		fmt.Fprintf(ctx.Stdout, "  - %s %s\n", task.ID, task.Content)
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	"github.com/agisilaos/todoist-cli/internal/config"
+	"github.com/agisilaos/todoist-cli/internal/mockserver"
+	"github.com/agisilaos/todoist-cli/internal/output"
+)
+
+func TestRunBulkBoundsConcurrencyAndKeepsErrorOrder(t *testing.T) {
+	var progressOut bytes.Buffer
+	ctx := &Context{
+		Config:   config.Config{BulkConcurrency: 3},
+		Now:      time.Now,
+		Progress: &progressSink{out: &progressOut},
+	}
+	ids := []string{"t1", "t2", "t3", "t4", "t5", "t6", "t7"}
+	var active, peak int32
+	var mu sync.Mutex
+	errs := runBulk(ctx, "task complete", ids, func(id string) (string, error) {
+		n := atomic.AddInt32(&active, 1)
+		mu.Lock()
+		if n > peak {
+			peak = n
+		}
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&active, -1)
+		if id == "t4" {
+			return "", errors.New("boom")
+		}
+		return "req-" + id, nil
+	})
+	if peak > 3 {
+		t.Fatalf("expected at most 3 workers, saw %d", peak)
+	}
+	for idx, err := range errs {
+		if (err != nil) != (ids[idx] == "t4") {
+			t.Fatalf("unexpected error at %d: %v", idx, err)
+		}
+	}
+	if ctx.RequestID == "" {
+		t.Fatalf("expected request id from a successful call")
+	}
+	lines := progressLines(t, progressOut.String())
+	if len(lines) != 2 || lines[0]["type"] != "bulk_start" || lines[0]["concurrency"] != float64(3) {
+		t.Fatalf("unexpected bulk_start event: %v", lines)
+	}
+	if lines[1]["type"] != "bulk_complete" || lines[1]["failed"] != float64(1) || lines[1]["succeeded"] != float64(6) {
+		t.Fatalf("unexpected bulk_complete event: %v", lines)
+	}
+}
+
+func TestBulkConcurrencyDefaultsAndCaps(t *testing.T) {
+	ctx := &Context{}
+	if got := bulkConcurrency(ctx, 100); got != defaultBulkConcurrency {
+		t.Fatalf("expected default concurrency, got %d", got)
+	}
+	ctx.Config.BulkConcurrency = 50
+	if got := bulkConcurrency(ctx, 100); got != maxBulkConcurrency {
+		t.Fatalf("expected capped concurrency, got %d", got)
+	}
+	if got := bulkConcurrency(ctx, 2); got != 2 {
+		t.Fatalf("expected concurrency bounded by item count, got %d", got)
+	}
+}
+
+func TestRunBulkReportKeepsPerTaskStatus(t *testing.T) {
+	ctx := &Context{Config: config.Config{BulkConcurrency: 2}, Now: time.Now}
+	tasks := []api.Task{{ID: "a", Content: "A"}, {ID: "b", Content: "B"}, {ID: "c", Content: "C"}}
+	results := runBulkReport(ctx, "task delete", tasks, "deleted", func(task api.Task) (string, error) {
+		if task.ID == "b" {
+			return "req-b", errors.New("boom")
+		}
+		return "req-" + task.ID, nil
+	})
+	if results[0].Status != "deleted" || results[0].RequestID != "req-a" || results[0].Content != "A" {
+		t.Fatalf("unexpected first result: %#v", results[0])
+	}
+	if results[1].Status != "failed" || results[1].Error != "boom" || results[1].RequestID != "" {
+		t.Fatalf("unexpected failed result: %#v", results[1])
+	}
+
+	dupes := runBulkReport(ctx, "task update", []api.Task{{ID: "a", Content: "first"}, {ID: "a", Content: "second"}}, "updated", func(task api.Task) (string, error) {
+		if task.Content == "second" {
+			return "", errors.New("boom")
+		}
+		return "req-" + task.Content, nil
+	})
+	if dupes[0].Status != "updated" || dupes[0].RequestID != "req-first" || dupes[1].Status != "failed" {
+		t.Fatalf("expected duplicate IDs to keep separate results: %#v", dupes)
+	}
+
+	var out bytes.Buffer
+	ctx.Stdout = &out
+	ctx.Mode = output.ModeHuman
+	if err := writeBulkResults(ctx, "delete", "today", results); err != nil {
+		t.Fatalf("writeBulkResults: %v", err)
+	}
+	if !strings.Contains(out.String(), "failed b B: boom") || !strings.Contains(out.String(), "bulk delete done: succeeded=2 failed=1 total=3") {
+		t.Fatalf("unexpected human output: %q", out.String())
+	}
+}
+
+func TestTaskUpdateAndDeleteByFilter(t *testing.T) {
+	run := newMockCLIRunner(t, mockserver.Fixture{
+		Projects: []api.Project{{ID: "p1", Name: "Work"}},
+		Tasks: []api.Task{
+			{ID: "t1", Content: "Chase invoice", ProjectID: "p1", Labels: []string{"waiting"}},
+			{ID: "t2", Content: "Chase reply", ProjectID: "p1", Labels: []string{"waiting", "stale"}},
+			{ID: "t3", Content: "Write report", ProjectID: "p1"},
+		},
+	}).run
+
+	run(exitUsage, "task", "update", "--filter", "@waiting", "--add-label", "stale")
+	run(exitUsage, "task", "update", "--filter", "@waiting", "--content", "x", "--yes")
+	run(exitUsage, "task", "delete", "--filter", "@waiting")
+	run(exitUsage, "task", "delete", "--filter", "@waiting", "--yes", "--recursive")
+
+	var preview struct {
+		DryRun  bool `json:"dry_run"`
+		Payload struct {
+			Count int `json:"count"`
+			Tasks []struct {
+				ID     string   `json:"id"`
+				Labels []string `json:"labels"`
+			} `json:"tasks"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal([]byte(run(exitOK, "--json", "--dry-run", "task", "update", "--filter", "@waiting", "--add-label", "stale", "--priority", "p3", "--yes")), &preview); err != nil {
+		t.Fatalf("decode preview: %v", err)
+	}
+	if !preview.DryRun || preview.Payload.Count != 2 || strings.Join(preview.Payload.Tasks[0].Labels, ",") != "waiting,stale" {
+		t.Fatalf("unexpected preview: %#v", preview)
+	}
+
+	var report struct {
+		Action    string           `json:"action"`
+		Results   []bulkItemResult `json:"results"`
+		Succeeded int              `json:"succeeded"`
+		Failed    int              `json:"failed"`
+	}
+	if err := json.Unmarshal([]byte(run(exitOK, "--json", "task", "update", "--filter", "@waiting", "--add-label", "stale", "--priority", "p3", "--yes")), &report); err != nil {
+		t.Fatalf("decode report: %v", err)
+	}
+	if report.Action != "update" || report.Succeeded != 2 || report.Failed != 0 || report.Results[0].Status != "updated" {
+		t.Fatalf("unexpected report: %#v", report)
+	}
+	var tasks []api.Task
+	if err := json.Unmarshal([]byte(run(exitOK, "--json", "task", "list", "--project", "Work")), &tasks); err != nil {
+		t.Fatalf("decode tasks: %v", err)
+	}
+	for _, task := range tasks {
+		if task.ID == "t3" {
+			continue
+		}
+		if strings.Join(task.Labels, ",") != "waiting,stale" || task.Priority != 2 {
+			t.Fatalf("unexpected updated task: %#v", task)
+		}
+	}
+
+	run(exitOK, "task", "update", "id:t3", "--add-label", "urgent")
+	if out := run(exitOK, "--json", "task", "view", "id:t3"); !strings.Contains(out, `"urgent"`) {
+		t.Fatalf("expected single update to add label: %s", out)
+	}
+
+	if err := json.Unmarshal([]byte(run(exitOK, "--json", "task", "delete", "--filter", "@stale", "--yes")), &report); err != nil {
+		t.Fatalf("decode delete report: %v", err)
+	}
+	if report.Action != "delete" || report.Succeeded != 2 {
+		t.Fatalf("unexpected delete report: %#v", report)
+	}
+	if out := run(exitOK, "--plain", "task", "list", "--project", "Work"); strings.Contains(out, "Chase") || !strings.Contains(out, "Write report") {
+		t.Fatalf("unexpected remaining tasks: %q", out)
+	}
+}
+
+func TestTaskDeleteByFilterSkipsCascadedSubtasks(t *testing.T) {
+	run := newMockCLIRunner(t, mockserver.Fixture{
+		Projects: []api.Project{{ID: "p1", Name: "Work"}},
+		Tasks: []api.Task{
+			{ID: "t1", Content: "Parent", ProjectID: "p1", Labels: []string{"old"}},
+			{ID: "t2", Content: "Middle", ProjectID: "p1", ParentID: "t1"},
+			{ID: "t3", Content: "Grandchild", ProjectID: "p1", ParentID: "t2", Labels: []string{"old"}},
+			{ID: "t4", Content: "Keep", ProjectID: "p1"},
+		},
+	}).run
+
+	var report struct {
+		Results   []bulkItemResult `json:"results"`
+		Succeeded int              `json:"succeeded"`
+		Failed    int              `json:"failed"`
+	}
+	if err := json.Unmarshal([]byte(run(exitOK, "--json", "task", "delete", "--filter", "@old", "--yes")), &report); err != nil {
+		t.Fatalf("decode delete report: %v", err)
+	}
+	if report.Succeeded != 2 || report.Failed != 0 || len(report.Results) != 2 || report.Results[0].ID != "t1" || report.Results[1].Status != "deleted" {
+		t.Fatalf("unexpected delete report: %#v", report)
+	}
+	if out := run(exitOK, "--plain", "task", "list", "--project", "Work"); strings.Contains(out, "Grandchild") || !strings.Contains(out, "Keep") {
+		t.Fatalf("unexpected remaining tasks: %q", out)
+	}
+}
@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agisilaos/todoist-cli/internal/output"
+)
+
+type cacheEntryStatus struct {
+	Key        string `json:"key"`
+	Resource   string `json:"resource"`
+	Items      int    `json:"items"`
+	Bytes      int    `json:"bytes"`
+	FetchedAt  string `json:"fetched_at"`
+	AgeSeconds int    `json:"age_seconds"`
+	TTLSeconds int    `json:"ttl_seconds"`
+	Fresh      bool   `json:"fresh"`
+}
+
+func cacheCommand(ctx *Context, args []string) error {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printCacheHelp(ctx.Stdout)
+		return nil
+	}
+	switch args[0] {
+	case "status":
+		return cacheStatus(ctx, args[1:])
+	case "clear":
+		return cacheClear(ctx, args[1:])
+	default:
+		return &CodeError{Code: exitUsage, Err: fmt.Errorf("unknown cache subcommand: %s", args[0])}
+	}
+}
+
+func cacheStatus(ctx *Context, args []string) error {
+	fs := newFlagSet("cache status")
+	var help bool
+	bindHelpFlag(fs, &help)
+	if err := parseFlagSetInterspersed(fs, args); err != nil {
+		return &CodeError{Code: exitUsage, Err: err}
+	}
+	if help {
+		printCacheHelp(ctx.Stdout)
+		return nil
+	}
+	disk, err := loadDiskLookupCache(lookupCachePath(ctx))
+	if err != nil {
+		return err
+	}
+	items := buildCacheStatus(disk, applyNow(ctx))
+	if ctx.Mode == output.ModeJSON {
+		return output.WriteJSON(ctx.Stdout, items, output.Meta{Count: len(items)})
+	}
+	if ctx.Mode == output.ModeNDJSON {
+		return output.WriteNDJSONSlice(ctx.Stdout, items)
+	}
+	if len(items) == 0 {
+		if ctx.Mode == output.ModeHuman {
+			fmt.Fprintln(ctx.Stdout, "Cache is empty.")
+		}
+		return nil
+	}
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		state := "expired"
+		if item.Fresh {
+			state = "fresh"
+		}
+		age := (time.Duration(item.AgeSeconds) * time.Second).String()
+		ttl := (time.Duration(item.TTLSeconds) * time.Second).String()
+		rows = append(rows, []string{item.Key, strconv.Itoa(item.Items), strconv.Itoa(item.Bytes), age, ttl, state})
+	}
+	if ctx.Mode == output.ModePlain {
+		return output.WritePlain(ctx.Stdout, rows)
+	}
+	return output.WriteTable(ctx.Stdout, []string{"Key", "Items", "Bytes", "Age", "TTL", "State"}, rows)
+}
+
+func buildCacheStatus(disk diskLookupCache, now time.Time) []cacheEntryStatus {
+	items := make([]cacheEntryStatus, 0, len(disk.Entries))
+	for key, entry := range disk.Entries {
+		resource, _ := splitLookupCacheKey(key)
+		var list []json.RawMessage
+		_ = json.Unmarshal(entry.Data, &list)
+		age := now.Sub(entry.FetchedAt)
+		if age < 0 {
+			age = 0
+		}
+		ttl := lookupCacheTTLs[resource]
+		items = append(items, cacheEntryStatus{
+			Key:        key,
+			Resource:   resource,
+			Items:      len(list),
+			Bytes:      len(entry.Data),
+			FetchedAt:  entry.FetchedAt.UTC().Format(time.RFC3339),
+			AgeSeconds: int(age / time.Second),
+			TTLSeconds: int(ttl / time.Second),
+			Fresh:      age <= ttl,
+		})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Key < items[j].Key })
+	return items
+}
+
+func cacheClear(ctx *Context, args []string) error {
+	fs := newFlagSet("cache clear")
+	var resource string
+	var help bool
+	fs.StringVar(&resource, "resource", "", "Only clear one resource ("+strings.Join(lookupCacheResources(), "|")+")")
+	bindHelpFlag(fs, &help)
+	if err := parseFlagSetInterspersed(fs, args); err != nil {
+		return &CodeError{Code: exitUsage, Err: err}
+	}
+	if help {
+		printCacheHelp(ctx.Stdout)
+		return nil
+	}
+	if resource == "" && len(fs.Args()) > 0 {
+		resource = fs.Arg(0)
+	}
+	resource = strings.TrimSpace(resource)
+	if resource != "" {
+		if _, ok := lookupCacheTTLs[resource]; !ok {
+			return &CodeError{Code: exitUsage, Err: fmt.Errorf("unknown cache resource %q; expected one of %s", resource, strings.Join(lookupCacheResources(), ", "))}
+		}
+	}
+	if ctx.Global.DryRun {
+		return writeDryRun(ctx, "cache clear", map[string]any{"resource": resource})
+	}
+	if resource != "" {
+		invalidateLookupCache(ctx, resource)
+		return writeSimpleResult(ctx, "cleared", resource)
+	}
+	path := lookupCachePath(ctx)
+	if path == "" {
+		return &CodeError{Code: exitError, Err: errors.New("cache path is unknown")}
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	ctx.lookupCache = nil
+	return writeSimpleResult(ctx, "cleared", "all")
+}
+
+func printCacheHelp(out interface{ Write([]byte) (int, error) }) {
+	fmt.Fprint(out, `Usage:
+  todoist cache status
+  todoist cache clear [resource] [--resource <name>]
+
+Notes:
+  - Project, section, label, filter, collaborator and workspace lookups are cached per profile in <config dir>/cache/<profile>.json.
+  - TTLs: projects/sections 15m, labels/filters 30m, collaborators/workspaces 1h.
+  - Successful mutations through the CLI invalidate the affected resource.
+  - Use --refresh on any command to bypass cached entries and re-fetch, or --no-cache (TODOIST_NO_CACHE=1) to disable the cache.
+
+Examples:
+  todoist cache status
+  todoist cache clear
+  todoist cache clear labels
+  todoist --refresh task add --content "Plan" --project Work --section Next
+`)
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	"github.com/agisilaos/todoist-cli/internal/output"
+)
+
+func TestCacheStatusAndClear(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	var out bytes.Buffer
+	ctx := &Context{
+		Stdout:     &out,
+		Stderr:     &bytes.Buffer{},
+		Mode:       output.ModeJSON,
+		ConfigPath: filepath.Join(t.TempDir(), "config.json"),
+		Now:        func() time.Time { return now.Add(-20 * time.Minute) },
+	}
+	storeLookup(ctx, "projects", []api.Project{{ID: "p1", Name: "Work"}, {ID: "p2", Name: "Home"}})
+	ctx.Now = func() time.Time { return now }
+	storeLookup(ctx, "labels", []api.Label{{ID: "l1", Name: "next"}})
+
+	if err := cacheCommand(ctx, []string{"status"}); err != nil {
+		t.Fatalf("cache status: %v", err)
+	}
+	items := buildCacheStatus(mustLoadDiskLookupCache(t, ctx), now)
+	if len(items) != 2 || items[0].Key != "labels" || !items[0].Fresh || items[1].Items != 2 || items[1].Fresh {
+		t.Fatalf("unexpected status items: %#v", items)
+	}
+	if !strings.Contains(out.String(), `"age_seconds": 1200`) {
+		t.Fatalf("expected project age in output: %s", out.String())
+	}
+
+	if err := cacheCommand(ctx, []string{"clear", "labels"}); err != nil {
+		t.Fatalf("cache clear labels: %v", err)
+	}
+	if disk := mustLoadDiskLookupCache(t, ctx); len(disk.Entries) != 1 {
+		t.Fatalf("expected only projects to remain: %#v", disk.Entries)
+	}
+	if err := cacheCommand(ctx, []string{"clear", "--resource", "tasks"}); err == nil {
+		t.Fatalf("expected unknown resource error")
+	}
+	if err := cacheCommand(ctx, []string{"clear"}); err != nil {
+		t.Fatalf("cache clear: %v", err)
+	}
+	if disk := mustLoadDiskLookupCache(t, ctx); len(disk.Entries) != 0 {
+		t.Fatalf("expected empty cache: %#v", disk.Entries)
+	}
+}
+
+func mustLoadDiskLookupCache(t *testing.T, ctx *Context) diskLookupCache {
+	t.Helper()
+	disk, err := loadDiskLookupCache(lookupCachePath(ctx))
+	if err != nil {
+		t.Fatalf("load cache: %v", err)
+	}
+	return disk
+}
@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+)
+
+// parseCassetteEnv parses TODOIST_CASSETTE ("record:<path>" or
+// "replay:<path>"). An empty value disables cassettes.
+func parseCassetteEnv(value string) (*api.CassetteTransport, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	mode, path, ok := strings.Cut(value, ":")
+	if !ok || strings.TrimSpace(path) == "" {
+		return nil, fmt.Errorf("invalid TODOIST_CASSETTE %q; expected record:<path> or replay:<path>", value)
+	}
+	transport, err := api.NewCassetteTransport(strings.TrimSpace(mode), strings.TrimSpace(path), nil)
+	if err != nil {
+		return nil, fmt.Errorf("TODOIST_CASSETTE: %w", err)
+	}
+	return transport, nil
+}
+
+// applyCassette routes the client through the cassette. Replays never reach
+// the API, so they skip the rate limiter.
+func applyCassette(ctx *Context) {
+	if ctx.Cassette == nil || ctx.Client == nil || ctx.Client.HTTP == nil {
+		return
+	}
+	ctx.Client.HTTP.Transport = ctx.Cassette
+	if ctx.Cassette.Mode == api.CassetteReplay {
+		ctx.Client.Limiter = nil
+	}
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+)
+
+func TestCassetteRecordAndReplayProjectList(t *testing.T) {
+	dir := t.TempDir()
+	cassettePath := filepath.Join(dir, "projects.json")
+	configPath := filepath.Join(dir, "config.json")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"id":"p1","name":"Work"}],"next_cursor":""}`))
+	}))
+
+	t.Setenv("TODOIST_TOKEN", "secret")
+	t.Setenv("TODOIST_CASSETTE", "record:"+cassettePath)
+	var stdout, stderr bytes.Buffer
+	args := []string{"--config", configPath, "--base-url", ts.URL, "--no-cache", "--json", "project", "list"}
+	if code := Execute(args, &stdout, &stderr); code != exitOK {
+		t.Fatalf("record exit %d: %s", code, stderr.String())
+	}
+	ts.Close()
+
+	t.Setenv("TODOIST_TOKEN", "")
+	t.Setenv("TODOIST_CASSETTE", "replay:"+cassettePath)
+	stdout.Reset()
+	stderr.Reset()
+	if code := Execute(args, &stdout, &stderr); code != exitOK {
+		t.Fatalf("replay exit %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), `"name": "Work"`) {
+		t.Fatalf("unexpected replay output: %s", stdout.String())
+	}
+
+	stderr.Reset()
+	missArgs := []string{"--config", configPath, "--base-url", ts.URL, "--no-cache", "--json", "label", "list"}
+	if code := Execute(missArgs, &stdout, &stderr); code == exitOK {
+		t.Fatalf("expected unmatched request to fail")
+	}
+	if !strings.Contains(stderr.String(), "no recorded interaction") {
+		t.Fatalf("expected cassette miss error, got %q", stderr.String())
+	}
+}
+
+func TestCassetteReplaySkipsRateLimiter(t *testing.T) {
+	for _, mode := range []string{api.CassetteRecord, api.CassetteReplay} {
+		cassette := &api.CassetteTransport{Mode: mode}
+		ctx := &Context{Cassette: cassette, Client: api.NewClient("https://example.com", "token", time.Second)}
+		applyCassette(ctx)
+		if ctx.Client.HTTP.Transport != cassette {
+			t.Fatalf("%s: expected the cassette transport", mode)
+		}
+		if limited := ctx.Client.Limiter != nil; limited != (mode == api.CassetteRecord) {
+			t.Fatalf("%s: unexpected limiter %v", mode, ctx.Client.Limiter)
+		}
+	}
+}
+
+func TestParseCassetteEnv(t *testing.T) {
+	if transport, err := parseCassetteEnv(""); err != nil || transport != nil {
+		t.Fatalf("expected disabled cassette, got %v %v", transport, err)
+	}
+	if _, err := parseCassetteEnv("record"); err == nil {
+		t.Fatalf("expected missing path error")
+	}
+	if _, err := parseCassetteEnv("rewind:/tmp/x.json"); err == nil {
+		t.Fatalf("expected invalid mode error")
+	}
+}
@@ -51,6 +51,9 @@ type GlobalOptions struct {
 	BaseURL       string
 	Fuzzy         bool
 	NoFuzzy       bool
+	OfflineQueue  bool
+	NoCache       bool
+	RefreshCache  bool
 	ProgressJSONL string
 }
 
@@ -66,6 +69,10 @@ type Context struct {
 	ConfigPath string
 	Fuzzy      bool
 	Accessible bool
+	// OfflineQueue queues supported mutations on transport errors.
+	OfflineQueue bool
+	// Cassette records or replays HTTP traffic (TODOIST_CASSETTE).
+	Cassette *api.CassetteTransport
 
 	Token       string
 	TokenSource string
@@ -163,6 +170,12 @@ func parseGlobalFlags(args []string, stderr io.Writer) (GlobalOptions, []string,
 			opts.Fuzzy = true
 		case arg == "--no-fuzzy":
 			opts.NoFuzzy = true
+		case arg == "--offline-queue":
+			opts.OfflineQueue = true
+		case arg == "--no-cache":
+			opts.NoCache = true
+		case arg == "--refresh":
+			opts.RefreshCache = true
 		case strings.HasPrefix(arg, "--timeout="):
 			val := strings.TrimPrefix(arg, "--timeout=")
 			timeout, err := strconv.Atoi(val)
@@ -260,6 +273,7 @@ func loadConfig(ctx *Context) error {
 		cfg.TimeoutSeconds = ctx.Global.TimeoutSec
 	}
 	applyEnvInt("TODOIST_TABLE_WIDTH", &cfg.TableWidth, true)
+	applyEnvInt("TODOIST_BULK_CONCURRENCY", &cfg.BulkConcurrency, true)
 	if cfg.TimeoutSeconds == 0 {
 		cfg.TimeoutSeconds = 10
 	}
@@ -281,6 +295,15 @@ func loadConfig(ctx *Context) error {
 		accessible = true
 	}
 	ctx.Accessible = accessible
+	ctx.OfflineQueue = cfg.OfflineQueue || ctx.Global.OfflineQueue || parsePositiveEnvFlag("TODOIST_OFFLINE_QUEUE")
+	if parsePositiveEnvFlag("TODOIST_NO_CACHE") {
+		ctx.Global.NoCache = true
+	}
+	cassette, err := parseCassetteEnv(os.Getenv("TODOIST_CASSETTE"))
+	if err != nil {
+		return err
+	}
+	ctx.Cassette = cassette
 
 	token := os.Getenv("TODOIST_TOKEN")
 	if token != "" {
@@ -297,8 +320,13 @@ func loadConfig(ctx *Context) error {
 			ctx.TokenSource = "credentials"
 		}
 	}
+	if ctx.Token == "" && ctx.Cassette != nil && ctx.Cassette.Mode == api.CassetteReplay {
+		ctx.Token = "cassette-replay"
+		ctx.TokenSource = "cassette"
+	}
 	if ctx.Token != "" {
 		ctx.Client = api.NewClient(cfg.BaseURL, ctx.Token, time.Duration(cfg.TimeoutSeconds)*time.Second)
+		applyCassette(ctx)
 	}
 	return nil
 }
@@ -313,16 +341,28 @@ func isTTYFile(w io.Writer) bool {
 
 func ensureClient(ctx *Context) error {
 	if ctx.Token == "" {
-		return &CodeError{Code: exitAuth, Err: fmt.Errorf("missing auth token; run 'todoist auth login' or set TODOIST_TOKEN")}
+		return &CodeError{Code: exitAuth, Kind: errCodeAuthMissing, Err: fmt.Errorf("missing auth token; run 'todoist auth login' or set TODOIST_TOKEN")}
 	}
 	if ctx.Client == nil {
 		ctx.Client = api.NewClient(ctx.Config.BaseURL, ctx.Token, time.Duration(ctx.Config.TimeoutSeconds)*time.Second)
+		applyCassette(ctx)
+	}
+	if ctx.Client.OnThrottle == nil && ctx.Progress != nil {
+		ctx.Client.OnThrottle = func(event api.ThrottleEvent) {
+			emitProgress(ctx, "rate_limit_wait", map[string]any{
+				"path":    event.Path,
+				"reason":  event.Reason,
+				"wait_ms": event.Wait.Milliseconds(),
+			})
+		}
 	}
 	return nil
 }
 
 type CodeError struct {
 	Code int
+	// Kind overrides the error.code reported in --json mode; see errorCode.
+	Kind string
 	Err  error
 }
 
@@ -358,8 +398,10 @@ func toExitCode(err error) int {
 	return exitError
 }
 
+// requestContext carries no deadline of its own: --timeout is the client's
+// per-attempt HTTP timeout, which starts after rate-limit waits.
 func requestContext(ctx *Context) (context.Context, context.CancelFunc) {
-	return context.WithTimeout(context.Background(), time.Duration(ctx.Config.TimeoutSeconds)*time.Second)
+	return context.WithCancel(context.Background())
 }
 
 func parseIDOrName(input string) string {
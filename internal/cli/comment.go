@@ -77,11 +77,9 @@ func commentList(ctx *Context, args []string) error {
 	if cursor != "" {
 		query.Set("cursor", cursor)
 	}
-	allComments, next, err := fetchPaginated[api.Comment](ctx, "/comments", query, all)
-	if err != nil {
-		return err
-	}
-	return writeCommentList(ctx, allComments, next)
+	return listPaginated(ctx, "/comments", query, all, 0, func(comments []api.Comment, next string) error {
+		return writeCommentList(ctx, comments, next)
+	})
 }
 
 func commentAdd(ctx *Context, args []string) error {
@@ -125,13 +123,13 @@ func commentAdd(ctx *Context, args []string) error {
 		return writeDryRun(ctx, "comment add", body)
 	}
 	var comment api.Comment
-	reqCtx, cancel := requestContext(ctx)
-	reqID, err := ctx.Client.Post(reqCtx, "/comments", nil, body, &comment, true)
-	cancel()
+	queued, err := postOrQueue(ctx, "comment add", "/comments", body, &comment)
 	if err != nil {
 		return err
 	}
-	setRequestID(ctx, reqID)
+	if queued {
+		return writeQueuedResult(ctx)
+	}
 	return writeCommentList(ctx, []api.Comment{comment}, "")
 }
 
@@ -8,10 +8,10 @@ _todoist() {
   prev="${COMP_WORDS[COMP_CWORD-1]}"
   cmd="${COMP_WORDS[1]}"
 
-  local global_flags="--help -h --version --quiet -q --quiet-json --verbose -v --accessible --json --plain --ndjson --no-color --no-input --timeout --config --profile --dry-run -n --force -f --fuzzy --no-fuzzy --progress-jsonl --base-url"
+  local global_flags="--help -h --version --quiet -q --quiet-json --verbose -v --accessible --json --plain --ndjson --no-color --no-input --timeout --config --profile --dry-run -n --force -f --fuzzy --no-fuzzy --offline-queue --no-cache --refresh --progress-jsonl --base-url"
 
   if [[ ${COMP_CWORD} -eq 1 ]]; then
-    COMPREPLY=( $(compgen -W "today completed upcoming inbox add auth task filter project workspace section label comment reminder notification activity stats settings view agent completion doctor schema planner help ${global_flags}" -- "$cur") )
+    COMPREPLY=( $(compgen -W "today completed upcoming inbox add auth task filter project workspace section label comment reminder notification activity stats settings view agent completion doctor schema sync queue cache mock-server template date search tui planner help ${global_flags}" -- "$cur") )
     return 0
   fi
 
@@ -52,12 +52,12 @@ _todoist() {
       fi
       ;;
     task)
-      local subs="list ls add view show update move complete reopen delete rm del"
+      local subs="list ls add view show update move complete reopen delete rm del postpone snooze duplicate dup block unblock"
       if [[ ${COMP_CWORD} -eq 2 ]]; then
         COMPREPLY=( $(compgen -W "${subs}" -- "$cur") )
         return 0
       fi
-      local task_flags="--filter --project --section --parent --label --id --cursor --limit --all --all-projects --completed --completed-by --since --until --wide --content --description --priority --due --due-date --due-datetime --due-lang --duration --duration-unit --deadline --assignee --quick --natural --preset --sort --truncate-width --yes"
+      local task_flags="--filter --project --section --parent --label --add-label --remove-label --clear-labels --id --cursor --limit --max-items --all --all-projects --completed --completed-by --since --until --wide --content --description --priority --due --due-date --due-datetime --due-lang --duration --duration-unit --deadline --assignee --quick --natural --preset --sort --truncate-width --tree --recursive --by --to --with-subtasks --with-comments --with-reminders --blocked --actionable --show-unblocked --yes"
       COMPREPLY=( $(compgen -W "${task_flags} ${global_flags}" -- "$cur") )
       return 0
       ;;
@@ -106,7 +106,7 @@ _todoist() {
         COMPREPLY=( $(compgen -W "${subs}" -- "$cur") )
         return 0
       fi
-      local label_flags="--id --name --color --favorite --unfavorite"
+      local label_flags="--id --name --color --favorite --unfavorite --offline"
       COMPREPLY=( $(compgen -W "${label_flags} ${global_flags}" -- "$cur") )
       return 0
       ;;
@@ -179,12 +179,12 @@ _todoist() {
       return 0
       ;;
     agent)
-      local subs="plan apply run schedule examples planner status"
+      local subs="plan apply run undo policy schedule examples planner status"
       if [[ ${COMP_CWORD} -eq 2 ]]; then
         COMPREPLY=( $(compgen -W "${subs}" -- "$cur") )
         return 0
       fi
-      local agent_flags="--out --planner --policy --plan --confirm --instruction --on-error --plan-version --context-project --context-label --context-completed"
+      local agent_flags="--out --planner --policy --plan --apply-id --diff --allow-stale --strict --confirm --instruction --on-error --batch --plan-version --context-project --context-label --context-completed"
       COMPREPLY=( $(compgen -W "${agent_flags} ${global_flags}" -- "$cur") )
       return 0
       ;;
@@ -193,6 +193,58 @@ _todoist() {
       COMPREPLY=( $(compgen -W "${schema_flags} ${global_flags}" -- "$cur") )
       return 0
       ;;
+    sync)
+      COMPREPLY=( $(compgen -W "--full --status ${global_flags}" -- "$cur") )
+      return 0
+      ;;
+    queue)
+      local subs="list ls flush drop rm"
+      if [[ ${COMP_CWORD} -eq 2 ]]; then
+        COMPREPLY=( $(compgen -W "${subs}" -- "$cur") )
+        return 0
+      fi
+      COMPREPLY=( $(compgen -W "--id --all --yes ${global_flags}" -- "$cur") )
+      return 0
+      ;;
+    cache)
+      local subs="status clear"
+      if [[ ${COMP_CWORD} -eq 2 ]]; then
+        COMPREPLY=( $(compgen -W "${subs}" -- "$cur") )
+        return 0
+      fi
+      COMPREPLY=( $(compgen -W "--resource projects sections labels filters collaborators workspaces ${global_flags}" -- "$cur") )
+      return 0
+      ;;
+    mock-server)
+      COMPREPLY=( $(compgen -W "--addr --seed ${global_flags}" -- "$cur") )
+      return 0
+      ;;
+    template)
+      local subs="export apply"
+      if [[ ${COMP_CWORD} -eq 2 ]]; then
+        COMPREPLY=( $(compgen -W "${subs}" -- "$cur") )
+        return 0
+      fi
+      local template_flags="--project --task --name --var --due-offset --batch --on-error"
+      COMPREPLY=( $(compgen -W "${template_flags} ${global_flags}" -- "$cur") )
+      return 0
+      ;;
+    date)
+      if [[ ${COMP_CWORD} -eq 2 ]]; then
+        COMPREPLY=( $(compgen -W "parse" -- "$cur") )
+        return 0
+      fi
+      COMPREPLY=( $(compgen -W "--now --count ${global_flags}" -- "$cur") )
+      return 0
+      ;;
+    search)
+      COMPREPLY=( $(compgen -W "--limit --offline --wide ${global_flags}" -- "$cur") )
+      return 0
+      ;;
+    tui)
+      COMPREPLY=( $(compgen -W "--view --days ${global_flags}" -- "$cur") )
+      return 0
+      ;;
     planner)
       local planner_flags="--set --cmd"
       COMPREPLY=( $(compgen -W "${planner_flags} ${global_flags}" -- "$cur") )
@@ -209,7 +261,7 @@ complete -F _todoist todoist
 
 const zshCompletion = `#compdef todoist
 _arguments -C \
-  '1:command:(today completed upcoming inbox add auth task filter project workspace section label comment reminder notification activity stats settings view agent completion doctor schema planner help)' \
+  '1:command:(today completed upcoming inbox add auth task filter project workspace section label comment reminder notification activity stats settings view agent completion doctor schema sync queue cache mock-server template date search tui planner help)' \
   '*::subcmd:->subcmds'
 
 case $words[1] in
@@ -232,7 +284,7 @@ case $words[1] in
     _arguments '2:subcommand:(login status logout)' '*:flags:(--token-stdin --print-env --oauth --oauth-device --no-browser --client-id --oauth-authorize-url --oauth-token-url --oauth-device-url --oauth-listen --oauth-redirect-uri)'
     ;;
   task)
-    _arguments '2:subcommand:(list ls add view show update move complete reopen delete rm del)' '*:flags:(--filter --project --section --parent --label --id --cursor --limit --all --all-projects --completed --completed-by --since --until --wide --content --description --priority --due --due-date --due-datetime --due-lang --duration --duration-unit --deadline --assignee --quick --natural --full --yes -n --dry-run -f --force --accessible --json --plain --ndjson --no-color --no-input --quiet -q --quiet-json --verbose -v --timeout --config --profile --fuzzy --no-fuzzy --progress-jsonl --base-url)'
+    _arguments '2:subcommand:(list ls add view show update move complete reopen delete rm del postpone snooze duplicate dup block unblock)' '*:flags:(--filter --project --section --parent --label --add-label --remove-label --clear-labels --id --cursor --limit --max-items --all --all-projects --completed --completed-by --since --until --wide --content --description --priority --due --due-date --due-datetime --due-lang --duration --duration-unit --deadline --assignee --quick --natural --full --tree --recursive --by --to --with-subtasks --with-comments --with-reminders --blocked --actionable --show-unblocked --yes -n --dry-run -f --force --accessible --json --plain --ndjson --no-color --no-input --quiet -q --quiet-json --verbose -v --timeout --config --profile --fuzzy --no-fuzzy --offline-queue --no-cache --refresh --progress-jsonl --base-url)'
     ;;
   filter)
     _arguments '2:subcommand:(list ls show add update delete rm del)' '*:flags:(--id --name --query --color --favorite --unfavorite --yes)'
@@ -247,7 +299,7 @@ case $words[1] in
     _arguments '2:subcommand:(list ls add update delete rm del)' '*:flags:(--project --name --id)'
     ;;
   label)
-    _arguments '2:subcommand:(list ls add update delete rm del)' '*:flags:(--id --name --color --favorite --unfavorite)'
+    _arguments '2:subcommand:(list ls add update delete rm del)' '*:flags:(--id --name --color --favorite --unfavorite --offline)'
     ;;
   comment)
     _arguments '2:subcommand:(list ls add update delete rm del)' '*:flags:(--task --project --content --id)'
@@ -271,11 +323,35 @@ case $words[1] in
     _arguments
     ;;
   agent)
-    _arguments '2:subcommand:(plan apply run schedule examples planner status)' '*:flags:(--out --planner --policy --plan --confirm --instruction --on-error --plan-version --context-project --context-label --context-completed)'
+    _arguments '2:subcommand:(plan apply run undo policy schedule examples planner status)' '*:flags:(--out --planner --policy --plan --apply-id --diff --allow-stale --strict --confirm --instruction --on-error --batch --plan-version --context-project --context-label --context-completed)'
     ;;
   schema)
     _arguments '*:flags:(--name)'
     ;;
+  sync)
+    _arguments '*:flags:(--full --status)'
+    ;;
+  queue)
+    _arguments '2:subcommand:(list ls flush drop rm)' '*:flags:(--id --all --yes)'
+    ;;
+  cache)
+    _arguments '2:subcommand:(status clear)' '*:flags:(--resource)'
+    ;;
+  mock-server)
+    _arguments '*:flags:(--addr --seed)'
+    ;;
+  template)
+    _arguments '2:subcommand:(export apply)' '*:flags:(--project --task --name --var --due-offset --batch --on-error)'
+    ;;
+  date)
+    _arguments '2:subcommand:(parse)' '*:flags:(--now --count)'
+    ;;
+  search)
+    _arguments '*:flags:(--limit --offline --wide)'
+    ;;
+  tui)
+    _arguments '*:flags:(--view --days)'
+    ;;
   planner)
     _arguments '*:flags:(--set --cmd)'
     ;;
@@ -286,13 +362,13 @@ case $words[1] in
     _arguments '2:shell:(bash zsh fish)'
     ;;
   help)
-    _arguments '2:command:(today completed upcoming inbox add auth task project section label comment reminder notification activity stats settings view agent completion doctor schema planner help)'
+    _arguments '2:command:(today completed upcoming inbox add auth task project section label comment reminder notification activity stats settings view agent completion doctor schema sync queue cache mock-server template date search tui planner help)'
     ;;
 esac
 `
 
 const fishCompletion = `# todoist completion
-complete -c todoist -f -n '__fish_use_subcommand' -a 'today completed upcoming inbox add auth task filter project workspace section label comment reminder notification activity stats settings view agent completion doctor schema planner help'
+complete -c todoist -f -n '__fish_use_subcommand' -a 'today completed upcoming inbox add auth task filter project workspace section label comment reminder notification activity stats settings view agent completion doctor schema sync queue cache mock-server template date search tui planner help'
 
 # Global flags
 complete -c todoist -s h -l help -d "Show help"
@@ -313,6 +389,9 @@ complete -c todoist -s n -l dry-run -d "Preview changes without applying"
 complete -c todoist -s f -l force -d "Skip confirmation prompts"
 complete -c todoist -l fuzzy -d "Enable fuzzy name resolution"
 complete -c todoist -l no-fuzzy -d "Disable fuzzy name resolution"
+complete -c todoist -l offline-queue -d "Queue adds that fail with a network error"
+complete -c todoist -l no-cache -d "Disable the on-disk lookup cache"
+complete -c todoist -l refresh -d "Re-fetch cached lookups"
 complete -c todoist -l progress-jsonl -d "Emit progress events as JSONL"
 complete -c todoist -l base-url -d "Override API base URL"
 
@@ -331,8 +410,8 @@ complete -c todoist -n '__fish_seen_subcommand_from auth; and contains login (co
 complete -c todoist -n '__fish_seen_subcommand_from auth; and contains login (commandline -opc)' -l oauth-redirect-uri -d "OAuth redirect URI"
 
 # task
-complete -c todoist -n '__fish_seen_subcommand_from task; and __fish_use_subcommand' -a 'list ls add view show update move complete reopen delete rm del'
-complete -c todoist -n '__fish_seen_subcommand_from task' -l filter -l project -l section -l parent -l label -l id -l cursor -l limit -l all -l all-projects -l completed -l completed-by -l since -l until -l wide -l content -l description -l priority -l due -l due-date -l due-datetime -l due-lang -l duration -l duration-unit -l deadline -l assignee -l full -l yes
+complete -c todoist -n '__fish_seen_subcommand_from task; and __fish_use_subcommand' -a 'list ls add view show update move complete reopen delete rm del postpone snooze duplicate dup block unblock'
+complete -c todoist -n '__fish_seen_subcommand_from task' -l filter -l project -l section -l parent -l label -l add-label -l remove-label -l clear-labels -l id -l cursor -l limit -l max-items -l all -l all-projects -l completed -l completed-by -l since -l until -l wide -l content -l description -l priority -l due -l due-date -l due-datetime -l due-lang -l duration -l duration-unit -l deadline -l assignee -l full -l tree -l recursive -l by -l to -l with-subtasks -l with-comments -l with-reminders -l blocked -l actionable -l show-unblocked -l yes
 
 # project
 complete -c todoist -n '__fish_seen_subcommand_from project; and __fish_use_subcommand' -a 'list ls view show browse collaborators add create update move archive unarchive delete rm del'
@@ -351,7 +430,7 @@ complete -c todoist -n '__fish_seen_subcommand_from section' -l project -l name
 
 # label
 complete -c todoist -n '__fish_seen_subcommand_from label; and __fish_use_subcommand' -a 'list ls add update delete rm del'
-complete -c todoist -n '__fish_seen_subcommand_from label' -l id -l name -l color -l favorite -l unfavorite
+complete -c todoist -n '__fish_seen_subcommand_from label' -l id -l name -l color -l favorite -l unfavorite -l offline
 
 # comment
 complete -c todoist -n '__fish_seen_subcommand_from comment; and __fish_use_subcommand' -a 'list ls add update delete rm del'
@@ -395,8 +474,8 @@ complete -c todoist -n '__fish_seen_subcommand_from upcoming' -l days -l project
 complete -c todoist -n '__fish_seen_subcommand_from add' -l content -l description -l project -l section -l parent -l label -l priority -l due -l due-date -l due-datetime -l due-lang -l duration -l duration-unit -l deadline -l assignee -l strict
 
 # agent
-complete -c todoist -n '__fish_seen_subcommand_from agent; and __fish_use_subcommand' -a 'plan apply run schedule examples planner status'
-complete -c todoist -n '__fish_seen_subcommand_from agent' -l out -l planner -l policy -l plan -l confirm -l instruction -l on-error -l plan-version -l context-project -l context-label -l context-completed
+complete -c todoist -n '__fish_seen_subcommand_from agent; and __fish_use_subcommand' -a 'plan apply run undo policy schedule examples planner status'
+complete -c todoist -n '__fish_seen_subcommand_from agent' -l out -l planner -l policy -l plan -l apply-id -l diff -l allow-stale -l strict -l confirm -l instruction -l on-error -l batch -l plan-version -l context-project -l context-label -l context-completed
 
 # doctor
 complete -c todoist -n '__fish_seen_subcommand_from doctor' -l strict
@@ -404,6 +483,33 @@ complete -c todoist -n '__fish_seen_subcommand_from doctor' -l strict
 # schema
 complete -c todoist -n '__fish_seen_subcommand_from schema' -l name
 
+# sync
+complete -c todoist -n '__fish_seen_subcommand_from sync' -l full -l status
+
+# queue
+complete -c todoist -n '__fish_seen_subcommand_from queue; and __fish_use_subcommand' -a 'list flush drop'
+complete -c todoist -n '__fish_seen_subcommand_from queue' -l id -l all -l yes
+
+# cache
+complete -c todoist -n '__fish_seen_subcommand_from cache; and __fish_use_subcommand' -a 'status clear'
+complete -c todoist -n '__fish_seen_subcommand_from cache' -l resource
+
+# mock-server
+complete -c todoist -n '__fish_seen_subcommand_from mock-server' -l addr -l seed
+
+# template
+complete -c todoist -n '__fish_seen_subcommand_from template; and __fish_use_subcommand' -a 'export apply'
+complete -c todoist -n '__fish_seen_subcommand_from template' -l project -l task -l name -l var -l due-offset -l batch -l on-error
+# date
+complete -c todoist -n '__fish_seen_subcommand_from date; and __fish_use_subcommand' -a 'parse'
+complete -c todoist -n '__fish_seen_subcommand_from date' -l now -l count
+
+# search
+complete -c todoist -n '__fish_seen_subcommand_from search' -l limit -l offline -l wide
+
+# tui
+complete -c todoist -n '__fish_seen_subcommand_from tui' -l view -l days
+
 # planner
 complete -c todoist -n '__fish_seen_subcommand_from planner' -l set
 complete -c todoist -n '__fish_seen_subcommand_from planner' -l cmd
@@ -0,0 +1,172 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	appdates "github.com/agisilaos/todoist-cli/internal/app/dates"
+	"github.com/agisilaos/todoist-cli/internal/output"
+)
+
+func dateCommand(ctx *Context, args []string) error {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printDateHelp(ctx.Stdout)
+		return nil
+	}
+	switch args[0] {
+	case "parse":
+		return dateParse(ctx, args[1:])
+	default:
+		return &CodeError{Code: exitUsage, Err: fmt.Errorf("unknown date subcommand: %s", args[0])}
+	}
+}
+
+func dateParse(ctx *Context, args []string) error {
+	fs := newFlagSet("date parse")
+	var nowValue string
+	var count int
+	var help bool
+	fs.StringVar(&nowValue, "now", "", "Reference time (RFC3339, YYYY-MM-DDTHH:MM or YYYY-MM-DD)")
+	fs.IntVar(&count, "count", 5, "Occurrences to list for recurring dues")
+	bindHelpFlag(fs, &help)
+	if err := parseFlagSetInterspersed(fs, args); err != nil {
+		return &CodeError{Code: exitUsage, Err: err}
+	}
+	if help {
+		printDateHelp(ctx.Stdout)
+		return nil
+	}
+	expr := strings.TrimSpace(strings.Join(fs.Args(), " "))
+	if expr == "" {
+		printDateHelp(ctx.Stderr)
+		return &CodeError{Code: exitUsage, Err: errors.New("date parse requires an expression")}
+	}
+	if count < 1 {
+		return &CodeError{Code: exitUsage, Err: errors.New("--count must be at least 1")}
+	}
+	now := applyNow(ctx)
+	if nowValue != "" {
+		parsed, err := parseReferenceTime(nowValue)
+		if err != nil {
+			return &CodeError{Code: exitUsage, Err: err}
+		}
+		now = parsed
+	}
+	due, err := appdates.Parse(expr, now)
+	if err != nil {
+		return &CodeError{Code: exitUsage, Err: err}
+	}
+	preview := due.Preview(count)
+	switch ctx.Mode {
+	case output.ModeJSON:
+		return output.WriteJSON(ctx.Stdout, preview, output.Meta{Count: len(preview.Occurrences)})
+	case output.ModeNDJSON:
+		return output.WriteNDJSONSlice(ctx.Stdout, []appdates.Preview{preview})
+	case output.ModePlain:
+		rows := [][]string{
+			{"date", preview.Date},
+			{"datetime", preview.Datetime},
+			{"is_recurring", strconv.FormatBool(preview.IsRecurring)},
+			{"description", preview.Description},
+			{"rule", preview.Rule},
+		}
+		for _, occurrence := range preview.Occurrences {
+			rows = append(rows, []string{"occurrence", occurrence})
+		}
+		return output.WritePlain(ctx.Stdout, rows)
+	}
+	fmt.Fprintf(ctx.Stdout, "Input: %s\n", preview.Input)
+	fmt.Fprintf(ctx.Stdout, "Due: %s\n", appdates.FormatDay(due.Date, due.HasTime))
+	if preview.IsRecurring {
+		fmt.Fprintf(ctx.Stdout, "Recurrence: %s\n", preview.Description)
+		if preview.Rule != "" {
+			fmt.Fprintf(ctx.Stdout, "Rule: %s\n", preview.Rule)
+		}
+		fmt.Fprintln(ctx.Stdout, "Next:")
+		for _, at := range due.Occurrences(count) {
+			fmt.Fprintf(ctx.Stdout, "  %s\n", appdates.FormatDay(at, due.HasTime))
+		}
+	}
+	return nil
+}
+
+func parseReferenceTime(value string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04", time.DateOnly} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid --now %q (use RFC3339, YYYY-MM-DDTHH:MM or YYYY-MM-DD)", value)
+}
+
+// resolvedDue is the dry-run view of a --due string: the local parse when it
+// succeeds, otherwise the reason Todoist will be left to interpret it.
+type resolvedDue struct {
+	String   string            `json:"string"`
+	Resolved bool              `json:"resolved"`
+	Preview  *appdates.Preview `json:"preview,omitempty"`
+	Error    string            `json:"error,omitempty"`
+}
+
+func resolveDueString(ctx *Context, value string) resolvedDue {
+	out := resolvedDue{String: value}
+	due, err := appdates.Parse(value, applyNow(ctx))
+	if err != nil {
+		out.Error = err.Error()
+		return out
+	}
+	preview := due.Preview(3)
+	out.Resolved = true
+	out.Preview = &preview
+	return out
+}
+
+// writeTaskAddDryRun is writeDryRun plus the locally resolved due string, so
+// the preview shows the date Todoist is expected to pick.
+func writeTaskAddDryRun(ctx *Context, payload any, dueString string) error {
+	if strings.TrimSpace(dueString) == "" {
+		return writeDryRun(ctx, "task add", payload)
+	}
+	due := resolveDueString(ctx, dueString)
+	if ctx.Mode == output.ModeJSON {
+		return output.WriteJSON(ctx.Stdout, map[string]any{
+			"action":  "task add",
+			"payload": payload,
+			"due":     due,
+			"dry_run": true,
+		}, output.Meta{})
+	}
+	fmt.Fprintln(ctx.Stdout, "dry run: task add")
+	if !due.Resolved {
+		fmt.Fprintf(ctx.Stdout, "due: %q (not recognized locally: %s; Todoist will interpret it)\n", due.String, due.Error)
+		return nil
+	}
+	line := fmt.Sprintf("due: %q -> %s", due.String, due.Preview.Description)
+	if due.Preview.IsRecurring && len(due.Preview.Occurrences) > 0 {
+		line += ", next " + strings.Join(due.Preview.Occurrences, ", ")
+	}
+	fmt.Fprintln(ctx.Stdout, line)
+	return nil
+}
+
+func printDateHelp(out interface{ Write([]byte) (int, error) }) {
+	fmt.Fprint(out, `Usage:
+  todoist date parse <expression> [--now <time>] [--count <n>]
+
+Notes:
+  - Parses due strings locally, without calling the API, and shows the resolved date, datetime, recurrence rule and next occurrences.
+  - Covers common English: today, tomorrow, weekdays, next week/month, in 3 days, mar 10, 15th, at 9, 9:30pm, noon,
+    every day/weekday/other week, every mon, wed and fri, every 1st and 15th, every jan 5, every! 3 days, starting/until <date>.
+  - Weekday names resolve to the next such day after today; "next <weekday>" means that day in the following week.
+  - --now sets the reference time (default: now); its zone is used for the result.
+  - task add --dry-run shows the same resolution for --due.
+
+Examples:
+  todoist date parse "every other weekday at 9"
+  todoist date parse "next friday at 14:00" --now 2026-10-17T10:00
+  todoist date parse "every 1st and 15th" --count 6 --json
+`)
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	"github.com/agisilaos/todoist-cli/internal/mockserver"
+	"github.com/agisilaos/todoist-cli/internal/output"
+)
+
+func TestDateParseCommand(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	run := func(wantCode int, args ...string) string {
+		t.Helper()
+		var stdout, stderr bytes.Buffer
+		full := append([]string{"--config", configPath}, args...)
+		if code := Execute(full, &stdout, &stderr); code != wantCode {
+			t.Fatalf("%v exit %d: %s", args, code, stderr.String())
+		}
+		return stdout.String()
+	}
+
+	var preview struct {
+		Date        string   `json:"date"`
+		Datetime    string   `json:"datetime"`
+		IsRecurring bool     `json:"is_recurring"`
+		Description string   `json:"description"`
+		Occurrences []string `json:"occurrences"`
+	}
+	out := run(exitOK, "--json", "date", "parse", "every other weekday at 9", "--now", "2026-10-17T10:30", "--count", "3")
+	if err := json.Unmarshal([]byte(out), &preview); err != nil {
+		t.Fatalf("decode preview: %v (%s)", err, out)
+	}
+	if preview.Datetime != "2026-10-19T09:00:00" || !preview.IsRecurring || preview.Description != "every 2 weekdays at 09:00" {
+		t.Fatalf("unexpected preview: %#v", preview)
+	}
+	if strings.Join(preview.Occurrences, ",") != "2026-10-19T09:00:00,2026-10-21T09:00:00,2026-10-23T09:00:00" {
+		t.Fatalf("unexpected occurrences: %v", preview.Occurrences)
+	}
+
+	if out := run(exitOK, "--plain", "date", "parse", "next", "friday", "--now", "2026-10-17"); !strings.Contains(out, "date\t2026-10-23") {
+		t.Fatalf("unexpected plain output: %q", out)
+	}
+	run(exitUsage, "date", "parse", "someday", "--now", "2026-10-17")
+	run(exitUsage, "date", "parse", "today", "--now", "yesterday-ish")
+	run(exitUsage, "date", "parse")
+}
+
+func TestTaskAddDryRunResolvesDue(t *testing.T) {
+	stdout := newMockCLIRunner(t, mockserver.Fixture{
+		Projects: []api.Project{{ID: "p1", Name: "Work"}},
+	}).run(exitOK, "--json", "--dry-run", "task", "add", "Standup", "--due", "every weekday at 9")
+	var result struct {
+		Payload map[string]any `json:"payload"`
+		Due     resolvedDue    `json:"due"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &result); err != nil {
+		t.Fatalf("decode dry run: %v (%s)", err, stdout)
+	}
+	if result.Payload["due_string"] != "every weekday at 9" {
+		t.Fatalf("expected raw due_string in payload, got %#v", result.Payload)
+	}
+	if !result.Due.Resolved || result.Due.Preview == nil || result.Due.Preview.Rule != "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR;BYHOUR=9;BYMINUTE=0" {
+		t.Fatalf("unexpected resolved due: %#v", result.Due)
+	}
+
+	var out bytes.Buffer
+	ctx := &Context{Stdout: &out, Mode: output.ModeHuman, Now: func() time.Time { return time.Date(2026, 10, 17, 10, 30, 0, 0, time.UTC) }}
+	if err := writeTaskAddDryRun(ctx, map[string]any{}, "tomorrow at 9"); err != nil {
+		t.Fatalf("writeTaskAddDryRun: %v", err)
+	}
+	if !strings.Contains(out.String(), `due: "tomorrow at 9" -> Sun 2026-10-18 09:00`) {
+		t.Fatalf("unexpected human dry run: %q", out.String())
+	}
+	out.Reset()
+	if err := writeTaskAddDryRun(ctx, map[string]any{}, "whenever"); err != nil {
+		t.Fatalf("writeTaskAddDryRun: %v", err)
+	}
+	if !strings.Contains(out.String(), "not recognized locally") {
+		t.Fatalf("expected unresolved note, got %q", out.String())
+	}
+}
@@ -49,6 +49,22 @@ func dispatch(ctx *Context, args []string) int {
 		err = inboxCommand(ctx, rest)
 	case "schema":
 		err = schemaCommand(ctx, rest)
+	case "sync":
+		err = syncCommand(ctx, rest)
+	case "queue":
+		err = queueCommand(ctx, rest)
+	case "cache":
+		err = cacheCommand(ctx, rest)
+	case "mock-server":
+		err = mockServerCommand(ctx, rest)
+	case "template":
+		err = templateCommand(ctx, rest)
+	case "date":
+		err = dateCommand(ctx, rest)
+	case "search":
+		err = searchCommand(ctx, rest)
+	case "tui":
+		err = tuiCommand(ctx, rest)
 	case "planner":
 		err = agentPlanner(ctx, rest)
 	case "add":
@@ -71,6 +87,11 @@ func dispatch(ctx *Context, args []string) int {
 		printRootHelp(ctx.Stderr)
 		return exitUsage
 	}
+	if output.IsClosedPipe(err) {
+		// The reader went away (for example `| head`); there is nobody left
+		// to report to and the output it wanted was delivered.
+		return exitOK
+	}
 	if err != nil {
 		writeError(ctx, err)
 	}
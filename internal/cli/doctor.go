@@ -205,6 +205,7 @@ func checkPolicyFile(ctx *Context) doctorCheck {
 	check.Details["allow_count"] = len(policy.AllowActionTypes)
 	check.Details["deny_count"] = len(policy.DenyActionTypes)
 	check.Details["max_destructive_actions"] = policy.MaxDestructiveActions
+	check.Details["rule_count"] = len(policy.Rules)
 	return check
 }
 
@@ -0,0 +1,172 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	appagent "github.com/agisilaos/todoist-cli/internal/app/agent"
+)
+
+// Stable error.code values for the --json error envelope. Scripts branch on
+// these, so existing values must never change meaning.
+const (
+	errCodeUsage           = "usage"
+	errCodeAuthMissing     = "auth_missing"
+	errCodeAuthInvalid     = "auth_invalid"
+	errCodeRefAmbiguous    = "ref_ambiguous"
+	errCodeRefNotFound     = "ref_not_found"
+	errCodeConflict        = "conflict"
+	errCodeRateLimited     = "rate_limited"
+	errCodeAPIValidation   = "api_validation"
+	errCodeAPIServer       = "api_server"
+	errCodeAPIError        = "api_error"
+	errCodeNetwork         = "network"
+	errCodeTimeout         = "timeout"
+	errCodePolicyDenied    = "policy_denied"
+	errCodePlanStale       = "plan_stale"
+	errCodeConfirmMismatch = "confirm_mismatch"
+	errCodeInternal        = "internal"
+)
+
+var errorCodes = []string{
+	errCodeUsage,
+	errCodeAuthMissing,
+	errCodeAuthInvalid,
+	errCodeRefAmbiguous,
+	errCodeRefNotFound,
+	errCodeConflict,
+	errCodeRateLimited,
+	errCodeAPIValidation,
+	errCodeAPIServer,
+	errCodeAPIError,
+	errCodeNetwork,
+	errCodeTimeout,
+	errCodePolicyDenied,
+	errCodePlanStale,
+	errCodeConfirmMismatch,
+	errCodeInternal,
+}
+
+type errorBody struct {
+	Code     string         `json:"code"`
+	Message  string         `json:"message"`
+	ExitCode int            `json:"exit_code"`
+	Details  map[string]any `json:"details,omitempty"`
+}
+
+func newErrorBody(err error) errorBody {
+	return errorBody{
+		Code:     errorCode(err),
+		Message:  err.Error(),
+		ExitCode: toExitCode(err),
+		Details:  errorDetails(err),
+	}
+}
+
+func errorCode(err error) string {
+	var violation *policyViolation
+	if errors.As(err, &violation) {
+		return errCodePolicyDenied
+	}
+	var ambiguousErr *AmbiguousMatchError
+	if errors.As(err, &ambiguousErr) {
+		return errCodeRefAmbiguous
+	}
+	var staleErr *appagent.StaleError
+	if errors.As(err, &staleErr) {
+		return errCodePlanStale
+	}
+	var codeErr *CodeError
+	if errors.As(err, &codeErr) && codeErr.Kind != "" {
+		return codeErr.Kind
+	}
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.Status == http.StatusUnauthorized || apiErr.Status == http.StatusForbidden:
+			return errCodeAuthInvalid
+		case apiErr.Status == http.StatusNotFound:
+			return errCodeRefNotFound
+		case apiErr.Status == http.StatusConflict:
+			return errCodeConflict
+		case apiErr.Status == http.StatusTooManyRequests:
+			return errCodeRateLimited
+		case apiErr.Status == http.StatusBadRequest || apiErr.Status == http.StatusUnprocessableEntity:
+			return errCodeAPIValidation
+		case apiErr.Status >= 500:
+			return errCodeAPIServer
+		default:
+			return errCodeAPIError
+		}
+	}
+	if errors.Is(err, appagent.ErrConfirmMismatch) {
+		return errCodeConfirmMismatch
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return errCodeTimeout
+	}
+	if api.IsTransportError(err) {
+		return errCodeNetwork
+	}
+	if codeErr != nil {
+		switch codeErr.Code {
+		case exitUsage:
+			return errCodeUsage
+		case exitAuth:
+			return errCodeAuthInvalid
+		case exitNotFound:
+			return errCodeRefNotFound
+		case exitConflict:
+			return errCodeConflict
+		}
+	}
+	return errCodeInternal
+}
+
+func errorDetails(err error) map[string]any {
+	var violation *policyViolation
+	if errors.As(err, &violation) {
+		details := map[string]any{"type": "policy_violation", "rule": violation.Rule}
+		if violation.Action > 0 {
+			details["action"] = violation.Action
+		}
+		if violation.ActionType != "" {
+			details["action_type"] = violation.ActionType
+		}
+		if violation.Reason != "" {
+			details["reason"] = violation.Reason
+		}
+		if violation.Limit > 0 {
+			details["limit"] = violation.Limit
+			details["count"] = violation.Count
+		}
+		return details
+	}
+	var ambiguousErr *AmbiguousMatchError
+	if errors.As(err, &ambiguousErr) {
+		return map[string]any{
+			"type":    "ambiguous_match",
+			"entity":  ambiguousErr.Entity,
+			"input":   ambiguousErr.Input,
+			"matches": ambiguousErr.Matches,
+		}
+	}
+	var staleErr *appagent.StaleError
+	if errors.As(err, &staleErr) {
+		return map[string]any{"type": "plan_stale", "targets": staleErr.Targets}
+	}
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) {
+		details := map[string]any{"type": "api_error", "status": apiErr.Status}
+		if apiErr.RequestID != "" {
+			details["request_id"] = apiErr.RequestID
+		}
+		if tag := apiErr.Tag(); tag != "" {
+			details["error_tag"] = tag
+		}
+		return details
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	appagent "github.com/agisilaos/todoist-cli/internal/app/agent"
+	"github.com/agisilaos/todoist-cli/internal/output"
+)
+
+func TestErrorCodeTaxonomy(t *testing.T) {
+	cases := []struct {
+		err  error
+		want string
+	}{
+		{&CodeError{Code: exitUsage, Err: errors.New("bad flag")}, errCodeUsage},
+		{&CodeError{Code: exitUsage, Err: &AmbiguousMatchError{Entity: "project", Input: "ho"}}, errCodeRefAmbiguous},
+		{&CodeError{Code: exitNotFound, Err: errors.New("task not found")}, errCodeRefNotFound},
+		{&api.APIError{Status: 401}, errCodeAuthInvalid},
+		{&api.APIError{Status: 404}, errCodeRefNotFound},
+		{&api.APIError{Status: 429}, errCodeRateLimited},
+		{fmt.Errorf("add task: %w", &api.APIError{Status: 400, Message: "bad"}), errCodeAPIValidation},
+		{&api.APIError{Status: 503}, errCodeAPIServer},
+		{fmt.Errorf("apply: %w", appagent.ErrConfirmMismatch), errCodeConfirmMismatch},
+		{&url.Error{Op: "Get", URL: "https://example.com", Err: errors.New("connection refused")}, errCodeNetwork},
+		{fmt.Errorf("list: %w", context.DeadlineExceeded), errCodeTimeout},
+		{errors.New("boom"), errCodeInternal},
+	}
+	for _, tc := range cases {
+		if got := errorCode(tc.err); got != tc.want {
+			t.Fatalf("errorCode(%v)=%q, want %q", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestWriteErrorJSONIncludesPolicyViolation(t *testing.T) {
+	plan := Plan{Actions: []Action{{Type: "task_delete"}, {Type: "project_delete"}}}
+	_, err := enforceAgentPolicy(plan, &agentPolicy{MaxDestructiveActions: 1}, nil)
+	if err == nil || err.Error() != "policy exceeded max destructive actions: 2 > 1" {
+		t.Fatalf("unexpected policy error: %v", err)
+	}
+	var stderr bytes.Buffer
+	writeError(&Context{Stderr: &stderr, Mode: output.ModeJSON}, err)
+	var got struct {
+		Error errorBody `json:"error"`
+	}
+	if err := json.Unmarshal(stderr.Bytes(), &got); err != nil {
+		t.Fatalf("decode envelope: %v", err)
+	}
+	if got.Error.Code != errCodePolicyDenied || got.Error.ExitCode != exitUsage {
+		t.Fatalf("unexpected envelope: %#v", got.Error)
+	}
+	if got.Error.Details["rule"] != "max_destructive_actions" || got.Error.Details["limit"] != float64(1) || got.Error.Details["count"] != float64(2) {
+		t.Fatalf("unexpected policy details: %#v", got.Error.Details)
+	}
+}
+
+func TestMissingTokenReportsAuthMissing(t *testing.T) {
+	t.Setenv("TODOIST_TOKEN", "")
+	var stdout, stderr bytes.Buffer
+	args := []string{"--config", filepath.Join(t.TempDir(), "config.json"), "--json", "project", "list"}
+	if code := Execute(args, &stdout, &stderr); code != exitAuth {
+		t.Fatalf("expected exit %d, got %d: %s", exitAuth, code, stderr.String())
+	}
+	var got struct {
+		Error errorBody `json:"error"`
+	}
+	if err := json.Unmarshal(stderr.Bytes(), &got); err != nil {
+		t.Fatalf("decode envelope: %v (%s)", err, stderr.String())
+	}
+	if got.Error.Code != errCodeAuthMissing {
+		t.Fatalf("expected auth_missing, got %#v", got.Error)
+	}
+}
@@ -50,7 +50,7 @@ func filterList(ctx *Context, args []string) error {
 	if err := ensureClient(ctx); err != nil {
 		return err
 	}
-	filters, reqID, err := listAllFilters(ctx)
+	filters, reqID, err := fetchAllFilters(ctx)
 	if err != nil {
 		return err
 	}
@@ -129,6 +129,7 @@ func filterAdd(ctx *Context, args []string) error {
 		return err
 	}
 	setRequestID(ctx, reqID)
+	invalidateLookupCache(ctx, "filters")
 	return writeFilterList(ctx, []api.Filter{filter})
 }
 
@@ -188,6 +189,7 @@ func filterUpdate(ctx *Context, args []string) error {
 		return err
 	}
 	setRequestID(ctx, reqID)
+	invalidateLookupCache(ctx, "filters")
 	return writeFilterList(ctx, []api.Filter{out})
 }
 
@@ -231,6 +233,7 @@ func filterDelete(ctx *Context, args []string) error {
 		return err
 	}
 	setRequestID(ctx, reqID)
+	invalidateLookupCache(ctx, "filters")
 	return writeSimpleResult(ctx, "deleted", filter.ID)
 }
 
@@ -272,7 +275,19 @@ func resolveFilterRef(ctx *Context, ref string) (api.Filter, error) {
 	return api.Filter{}, &CodeError{Code: exitNotFound, Err: fmt.Errorf("filter %q not found", result.Normalized)}
 }
 
+// listAllFilters returns every filter for resolving references and names; it
+// may be served from the on-disk lookup cache.
 func listAllFilters(ctx *Context) ([]api.Filter, string, error) {
+	if cache := ctx.cache(); cache != nil && !cache.filtersLoaded {
+		if filters, ok := persistedLookup[api.Filter](ctx, "filters"); ok {
+			return filters, ctx.RequestID, nil
+		}
+	}
+	return fetchAllFilters(ctx)
+}
+
+// fetchAllFilters returns the live filter list, fetched once per command.
+func fetchAllFilters(ctx *Context) ([]api.Filter, string, error) {
 	if cache := ctx.cache(); cache != nil && cache.filtersLoaded {
 		return cloneSlice(cache.filters), ctx.RequestID, nil
 	}
@@ -287,6 +302,7 @@ func listAllFilters(ctx *Context) ([]api.Filter, string, error) {
 		cache.filters = cloneSlice(filters)
 		cache.filtersLoaded = true
 	}
+	storeLookup(ctx, "filters", filters)
 	return filters, reqID, nil
 }
 
@@ -29,9 +29,17 @@ Commands:
   settings    Manage user settings
   view        Open Todoist web URLs in CLI
   agent       Plan and apply agentic actions
+  template    Export and apply project templates
+  date        Preview how due strings resolve
+  search      Full-text search over tasks, descriptions and comments
+  tui         Interactive full-screen browser for projects and tasks
   completion  Shell completion
   doctor      Run environment and configuration checks
   schema      Show JSON schemas for outputs
+  sync        Incrementally sync a local snapshot
+  queue       Replay mutations queued while offline
+  cache       Inspect or clear the lookup cache
+  mock-server Run an in-memory Todoist API for offline testing
   planner     Show or set planner command
   help        Show help for a command
 
@@ -54,6 +62,9 @@ Global flags:
   -f, --force           Skip confirmation prompts
   --fuzzy               Enable fuzzy name resolution
   --no-fuzzy            Disable fuzzy name resolution
+  --offline-queue       Queue adds that fail with a network error
+  --no-cache            Disable the on-disk lookup cache
+  --refresh             Re-fetch cached lookups instead of reading the cache
   --progress-jsonl      Emit progress events as JSONL to stderr or file
   --base-url <url>      Override API base URL
 
@@ -115,12 +126,28 @@ func helpCommand(ctx *Context, args []string) error {
 		printViewHelp(ctx.Stdout)
 	case "agent":
 		printAgentHelp(ctx.Stdout)
+	case "template":
+		printTemplateHelp(ctx.Stdout)
+	case "date":
+		printDateHelp(ctx.Stdout)
+	case "search":
+		printSearchHelp(ctx.Stdout)
+	case "tui":
+		printTUIHelp(ctx.Stdout)
 	case "completion":
 		printCompletionHelp(ctx.Stdout)
 	case "doctor":
 		printDoctorHelp(ctx.Stdout)
 	case "schema":
 		printSchemaHelp(ctx.Stdout)
+	case "sync":
+		printSyncHelp(ctx.Stdout)
+	case "queue":
+		printQueueHelp(ctx.Stdout)
+	case "cache":
+		printCacheHelp(ctx.Stdout)
+	case "mock-server":
+		printMockServerHelp(ctx.Stdout)
 	case "planner":
 		printAgentPlannerHelp(ctx.Stdout)
 	case "examples":
@@ -182,16 +209,23 @@ Examples:
 
 func printTaskHelp(out interface{ Write([]byte) (int, error) }) {
 	fmt.Fprint(out, `Usage:
-  todoist task list [--filter <query>] [--project <id|name>] [--section <id|name>] [--label <name>] [--completed] [--completed-by completion|due] [--since <date>] [--until <date>] [--wide] [--all-projects]
+  todoist task list [--filter <query>] [--project <id|name>] [--section <id|name>] [--label <name>] [--completed] [--completed-by completion|due] [--since <date>] [--until <date>] [--max-items <n>] [--tree] [--wide] [--all-projects] [--blocked|--actionable]
   todoist task add --content <text> [flags]
-  todoist task view <ref> [--full]
+  todoist task view <ref> [--full] [--tree]
   todoist task update <ref> [flags]
-  todoist task move <ref> [--project <id|name>] [--section <id|name>] [--parent <id>]
+  todoist task update --filter <query> [flags] --yes
+  todoist task move <ref> [--project <id|name>] [--section <id|name>] [--parent <id>] [--recursive]
   todoist task move --filter <query> [--project <id|name>] [--section <id|name>] [--parent <id>] --yes
-  todoist task complete <ref>
-  todoist task complete --filter <query> --yes
-  todoist task reopen <ref>
-  todoist task delete <ref> [--yes]
+  todoist task complete <ref> [--recursive] [--show-unblocked]
+  todoist task complete --filter <query> --yes [--show-unblocked]
+  todoist task reopen <ref> [--recursive]
+  todoist task delete <ref> [--yes] [--recursive]
+  todoist task delete --filter <query> --yes
+  todoist task postpone <ref> (--by <2d|1w|3h> | --to <date>)
+  todoist task postpone --filter <query> (--by <2d|1w|3h> | --to <date>) --yes
+  todoist task duplicate <ref> [--project <id|name>] [--with-subtasks] [--with-comments] [--with-reminders]
+  todoist task block <ref> --by <ref> [--by <ref>]
+  todoist task unblock <ref> (--by <ref> | --all)
 
 Task flags:
   --content <text>           Task content ("-" reads stdin)
@@ -201,6 +235,9 @@ Task flags:
   --section <id|name>        Section reference
   --parent <id>              Parent task ID
   --label <name>             Label name (repeatable)
+  --add-label <name>         Add a label, keeping existing ones (update only; repeatable)
+  --remove-label <name>      Remove a label, keeping the others (update only; repeatable)
+  --clear-labels             Remove all labels (update only)
   --priority <1-4>           Priority (accepts p1..p4)
   --due <string>             Natural language due
   --due-date <YYYY-MM-DD>    Due date
@@ -211,6 +248,14 @@ Task flags:
   --deadline <YYYY-MM-DD>    Deadline date
   --assignee <ref>           Assignee reference (id, me, name, email)
   --natural                  Parse quick-add style tokens in content (#project @label p1..p4 due:...)
+  --by <shift|ref>            Postpone by 2d, 1w, 3h or 30m (postpone); blocking task (block/unblock, repeatable)
+  --to <date>                Postpone to a date such as monday or "mar 10 at 9" (postpone only)
+  --blocked                  Only tasks waiting on an open blocker (list only)
+  --actionable               Only tasks without open blockers (list only)
+  --show-unblocked           Print tasks whose last open blocker was completed (complete only)
+  --with-subtasks            Copy open subtasks in order (duplicate only)
+  --with-comments            Re-post comments on the copies (duplicate only)
+  --with-reminders           Recreate reminders on the copies (duplicate only)
   --yes                      Skip delete confirmation
 
 Notes:
@@ -219,7 +264,20 @@ Notes:
   Aliases: ls=list, show=view, rm/delete=delete.
   Completed listing supports YYYY-MM-DD, RFC3339, today/yesterday, weekday names, and "<N> days ago".
   If --completed uses --since without --until, --until defaults to today.
+  --max-items caps how many tasks are fetched across pages; --ndjson writes each page as soon as it arrives.
+  --tree nests subtasks under their parents (indented in human output, "children" arrays in JSON); use --all for complete trees.
+  --recursive walks the subtree and reports a result per task: complete/delete handle subtasks before parents,
+  reopen reopens subtasks completed in the last 89 days, and move moves the parent (subtasks follow it).
+  task add --dry-run resolves --due locally (see "todoist date parse") and shows the expected date and next occurrences.
+  postpone (alias snooze) shifts due date/datetime and keeps a recurring task's due string, so the recurrence survives;
+  --to keeps the task's time of day unless the target names one. Each task is reported as before -> after.
+  duplicate (alias dup) copies content, description, labels, priority, due, duration and deadline; the copy lands next to
+  the original unless --project is given. Output maps every old ID to its new ID ("mapping" in --json).
+  block/unblock store "blocked_by: <ids>" in a fenced todoist-cli block at the end of the description; a link that would
+  close a cycle is refused. A blocker stays open until it is completed or deleted; --blocked/--actionable filter on that.
+  task update --description replaces the whole description, including the block.
   For bulk actions, plain --filter text is treated as search text when not a Todoist query.
+  Bulk update/delete need --yes (or --force); --dry-run lists every matched task, and --json reports per-task status.
   Output columns (human/--plain): ID, Content, Project, Section, Labels, Due, Priority, Completed.
   Human output resolves project/section names; --plain uses IDs.
   Task updates/completions/deletes require task IDs; projects/sections/labels resolve names.
@@ -236,6 +294,11 @@ Examples:
   todoist task list --preset today --sort priority
   echo "From stdin" | todoist task add --content -
   todoist task view id:123456 --full
+  todoist task update --filter "@waiting & overdue" --add-label stale --priority p3 --yes
+  todoist task postpone --filter overdue --to tomorrow --yes
+  todoist task duplicate id:123456 --with-subtasks --with-comments --project "Next sprint"
+  todoist task block "Deploy" --by "Run migrations"
+  todoist task list --all-projects --actionable
 `)
 }
 
@@ -282,7 +345,7 @@ func printSectionHelp(out interface{ Write([]byte) (int, error) }) {
 
 func printLabelHelp(out interface{ Write([]byte) (int, error) }) {
 	fmt.Fprint(out, `Usage:
-  todoist label list
+  todoist label list [--offline]
   todoist label add --name <name> [--color <color>] [--favorite]
   todoist label update --id <label_id> [flags]
   todoist label delete --id <label_id>
@@ -344,13 +407,38 @@ Examples:
 `)
 }
 
+func printQueueHelp(out interface{ Write([]byte) (int, error) }) {
+	fmt.Fprint(out, `Usage:
+  todoist queue list
+  todoist queue flush
+  todoist queue drop [id] [--id <id>] [--all --yes]
+
+Notes:
+  - Enable queueing with --offline-queue, TODOIST_OFFLINE_QUEUE=1, or "offline_queue": true in config.
+  - When enabled, task add, inbox add, add and comment add are queued if the request fails with a network error.
+  - Queued mutations are stored per profile in <config dir>/queue/<profile>.json.
+  - flush replays in order with the original request ID; a network error stops the flush.
+  - Mutations rejected by the API are reported as failed and dropped from the queue.
+  - "drop --all" requires --yes unless --force is set.
+
+Examples:
+  todoist --offline-queue add "Buy milk #Home"
+  todoist queue list
+  todoist queue flush --json
+  todoist queue drop --all --yes
+`)
+}
+
 func printAgentHelp(out interface{ Write([]byte) (int, error) }) {
 	fmt.Fprint(out, `Usage:
-  todoist agent plan <instruction> [--out <file>] [--planner <cmd>]
+  todoist agent plan <instruction> [--out <file>] [--planner <cmd>] [--diff]
   todoist agent apply <instruction> --confirm <token> [--planner <cmd>] [--policy <file>]
-  todoist agent apply --plan <file> --confirm <token>
-  todoist agent apply --plan <file> --confirm <token> --dry-run [--policy <file>]
+  todoist agent apply --plan <file> --confirm <token> [--allow-stale]
+  todoist agent apply --plan <file> --confirm <token> --dry-run [--diff] [--policy <file>]
+  todoist agent apply --plan <file> --confirm <token> --batch
   todoist agent run --instruction <text> [--planner <cmd>] [--confirm <token>|--force] [--policy <file>]
+  todoist agent undo [--apply-id <id>] [--confirm <token>|--force] [--policy <file>] [--batch]
+  todoist agent policy check --plan <file> [--policy <file>] [--strict]
   todoist agent schedule print --weekly "sat 09:00" [--instruction <text>] [--planner <cmd>] [--confirm <token>|--force]
   todoist agent examples
   todoist agent planner
@@ -359,6 +447,7 @@ func printAgentHelp(out interface{ Write([]byte) (int, error) }) {
 Examples:
   todoist agent plan "Move overdue tasks to Catch Up" --out plan.json
   todoist agent apply --plan plan.json --confirm 6f2b
+  todoist agent undo
   todoist agent run --instruction "Triage inbox"
   todoist agent schedule print --weekly "sat 09:00" --instruction "Move 3 articles from Learning to Today"
 
@@ -366,13 +455,26 @@ Context flags:
   --context-project <name>   Limit planner context to project(s) (repeatable)
   --context-label <name>     Limit planner context to label(s) (repeatable)
   --context-completed <Nd>   Include completed tasks for last N days (e.g. 7d)
-  --policy <file>            Enforce policy rules for planned actions
+  --policy <file>            Enforce policy rules for planned actions (default: agent_policy.json)
 
 Notes:
   agent status is safe on first run and reports planner config + whether a last plan exists.
   agent apply/agent run allow no-action plans in --dry-run mode for pipeline validation.
   Planner context includes active tasks plus project/section/label/completed slices.
   Plan actions may include optional "reason" text; human previews print it when present.
+  --diff shows what each action changes against live state (before → after fields, deleted subtasks/comments).
+  --batch sends actions as Sync API commands (up to 100 per request) instead of one REST call each;
+  with --on-error fail, commands after a failed one in the same batch still apply.
+  Create actions may set "alias"; later actions reference the new entity as "$alias" in id fields.
+  Every apply is journaled (agent_journal.json) with the pre-state of what it changed; agent undo
+  previews the inverse plan, then applies it with --confirm. Deletes cannot be undone.
+  Policy "rules" scope deny/require/max checks to action types with conditions on the touched
+  projects (project, project_under), priority, label, has_comments and has_subtasks.
+  agent policy check lints a plan offline; conditions needing live state are listed as unchecked.
+  Planned plans record fingerprints of the entities they target; applying a plan file whose
+  targets changed since fails with plan_stale (exit 5) unless --allow-stale, which only warns.
+  Version 2 plans add "vars" ({{name}}), per-action "foreach" over a filter query ({{item.id}},
+  {{item.content}}, ...) and "when" guards; they expand against live tasks before policy checks.
 `)
 }
 
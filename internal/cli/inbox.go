@@ -10,7 +10,7 @@ import (
 
 func inboxCommand(ctx *Context, args []string) error {
 	if len(args) == 0 {
-		return taskListActive(ctx, "", "", "", "", "", "", 50, true, false, false, "")
+		return taskListActive(ctx, taskListOptions{Limit: 50, All: true})
 	}
 	if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
 		printInboxHelp(ctx.Stdout)
@@ -93,11 +93,16 @@ func inboxAdd(ctx *Context, args []string) error {
 			return err
 		}
 		id, err := inboxProjectID(ctx)
-		if err != nil || id == "" {
+		switch {
+		case err != nil && ctx.OfflineQueue && api.IsTransportError(err) && strings.TrimSpace(section) == "":
+			// Tasks without a project land in the Inbox, so the queued request
+			// can omit project_id.
+		case err != nil || id == "":
 			return &CodeError{Code: exitError, Err: errors.New("failed to resolve Inbox project")}
+		default:
+			inboxID = id
+			body["project_id"] = inboxID
 		}
-		inboxID = id
-		body["project_id"] = inboxID
 	}
 	if description != "" {
 		body["description"] = description
@@ -143,12 +148,13 @@ func inboxAdd(ctx *Context, args []string) error {
 		return writeDryRun(ctx, "inbox add", body)
 	}
 	var task api.Task
-	reqCtx, cancel := requestContext(ctx)
-	reqID, err := ctx.Client.Post(reqCtx, "/tasks", nil, body, &task, true)
-	cancel()
+	queued, err := postOrQueue(ctx, "inbox add", "/tasks", body, &task)
 	if err != nil {
 		return err
 	}
-	setRequestID(ctx, reqID)
+	if queued {
+		return writeQueuedResult(ctx)
+	}
+	invalidateNewLabels(ctx, task.Labels)
 	return writeTaskList(ctx, []api.Task{task}, "", false)
 }
@@ -40,10 +40,12 @@ func labelList(ctx *Context, args []string) error {
 	var cursor string
 	var limit int
 	var all bool
+	var offline bool
 	var help bool
 	fs.StringVar(&cursor, "cursor", "", "Cursor")
 	fs.IntVar(&limit, "limit", 50, "Limit")
 	fs.BoolVar(&all, "all", false, "Fetch all pages")
+	fs.BoolVar(&offline, "offline", false, "List labels from the stored sync snapshot")
 	bindHelpFlag(fs, &help)
 	if err := parseFlagSetInterspersed(fs, args); err != nil {
 		return &CodeError{Code: exitUsage, Err: err}
@@ -52,15 +54,20 @@ func labelList(ctx *Context, args []string) error {
 		printLabelHelp(ctx.Stdout)
 		return nil
 	}
+	if offline {
+		state, err := loadStoredSnapshot(ctx)
+		if err != nil {
+			return err
+		}
+		return writeLabelList(ctx, state.Labels, "")
+	}
 	if err := ensureClient(ctx); err != nil {
 		return err
 	}
 	query := applabels.BuildListQuery(applabels.ListInput{Limit: limit, Cursor: cursor})
-	allLabels, next, err := fetchPaginated[api.Label](ctx, "/labels", query, all)
-	if err != nil {
-		return err
-	}
-	return writeLabelList(ctx, allLabels, next)
+	return listPaginated(ctx, "/labels", query, all, 0, func(labels []api.Label, next string) error {
+		return writeLabelList(ctx, labels, next)
+	})
 }
 
 func labelAdd(ctx *Context, args []string) error {
@@ -106,6 +113,7 @@ func labelAdd(ctx *Context, args []string) error {
 		return err
 	}
 	setRequestID(ctx, reqID)
+	invalidateLookupCache(ctx, "labels")
 	return writeLabelList(ctx, []api.Label{label}, "")
 }
 
@@ -165,6 +173,7 @@ func labelUpdate(ctx *Context, args []string) error {
 		return err
 	}
 	setRequestID(ctx, reqID)
+	invalidateLookupCache(ctx, "labels")
 	return writeLabelList(ctx, []api.Label{label}, "")
 }
 
@@ -196,6 +205,7 @@ func labelDelete(ctx *Context, args []string) error {
 		return err
 	}
 	setRequestID(ctx, reqID)
+	invalidateLookupCache(ctx, "labels")
 	return writeSimpleResult(ctx, "deleted", id)
 }
 
@@ -1,8 +1,23 @@
 package cli
 
-import "github.com/agisilaos/todoist-cli/internal/api"
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/agisilaos/todoist-cli/internal/api"
+)
+
+// lookupCache holds the lists fetched during this command, plus the unexpired
+// entries of the on-disk cache. Persisted entries only serve reference and
+// name resolution (the listAll* helpers); reads that must reflect live state
+// use the fetchAll* helpers, which ignore them.
 type lookupCache struct {
+	persisted map[string]json.RawMessage
+
 	projectsLoaded bool
 	projects       []api.Project
 
@@ -23,19 +38,250 @@ type lookupCache struct {
 	workspaces       []api.Workspace
 }
 
+// lookupCacheTTLs lists the resources persisted across runs. Active tasks
+// change too often and stay in the per-process cache only.
+var lookupCacheTTLs = map[string]time.Duration{
+	"projects":      15 * time.Minute,
+	"sections":      15 * time.Minute,
+	"labels":        30 * time.Minute,
+	"filters":       30 * time.Minute,
+	"collaborators": time.Hour,
+	"workspaces":    time.Hour,
+}
+
+// diskLookupCache is the per-profile on-disk cache. Keys are a resource name,
+// optionally followed by ":<scope>" (for example "sections:<project id>").
+type diskLookupCache struct {
+	Entries map[string]diskLookupEntry `json:"entries"`
+}
+
+type diskLookupEntry struct {
+	FetchedAt time.Time       `json:"fetched_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
 func (ctx *Context) cache() *lookupCache {
 	if ctx == nil {
 		return nil
 	}
 	if ctx.lookupCache == nil {
 		ctx.lookupCache = &lookupCache{
+			persisted:              map[string]json.RawMessage{},
 			sectionsByProject:      map[string][]api.Section{},
 			collaboratorsByProject: map[string][]api.Collaborator{},
 		}
+		if !ctx.Global.NoCache && !ctx.Global.RefreshCache {
+			hydrateLookupCache(ctx, ctx.lookupCache)
+		}
 	}
 	return ctx.lookupCache
 }
 
+func hydrateLookupCache(ctx *Context, cache *lookupCache) {
+	disk, err := loadDiskLookupCache(lookupCachePath(ctx))
+	if err != nil {
+		return
+	}
+	now := applyNow(ctx)
+	for key, entry := range disk.Entries {
+		resource, _ := splitLookupCacheKey(key)
+		ttl, ok := lookupCacheTTLs[resource]
+		if !ok || now.Sub(entry.FetchedAt) > ttl {
+			continue
+		}
+		cache.persisted[key] = entry.Data
+	}
+}
+
+// persistedLookup returns the on-disk cache entry for key, if it was
+// unexpired when the command started.
+func persistedLookup[T any](ctx *Context, key string) ([]T, bool) {
+	cache := ctx.cache()
+	if cache == nil {
+		return nil, false
+	}
+	raw, ok := cache.persisted[key]
+	if !ok {
+		return nil, false
+	}
+	var out []T
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, false
+	}
+	return out, true
+}
+
+// storeLookup writes a freshly fetched resource list through to the on-disk
+// cache. Failures are ignored: the cache is an optimization only.
+func storeLookup(ctx *Context, key string, data any) {
+	if ctx == nil || ctx.Global.NoCache {
+		return
+	}
+	path := lookupCachePath(ctx)
+	if path == "" {
+		return
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+	disk, err := loadDiskLookupCache(path)
+	if err != nil {
+		disk = diskLookupCache{}
+	}
+	if disk.Entries == nil {
+		disk.Entries = map[string]diskLookupEntry{}
+	}
+	disk.Entries[key] = diskLookupEntry{FetchedAt: applyNow(ctx).UTC(), Data: raw}
+	_ = saveDiskLookupCache(path, disk)
+}
+
+// invalidateLookupCache drops cached lists for the given resources after a
+// successful mutation, both in memory and on disk.
+func invalidateLookupCache(ctx *Context, resources ...string) {
+	if ctx == nil {
+		return
+	}
+	if cache := ctx.lookupCache; cache != nil {
+		cache.forget(resources...)
+	}
+	path := lookupCachePath(ctx)
+	disk, err := loadDiskLookupCache(path)
+	if err != nil || len(disk.Entries) == 0 {
+		return
+	}
+	removed := false
+	for key := range disk.Entries {
+		resource, _ := splitLookupCacheKey(key)
+		for _, target := range resources {
+			if resource == target {
+				delete(disk.Entries, key)
+				removed = true
+			}
+		}
+	}
+	if removed {
+		_ = saveDiskLookupCache(path, disk)
+	}
+}
+
+// invalidateNewLabels drops the cached labels when a task carries a label
+// they do not list: the API creates missing labels on task add and update.
+func invalidateNewLabels(ctx *Context, names []string) {
+	if len(names) == 0 {
+		return
+	}
+	known := map[string]bool{}
+	if cache := ctx.cache(); cache != nil && cache.labelsLoaded {
+		for _, label := range cache.labels {
+			known[label.Name] = true
+		}
+	} else if labels, ok := persistedLookup[api.Label](ctx, "labels"); ok {
+		for _, label := range labels {
+			known[label.Name] = true
+		}
+	}
+	for _, name := range names {
+		if !known[name] {
+			invalidateLookupCache(ctx, "labels")
+			return
+		}
+	}
+}
+
+// forget drops the in-memory lists for resources, fetched or persisted.
+func (cache *lookupCache) forget(resources ...string) {
+	for _, resource := range resources {
+		switch resource {
+		case "projects":
+			cache.projectsLoaded = false
+			cache.projects = nil
+		case "sections":
+			cache.sectionsByProject = map[string][]api.Section{}
+		case "labels":
+			cache.labelsLoaded = false
+			cache.labels = nil
+		case "filters":
+			cache.filtersLoaded = false
+			cache.filters = nil
+		case "collaborators":
+			cache.collaboratorsByProject = map[string][]api.Collaborator{}
+		case "workspaces":
+			cache.workspacesLoaded = false
+			cache.workspaces = nil
+		}
+		for key := range cache.persisted {
+			if name, _ := splitLookupCacheKey(key); name == resource {
+				delete(cache.persisted, key)
+			}
+		}
+	}
+}
+
+func lookupCacheKey(resource, scope string) string {
+	if scope == "" {
+		return resource
+	}
+	return resource + ":" + scope
+}
+
+func splitLookupCacheKey(key string) (string, string) {
+	resource, scope, _ := strings.Cut(key, ":")
+	return resource, scope
+}
+
+func lookupCacheResources() []string {
+	resources := make([]string, 0, len(lookupCacheTTLs))
+	for resource := range lookupCacheTTLs {
+		resources = append(resources, resource)
+	}
+	sort.Strings(resources)
+	return resources
+}
+
+func lookupCachePath(ctx *Context) string {
+	return profileStatePath(ctx, "cache")
+}
+
+func loadDiskLookupCache(path string) (diskLookupCache, error) {
+	if path == "" {
+		return diskLookupCache{}, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return diskLookupCache{}, nil
+		}
+		return diskLookupCache{}, err
+	}
+	var disk diskLookupCache
+	if err := json.Unmarshal(data, &disk); err != nil {
+		return diskLookupCache{}, err
+	}
+	return disk, nil
+}
+
+func saveDiskLookupCache(path string, disk diskLookupCache) error {
+	if path == "" {
+		return nil
+	}
+	if disk.Entries == nil {
+		disk.Entries = map[string]diskLookupEntry{}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(disk)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
 func cloneSlice[T any](in []T) []T {
 	if len(in) == 0 {
 		return nil
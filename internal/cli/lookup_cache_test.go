@@ -1,13 +1,16 @@
 package cli
 
 import (
+	"bytes"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/agisilaos/todoist-cli/internal/api"
 	"github.com/agisilaos/todoist-cli/internal/config"
+	"github.com/agisilaos/todoist-cli/internal/output"
 )
 
 func TestListAllProjectsUsesCache(t *testing.T) {
@@ -77,3 +80,188 @@ func TestListAllFiltersUsesCache(t *testing.T) {
 		t.Fatalf("expected one API hit, got %d", hits)
 	}
 }
+
+func newLookupCacheTestContext(serverURL, configPath string, now time.Time) *Context {
+	return &Context{
+		Stdout:     &bytes.Buffer{},
+		Stderr:     &bytes.Buffer{},
+		Mode:       output.ModeJSON,
+		Token:      "token",
+		ConfigPath: configPath,
+		Client:     api.NewClient(serverURL, "token", time.Second),
+		Config:     config.Config{TimeoutSeconds: 2},
+		Now:        func() time.Time { return now },
+	}
+}
+
+func TestLookupCachePersistsAcrossRunsUntilTTL(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/projects" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		calls++
+		_, _ = w.Write([]byte(`{"results":[{"id":"p1","name":"Work"}],"next_cursor":""}`))
+	}))
+	defer ts.Close()
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	if _, err := listAllProjects(newLookupCacheTestContext(ts.URL, configPath, start)); err != nil {
+		t.Fatalf("first list: %v", err)
+	}
+	projects, err := listAllProjects(newLookupCacheTestContext(ts.URL, configPath, start.Add(5*time.Minute)))
+	if err != nil {
+		t.Fatalf("cached list: %v", err)
+	}
+	if calls != 1 || len(projects) != 1 || projects[0].Name != "Work" {
+		t.Fatalf("expected cached projects, calls=%d projects=%#v", calls, projects)
+	}
+
+	refresh := newLookupCacheTestContext(ts.URL, configPath, start.Add(5*time.Minute))
+	refresh.Global.RefreshCache = true
+	if _, err := listAllProjects(refresh); err != nil {
+		t.Fatalf("refresh list: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected --refresh to re-fetch, calls=%d", calls)
+	}
+
+	if _, err := listAllProjects(newLookupCacheTestContext(ts.URL, configPath, start.Add(time.Hour))); err != nil {
+		t.Fatalf("expired list: %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected expired entry to re-fetch, calls=%d", calls)
+	}
+}
+
+func TestLookupCacheNoCacheSkipsDisk(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"results":[{"id":"l1","name":"next"}],"next_cursor":""}`))
+	}))
+	defer ts.Close()
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < 2; i++ {
+		ctx := newLookupCacheTestContext(ts.URL, configPath, now)
+		ctx.Global.NoCache = true
+		if _, err := listAllLabels(ctx); err != nil {
+			t.Fatalf("list labels: %v", err)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("expected no disk cache with --no-cache, calls=%d", calls)
+	}
+	disk, err := loadDiskLookupCache(lookupCachePath(newLookupCacheTestContext(ts.URL, configPath, now)))
+	if err != nil || len(disk.Entries) != 0 {
+		t.Fatalf("expected empty disk cache, got %#v (%v)", disk, err)
+	}
+}
+
+func TestLabelAddInvalidatesLabelCache(t *testing.T) {
+	listCalls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/labels":
+			listCalls++
+			_, _ = w.Write([]byte(`{"results":[{"id":"l1","name":"next"}],"next_cursor":""}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/labels":
+			_, _ = w.Write([]byte(`{"id":"l2","name":"later"}`))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	ctx := newLookupCacheTestContext(ts.URL, configPath, now)
+	if _, err := listAllLabels(ctx); err != nil {
+		t.Fatalf("list labels: %v", err)
+	}
+	if err := labelAdd(ctx, []string{"--name", "later"}); err != nil {
+		t.Fatalf("label add: %v", err)
+	}
+	if _, err := listAllLabels(newLookupCacheTestContext(ts.URL, configPath, now)); err != nil {
+		t.Fatalf("list labels after add: %v", err)
+	}
+	if listCalls != 2 {
+		t.Fatalf("expected label add to invalidate the cache, list calls=%d", listCalls)
+	}
+}
+
+func TestLiveReadsBypassPersistedLookupCache(t *testing.T) {
+	calls := map[string]int{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls[r.URL.Path]++
+		switch r.URL.Path {
+		case "/filters":
+			_, _ = w.Write([]byte(`[{"id":"f1","name":"Today","query":"today"}]`))
+		case "/projects":
+			_, _ = w.Write([]byte(`{"results":[{"id":"p1","name":"Work"}],"next_cursor":""}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	seed := newLookupCacheTestContext(ts.URL, configPath, now)
+	if _, _, err := listAllFilters(seed); err != nil {
+		t.Fatalf("seed filters: %v", err)
+	}
+	if _, err := listAllProjects(seed); err != nil {
+		t.Fatalf("seed projects: %v", err)
+	}
+
+	if err := filterList(newLookupCacheTestContext(ts.URL, configPath, now), nil); err != nil {
+		t.Fatalf("filter list: %v", err)
+	}
+	if _, err := fetchAllProjects(newLookupCacheTestContext(ts.URL, configPath, now)); err != nil {
+		t.Fatalf("fetch projects: %v", err)
+	}
+	if calls["/filters"] != 2 || calls["/projects"] != 2 {
+		t.Fatalf("expected live reads to refetch, calls=%v", calls)
+	}
+	if _, err := resolveProjectID(newLookupCacheTestContext(ts.URL, configPath, now), "Work"); err != nil {
+		t.Fatalf("resolve project: %v", err)
+	}
+	if calls["/projects"] != 2 {
+		t.Fatalf("expected reference resolution to use the cache, calls=%v", calls)
+	}
+}
+
+func TestTaskAddWithNewLabelInvalidatesLabelCache(t *testing.T) {
+	listCalls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/labels":
+			listCalls++
+			_, _ = w.Write([]byte(`{"results":[{"id":"l1","name":"next"}],"next_cursor":""}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/tasks":
+			_, _ = w.Write([]byte(`{"id":"t1","content":"Call","labels":["next","errand"]}`))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	if _, err := listAllLabels(newLookupCacheTestContext(ts.URL, configPath, now)); err != nil {
+		t.Fatalf("list labels: %v", err)
+	}
+	if err := taskAdd(newLookupCacheTestContext(ts.URL, configPath, now), []string{"--content", "Call", "--label", "next", "--label", "errand"}); err != nil {
+		t.Fatalf("task add: %v", err)
+	}
+	if _, err := listAllLabels(newLookupCacheTestContext(ts.URL, configPath, now)); err != nil {
+		t.Fatalf("list labels after add: %v", err)
+	}
+	if listCalls != 2 {
+		t.Fatalf("expected the new label to invalidate the cache, list calls=%d", listCalls)
+	}
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/agisilaos/todoist-cli/internal/mockserver"
+	"github.com/agisilaos/todoist-cli/internal/output"
+)
+
+type mockServerInfo struct {
+	Status  string `json:"status"`
+	Addr    string `json:"addr"`
+	BaseURL string `json:"base_url"`
+	Seed    string `json:"seed,omitempty"`
+}
+
+// mockServerContext is replaced in tests to stop the server without a signal.
+var mockServerContext = func() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
+func mockServerCommand(ctx *Context, args []string) error {
+	fs := newFlagSet("mock-server")
+	var addr string
+	var seed string
+	var help bool
+	fs.StringVar(&addr, "addr", "127.0.0.1:8765", "Listen address (port 0 picks a free port)")
+	fs.StringVar(&seed, "seed", "", "Fixture JSON to seed projects, sections, tasks, labels and comments")
+	bindHelpFlag(fs, &help)
+	if err := parseFlagSetInterspersed(fs, args); err != nil {
+		return &CodeError{Code: exitUsage, Err: err}
+	}
+	if help {
+		printMockServerHelp(ctx.Stdout)
+		return nil
+	}
+	if len(fs.Args()) > 0 {
+		return &CodeError{Code: exitUsage, Err: fmt.Errorf("unexpected argument: %s", fs.Args()[0])}
+	}
+	fixture := mockserver.Fixture{}
+	if seed != "" {
+		loaded, err := mockserver.LoadFixture(seed)
+		if err != nil {
+			return err
+		}
+		fixture = loaded
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	server := &http.Server{Handler: mockserver.New(fixture), ReadHeaderTimeout: 10 * time.Second}
+	info := mockServerInfo{Status: "listening", Addr: ln.Addr().String(), BaseURL: "http://" + ln.Addr().String(), Seed: seed}
+	if err := writeMockServerInfo(ctx, info); err != nil {
+		_ = ln.Close()
+		return err
+	}
+
+	runCtx, stop := mockServerContext()
+	defer stop()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Serve(ln)
+	}()
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	case <-runCtx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return server.Shutdown(shutdownCtx)
+}
+
+func writeMockServerInfo(ctx *Context, info mockServerInfo) error {
+	switch ctx.Mode {
+	case output.ModeJSON:
+		return output.WriteJSON(ctx.Stdout, info, output.Meta{})
+	case output.ModeNDJSON:
+		return output.WriteNDJSONSlice(ctx.Stdout, []mockServerInfo{info})
+	case output.ModePlain:
+		return output.WritePlain(ctx.Stdout, [][]string{{info.BaseURL}})
+	}
+	fmt.Fprintf(ctx.Stdout, "Mock Todoist API listening on %s\n", info.BaseURL)
+	fmt.Fprintf(ctx.Stdout, "Point the CLI at it with: TODOIST_TOKEN=mock todoist --base-url %s <command>\n", info.BaseURL)
+	fmt.Fprintln(ctx.Stdout, "Press Ctrl-C to stop.")
+	return nil
+}
+
+func printMockServerHelp(out interface{ Write([]byte) (int, error) }) {
+	fmt.Fprint(out, `Usage:
+  todoist mock-server [--addr <host:port>] [--seed <fixture.json>]
+
+Notes:
+  - Serves an in-memory stand-in for the Todoist API v1 endpoints this CLI uses: /tasks, /tasks/filter, /tasks/quick, /tasks/completed/..., /projects, /sections, /labels, /comments and /sync.
+  - State is kept in memory and lost on exit; list endpoints paginate with next_cursor.
+  - Any bearer token is accepted. Filters support a subset of the Todoist query language (today, overdue, pN, #project, @label, search:, &, |, !).
+  - --addr defaults to 127.0.0.1:8765; use port 0 to pick a free port (the chosen URL is printed on start).
+  - The seed file is JSON with optional "projects", "sections", "tasks", "labels", "comments", "workspaces" and "user_id" keys; an Inbox project is added when none is seeded.
+
+Examples:
+  todoist mock-server --addr 127.0.0.1:0 --seed fixture.json
+  todoist --ndjson mock-server --addr 127.0.0.1:0
+  TODOIST_TOKEN=mock todoist --base-url http://127.0.0.1:8765 task list
+  TODOIST_TOKEN=mock todoist --base-url http://127.0.0.1:8765 agent apply --plan plan.json --confirm <token> --batch
+`)
+}
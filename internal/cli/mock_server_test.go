@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	"github.com/agisilaos/todoist-cli/internal/mockserver"
+)
+
+// mockCLIRunner runs CLI commands against an in-process mock server with a
+// fresh config and no lookup cache.
+type mockCLIRunner struct {
+	t          *testing.T
+	baseURL    string
+	configPath string
+}
+
+func newMockCLIRunner(t *testing.T, fixture mockserver.Fixture) *mockCLIRunner {
+	t.Helper()
+	return newMockCLIHandlerRunner(t, mockserver.New(fixture))
+}
+
+// newMockCLIHandlerRunner serves handler instead, for tests that adjust the
+// mock server or wrap it to inject API failures.
+func newMockCLIHandlerRunner(t *testing.T, handler http.Handler) *mockCLIRunner {
+	t.Helper()
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+	t.Setenv("TODOIST_TOKEN", "mock")
+	return &mockCLIRunner{t: t, baseURL: ts.URL, configPath: filepath.Join(t.TempDir(), "config.json")}
+}
+
+// run executes args, fails the test unless the exit code is wantCode, and
+// returns stdout.
+func (r *mockCLIRunner) run(wantCode int, args ...string) string {
+	r.t.Helper()
+	stdout, _ := r.runWithStderr(wantCode, args...)
+	return stdout
+}
+
+func (r *mockCLIRunner) runWithStderr(wantCode int, args ...string) (string, string) {
+	r.t.Helper()
+	var stdout, stderr bytes.Buffer
+	full := append([]string{"--config", r.configPath, "--base-url", r.baseURL, "--no-cache"}, args...)
+	if code := Execute(full, &stdout, &stderr); code != wantCode {
+		r.t.Fatalf("%v exit %d, want %d: %s%s", args, code, wantCode, stdout.String(), stderr.String())
+	}
+	return stdout.String(), stderr.String()
+}
+
+func TestMockServerCommandPrintsBaseURL(t *testing.T) {
+	orig := mockServerContext
+	mockServerContext = func() (context.Context, context.CancelFunc) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		return ctx, cancel
+	}
+	t.Cleanup(func() { mockServerContext = orig })
+
+	dir := t.TempDir()
+	seedPath := filepath.Join(dir, "fixture.json")
+	if err := os.WriteFile(seedPath, []byte(`{"projects":[{"id":"p1","name":"Work"}]}`), 0o600); err != nil {
+		t.Fatalf("write seed: %v", err)
+	}
+	var stdout, stderr bytes.Buffer
+	args := []string{"--config", filepath.Join(dir, "config.json"), "--ndjson", "mock-server", "--addr", "127.0.0.1:0", "--seed", seedPath}
+	if code := Execute(args, &stdout, &stderr); code != exitOK {
+		t.Fatalf("mock-server exit %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), `"base_url":"http://127.0.0.1:`) {
+		t.Fatalf("expected base_url in output: %s", stdout.String())
+	}
+
+	stdout.Reset()
+	stderr.Reset()
+	args[len(args)-1] = filepath.Join(dir, "missing.json")
+	if code := Execute(args, &stdout, &stderr); code != exitError {
+		t.Fatalf("expected missing seed to fail with exit %d, got %d", exitError, code)
+	}
+}
+
+func TestCommandsRunAgainstMockServer(t *testing.T) {
+	cli := newMockCLIRunner(t, mockserver.Fixture{
+		Projects: []api.Project{{ID: "p1", Name: "Work"}},
+	})
+	run := func(args ...string) string {
+		t.Helper()
+		return cli.run(exitOK, append([]string{"--json"}, args...)...)
+	}
+
+	run("task", "add", "--content", "Ship release", "--project", "Work", "--priority", "1")
+	run("add", "Call bank #Work @errands")
+	out := run("task", "list", "--filter", "#Work & @errands")
+	if !strings.Contains(out, "Call bank") || strings.Contains(out, "Ship release") {
+		t.Fatalf("unexpected filtered list: %s", out)
+	}
+	out = run("task", "list", "--project", "Work")
+	if !strings.Contains(out, "Ship release") || !strings.Contains(out, "Call bank") {
+		t.Fatalf("unexpected project list: %s", out)
+	}
+}
@@ -148,7 +148,11 @@ func TestWriteErrorJSON(t *testing.T) {
 	writeError(ctx, errors.New("boom"))
 	got := ctx.Stderr.(*bytes.Buffer).String()
 	want := `{
-  "error": "boom",
+  "error": {
+    "code": "internal",
+    "message": "boom",
+    "exit_code": 1
+  },
   "meta": {
     "request_id": "req-456"
   }
@@ -167,7 +171,7 @@ func TestWriteErrorJSONQuietSingleLine(t *testing.T) {
 	}
 	writeError(ctx, errors.New("boom"))
 	got := strings.TrimSpace(ctx.Stderr.(*bytes.Buffer).String())
-	want := `{"error":"boom","meta":{}}`
+	want := `{"error":{"code":"internal","message":"boom","exit_code":1},"meta":{}}`
 	if got != want {
 		t.Fatalf("unexpected quiet json error: %q", got)
 	}
@@ -181,7 +185,16 @@ func TestWriteErrorJSONUsesAPIRequestID(t *testing.T) {
 	writeError(ctx, &api.APIError{Status: 500, Message: "boom", RequestID: "req-api-1"})
 	got := ctx.Stderr.(*bytes.Buffer).String()
 	want := `{
-  "error": "api error: status 500: boom",
+  "error": {
+    "code": "api_server",
+    "message": "api error: status 500: boom",
+    "exit_code": 1,
+    "details": {
+      "request_id": "req-api-1",
+      "status": 500,
+      "type": "api_error"
+    }
+  },
   "meta": {
     "request_id": "req-api-1"
   }
@@ -207,7 +220,7 @@ func TestWriteErrorJSONIncludesAmbiguousDetails(t *testing.T) {
 	}
 	writeError(ctx, err)
 	got := ctx.Stderr.(*bytes.Buffer).String()
-	if !strings.Contains(got, `"details"`) || !strings.Contains(got, `"ambiguous_match"`) || !strings.Contains(got, `"project"`) {
+	if !strings.Contains(got, `"details"`) || !strings.Contains(got, `"ambiguous_match"`) || !strings.Contains(got, `"project"`) || !strings.Contains(got, `"code": "ref_ambiguous"`) {
 		t.Fatalf("expected ambiguity details, got %q", got)
 	}
 }
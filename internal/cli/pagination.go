@@ -1,33 +1,97 @@
 package cli
 
-import "net/url"
+import (
+	"net/url"
 
-func fetchPaginated[T any](ctx *Context, path string, query url.Values, all bool) ([]T, string, error) {
-	q := cloneQuery(query)
-	var items []T
-	var next string
-	for {
-		var page struct {
-			Results    []T    `json:"results"`
-			NextCursor string `json:"next_cursor"`
-		}
+	"github.com/agisilaos/todoist-cli/internal/api"
+	"github.com/agisilaos/todoist-cli/internal/output"
+)
+
+// newPager returns an api.Pager that reports each page as a "page_fetched"
+// progress event.
+func newPager[T any](ctx *Context, path string, query url.Values, maxItems int) *api.Pager[T] {
+	pager := api.NewPager[T](ctx.Client, path, query)
+	pager.MaxItems = maxItems
+	pager.OnPage = func(event api.PageEvent) {
+		emitProgress(ctx, "page_fetched", map[string]any{
+			"path":       event.Path,
+			"page":       event.Page,
+			"items":      event.Items,
+			"total":      event.Total,
+			"has_more":   event.HasMore,
+			"request_id": event.RequestID,
+		})
+	}
+	return pager
+}
+
+// streamPaginated hands each page to fn as it arrives and returns the cursor
+// to resume from. Without all only the first page is fetched.
+func streamPaginated[T any](ctx *Context, path string, query url.Values, all bool, maxItems int, fn func([]T) error) (string, error) {
+	pager := newPager[T](ctx, path, query, maxItems)
+	for !pager.Done() {
 		reqCtx, cancel := requestContext(ctx)
-		reqID, err := ctx.Client.Get(reqCtx, path, q, &page)
+		page, err := pager.Next(reqCtx)
 		cancel()
 		if err != nil {
-			return nil, "", err
+			return "", err
+		}
+		setRequestID(ctx, pager.RequestID())
+		if err := fn(page); err != nil {
+			return "", err
 		}
-		setRequestID(ctx, reqID)
-		items = append(items, page.Results...)
-		next = page.NextCursor
-		if !all || next == "" {
+		if !all {
 			break
 		}
-		q.Set("cursor", next)
+	}
+	return pager.Cursor(), nil
+}
+
+func fetchPaginated[T any](ctx *Context, path string, query url.Values, all bool) ([]T, string, error) {
+	var items []T
+	next, err := streamPaginated(ctx, path, query, all, 0, func(page []T) error {
+		items = append(items, page...)
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
 	}
 	return items, next, nil
 }
 
+// listPaginated writes NDJSON record by record as pages arrive, stopping
+// pagination as soon as stdout is closed; other modes buffer every page and
+// hand the result to write.
+func listPaginated[T any](ctx *Context, path string, query url.Values, all bool, maxItems int, write func([]T, string) error) error {
+	if ctx.Mode == output.ModeNDJSON {
+		enc := output.NewNDJSONEncoder(ctx.Stdout)
+		_, err := streamPaginated(ctx, path, query, all, maxItems, func(page []T) error {
+			for _, item := range page {
+				if err := enc.Encode(item); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		return err
+	}
+	return collectPaginated(ctx, path, query, all, maxItems, write)
+}
+
+// collectPaginated buffers every page before calling write, for output that
+// needs the whole result set (tables, JSON arrays, trees).
+func collectPaginated[T any](ctx *Context, path string, query url.Values, all bool, maxItems int, write func([]T, string) error) error {
+	var items []T
+	next, err := streamPaginated(ctx, path, query, all, maxItems, func(page []T) error {
+		items = append(items, page...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return write(items, next)
+}
+
 func cloneQuery(in url.Values) url.Values {
 	if in == nil {
 		return url.Values{}
@@ -1,14 +1,20 @@
 package cli
 
 import (
+	"bytes"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"syscall"
 	"testing"
 	"time"
 
 	"github.com/agisilaos/todoist-cli/internal/api"
 	"github.com/agisilaos/todoist-cli/internal/config"
+	"github.com/agisilaos/todoist-cli/internal/output"
 )
 
 func TestCloneQueryIsIndependent(t *testing.T) {
@@ -50,3 +56,70 @@ func TestFetchPaginatedCollectsPages(t *testing.T) {
 		t.Fatalf("expected empty final cursor, got %q", next)
 	}
 }
+
+func TestListPaginatedStreamsNDJSONPerPage(t *testing.T) {
+	var stdout bytes.Buffer
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			_, _ = w.Write([]byte(`{"results":[{"id":"1","name":"A"}],"next_cursor":"c1"}`))
+		case "c1":
+			if !strings.Contains(stdout.String(), `"id":"1"`) {
+				t.Errorf("expected first page to be written before the second is fetched, got %q", stdout.String())
+			}
+			_, _ = w.Write([]byte(`{"results":[{"id":"2","name":"B"}],"next_cursor":null}`))
+		}
+	}))
+	defer ts.Close()
+
+	var progress bytes.Buffer
+	ctx := &Context{
+		Stdout:   &stdout,
+		Mode:     output.ModeNDJSON,
+		Client:   api.NewClient(ts.URL, "token", time.Second),
+		Config:   config.Config{TimeoutSeconds: 2},
+		Progress: &progressSink{out: &progress},
+	}
+	err := listPaginated(ctx, "/projects", url.Values{}, true, 0, func([]api.Project, string) error {
+		t.Fatalf("NDJSON mode should not buffer")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("listPaginated: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(stdout.String()), "\n"); len(lines) != 2 {
+		t.Fatalf("expected two NDJSON lines, got %q", stdout.String())
+	}
+	events := progressLines(t, progress.String())
+	if len(events) != 2 || events[1]["type"] != "page_fetched" || events[1]["total"] != float64(2) || events[1]["has_more"] != false {
+		t.Fatalf("unexpected page events: %#v", events)
+	}
+}
+
+type closedPipeWriter struct{}
+
+func (closedPipeWriter) Write([]byte) (int, error) {
+	return 0, syscall.EPIPE
+}
+
+func TestTaskListNDJSONStopsPaginatingWhenStdoutCloses(t *testing.T) {
+	var requests atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`{"results":[{"id":"1","content":"A"},{"id":"2","content":"B"}],"next_cursor":"more"}`))
+	}))
+	defer ts.Close()
+
+	t.Setenv("TODOIST_TOKEN", "token")
+	var stderr bytes.Buffer
+	args := []string{"--config", filepath.Join(t.TempDir(), "config.json"), "--base-url", ts.URL, "--no-cache", "--ndjson", "task", "list", "--all-projects", "--all"}
+	if code := Execute(args, closedPipeWriter{}, &stderr); code != exitOK {
+		t.Fatalf("expected exit %d on closed stdout, got %d: %s", exitOK, code, stderr.String())
+	}
+	if stderr.Len() != 0 {
+		t.Fatalf("expected no error output, got %q", stderr.String())
+	}
+	if got := requests.Load(); got != 1 {
+		t.Fatalf("expected pagination to stop after the first page, got %d requests", got)
+	}
+}
@@ -3,12 +3,14 @@ package cli
 import (
 	"encoding/json"
 	"os"
+	"sync"
 	"time"
 
 	"io"
 )
 
 type progressSink struct {
+	mu     sync.Mutex
 	out    io.Writer
 	closer io.Closer
 }
@@ -45,6 +47,8 @@ func emitProgress(ctx *Context, eventType string, fields map[string]any) {
 	for k, v := range fields {
 		payload[k] = v
 	}
+	ctx.Progress.mu.Lock()
+	defer ctx.Progress.mu.Unlock()
 	enc := json.NewEncoder(ctx.Progress.out)
 	_ = enc.Encode(payload)
 }
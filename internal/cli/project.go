@@ -146,11 +146,9 @@ func projectCollaborators(ctx *Context, args []string) error {
 	if cursor != "" {
 		query.Set("cursor", cursor)
 	}
-	allCollaborators, next, err := fetchPaginated[api.Collaborator](ctx, "/projects/"+resolvedID+"/collaborators", query, all)
-	if err != nil {
-		return err
-	}
-	return writeProjectCollaborators(ctx, allCollaborators, next)
+	return listPaginated(ctx, "/projects/"+resolvedID+"/collaborators", query, all, 0, func(collaborators []api.Collaborator, next string) error {
+		return writeProjectCollaborators(ctx, collaborators, next)
+	})
 }
 
 func projectList(ctx *Context, args []string) error {
@@ -184,11 +182,9 @@ func projectList(ctx *Context, args []string) error {
 	if cursor != "" {
 		query.Set("cursor", cursor)
 	}
-	allProjects, next, err := fetchPaginated[api.Project](ctx, path, query, all)
-	if err != nil {
-		return err
-	}
-	return writeProjectList(ctx, allProjects, next)
+	return listPaginated(ctx, path, query, all, 0, func(projects []api.Project, next string) error {
+		return writeProjectList(ctx, projects, next)
+	})
 }
 
 func projectAdd(ctx *Context, args []string) error {
@@ -251,6 +247,7 @@ func projectAdd(ctx *Context, args []string) error {
 		return err
 	}
 	setRequestID(ctx, reqID)
+	invalidateLookupCache(ctx, "projects")
 	return writeProjectList(ctx, []api.Project{project}, "")
 }
 
@@ -311,6 +308,7 @@ func projectUpdate(ctx *Context, args []string) error {
 		return err
 	}
 	setRequestID(ctx, reqID)
+	invalidateLookupCache(ctx, "projects")
 	return writeProjectList(ctx, []api.Project{project}, "")
 }
 
@@ -342,6 +340,7 @@ func projectArchive(ctx *Context, args []string) error {
 		return err
 	}
 	setRequestID(ctx, reqID)
+	invalidateLookupCache(ctx, "projects", "sections")
 	return writeSimpleResult(ctx, "archived", id)
 }
 
@@ -364,6 +363,7 @@ func projectUnarchive(ctx *Context, args []string) error {
 		return err
 	}
 	setRequestID(ctx, reqID)
+	invalidateLookupCache(ctx, "projects", "sections")
 	return writeSimpleResult(ctx, "unarchived", id)
 }
 
@@ -395,6 +395,7 @@ func projectDelete(ctx *Context, args []string) error {
 		return err
 	}
 	setRequestID(ctx, reqID)
+	invalidateLookupCache(ctx, "projects", "sections", "collaborators")
 	return writeSimpleResult(ctx, "deleted", id)
 }
 
@@ -527,6 +528,7 @@ func projectMove(ctx *Context, args []string) error {
 			return err
 		}
 		setRequestID(ctx, reqID)
+		invalidateLookupCache(ctx, "projects", "collaborators")
 		return writeProjectList(ctx, []api.Project{moved}, "")
 	}
 	if project.WorkspaceID == "" {
@@ -550,6 +552,7 @@ func projectMove(ctx *Context, args []string) error {
 		return err
 	}
 	setRequestID(ctx, reqID)
+	invalidateLookupCache(ctx, "projects", "collaborators")
 	return writeProjectList(ctx, []api.Project{moved}, "")
 }
 
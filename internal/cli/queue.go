@@ -0,0 +1,359 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	"github.com/agisilaos/todoist-cli/internal/output"
+)
+
+// queuedMutation is a POST that failed with a transport error while the
+// offline queue was enabled. ID doubles as the X-Request-Id sent on replay so
+// a mutation the server already received is not applied twice.
+type queuedMutation struct {
+	ID        string         `json:"id"`
+	Command   string         `json:"command"`
+	Path      string         `json:"path"`
+	Body      map[string]any `json:"body"`
+	QueuedAt  string         `json:"queued_at"`
+	Attempts  int            `json:"attempts"`
+	LastError string         `json:"last_error,omitempty"`
+}
+
+type offlineQueue struct {
+	Mutations []queuedMutation `json:"mutations"`
+}
+
+type queueFlushResult struct {
+	ID      string `json:"id"`
+	Command string `json:"command"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
+func queueCommand(ctx *Context, args []string) error {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printQueueHelp(ctx.Stdout)
+		return nil
+	}
+	sub := canonicalSubcommand(args[0], map[string]string{
+		"ls": "list",
+		"rm": "drop",
+	})
+	switch sub {
+	case "list":
+		return queueList(ctx, args[1:])
+	case "flush":
+		return queueFlush(ctx, args[1:])
+	case "drop":
+		return queueDrop(ctx, args[1:])
+	default:
+		return &CodeError{Code: exitUsage, Err: fmt.Errorf("unknown queue subcommand: %s", args[0])}
+	}
+}
+
+func queueList(ctx *Context, args []string) error {
+	fs := newFlagSet("queue list")
+	var help bool
+	bindHelpFlag(fs, &help)
+	if err := parseFlagSetInterspersed(fs, args); err != nil {
+		return &CodeError{Code: exitUsage, Err: err}
+	}
+	if help {
+		printQueueHelp(ctx.Stdout)
+		return nil
+	}
+	queue, err := loadOfflineQueue(offlineQueuePath(ctx))
+	if err != nil {
+		return err
+	}
+	items := queue.Mutations
+	if items == nil {
+		items = []queuedMutation{}
+	}
+	if ctx.Mode == output.ModeJSON {
+		return output.WriteJSON(ctx.Stdout, items, output.Meta{Count: len(items)})
+	}
+	if ctx.Mode == output.ModeNDJSON {
+		return output.WriteNDJSONSlice(ctx.Stdout, items)
+	}
+	if len(items) == 0 {
+		if ctx.Mode == output.ModeHuman {
+			fmt.Fprintln(ctx.Stdout, "Queue is empty.")
+		}
+		return nil
+	}
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		rows = append(rows, []string{item.ID, item.Command, item.QueuedAt, strconv.Itoa(item.Attempts), item.LastError})
+	}
+	if ctx.Mode == output.ModePlain {
+		return output.WritePlain(ctx.Stdout, rows)
+	}
+	return output.WriteTable(ctx.Stdout, []string{"ID", "Command", "Queued", "Attempts", "Last Error"}, rows)
+}
+
+func queueFlush(ctx *Context, args []string) error {
+	fs := newFlagSet("queue flush")
+	var help bool
+	bindHelpFlag(fs, &help)
+	if err := parseFlagSetInterspersed(fs, args); err != nil {
+		return &CodeError{Code: exitUsage, Err: err}
+	}
+	if help {
+		printQueueHelp(ctx.Stdout)
+		return nil
+	}
+	path := offlineQueuePath(ctx)
+	queue, err := loadOfflineQueue(path)
+	if err != nil {
+		return err
+	}
+	if ctx.Global.DryRun {
+		return writeDryRun(ctx, "queue flush", map[string]any{"count": len(queue.Mutations)})
+	}
+	if len(queue.Mutations) == 0 {
+		return writeQueueFlushResults(ctx, []queueFlushResult{}, 0)
+	}
+	if err := ensureClient(ctx); err != nil {
+		return err
+	}
+	results, remaining, flushErr := flushOfflineQueue(ctx, queue.Mutations)
+	queue.Mutations = remaining
+	if err := saveOfflineQueue(path, queue); err != nil {
+		return err
+	}
+	// Report what was sent before a transport error stopped the flush.
+	if err := writeQueueFlushResults(ctx, results, len(remaining)); err != nil {
+		return err
+	}
+	if flushErr != nil {
+		return flushErr
+	}
+	for _, result := range results {
+		if result.Status == "failed" {
+			return &CodeError{Code: exitError, Err: errors.New("some queued mutations were rejected by the API and dropped")}
+		}
+	}
+	return nil
+}
+
+// flushOfflineQueue replays mutations in queue order. A transport error stops
+// the flush so later mutations keep their order; a mutation the API rejects
+// would fail the same way on every flush, so it is reported and dropped.
+func flushOfflineQueue(ctx *Context, mutations []queuedMutation) ([]queueFlushResult, []queuedMutation, error) {
+	results := make([]queueFlushResult, 0, len(mutations))
+	remaining := make([]queuedMutation, 0, len(mutations))
+	for idx, item := range mutations {
+		item.Attempts++
+		reqCtx, cancel := requestContext(ctx)
+		reqID, err := ctx.Client.PostWithRequestID(reqCtx, item.Path, nil, item.Body, nil, item.ID)
+		cancel()
+		setRequestID(ctx, reqID)
+		if err == nil {
+			results = append(results, queueFlushResult{ID: item.ID, Command: item.Command, Status: "flushed"})
+			emitProgress(ctx, "queue_flushed", map[string]any{"id": item.ID, "command": item.Command})
+			continue
+		}
+		if api.IsTransportError(err) {
+			item.LastError = err.Error()
+			remaining = append(remaining, item)
+			remaining = append(remaining, mutations[idx+1:]...)
+			return results, remaining, err
+		}
+		results = append(results, queueFlushResult{ID: item.ID, Command: item.Command, Status: "failed", Error: err.Error()})
+		emitProgress(ctx, "queue_failed", map[string]any{"id": item.ID, "command": item.Command, "error": err.Error()})
+	}
+	return results, remaining, nil
+}
+
+func queueDrop(ctx *Context, args []string) error {
+	fs := newFlagSet("queue drop")
+	var id string
+	var all bool
+	var yes bool
+	var help bool
+	fs.StringVar(&id, "id", "", "Queued mutation ID")
+	fs.BoolVar(&all, "all", false, "Drop every queued mutation")
+	fs.BoolVar(&yes, "yes", false, "Confirm dropping all mutations")
+	bindHelpFlag(fs, &help)
+	if err := parseFlagSetInterspersed(fs, args); err != nil {
+		return &CodeError{Code: exitUsage, Err: err}
+	}
+	if help {
+		printQueueHelp(ctx.Stdout)
+		return nil
+	}
+	if id == "" && len(fs.Args()) > 0 {
+		id = fs.Arg(0)
+	}
+	id = stripIDPrefix(strings.TrimSpace(id))
+	if (id == "") == !all {
+		return &CodeError{Code: exitUsage, Err: errors.New("queue drop requires exactly one of --id or --all")}
+	}
+	if all && !yes && !ctx.Global.Force && !ctx.Global.DryRun {
+		return &CodeError{Code: exitUsage, Err: errors.New("queue drop --all requires --yes (or --force)")}
+	}
+	path := offlineQueuePath(ctx)
+	queue, err := loadOfflineQueue(path)
+	if err != nil {
+		return err
+	}
+	if ctx.Global.DryRun {
+		return writeDryRun(ctx, "queue drop", map[string]any{"id": id, "all": all})
+	}
+	if all {
+		queue.Mutations = nil
+		if err := saveOfflineQueue(path, queue); err != nil {
+			return err
+		}
+		return writeSimpleResult(ctx, "dropped", "all")
+	}
+	kept := make([]queuedMutation, 0, len(queue.Mutations))
+	for _, item := range queue.Mutations {
+		if item.ID != id {
+			kept = append(kept, item)
+		}
+	}
+	if len(kept) == len(queue.Mutations) {
+		return &CodeError{Code: exitNotFound, Err: fmt.Errorf("queued mutation not found: %s", id)}
+	}
+	queue.Mutations = kept
+	if err := saveOfflineQueue(path, queue); err != nil {
+		return err
+	}
+	return writeSimpleResult(ctx, "dropped", id)
+}
+
+// postOrQueue sends a create request. With the offline queue enabled, a
+// transport error appends the mutation to the queue instead of failing;
+// queued reports whether that happened.
+func postOrQueue(ctx *Context, command, path string, body map[string]any, out any) (queued bool, err error) {
+	if !ctx.OfflineQueue {
+		reqCtx, cancel := requestContext(ctx)
+		reqID, err := ctx.Client.Post(reqCtx, path, nil, body, out, true)
+		cancel()
+		if err != nil {
+			return false, err
+		}
+		setRequestID(ctx, reqID)
+		return false, nil
+	}
+	requestID := api.NewRequestID()
+	reqCtx, cancel := requestContext(ctx)
+	reqID, err := ctx.Client.PostWithRequestID(reqCtx, path, nil, body, out, requestID)
+	cancel()
+	setRequestID(ctx, reqID)
+	if err == nil {
+		return false, nil
+	}
+	if !api.IsTransportError(err) {
+		return false, err
+	}
+	queuePath := offlineQueuePath(ctx)
+	if queuePath == "" {
+		return false, err
+	}
+	queue, loadErr := loadOfflineQueue(queuePath)
+	if loadErr != nil {
+		return false, loadErr
+	}
+	queue.Mutations = append(queue.Mutations, queuedMutation{
+		ID:        requestID,
+		Command:   command,
+		Path:      path,
+		Body:      body,
+		QueuedAt:  ctx.Now().UTC().Format(time.RFC3339),
+		LastError: err.Error(),
+	})
+	if saveErr := saveOfflineQueue(queuePath, queue); saveErr != nil {
+		return false, saveErr
+	}
+	emitProgress(ctx, "queue_enqueued", map[string]any{"id": requestID, "command": command})
+	return true, nil
+}
+
+func writeQueuedResult(ctx *Context) error {
+	if ctx.Mode == output.ModeHuman && ctx.Stderr != nil {
+		fmt.Fprintln(ctx.Stderr, "Network unavailable; queued for 'todoist queue flush'.")
+	}
+	return writeSimpleResult(ctx, "queued", ctx.RequestID)
+}
+
+func writeQueueFlushResults(ctx *Context, results []queueFlushResult, remaining int) error {
+	if ctx.Mode == output.ModeJSON {
+		return output.WriteJSON(ctx.Stdout, map[string]any{
+			"results":   results,
+			"remaining": remaining,
+		}, output.Meta{RequestID: ctx.RequestID, Count: len(results)})
+	}
+	if ctx.Mode == output.ModeNDJSON {
+		return output.WriteNDJSONSlice(ctx.Stdout, results)
+	}
+	if ctx.Mode == output.ModePlain {
+		rows := make([][]string, 0, len(results))
+		for _, result := range results {
+			rows = append(rows, []string{result.ID, result.Command, result.Status, result.Error})
+		}
+		return output.WritePlain(ctx.Stdout, rows)
+	}
+	for _, result := range results {
+		if result.Error != "" {
+			fmt.Fprintf(ctx.Stdout, "%s %s %s: %s\n", result.Status, result.Command, result.ID, result.Error)
+			continue
+		}
+		fmt.Fprintf(ctx.Stdout, "%s %s %s\n", result.Status, result.Command, result.ID)
+	}
+	fmt.Fprintf(ctx.Stdout, "%d queued mutation(s) remaining\n", remaining)
+	return nil
+}
+
+func offlineQueuePath(ctx *Context) string {
+	return profileStatePath(ctx, "queue")
+}
+
+func loadOfflineQueue(path string) (offlineQueue, error) {
+	if path == "" {
+		return offlineQueue{}, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return offlineQueue{}, nil
+		}
+		return offlineQueue{}, err
+	}
+	var queue offlineQueue
+	if err := json.Unmarshal(data, &queue); err != nil {
+		return offlineQueue{}, err
+	}
+	return queue, nil
+}
+
+func saveOfflineQueue(path string, queue offlineQueue) error {
+	if path == "" {
+		return nil
+	}
+	if queue.Mutations == nil {
+		queue.Mutations = []queuedMutation{}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(queue, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,213 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	"github.com/agisilaos/todoist-cli/internal/config"
+	"github.com/agisilaos/todoist-cli/internal/output"
+)
+
+func TestTaskAddQueuesOnTransportErrorAndFlushReplaysWithRequestID(t *testing.T) {
+	dir := t.TempDir()
+	var out bytes.Buffer
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+	ctx := &Context{
+		Stdout:       &out,
+		Stderr:       &bytes.Buffer{},
+		Mode:         output.ModeJSON,
+		Token:        "token",
+		ConfigPath:   filepath.Join(dir, "config.json"),
+		Client:       api.NewClient(down.URL, "token", time.Second),
+		Config:       config.Config{TimeoutSeconds: 2},
+		Now:          time.Now,
+		OfflineQueue: true,
+	}
+	if err := taskAdd(ctx, []string{"--content", "Buy milk", "--project", "id:p1"}); err != nil {
+		t.Fatalf("task add: %v", err)
+	}
+	if !strings.Contains(out.String(), `"status": "queued"`) {
+		t.Fatalf("expected queued result, got %s", out.String())
+	}
+	queue, err := loadOfflineQueue(offlineQueuePath(ctx))
+	if err != nil {
+		t.Fatalf("load queue: %v", err)
+	}
+	if len(queue.Mutations) != 1 || queue.Mutations[0].Path != "/tasks" || queue.Mutations[0].ID == "" {
+		t.Fatalf("unexpected queue: %#v", queue)
+	}
+	queuedID := queue.Mutations[0].ID
+
+	var gotRequestID string
+	var gotBody map[string]any
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotRequestID = r.Header.Get("X-Request-Id")
+		data, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(data, &gotBody)
+		_, _ = w.Write([]byte(`{"id":"t1"}`))
+	}))
+	defer up.Close()
+	ctx.Client = api.NewClient(up.URL, "token", time.Second)
+	out.Reset()
+	if err := queueCommand(ctx, []string{"flush"}); err != nil {
+		t.Fatalf("queue flush: %v", err)
+	}
+	if gotRequestID != queuedID {
+		t.Fatalf("expected replay with request id %q, got %q", queuedID, gotRequestID)
+	}
+	if gotBody["content"] != "Buy milk" || gotBody["project_id"] != "p1" {
+		t.Fatalf("unexpected replay body: %#v", gotBody)
+	}
+	if !strings.Contains(out.String(), `"remaining": 0`) {
+		t.Fatalf("unexpected flush output: %s", out.String())
+	}
+	queue, err = loadOfflineQueue(offlineQueuePath(ctx))
+	if err != nil || len(queue.Mutations) != 0 {
+		t.Fatalf("expected empty queue, got %#v (%v)", queue, err)
+	}
+}
+
+func TestTaskAddWithoutOfflineQueueFailsOnTransportError(t *testing.T) {
+	dir := t.TempDir()
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+	ctx := &Context{
+		Stdout:     &bytes.Buffer{},
+		Stderr:     &bytes.Buffer{},
+		Mode:       output.ModeJSON,
+		Token:      "token",
+		ConfigPath: filepath.Join(dir, "config.json"),
+		Client:     api.NewClient(down.URL, "token", time.Second),
+		Config:     config.Config{TimeoutSeconds: 2},
+		Now:        time.Now,
+	}
+	if err := taskAdd(ctx, []string{"--content", "Buy milk", "--project", "id:p1"}); err == nil {
+		t.Fatalf("expected transport error")
+	}
+	queue, err := loadOfflineQueue(offlineQueuePath(ctx))
+	if err != nil || len(queue.Mutations) != 0 {
+		t.Fatalf("expected nothing queued, got %#v (%v)", queue, err)
+	}
+}
+
+func TestQueueFlushDropsRejectedMutations(t *testing.T) {
+	dir := t.TempDir()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Request-Id") == "q1" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"bad project"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"c1"}`))
+	}))
+	defer ts.Close()
+	ctx := &Context{
+		Stdout:     &bytes.Buffer{},
+		Stderr:     &bytes.Buffer{},
+		Mode:       output.ModeJSON,
+		Token:      "token",
+		ConfigPath: filepath.Join(dir, "config.json"),
+		Client:     api.NewClient(ts.URL, "token", time.Second),
+		Config:     config.Config{TimeoutSeconds: 2},
+		Now:        time.Now,
+	}
+	path := offlineQueuePath(ctx)
+	if err := saveOfflineQueue(path, offlineQueue{Mutations: []queuedMutation{
+		{ID: "q1", Command: "task add", Path: "/tasks", Body: map[string]any{"content": "a"}},
+		{ID: "q2", Command: "comment add", Path: "/comments", Body: map[string]any{"content": "b", "task_id": "t1"}},
+	}}); err != nil {
+		t.Fatalf("save queue: %v", err)
+	}
+	err := queueCommand(ctx, []string{"flush"})
+	var codeErr *CodeError
+	if !errors.As(err, &codeErr) || codeErr.Code != exitError {
+		t.Fatalf("expected exitError, got %v", err)
+	}
+	var flushed struct {
+		Results []queueFlushResult `json:"results"`
+	}
+	if err := json.Unmarshal(ctx.Stdout.(*bytes.Buffer).Bytes(), &flushed); err != nil {
+		t.Fatalf("decode flush output: %v", err)
+	}
+	if len(flushed.Results) != 2 || flushed.Results[0].Status != "failed" || !strings.Contains(flushed.Results[0].Error, "bad project") || flushed.Results[1].Status != "flushed" {
+		t.Fatalf("unexpected flush results: %#v", flushed.Results)
+	}
+	queue, err := loadOfflineQueue(path)
+	if err != nil {
+		t.Fatalf("load queue: %v", err)
+	}
+	if len(queue.Mutations) != 0 {
+		t.Fatalf("expected the rejected mutation dropped: %#v", queue.Mutations)
+	}
+
+	if err := saveOfflineQueue(path, offlineQueue{Mutations: []queuedMutation{{ID: "q1", Command: "task add", Path: "/tasks"}}}); err != nil {
+		t.Fatalf("save queue: %v", err)
+	}
+
+	err = queueCommand(ctx, []string{"drop", "--all"})
+	if !errors.As(err, &codeErr) || codeErr.Code != exitUsage {
+		t.Fatalf("expected usage error without --yes, got %v", err)
+	}
+	if err := queueCommand(ctx, []string{"drop", "q1"}); err != nil {
+		t.Fatalf("queue drop: %v", err)
+	}
+	queue, _ = loadOfflineQueue(path)
+	if len(queue.Mutations) != 0 {
+		t.Fatalf("expected empty queue after drop: %#v", queue.Mutations)
+	}
+}
+
+func TestQueueFlushReportsSentMutationsBeforeTransportError(t *testing.T) {
+	dir := t.TempDir()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Request-Id") == "q2" {
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			_ = conn.Close()
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"t1"}`))
+	}))
+	defer ts.Close()
+	var out bytes.Buffer
+	ctx := &Context{
+		Stdout:     &out,
+		Stderr:     &bytes.Buffer{},
+		Mode:       output.ModeJSON,
+		Token:      "token",
+		ConfigPath: filepath.Join(dir, "config.json"),
+		Client:     api.NewClient(ts.URL, "token", time.Second),
+		Config:     config.Config{TimeoutSeconds: 2},
+		Now:        time.Now,
+	}
+	path := offlineQueuePath(ctx)
+	if err := saveOfflineQueue(path, offlineQueue{Mutations: []queuedMutation{
+		{ID: "q1", Command: "task add", Path: "/tasks", Body: map[string]any{"content": "a"}},
+		{ID: "q2", Command: "task add", Path: "/tasks", Body: map[string]any{"content": "b"}},
+		{ID: "q3", Command: "task add", Path: "/tasks", Body: map[string]any{"content": "c"}},
+	}}); err != nil {
+		t.Fatalf("save queue: %v", err)
+	}
+	if err := queueCommand(ctx, []string{"flush"}); err == nil || !api.IsTransportError(err) {
+		t.Fatalf("expected transport error, got %v", err)
+	}
+	var body struct {
+		Results   []queueFlushResult `json:"results"`
+		Remaining int                `json:"remaining"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &body); err != nil {
+		t.Fatalf("decode flush output: %v\n%s", err, out.String())
+	}
+	if len(body.Results) != 1 || body.Results[0].ID != "q1" || body.Results[0].Status != "flushed" || body.Remaining != 2 {
+		t.Fatalf("unexpected partial flush output: %s", out.String())
+	}
+}
@@ -167,7 +167,19 @@ func resolveFuzzy[T any](value string, items []T, nameFn func(T) string, idFn fu
 	return "", nil
 }
 
+// listAllProjects returns every project for resolving references and names;
+// it may be served from the on-disk lookup cache.
 func listAllProjects(ctx *Context) ([]api.Project, error) {
+	if cache := ctx.cache(); cache != nil && !cache.projectsLoaded {
+		if projects, ok := persistedLookup[api.Project](ctx, "projects"); ok {
+			return projects, nil
+		}
+	}
+	return fetchAllProjects(ctx)
+}
+
+// fetchAllProjects returns the live project list, fetched once per command.
+func fetchAllProjects(ctx *Context) ([]api.Project, error) {
 	if cache := ctx.cache(); cache != nil && cache.projectsLoaded {
 		return cloneSlice(cache.projects), nil
 	}
@@ -184,10 +196,28 @@ func listAllProjects(ctx *Context) ([]api.Project, error) {
 		cache.projects = cloneSlice(all)
 		cache.projectsLoaded = true
 	}
+	storeLookup(ctx, "projects", all)
 	return all, nil
 }
 
+// listAllSections returns the sections of project (all sections when empty)
+// for resolving references and names; it may be served from the on-disk
+// lookup cache.
 func listAllSections(ctx *Context, project string) ([]api.Section, error) {
+	key := strings.TrimSpace(project)
+	if cache := ctx.cache(); cache != nil {
+		if _, ok := cache.sectionsByProject[key]; !ok {
+			if sections, ok := persistedLookup[api.Section](ctx, lookupCacheKey("sections", key)); ok {
+				return sections, nil
+			}
+		}
+	}
+	return fetchAllSections(ctx, project)
+}
+
+// fetchAllSections returns the live sections of project, fetched once per
+// command.
+func fetchAllSections(ctx *Context, project string) ([]api.Section, error) {
 	key := strings.TrimSpace(project)
 	if cache := ctx.cache(); cache != nil {
 		if cached, ok := cache.sectionsByProject[key]; ok {
@@ -216,10 +246,23 @@ func listAllSections(ctx *Context, project string) ([]api.Section, error) {
 			cache.sectionsByProject[trimmed] = cloneSlice(all)
 		}
 	}
+	storeLookup(ctx, lookupCacheKey("sections", key), all)
 	return all, nil
 }
 
+// listAllLabels returns every label for resolving references; it may be
+// served from the on-disk lookup cache.
 func listAllLabels(ctx *Context) ([]api.Label, error) {
+	if cache := ctx.cache(); cache != nil && !cache.labelsLoaded {
+		if labels, ok := persistedLookup[api.Label](ctx, "labels"); ok {
+			return labels, nil
+		}
+	}
+	return fetchAllLabels(ctx)
+}
+
+// fetchAllLabels returns the live label list, fetched once per command.
+func fetchAllLabels(ctx *Context) ([]api.Label, error) {
 	if cache := ctx.cache(); cache != nil && cache.labelsLoaded {
 		return cloneSlice(cache.labels), nil
 	}
@@ -236,6 +279,7 @@ func listAllLabels(ctx *Context) ([]api.Label, error) {
 		cache.labels = cloneSlice(all)
 		cache.labelsLoaded = true
 	}
+	storeLookup(ctx, "labels", all)
 	return all, nil
 }
 
@@ -287,6 +331,9 @@ func listAllWorkspaces(ctx *Context) ([]api.Workspace, error) {
 	if cache := ctx.cache(); cache != nil && cache.workspacesLoaded {
 		return cloneSlice(cache.workspaces), nil
 	}
+	if workspaces, ok := persistedLookup[api.Workspace](ctx, "workspaces"); ok {
+		return workspaces, nil
+	}
 	if err := ensureClient(ctx); err != nil {
 		return nil, err
 	}
@@ -301,6 +348,7 @@ func listAllWorkspaces(ctx *Context) ([]api.Workspace, error) {
 		cache.workspaces = cloneSlice(workspaces)
 		cache.workspacesLoaded = true
 	}
+	storeLookup(ctx, "workspaces", workspaces)
 	return workspaces, nil
 }
 
@@ -61,11 +61,26 @@ var schemas = []schemaDef{
 	},
 	{
 		Name:        "error",
-		Description: "Error envelope when --json is set",
+		Description: "Error envelope written to stderr when --json is set; branch on error.code",
 		Schema: map[string]any{
 			"type": "object",
 			"properties": map[string]any{
-				"error": map[string]string{"type": "string"},
+				"error": map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"code":      map[string]any{"type": "string", "enum": errorCodes},
+						"message":   map[string]string{"type": "string"},
+						"exit_code": map[string]string{"type": "integer"},
+						"details": map[string]any{
+							"type":        "object",
+							"description": "Present for ambiguous_match (entity, input, matches), api_error (status, request_id, error_tag) and policy_violation (rule, action_type, limit, count)",
+							"properties": map[string]any{
+								"type": map[string]any{"type": "string", "enum": []string{"ambiguous_match", "api_error", "policy_violation"}},
+							},
+						},
+					},
+					"required": []string{"code", "message", "exit_code"},
+				},
 				"meta": map[string]any{
 					"type": "object",
 					"properties": map[string]any{
@@ -82,11 +97,20 @@ var schemas = []schemaDef{
 		Schema: map[string]any{
 			"type": "object",
 			"properties": map[string]any{
-				"version":       map[string]string{"type": "integer"},
+				"version":       map[string]any{"type": "integer", "enum": []int{1, 2}},
 				"instruction":   map[string]string{"type": "string"},
 				"created_at":    map[string]string{"type": "string"},
 				"confirm_token": map[string]string{"type": "string"},
 				"applied_at":    map[string]string{"type": "string"},
+				"apply_id":      map[string]string{"type": "string"},
+				"fingerprints": map[string]any{
+					"type":                 "object",
+					"additionalProperties": map[string]string{"type": "string"},
+				},
+				"vars": map[string]any{
+					"type":                 "object",
+					"additionalProperties": map[string]string{"type": "string"},
+				},
 				"summary": map[string]any{
 					"type": "object",
 					"properties": map[string]any{
@@ -104,6 +128,7 @@ var schemas = []schemaDef{
 						"type": "object",
 						"properties": map[string]any{
 							"type":          map[string]string{"type": "string"},
+							"alias":         map[string]string{"type": "string"},
 							"reason":        map[string]string{"type": "string"},
 							"task_id":       map[string]string{"type": "string"},
 							"project_id":    map[string]string{"type": "string"},
@@ -114,6 +139,8 @@ var schemas = []schemaDef{
 							"description":   map[string]string{"type": "string"},
 							"name":          map[string]string{"type": "string"},
 							"labels":        map[string]any{"type": "array", "items": map[string]string{"type": "string"}},
+							"add_labels":    map[string]any{"type": "array", "items": map[string]string{"type": "string"}},
+							"remove_labels": map[string]any{"type": "array", "items": map[string]string{"type": "string"}},
 							"project":       map[string]string{"type": "string"},
 							"section":       map[string]string{"type": "string"},
 							"parent":        map[string]string{"type": "string"},
@@ -130,6 +157,8 @@ var schemas = []schemaDef{
 							"order":         map[string]string{"type": "integer"},
 							"is_favorite":   map[string]string{"type": "boolean"},
 							"idempotent":    map[string]string{"type": "boolean"},
+							"foreach":       map[string]string{"type": "string"},
+							"when":          map[string]string{"type": "string"},
 						},
 						"required": []string{"type"},
 					},
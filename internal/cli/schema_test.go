@@ -3,6 +3,7 @@ package cli
 import (
 	"bytes"
 	"encoding/json"
+	"reflect"
 	"testing"
 
 	"github.com/agisilaos/todoist-cli/internal/output"
@@ -78,3 +79,21 @@ func containsString(values []string, needle string) bool {
 	}
 	return false
 }
+
+func TestPlanSchemaDescribesVersion2(t *testing.T) {
+	for _, s := range schemas {
+		if s.Name != "plan" {
+			continue
+		}
+		props := s.Schema.(map[string]any)["properties"].(map[string]any)
+		if version := props["version"].(map[string]any); !reflect.DeepEqual(version["enum"], []int{1, 2}) {
+			t.Fatalf("unexpected version schema: %#v", version)
+		}
+		actionProps := props["actions"].(map[string]any)["items"].(map[string]any)["properties"].(map[string]any)
+		if props["vars"] == nil || actionProps["foreach"] == nil || actionProps["when"] == nil {
+			t.Fatalf("plan schema missing v2 fields")
+		}
+		return
+	}
+	t.Fatal("plan schema not found")
+}
@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	appsearch "github.com/agisilaos/todoist-cli/internal/app/search"
+	"github.com/agisilaos/todoist-cli/internal/output"
+)
+
+// searchHit is a task with its rank; JSON keeps the task fields flat so hits
+// read like task list entries.
+type searchHit struct {
+	api.Task
+	Score         float64           `json:"score"`
+	MatchedFields []appsearch.Field `json:"matched_fields"`
+}
+
+func searchCommand(ctx *Context, args []string) error {
+	fs := newFlagSet("search")
+	var offline bool
+	var limit int
+	var wide bool
+	var help bool
+	fs.BoolVar(&offline, "offline", false, "Search the stored snapshot without syncing")
+	fs.IntVar(&limit, "limit", 20, "Maximum results (0 for all)")
+	fs.BoolVar(&wide, "wide", false, "Wider table output")
+	bindHelpFlag(fs, &help)
+	if err := parseFlagSetInterspersed(fs, args); err != nil {
+		return &CodeError{Code: exitUsage, Err: err}
+	}
+	if help {
+		printSearchHelp(ctx.Stdout)
+		return nil
+	}
+	if limit < 0 {
+		return &CodeError{Code: exitUsage, Err: errors.New("--limit must be zero or positive")}
+	}
+	query, err := appsearch.ParseQuery(strings.Join(fs.Args(), " "))
+	if err != nil {
+		printSearchHelp(ctx.Stderr)
+		return &CodeError{Code: exitUsage, Err: err}
+	}
+	var state api.SyncState
+	if offline {
+		if state, err = loadStoredSnapshot(ctx); err != nil {
+			return err
+		}
+	} else if state, _, err = syncSnapshot(ctx, false); err != nil {
+		return err
+	}
+
+	tasks := state.ActiveItems()
+	byID := make(map[string]api.Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+	index := appsearch.NewIndex(appsearch.Documents(tasks, state.Notes, state.Projects, state.Sections))
+	hits := index.Search(query)
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	results := make([]searchHit, 0, len(hits))
+	for _, hit := range hits {
+		results = append(results, searchHit{Task: byID[hit.ID], Score: hit.Score, MatchedFields: hit.Fields})
+	}
+	emitProgress(ctx, "search_complete", map[string]any{"indexed": index.Len(), "hits": len(results)})
+	seedLookupsFromSnapshot(ctx, state)
+	return writeSearchResults(ctx, results, wide)
+}
+
+// seedLookupsFromSnapshot lets human output name projects and sections from
+// the snapshot instead of fetching them.
+func seedLookupsFromSnapshot(ctx *Context, state api.SyncState) {
+	cache := ctx.cache()
+	if cache == nil {
+		return
+	}
+	cache.projects = cloneSlice(state.Projects)
+	cache.projectsLoaded = true
+	cache.sectionsByProject[""] = cloneSlice(state.Sections)
+}
+
+func writeSearchResults(ctx *Context, results []searchHit, wide bool) error {
+	switch ctx.Mode {
+	case output.ModeJSON:
+		return output.WriteJSON(ctx.Stdout, results, output.Meta{RequestID: ctxRequestIDValue(ctx), Count: len(results)})
+	case output.ModeNDJSON:
+		return output.WriteNDJSONSlice(ctx.Stdout, results)
+	}
+	tasks := make([]api.Task, 0, len(results))
+	for _, result := range results {
+		tasks = append(tasks, result.Task)
+	}
+	return writeTaskTable(ctx, tasks, nil, wide)
+}
+
+func printSearchHelp(out interface{ Write([]byte) (int, error) }) {
+	fmt.Fprint(out, `Usage:
+  todoist search <query> [--limit <n>] [--offline] [--wide]
+
+Query syntax:
+  word               Every word must match (case-insensitive)
+  "exact phrase"     Words next to each other, in one field
+  pref*              Any word starting with pref
+  in:<field>[,...]   Only look in title, desc, comment, project or section
+  project:<name|id>  Only tasks in that project (quote names with spaces)
+
+Notes:
+  - Searches open tasks, their descriptions and comments, and their project/section names locally.
+  - The index is built from the sync snapshot (see "todoist sync"), which is brought up to date first;
+    --offline searches the stored snapshot without calling the API.
+  - Results are ranked: title matches outrank description and comment matches, and rare words weigh more.
+  - --json/--ndjson add "score" and "matched_fields" to each task; human and --plain output match task list.
+
+Examples:
+  todoist search invoice
+  todoist search "release notes" in:desc,comment
+  todoist search deplo* project:"Home Office" --json
+`)
+}
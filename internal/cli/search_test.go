@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	"github.com/agisilaos/todoist-cli/internal/mockserver"
+)
+
+func TestSearchCommand(t *testing.T) {
+	run := newMockCLIRunner(t, mockserver.Fixture{
+		Projects: []api.Project{{ID: "p1", Name: "Home Office"}, {ID: "p2", Name: "Work"}},
+		Tasks: []api.Task{
+			{ID: "t1", Content: "Pay invoice", ProjectID: "p1"},
+			{ID: "t2", Content: "Call accountant", ProjectID: "p2", Description: "ask about the invoice"},
+			{ID: "t3", Content: "Deploy release", ProjectID: "p2"},
+		},
+		Comments: []mockserver.Comment{
+			{Comment: api.Comment{ID: "c1", Content: "release notes drafted"}, TaskID: "t3"},
+		},
+	}).run
+	search := func(args ...string) []searchHit {
+		t.Helper()
+		var hits []searchHit
+		if err := json.Unmarshal([]byte(run(exitOK, append([]string{"--json", "search"}, args...)...)), &hits); err != nil {
+			t.Fatalf("decode search: %v", err)
+		}
+		return hits
+	}
+
+	run(exitUsage, "search")
+	run(exitUsage, "search", "in:body", "invoice")
+	run(exitUsage, "search", "--offline", "invoice")
+
+	hits := search("invoice")
+	if len(hits) != 2 || hits[0].ID != "t1" || hits[1].ID != "t2" || hits[0].Score <= hits[1].Score {
+		t.Fatalf("unexpected ranking: %#v", hits)
+	}
+	if got := hits[1].MatchedFields; len(got) != 1 || got[0] != "desc" {
+		t.Fatalf("unexpected matched fields: %#v", got)
+	}
+	if hits := search("invoice", "in:desc"); len(hits) != 1 || hits[0].ID != "t2" {
+		t.Fatalf("unexpected in:desc hits: %#v", hits)
+	}
+	if hits := search(`"release notes"`, "in:comment"); len(hits) != 1 || hits[0].ID != "t3" {
+		t.Fatalf("unexpected phrase hits: %#v", hits)
+	}
+	if hits := search("invoice", `project:"Home Office"`); len(hits) != 1 || hits[0].ID != "t1" {
+		t.Fatalf("unexpected project hits: %#v", hits)
+	}
+	if hits := search("deplo*", "--offline"); len(hits) != 1 || hits[0].ID != "t3" {
+		t.Fatalf("unexpected offline prefix hits: %#v", hits)
+	}
+	if out := run(exitOK, "--plain", "search", "account*"); !strings.HasPrefix(out, "t2\t") {
+		t.Fatalf("unexpected plain output: %q", out)
+	}
+	if out := run(exitOK, "--ndjson", "search", "invoice", "--limit", "1"); strings.Count(out, "\n") != 1 || !strings.Contains(out, `"score"`) {
+		t.Fatalf("unexpected ndjson output: %q", out)
+	}
+}
@@ -68,11 +68,9 @@ func sectionList(ctx *Context, args []string) error {
 		Cursor:    cursor,
 		ProjectID: resolvedProjectID,
 	})
-	allSections, next, err := fetchPaginated[api.Section](ctx, "/sections", query, all)
-	if err != nil {
-		return err
-	}
-	return writeSectionList(ctx, allSections, next)
+	return listPaginated(ctx, "/sections", query, all, 0, func(sections []api.Section, next string) error {
+		return writeSectionList(ctx, sections, next)
+	})
 }
 
 func sectionAdd(ctx *Context, args []string) error {
@@ -113,6 +111,7 @@ func sectionAdd(ctx *Context, args []string) error {
 		return err
 	}
 	setRequestID(ctx, reqID)
+	invalidateLookupCache(ctx, "sections")
 	return writeSectionList(ctx, []api.Section{section}, "")
 }
 
@@ -150,6 +149,7 @@ func sectionUpdate(ctx *Context, args []string) error {
 		return err
 	}
 	setRequestID(ctx, reqID)
+	invalidateLookupCache(ctx, "sections")
 	return writeSectionList(ctx, []api.Section{section}, "")
 }
 
@@ -190,6 +190,7 @@ func sectionDelete(ctx *Context, args []string) error {
 		return err
 	}
 	setRequestID(ctx, reqID)
+	invalidateLookupCache(ctx, "sections")
 	return writeSimpleResult(ctx, "deleted", id)
 }
 
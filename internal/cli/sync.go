@@ -0,0 +1,177 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	"github.com/agisilaos/todoist-cli/internal/output"
+)
+
+type syncSummary struct {
+	Profile    string                    `json:"profile"`
+	Path       string                    `json:"path,omitempty"`
+	Synced     bool                      `json:"synced"`
+	FullSync   bool                      `json:"full_sync"`
+	HasToken   bool                      `json:"has_token"`
+	FullSyncAt string                    `json:"full_sync_at,omitempty"`
+	UpdatedAt  string                    `json:"updated_at,omitempty"`
+	Counts     map[string]int            `json:"counts"`
+	Changes    map[string]api.SyncChange `json:"changes,omitempty"`
+}
+
+func syncCommand(ctx *Context, args []string) error {
+	fs := newFlagSet("sync")
+	var full bool
+	var status bool
+	var help bool
+	fs.BoolVar(&full, "full", false, "Discard the sync token and run a full sync")
+	fs.BoolVar(&status, "status", false, "Show the local snapshot without syncing")
+	bindHelpFlag(fs, &help)
+	if err := parseFlagSetInterspersed(fs, args); err != nil {
+		return &CodeError{Code: exitUsage, Err: err}
+	}
+	if help {
+		printSyncHelp(ctx.Stdout)
+		return nil
+	}
+	if full && status {
+		return &CodeError{Code: exitUsage, Err: fmt.Errorf("--full and --status are mutually exclusive")}
+	}
+	path := syncStatePath(ctx)
+	if status {
+		state, err := api.LoadSyncState(path)
+		if err != nil {
+			return err
+		}
+		return writeSyncSummary(ctx, buildSyncSummary(ctx, path, state, nil))
+	}
+	state, result, err := syncSnapshot(ctx, full)
+	if err != nil {
+		return err
+	}
+	return writeSyncSummary(ctx, buildSyncSummary(ctx, path, state, &result))
+}
+
+func syncStatePath(ctx *Context) string {
+	return profileStatePath(ctx, "sync")
+}
+
+// loadStoredSnapshot returns the profile's snapshot as last synced, for
+// --offline reads that must not call the API.
+func loadStoredSnapshot(ctx *Context) (api.SyncState, error) {
+	state, err := api.LoadSyncState(syncStatePath(ctx))
+	if err != nil {
+		return api.SyncState{}, err
+	}
+	if !state.HasToken() {
+		return api.SyncState{}, &CodeError{Code: exitUsage, Err: errors.New("no local snapshot yet; run `todoist sync` or drop --offline")}
+	}
+	return state, nil
+}
+
+func syncSnapshot(ctx *Context, full bool) (api.SyncState, api.SyncResult, error) {
+	if err := ensureClient(ctx); err != nil {
+		return api.SyncState{}, api.SyncResult{}, err
+	}
+	path := syncStatePath(ctx)
+	state, err := api.LoadSyncState(path)
+	if err != nil {
+		return api.SyncState{}, api.SyncResult{}, err
+	}
+	reqCtx, cancel := requestContext(ctx)
+	result, reqID, err := ctx.Client.Sync(reqCtx, &state, full, ctx.Now())
+	cancel()
+	if err != nil {
+		return api.SyncState{}, api.SyncResult{}, err
+	}
+	setRequestID(ctx, reqID)
+	if err := api.SaveSyncState(path, state); err != nil {
+		return api.SyncState{}, api.SyncResult{}, err
+	}
+	emitProgress(ctx, "sync_complete", map[string]any{
+		"full_sync": result.FullSync,
+		"changes":   len(result.Changes),
+	})
+	return state, result, nil
+}
+
+func buildSyncSummary(ctx *Context, path string, state api.SyncState, result *api.SyncResult) syncSummary {
+	summary := syncSummary{
+		Profile:    ctx.Profile,
+		Path:       path,
+		HasToken:   state.HasToken(),
+		FullSyncAt: state.FullSyncAt,
+		UpdatedAt:  state.UpdatedAt,
+		Counts:     state.Counts(),
+	}
+	if result != nil {
+		summary.Synced = true
+		summary.FullSync = result.FullSync
+		summary.Changes = result.Changes
+	}
+	return summary
+}
+
+func writeSyncSummary(ctx *Context, summary syncSummary) error {
+	if ctx.Mode == output.ModeJSON {
+		return output.WriteJSON(ctx.Stdout, summary, output.Meta{RequestID: ctx.RequestID})
+	}
+	if ctx.Mode == output.ModeNDJSON {
+		return output.WriteNDJSONSlice(ctx.Stdout, []syncSummary{summary})
+	}
+	names := make([]string, 0, len(summary.Counts))
+	for name := range summary.Counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if ctx.Mode == output.ModePlain {
+		rows := make([][]string, 0, len(names)+2)
+		rows = append(rows, []string{"full_sync", strconv.FormatBool(summary.FullSync)})
+		rows = append(rows, []string{"updated_at", summary.UpdatedAt})
+		for _, name := range names {
+			change := summary.Changes[name]
+			rows = append(rows, []string{name, strconv.Itoa(summary.Counts[name]), strconv.Itoa(change.Updated), strconv.Itoa(change.Deleted)})
+		}
+		return output.WritePlain(ctx.Stdout, rows)
+	}
+	switch {
+	case !summary.Synced && !summary.HasToken:
+		fmt.Fprintln(ctx.Stdout, "No local snapshot yet; run `todoist sync`.")
+		return nil
+	case !summary.Synced:
+		fmt.Fprintf(ctx.Stdout, "Snapshot updated %s\n", summary.UpdatedAt)
+	case summary.FullSync:
+		fmt.Fprintln(ctx.Stdout, "Full sync complete")
+	default:
+		fmt.Fprintln(ctx.Stdout, "Incremental sync complete")
+	}
+	for _, name := range names {
+		line := fmt.Sprintf("  %-18s %d", name, summary.Counts[name])
+		if change, ok := summary.Changes[name]; ok && !summary.FullSync {
+			line += fmt.Sprintf(" (+%d/-%d)", change.Updated, change.Deleted)
+		}
+		fmt.Fprintln(ctx.Stdout, line)
+	}
+	return nil
+}
+
+func printSyncHelp(out interface{ Write([]byte) (int, error) }) {
+	fmt.Fprint(out, `Usage:
+  todoist sync [--full]
+  todoist sync --status
+
+Notes:
+  - Runs an incremental Sync API request using the stored sync_token.
+  - The first run (or --full) downloads a full snapshot.
+  - Snapshots are stored per profile in <config dir>/sync/<profile>.json.
+  - Tracks items, projects, sections, labels, filters, notes, reminders and live notifications.
+
+Examples:
+  todoist sync
+  todoist sync --full
+  todoist sync --status --json
+`)
+}
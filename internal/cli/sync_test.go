@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	"github.com/agisilaos/todoist-cli/internal/config"
+	"github.com/agisilaos/todoist-cli/internal/mockserver"
+	"github.com/agisilaos/todoist-cli/internal/output"
+)
+
+func TestSyncCommandPersistsTokenPerProfile(t *testing.T) {
+	var tokens []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/sync" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		values, _ := url.ParseQuery(string(body))
+		tokens = append(tokens, values.Get("sync_token"))
+		if len(tokens) == 1 {
+			_, _ = w.Write([]byte(`{"sync_token":"tok1","full_sync":true,"items":[{"id":"t1","content":"a"}],"projects":[{"id":"p1","name":"Inbox"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"sync_token":"tok2","full_sync":false,"items":[{"id":"t2","content":"b"}]}`))
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	var out bytes.Buffer
+	ctx := &Context{
+		Stdout:     &out,
+		Stderr:     &bytes.Buffer{},
+		Mode:       output.ModeJSON,
+		Token:      "token",
+		Profile:    "work",
+		ConfigPath: filepath.Join(dir, "config.json"),
+		Client:     api.NewClient(ts.URL, "token", time.Second),
+		Config:     config.Config{TimeoutSeconds: 2},
+		Now:        time.Now,
+	}
+	if err := syncCommand(ctx, nil); err != nil {
+		t.Fatalf("first sync: %v", err)
+	}
+	out.Reset()
+	if err := syncCommand(ctx, nil); err != nil {
+		t.Fatalf("second sync: %v", err)
+	}
+	if len(tokens) != 2 || tokens[0] != "*" || tokens[1] != "tok1" {
+		t.Fatalf("unexpected tokens: %#v", tokens)
+	}
+	if !strings.Contains(out.String(), `"full_sync": false`) || !strings.Contains(out.String(), `"items": 2`) {
+		t.Fatalf("unexpected output: %s", out.String())
+	}
+	state, err := api.LoadSyncState(filepath.Join(dir, "sync", "work.json"))
+	if err != nil {
+		t.Fatalf("load state: %v", err)
+	}
+	if state.SyncToken != "tok2" || len(state.Items) != 2 || len(state.Projects) != 1 {
+		t.Fatalf("unexpected persisted state: %#v", state)
+	}
+}
+
+func TestSyncCommandStatusWithoutSnapshot(t *testing.T) {
+	var out bytes.Buffer
+	ctx := &Context{
+		Stdout:     &out,
+		Stderr:     &bytes.Buffer{},
+		Mode:       output.ModeHuman,
+		Profile:    "default",
+		ConfigPath: filepath.Join(t.TempDir(), "config.json"),
+	}
+	if err := syncCommand(ctx, []string{"--status"}); err != nil {
+		t.Fatalf("sync --status: %v", err)
+	}
+	if !strings.Contains(out.String(), "No local snapshot yet") {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
+
+func TestLabelListOfflineReadsSnapshot(t *testing.T) {
+	run := newMockCLIRunner(t, mockserver.Fixture{
+		Labels: []api.Label{{ID: "l1", Name: "waiting"}},
+	}).run
+	run(exitUsage, "label", "list", "--offline")
+	run(exitOK, "sync")
+	run(exitOK, "label", "add", "--name", "focus")
+
+	var labels []api.Label
+	if err := json.Unmarshal([]byte(run(exitOK, "--json", "label", "list", "--offline")), &labels); err != nil {
+		t.Fatalf("decode labels: %v", err)
+	}
+	if len(labels) != 1 || labels[0].Name != "waiting" {
+		t.Fatalf("expected the synced labels only, got %#v", labels)
+	}
+}
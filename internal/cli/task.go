@@ -133,10 +133,12 @@ func taskCommand(ctx *Context, args []string) error {
 		return nil
 	}
 	sub := canonicalSubcommand(args[0], map[string]string{
-		"ls":   "list",
-		"rm":   "delete",
-		"del":  "delete",
-		"show": "view",
+		"ls":     "list",
+		"rm":     "delete",
+		"del":    "delete",
+		"show":   "view",
+		"snooze": "postpone",
+		"dup":    "duplicate",
 	})
 	switch sub {
 	case "list":
@@ -155,6 +157,14 @@ func taskCommand(ctx *Context, args []string) error {
 		return taskReopen(ctx, args[1:])
 	case "delete":
 		return taskDelete(ctx, args[1:])
+	case "postpone":
+		return taskPostpone(ctx, args[1:])
+	case "duplicate":
+		return taskDuplicate(ctx, args[1:])
+	case "block":
+		return taskBlock(ctx, args[1:])
+	case "unblock":
+		return taskUnblock(ctx, args[1:])
 	default:
 		return &CodeError{Code: exitUsage, Err: fmt.Errorf("unknown task subcommand: %s", args[0])}
 	}
@@ -236,14 +246,16 @@ func quickAddCommand(ctx *Context, args []string) error {
 		return err
 	}
 	if ctx.Global.DryRun {
-		return writeDryRun(ctx, "task add", map[string]any{"text": text, "sync_quick_add": true})
+		return writeTaskAddDryRun(ctx, map[string]any{"text": text, "sync_quick_add": true}, dueString)
 	}
-	reqCtx, cancel := requestContext(ctx)
-	task, reqID, err := ctx.Client.QuickAdd(reqCtx, text)
-	cancel()
+	var task api.Task
+	queued, err := postOrQueue(ctx, "add", "/tasks/quick", map[string]any{"text": text}, &task)
 	if err != nil {
 		return err
 	}
-	setRequestID(ctx, reqID)
+	if queued {
+		return writeQueuedResult(ctx)
+	}
+	invalidateNewLabels(ctx, task.Labels)
 	return writeTaskList(ctx, []api.Task{task}, "", false)
 }
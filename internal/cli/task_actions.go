@@ -19,6 +19,7 @@ func taskMove(ctx *Context, args []string) error {
 	var parent string
 	var filter string
 	var yes bool
+	var recursive bool
 	var help bool
 	fs.StringVar(&id, "id", "", "Task ID")
 	fs.StringVar(&project, "project", "", "Project")
@@ -26,6 +27,7 @@ func taskMove(ctx *Context, args []string) error {
 	fs.StringVar(&parent, "parent", "", "Parent")
 	fs.StringVar(&filter, "filter", "", "Filter query for bulk move")
 	fs.BoolVar(&yes, "yes", false, "Required for bulk move")
+	fs.BoolVar(&recursive, "recursive", false, "Report per-node results for the task and its subtasks")
 	bindHelpFlag(fs, &help)
 	if err := parseFlagSetInterspersed(fs, args); err != nil {
 		return &CodeError{Code: exitUsage, Err: err}
@@ -58,6 +60,9 @@ func taskMove(ctx *Context, args []string) error {
 	if err != nil {
 		return asUsageIfGeneric(err)
 	}
+	if recursive && resolved.Mode == "bulk" {
+		return &CodeError{Code: exitUsage, Err: errors.New("--recursive cannot be combined with --filter")}
+	}
 	if resolved.Mode == "bulk" {
 		body, err := buildTaskMovePayload(ctx, "", project, "", section, parent)
 		if err != nil {
@@ -68,15 +73,16 @@ func taskMove(ctx *Context, args []string) error {
 		}
 		moved := 0
 		failed := 0
-		for _, taskID := range resolved.IDs {
+		errs := runBulk(ctx, "task move", resolved.IDs, func(taskID string) (string, error) {
 			reqCtx, cancel := requestContext(ctx)
-			reqID, err := ctx.Client.Post(reqCtx, "/tasks/"+taskID+"/move", nil, body, nil, true)
-			cancel()
+			defer cancel()
+			return ctx.Client.Post(reqCtx, "/tasks/"+taskID+"/move", nil, body, nil, true)
+		})
+		for _, err := range errs {
 			if err != nil {
 				failed++
 				continue
 			}
-			setRequestID(ctx, reqID)
 			moved++
 		}
 		if ctx.Mode == output.ModeJSON {
@@ -95,6 +101,9 @@ func taskMove(ctx *Context, args []string) error {
 	if err != nil {
 		return err
 	}
+	if recursive {
+		return taskMoveRecursive(ctx, id, body)
+	}
 	if ctx.Global.DryRun {
 		return writeDryRun(ctx, "task move", body)
 	}
@@ -113,10 +122,14 @@ func taskComplete(ctx *Context, args []string) error {
 	var id string
 	var filter string
 	var yes bool
+	var recursive bool
+	var showUnblocked bool
 	var help bool
 	fs.StringVar(&id, "id", "", "Task ID")
 	fs.StringVar(&filter, "filter", "", "Filter query for bulk complete")
 	fs.BoolVar(&yes, "yes", false, "Required for bulk complete")
+	fs.BoolVar(&recursive, "recursive", false, "Complete subtasks first and report per-node results")
+	fs.BoolVar(&showUnblocked, "show-unblocked", false, "List tasks whose last open blocker was completed")
 	bindHelpFlag(fs, &help)
 	if err := parseFlagSetInterspersed(fs, args); err != nil {
 		return &CodeError{Code: exitUsage, Err: err}
@@ -146,32 +159,58 @@ func taskComplete(ctx *Context, args []string) error {
 	if err != nil {
 		return asUsageIfGeneric(err)
 	}
+	if recursive && resolved.Mode == "bulk" {
+		return &CodeError{Code: exitUsage, Err: errors.New("--recursive cannot be combined with --filter")}
+	}
+	if recursive && showUnblocked {
+		return &CodeError{Code: exitUsage, Err: errors.New("--show-unblocked cannot be combined with --recursive")}
+	}
+	// The active list is read before completing, while the completed tasks
+	// still count as open blockers.
+	var active []api.Task
+	if showUnblocked && !ctx.Global.DryRun {
+		if active, err = listAllActiveTasks(ctx); err != nil {
+			return err
+		}
+	}
 	if resolved.Mode == "bulk" {
 		if ctx.Global.DryRun {
 			return writeDryRun(ctx, "task complete bulk", map[string]any{"filter": resolved.Filter, "count": len(resolved.IDs), "ids": resolved.IDs})
 		}
 		completed := 0
 		failed := 0
-		for _, taskID := range resolved.IDs {
+		errs := runBulk(ctx, "task complete", resolved.IDs, func(taskID string) (string, error) {
 			reqCtx, cancel := requestContext(ctx)
-			reqID, err := ctx.Client.Post(reqCtx, "/tasks/"+taskID+"/close", nil, nil, nil, true)
-			cancel()
+			defer cancel()
+			return ctx.Client.Post(reqCtx, "/tasks/"+taskID+"/close", nil, nil, nil, true)
+		})
+		var completedIDs []string
+		for i, err := range errs {
 			if err != nil {
 				failed++
 				continue
 			}
-			setRequestID(ctx, reqID)
 			completed++
+			completedIDs = append(completedIDs, resolved.IDs[i])
+		}
+		var unblocked []api.Task
+		if showUnblocked {
+			unblocked = apptasks.Unblocked(active, completedIDs)
 		}
 		if ctx.Mode == output.ModeJSON {
-			return output.WriteJSON(ctx.Stdout, map[string]any{
+			data := map[string]any{
 				"filter":    resolved.Filter,
 				"completed": completed,
 				"failed":    failed,
 				"count":     len(resolved.IDs),
-			}, output.Meta{RequestID: ctx.RequestID})
+			}
+			if showUnblocked {
+				data["unblocked"] = taskRefs(unblocked)
+			}
+			return output.WriteJSON(ctx.Stdout, data, output.Meta{RequestID: ctx.RequestID})
 		}
 		fmt.Fprintf(ctx.Stdout, "bulk complete done: completed=%d failed=%d total=%d\n", completed, failed, len(resolved.IDs))
+		writeUnblocked(ctx, unblocked)
 		return nil
 	}
 	id = resolved.ID
@@ -179,6 +218,13 @@ func taskComplete(ctx *Context, args []string) error {
 		printTaskHelp(ctx.Stderr)
 		return &CodeError{Code: exitUsage, Err: errors.New("task complete requires --id or a reference")}
 	}
+	if recursive {
+		return taskRecursive(ctx, "complete", id, false, func(taskID string) (string, error) {
+			reqCtx, cancel := requestContext(ctx)
+			defer cancel()
+			return ctx.Client.Post(reqCtx, "/tasks/"+taskID+"/close", nil, nil, nil, true)
+		})
+	}
 	if ctx.Global.DryRun {
 		return writeDryRun(ctx, "task complete", map[string]any{"id": id})
 	}
@@ -189,7 +235,20 @@ func taskComplete(ctx *Context, args []string) error {
 		return err
 	}
 	setRequestID(ctx, reqID)
-	return writeSimpleResult(ctx, "completed", id)
+	if !showUnblocked {
+		return writeSimpleResult(ctx, "completed", id)
+	}
+	unblocked := apptasks.Unblocked(active, []string{id})
+	if ctx.Mode == output.ModeJSON {
+		return output.WriteJSON(ctx.Stdout, map[string]any{
+			"id":        id,
+			"status":    "completed",
+			"unblocked": taskRefs(unblocked),
+		}, output.Meta{RequestID: ctx.RequestID})
+	}
+	fmt.Fprintf(ctx.Stdout, "completed %s\n", id)
+	writeUnblocked(ctx, unblocked)
+	return nil
 }
 
 type cliTaskResolver struct {
@@ -221,14 +280,40 @@ func asUsageIfGeneric(err error) error {
 }
 
 func taskReopen(ctx *Context, args []string) error {
-	id, err := requireTaskID(ctx, "task reopen", args)
-	if err != nil {
+	fs := newFlagSet("task reopen")
+	var id string
+	var recursive bool
+	var help bool
+	fs.StringVar(&id, "id", "", "Task ID")
+	fs.BoolVar(&recursive, "recursive", false, "Reopen completed subtasks and report per-node results")
+	bindHelpFlag(fs, &help)
+	if err := parseFlagSetInterspersed(fs, args); err != nil {
+		return &CodeError{Code: exitUsage, Err: err}
+	}
+	if help {
+		printTaskHelp(ctx.Stdout)
+		return nil
+	}
+	if strings.TrimSpace(id) == "" && len(fs.Args()) == 0 {
 		printTaskHelp(ctx.Stderr)
-		return err
+		return &CodeError{Code: exitUsage, Err: errors.New("task reopen requires --id or a reference")}
 	}
 	if err := ensureClient(ctx); err != nil {
 		return err
 	}
+	svc := apptasks.Service{Resolver: cliTaskResolver{ctx: ctx}}
+	id, err := svc.ResolveTaskTarget(context.Background(), apptasks.ResolveTaskTargetInput{ID: id, Ref: strings.Join(fs.Args(), " ")})
+	if err != nil {
+		printTaskHelp(ctx.Stderr)
+		return asUsageIfGeneric(err)
+	}
+	if recursive {
+		return taskRecursive(ctx, "reopen", id, true, func(taskID string) (string, error) {
+			reqCtx, cancel := requestContext(ctx)
+			defer cancel()
+			return ctx.Client.Post(reqCtx, "/tasks/"+taskID+"/reopen", nil, nil, nil, true)
+		})
+	}
 	if ctx.Global.DryRun {
 		return writeDryRun(ctx, "task reopen", map[string]any{"id": id})
 	}
@@ -242,13 +327,76 @@ func taskReopen(ctx *Context, args []string) error {
 	return writeSimpleResult(ctx, "reopened", id)
 }
 
+// taskRecursive applies fn across the subtree of id. Completing and deleting
+// walk children before parents; reopening walks the completed subtree
+// parents first.
+func taskRecursive(ctx *Context, action, id string, reopen bool, fn func(id string) (string, error)) error {
+	root, err := fetchTask(ctx, id)
+	if err != nil {
+		return err
+	}
+	var nodes []apptasks.FlatNode
+	if reopen {
+		tree, err := completedSubtree(ctx, root)
+		if err != nil {
+			return err
+		}
+		nodes = apptasks.Flatten([]*apptasks.TreeNode{tree})
+	} else {
+		tree, err := activeSubtree(ctx, root)
+		if err != nil {
+			return err
+		}
+		nodes = apptasks.PostOrder(tree)
+	}
+	if ctx.Global.DryRun {
+		return writeDryRun(ctx, "task "+action+" recursive", map[string]any{"id": id, "count": len(nodes), "ids": taskNodeIDs(nodes)})
+	}
+	status := map[string]string{"complete": "completed", "reopen": "reopened", "delete": "deleted"}[action]
+	results := runTaskRecursive(ctx, "task "+action, nodes, status, fn)
+	return writeTaskNodeResults(ctx, action, id, results)
+}
+
+func taskMoveRecursive(ctx *Context, id string, body map[string]any) error {
+	root, err := fetchTask(ctx, id)
+	if err != nil {
+		return err
+	}
+	tree, err := activeSubtree(ctx, root)
+	if err != nil {
+		return err
+	}
+	nodes := apptasks.Flatten([]*apptasks.TreeNode{tree})
+	if target, _ := body["parent_id"].(string); target != "" {
+		for _, node := range nodes {
+			if node.Task.ID == target {
+				return &CodeError{Code: exitUsage, Err: fmt.Errorf("cannot move task %s under its own subtree (%s)", id, target)}
+			}
+		}
+	}
+	if ctx.Global.DryRun {
+		return writeDryRun(ctx, "task move recursive", map[string]any{"id": id, "count": len(nodes), "ids": taskNodeIDs(nodes), "payload": body})
+	}
+	// Subtasks follow their parent, so only the root is moved through the API.
+	moved := runTaskRecursive(ctx, "task move", nodes[:1], "moved", func(taskID string) (string, error) {
+		reqCtx, cancel := requestContext(ctx)
+		defer cancel()
+		return ctx.Client.Post(reqCtx, "/tasks/"+taskID+"/move", nil, body, nil, true)
+	})
+	return writeTaskNodeResults(ctx, "move", id, recursiveMoveResults(moved[0], nodes[1:]))
+}
+
 func taskDelete(ctx *Context, args []string) error {
 	fs := newFlagSet("task delete")
 	var id string
+	var filter string
 	var yes bool
+	var recursive bool
 	var help bool
 	fs.StringVar(&id, "id", "", "Task ID")
+	fs.StringVar(&filter, "filter", "", "Filter query for bulk delete")
 	fs.BoolVar(&yes, "yes", false, "Skip confirmation")
+	fs.BoolVar(&recursive, "recursive", false, "Delete subtasks first and report per-node results")
 	bindHelpFlag(fs, &help)
 	if err := parseFlagSetInterspersed(fs, args); err != nil {
 		return &CodeError{Code: exitUsage, Err: err}
@@ -261,23 +409,53 @@ func taskDelete(ctx *Context, args []string) error {
 	if len(fs.Args()) > 0 {
 		ref = strings.Join(fs.Args(), " ")
 	}
+	if strings.TrimSpace(id) == "" && strings.TrimSpace(ref) == "" && strings.TrimSpace(filter) == "" {
+		printTaskHelp(ctx.Stderr)
+		return &CodeError{Code: exitUsage, Err: errors.New("task delete requires --id or a reference")}
+	}
 	if err := ensureClient(ctx); err != nil {
 		return err
 	}
-	svc := apptasks.Service{Resolver: cliTaskResolver{ctx: ctx}}
-	resolvedID, err := svc.ResolveTaskTarget(context.Background(), apptasks.ResolveTaskTargetInput{ID: id, Ref: ref})
+	svc := apptasks.Service{
+		Resolver: cliTaskResolver{ctx: ctx},
+		Lister:   cliTaskFilterLister{ctx: ctx},
+	}
+	resolved, err := svc.ResolveBulkTargets(context.Background(), apptasks.ResolveBulkInput{
+		Action: "delete",
+		ID:     id,
+		Ref:    ref,
+		Filter: filter,
+		Yes:    yes,
+		Force:  ctx.Global.Force,
+	})
 	if err != nil {
-		if strings.TrimSpace(id) == "" && strings.TrimSpace(ref) == "" {
-			printTaskHelp(ctx.Stderr)
-			return &CodeError{Code: exitUsage, Err: errors.New("task delete requires --id or a reference")}
-		}
 		printTaskHelp(ctx.Stderr)
 		return asUsageIfGeneric(err)
 	}
-	id = resolvedID
+	if recursive && resolved.Mode == "bulk" {
+		return &CodeError{Code: exitUsage, Err: errors.New("--recursive cannot be combined with --filter")}
+	}
+	if resolved.Mode == "bulk" {
+		if ctx.Global.DryRun {
+			return writeBulkPreview(ctx, "task delete bulk", resolved.Filter, resolved.Tasks, nil)
+		}
+		results, err := bulkDeleteTasks(ctx, resolved.Tasks)
+		if err != nil {
+			return err
+		}
+		return writeBulkResults(ctx, "delete", resolved.Filter, results)
+	}
+	id = resolved.ID
 	if !yes {
 		return &CodeError{Code: exitUsage, Err: errors.New("task delete requires --yes")}
 	}
+	if recursive {
+		return taskRecursive(ctx, "delete", id, false, func(taskID string) (string, error) {
+			reqCtx, cancel := requestContext(ctx)
+			defer cancel()
+			return ctx.Client.Delete(reqCtx, "/tasks/"+taskID, nil)
+		})
+	}
 	if ctx.Global.DryRun {
 		return writeDryRun(ctx, "task delete", map[string]any{"id": id})
 	}
@@ -290,3 +468,34 @@ func taskDelete(ctx *Context, args []string) error {
 	setRequestID(ctx, reqID)
 	return writeSimpleResult(ctx, "deleted", id)
 }
+
+// bulkDeleteTasks deletes the matched tasks concurrently. Deleting a task
+// removes its subtasks, so matched descendants of another matched task are
+// not sent (their delete would race the cascade and 404) and are reported as
+// deleted along with it.
+func bulkDeleteTasks(ctx *Context, matched []api.Task) ([]bulkItemResult, error) {
+	all, err := listAllActiveTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	act, cascaded := apptasks.SplitCascaded(matched, all)
+	sent := runBulkReport(ctx, "task delete", act, "deleted", func(task api.Task) (string, error) {
+		reqCtx, cancel := requestContext(ctx)
+		defer cancel()
+		return ctx.Client.Delete(reqCtx, "/tasks/"+task.ID, nil)
+	})
+	byCascade := make(map[string]bool, len(cascaded))
+	for _, task := range cascaded {
+		byCascade[task.ID] = true
+	}
+	results := make([]bulkItemResult, 0, len(matched))
+	for _, task := range matched {
+		if byCascade[task.ID] {
+			results = append(results, bulkItemResult{ID: task.ID, Content: task.Content, Status: "deleted"})
+			continue
+		}
+		results = append(results, sent[0])
+		sent = sent[1:]
+	}
+	return results, nil
+}
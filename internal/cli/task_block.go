@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	apptasks "github.com/agisilaos/todoist-cli/internal/app/tasks"
+	"github.com/agisilaos/todoist-cli/internal/output"
+)
+
+func taskBlock(ctx *Context, args []string) error {
+	return taskEditBlockers(ctx, "task block", args)
+}
+
+func taskUnblock(ctx *Context, args []string) error {
+	return taskEditBlockers(ctx, "task unblock", args)
+}
+
+// taskEditBlockers adds (task block) or removes (task unblock) blocker links
+// in the task's description metadata block.
+func taskEditBlockers(ctx *Context, command string, args []string) error {
+	block := command == "task block"
+	fs := newFlagSet(command)
+	var id string
+	var by multiValue
+	var clear bool
+	var help bool
+	fs.StringVar(&id, "id", "", "Task ID")
+	fs.Var(&by, "by", "Blocking task reference (repeatable)")
+	if !block {
+		fs.BoolVar(&clear, "all", false, "Remove every blocker")
+	}
+	bindHelpFlag(fs, &help)
+	if err := parseFlagSetInterspersed(fs, args); err != nil {
+		return &CodeError{Code: exitUsage, Err: err}
+	}
+	if help {
+		printTaskHelp(ctx.Stdout)
+		return nil
+	}
+	ref := id
+	if ref == "" && len(fs.Args()) > 0 {
+		ref = strings.Join(fs.Args(), " ")
+	}
+	switch {
+	case ref == "":
+		printTaskHelp(ctx.Stderr)
+		return &CodeError{Code: exitUsage, Err: fmt.Errorf("%s requires id or text reference", command)}
+	case block && len(by) == 0:
+		return &CodeError{Code: exitUsage, Err: errors.New("task block requires --by")}
+	case !block && (len(by) == 0) == !clear:
+		return &CodeError{Code: exitUsage, Err: errors.New("task unblock requires --by or --all")}
+	}
+	if err := ensureClient(ctx); err != nil {
+		return err
+	}
+	task, err := resolveTaskRef(ctx, ref)
+	if err != nil {
+		return err
+	}
+	current := apptasks.BlockedBy(task.Description)
+	blockerIDs := make([]string, 0, len(by))
+	for _, value := range by {
+		// A recorded blocker may already be deleted, so unblock matches
+		// raw IDs before resolving references.
+		if raw := stripIDPrefix(strings.TrimSpace(value)); !block && slices.Contains(current, raw) {
+			blockerIDs = append(blockerIDs, raw)
+			continue
+		}
+		blocker, err := resolveTaskRef(ctx, value)
+		if err != nil {
+			return err
+		}
+		if blocker.ID == task.ID {
+			return &CodeError{Code: exitUsage, Err: errors.New("a task cannot block itself")}
+		}
+		blockerIDs = append(blockerIDs, blocker.ID)
+	}
+
+	var next []string
+	if block {
+		active, err := listAllActiveTasks(ctx)
+		if err != nil {
+			return err
+		}
+		next = append(next, current...)
+		for _, blockerID := range blockerIDs {
+			if path := apptasks.BlockingPath(active, task.ID, blockerID); path != nil {
+				return &CodeError{Code: exitConflict, Err: fmt.Errorf("blocking %s on %s would create a cycle: %s", task.ID, blockerID, strings.Join(append(path, blockerID), " -> "))}
+			}
+			if !slices.Contains(next, blockerID) {
+				next = append(next, blockerID)
+			}
+		}
+	} else if !clear {
+		for _, blockerID := range current {
+			if !slices.Contains(blockerIDs, blockerID) {
+				next = append(next, blockerID)
+			}
+		}
+	}
+
+	status := "blocked"
+	if !block {
+		status = "unblocked"
+	}
+	if strings.Join(next, ",") == strings.Join(current, ",") {
+		return writeBlockerResult(ctx, "unchanged", task.ID, current)
+	}
+	body := map[string]any{"description": apptasks.WithBlockedBy(task.Description, next)}
+	if ctx.Global.DryRun {
+		return writeDryRun(ctx, command, map[string]any{"id": task.ID, "blocked_by": next, "body": body})
+	}
+	reqCtx, cancel := requestContext(ctx)
+	reqID, err := ctx.Client.Post(reqCtx, "/tasks/"+task.ID, nil, body, nil, true)
+	cancel()
+	if err != nil {
+		return err
+	}
+	setRequestID(ctx, reqID)
+	return writeBlockerResult(ctx, status, task.ID, next)
+}
+
+func writeBlockerResult(ctx *Context, status, id string, blockedBy []string) error {
+	if blockedBy == nil {
+		blockedBy = []string{}
+	}
+	if ctx.Mode == output.ModeJSON {
+		return output.WriteJSON(ctx.Stdout, map[string]any{
+			"id":         id,
+			"status":     status,
+			"blocked_by": blockedBy,
+		}, output.Meta{RequestID: ctx.RequestID})
+	}
+	if len(blockedBy) == 0 {
+		fmt.Fprintf(ctx.Stdout, "%s %s (no blockers)\n", status, id)
+		return nil
+	}
+	fmt.Fprintf(ctx.Stdout, "%s %s blocked_by=%s\n", status, id, strings.Join(blockedBy, ","))
+	return nil
+}
+
+// writeUnblocked reports tasks whose last open blocker was just completed.
+func writeUnblocked(ctx *Context, tasks []api.Task) {
+	for _, task := range tasks {
+		fmt.Fprintf(ctx.Stdout, "unblocked %s %s\n", task.ID, task.Content)
+	}
+}
+
+type taskRef struct {
+	ID      string `json:"id"`
+	Content string `json:"content"`
+}
+
+func taskRefs(tasks []api.Task) []taskRef {
+	out := make([]taskRef, 0, len(tasks))
+	for _, task := range tasks {
+		out = append(out, taskRef{ID: task.ID, Content: task.Content})
+	}
+	return out
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	"github.com/agisilaos/todoist-cli/internal/mockserver"
+)
+
+func TestTaskBlockListAndComplete(t *testing.T) {
+	run := newMockCLIRunner(t, mockserver.Fixture{
+		Projects: []api.Project{{ID: "p1", Name: "Release"}},
+		Tasks: []api.Task{
+			{ID: "t1", Content: "Run migrations", ProjectID: "p1"},
+			{ID: "t2", Content: "Deploy", ProjectID: "p1", Description: "after freeze"},
+			{ID: "t3", Content: "Announce", ProjectID: "p1"},
+		},
+	}).run
+	listIDs := func(flag string) string {
+		t.Helper()
+		var tasks []api.Task
+		if err := json.Unmarshal([]byte(run(exitOK, "--json", "task", "list", "--project", "Release", flag)), &tasks); err != nil {
+			t.Fatalf("decode list: %v", err)
+		}
+		var ids []string
+		for _, task := range tasks {
+			ids = append(ids, task.ID)
+		}
+		return strings.Join(ids, ",")
+	}
+
+	run(exitUsage, "task", "block", "id:t2")
+	run(exitUsage, "task", "block", "id:t2", "--by", "id:t2")
+	run(exitUsage, "task", "unblock", "id:t2")
+	run(exitUsage, "task", "list", "--blocked", "--actionable")
+
+	if out := run(exitOK, "task", "block", "id:t2", "--by", "id:t1"); out != "blocked t2 blocked_by=t1\n" {
+		t.Fatalf("unexpected block output: %q", out)
+	}
+	run(exitOK, "task", "block", "id:t3", "--by", "id:t2")
+	run(exitConflict, "task", "block", "id:t1", "--by", "id:t3")
+	if out := run(exitOK, "task", "block", "id:t2", "--by", "id:t1"); !strings.HasPrefix(out, "unchanged t2") {
+		t.Fatalf("expected unchanged, got %q", out)
+	}
+
+	var task api.Task
+	if err := json.Unmarshal([]byte(run(exitOK, "--json", "task", "view", "id:t2")), &task); err != nil {
+		t.Fatalf("decode task: %v", err)
+	}
+	if task.Description != "after freeze\n\n```todoist-cli\nblocked_by: t1\n```" {
+		t.Fatalf("unexpected description: %q", task.Description)
+	}
+
+	if got := listIDs("--blocked"); got != "t2,t3" {
+		t.Fatalf("unexpected blocked tasks: %s", got)
+	}
+	if got := listIDs("--actionable"); got != "t1" {
+		t.Fatalf("unexpected actionable tasks: %s", got)
+	}
+
+	var completed struct {
+		Status    string    `json:"status"`
+		Unblocked []taskRef `json:"unblocked"`
+	}
+	if err := json.Unmarshal([]byte(run(exitOK, "--json", "task", "complete", "id:t1", "--show-unblocked")), &completed); err != nil {
+		t.Fatalf("decode complete: %v", err)
+	}
+	if completed.Status != "completed" || len(completed.Unblocked) != 1 || completed.Unblocked[0].ID != "t2" {
+		t.Fatalf("unexpected complete result: %#v", completed)
+	}
+	if got := listIDs("--actionable"); got != "t2" {
+		t.Fatalf("expected t2 actionable after its blocker completed, got %s", got)
+	}
+
+	if out := run(exitOK, "task", "unblock", "id:t3", "--all"); out != "unblocked t3 (no blockers)\n" {
+		t.Fatalf("unexpected unblock output: %q", out)
+	}
+	if out := run(exitOK, "task", "complete", "id:t2", "--show-unblocked"); out != "completed t2\n" {
+		t.Fatalf("unexpected complete output: %q", out)
+	}
+}
@@ -0,0 +1,256 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	appcomments "github.com/agisilaos/todoist-cli/internal/app/comments"
+	apptasks "github.com/agisilaos/todoist-cli/internal/app/tasks"
+	"github.com/agisilaos/todoist-cli/internal/output"
+)
+
+// duplicateItem maps one copied object to its copy. TaskID is the new task a
+// copied comment or reminder belongs to; NewID is empty in a dry run.
+type duplicateItem struct {
+	Kind    string `json:"kind"`
+	OldID   string `json:"old_id"`
+	NewID   string `json:"new_id,omitempty"`
+	TaskID  string `json:"task_id,omitempty"`
+	Content string `json:"content,omitempty"`
+	Depth   int    `json:"depth,omitempty"`
+}
+
+func taskDuplicate(ctx *Context, args []string) error {
+	fs := newFlagSet("task duplicate")
+	var id string
+	var project string
+	var withSubtasks bool
+	var withComments bool
+	var withReminders bool
+	var help bool
+	fs.StringVar(&id, "id", "", "Task ID")
+	fs.StringVar(&project, "project", "", "Project for the copy (default: next to the original)")
+	fs.BoolVar(&withSubtasks, "with-subtasks", false, "Copy open subtasks")
+	fs.BoolVar(&withComments, "with-comments", false, "Copy comments")
+	fs.BoolVar(&withReminders, "with-reminders", false, "Copy reminders")
+	bindHelpFlag(fs, &help)
+	if err := parseFlagSetInterspersed(fs, args); err != nil {
+		return &CodeError{Code: exitUsage, Err: err}
+	}
+	if help {
+		printTaskHelp(ctx.Stdout)
+		return nil
+	}
+	ref := id
+	if ref == "" && len(fs.Args()) > 0 {
+		ref = strings.Join(fs.Args(), " ")
+	}
+	if ref == "" {
+		printTaskHelp(ctx.Stderr)
+		return &CodeError{Code: exitUsage, Err: errors.New("task duplicate requires id or text reference")}
+	}
+	if err := ensureClient(ctx); err != nil {
+		return err
+	}
+	root, err := resolveTaskRef(ctx, ref)
+	if err != nil {
+		return err
+	}
+	projectID := ""
+	if project != "" {
+		if projectID, err = resolveProjectID(ctx, project); err != nil {
+			return err
+		}
+	}
+	nodes := []apptasks.FlatNode{{Task: root}}
+	if withSubtasks {
+		tree, err := activeSubtree(ctx, root)
+		if err != nil {
+			return err
+		}
+		nodes = apptasks.Flatten([]*apptasks.TreeNode{tree})
+	}
+	comments := map[string][]api.Comment{}
+	if withComments {
+		for _, node := range nodes {
+			query := url.Values{}
+			query.Set("task_id", node.Task.ID)
+			query.Set("limit", "200")
+			if comments[node.Task.ID], _, err = fetchPaginated[api.Comment](ctx, "/comments", query, true); err != nil {
+				return err
+			}
+		}
+	}
+	reminders := map[string][]api.Reminder{}
+	if withReminders {
+		reqCtx, cancel := requestContext(ctx)
+		all, reqID, err := ctx.Client.FetchReminders(reqCtx)
+		cancel()
+		if err != nil {
+			return err
+		}
+		setRequestID(ctx, reqID)
+		for _, reminder := range all {
+			reminders[reminder.ItemID] = append(reminders[reminder.ItemID], reminder)
+		}
+	}
+
+	if ctx.Global.DryRun {
+		var items []duplicateItem
+		for _, node := range nodes {
+			items = append(items, duplicateItem{Kind: "task", OldID: node.Task.ID, Content: node.Task.Content, Depth: node.Depth})
+			for _, comment := range comments[node.Task.ID] {
+				items = append(items, duplicateItem{Kind: "comment", OldID: comment.ID, Content: comment.Content, Depth: node.Depth})
+			}
+			for _, reminder := range reminders[node.Task.ID] {
+				items = append(items, duplicateItem{Kind: "reminder", OldID: reminder.ID, Depth: node.Depth})
+			}
+		}
+		return writeDuplicateResult(ctx, root.ID, items, true, false)
+	}
+
+	newIDs := map[string]string{}
+	var items []duplicateItem
+	// fail reports the copies made so far, so they can be found and cleaned
+	// up, before returning err.
+	fail := func(err error) error {
+		if len(items) == 0 {
+			return err
+		}
+		if writeErr := writeDuplicateResult(ctx, root.ID, items, false, true); writeErr != nil {
+			return writeErr
+		}
+		return fmt.Errorf("duplicate stopped after %s: %w", duplicateSummary(items), err)
+	}
+	for _, node := range nodes {
+		task := node.Task
+		parentID := ""
+		if task.ID != root.ID {
+			parentID = newIDs[task.ParentID]
+		}
+		var created api.Task
+		reqCtx, cancel := requestContext(ctx)
+		reqID, err := ctx.Client.Post(reqCtx, "/tasks", nil, apptasks.DuplicatePayload(task, projectID, parentID), &created, true)
+		cancel()
+		if err != nil {
+			return fail(fmt.Errorf("copy task %s: %w", task.ID, err))
+		}
+		setRequestID(ctx, reqID)
+		newIDs[task.ID] = created.ID
+		items = append(items, duplicateItem{Kind: "task", OldID: task.ID, NewID: created.ID, Content: task.Content, Depth: node.Depth})
+
+		for _, comment := range comments[task.ID] {
+			copied, err := copyComment(ctx, comment, created.ID)
+			if err != nil {
+				return fail(fmt.Errorf("copy comment %s: %w", comment.ID, err))
+			}
+			items = append(items, duplicateItem{Kind: "comment", OldID: comment.ID, NewID: copied.ID, TaskID: created.ID, Content: comment.Content, Depth: node.Depth})
+		}
+		for _, reminder := range reminders[task.ID] {
+			reqCtx, cancel := requestContext(ctx)
+			reminderID, reqID, err := ctx.Client.AddReminder(reqCtx, api.ReminderAddInput{ItemID: created.ID, MinuteOffset: reminder.MinuteOffset, Due: reminder.Due})
+			cancel()
+			if err != nil {
+				return fail(fmt.Errorf("copy reminder %s: %w", reminder.ID, err))
+			}
+			setRequestID(ctx, reqID)
+			items = append(items, duplicateItem{Kind: "reminder", OldID: reminder.ID, NewID: reminderID, TaskID: created.ID, Depth: node.Depth})
+		}
+	}
+	return writeDuplicateResult(ctx, root.ID, items, false, false)
+}
+
+// copyComment re-posts comment on taskID. Attachment-only comments take the
+// file name as their text, since the API requires content.
+func copyComment(ctx *Context, comment api.Comment, taskID string) (api.Comment, error) {
+	content := comment.Content
+	if strings.TrimSpace(content) == "" && comment.Attachment != nil {
+		content = comment.Attachment.FileName
+	}
+	body, err := appcomments.BuildAddPayload(appcomments.AddInput{Content: content, TaskID: taskID})
+	if err != nil {
+		return api.Comment{}, err
+	}
+	if comment.Attachment != nil {
+		body["attachment"] = comment.Attachment
+	}
+	var created api.Comment
+	reqCtx, cancel := requestContext(ctx)
+	reqID, err := ctx.Client.Post(reqCtx, "/comments", nil, body, &created, true)
+	cancel()
+	if err != nil {
+		return api.Comment{}, err
+	}
+	setRequestID(ctx, reqID)
+	return created, nil
+}
+
+func duplicateSummary(items []duplicateItem) string {
+	counts := map[string]int{}
+	for _, item := range items {
+		counts[item.Kind]++
+	}
+	return fmt.Sprintf("tasks=%d comments=%d reminders=%d", counts["task"], counts["comment"], counts["reminder"])
+}
+
+func writeDuplicateResult(ctx *Context, sourceID string, items []duplicateItem, dryRun, partial bool) error {
+	mapping := map[string]string{}
+	newRootID := ""
+	for _, item := range items {
+		if item.NewID == "" {
+			continue
+		}
+		mapping[item.OldID] = item.NewID
+		if item.OldID == sourceID {
+			newRootID = item.NewID
+		}
+	}
+	switch ctx.Mode {
+	case output.ModeJSON:
+		return output.WriteJSON(ctx.Stdout, map[string]any{
+			"action":    "duplicate",
+			"source_id": sourceID,
+			"id":        newRootID,
+			"dry_run":   dryRun,
+			"partial":   partial,
+			"mapping":   mapping,
+			"items":     items,
+			"count":     len(items),
+		}, output.Meta{RequestID: ctx.RequestID, Count: len(items)})
+	case output.ModeNDJSON:
+		return output.WriteNDJSONSlice(ctx.Stdout, items)
+	case output.ModePlain:
+		rows := make([][]string, 0, len(items))
+		for _, item := range items {
+			rows = append(rows, []string{item.Kind, item.OldID, item.NewID, item.TaskID, item.Content})
+		}
+		return output.WritePlain(ctx.Stdout, rows)
+	}
+	if dryRun {
+		fmt.Fprintln(ctx.Stdout, "dry run: task duplicate")
+	}
+	for _, item := range items {
+		target := item.NewID
+		if dryRun {
+			target = "(new)"
+		}
+		line := fmt.Sprintf("%s%s %s -> %s", strings.Repeat("  ", item.Depth), item.Kind, item.OldID, target)
+		if item.Content != "" {
+			line += " " + item.Content
+		}
+		fmt.Fprintln(ctx.Stdout, line)
+	}
+	if dryRun {
+		fmt.Fprintf(ctx.Stdout, "would duplicate: %s\n", duplicateSummary(items))
+		return nil
+	}
+	if partial {
+		fmt.Fprintf(ctx.Stdout, "duplicate stopped: %s\n", duplicateSummary(items))
+		return nil
+	}
+	fmt.Fprintf(ctx.Stdout, "duplicate done: %s\n", duplicateSummary(items))
+	return nil
+}
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	"github.com/agisilaos/todoist-cli/internal/mockserver"
+)
+
+func TestTaskDuplicate(t *testing.T) {
+	cli := newMockCLIRunner(t, mockserver.Fixture{
+		Projects: []api.Project{{ID: "p1", Name: "Home"}, {ID: "p2", Name: "Work"}},
+		Tasks: []api.Task{
+			{ID: "t1", Content: "Plan trip", Description: "summer", ProjectID: "p1", Labels: []string{"travel"}, Priority: 3,
+				Due: &api.Due{Date: "2026-10-19", String: "every monday", IsRecurring: true}, Duration: &api.Duration{Amount: 30, Unit: "minute"}, Deadline: &api.Deadline{Date: "2026-11-01"}},
+			{ID: "t2", Content: "Book flights", ProjectID: "p1", ParentID: "t1"},
+			{ID: "t3", Content: "Pick seats", ProjectID: "p1", ParentID: "t2", Due: &api.Due{Date: "2026-10-20"}},
+			{ID: "t4", Content: "Book hotel", ProjectID: "p1", ParentID: "t1"},
+		},
+		Comments:  []mockserver.Comment{{Comment: api.Comment{ID: "c1", Content: "check prices"}, TaskID: "t2"}},
+		Reminders: []api.Reminder{{ID: "r1", ItemID: "t1", Type: "relative", MinuteOffset: 30}},
+	})
+	run := cli.run
+	type result struct {
+		ID      string            `json:"id"`
+		DryRun  bool              `json:"dry_run"`
+		Mapping map[string]string `json:"mapping"`
+		Items   []duplicateItem   `json:"items"`
+	}
+	decode := func(out string) result {
+		t.Helper()
+		var res result
+		if err := json.Unmarshal([]byte(out), &res); err != nil {
+			t.Fatalf("decode %q: %v", out, err)
+		}
+		return res
+	}
+
+	run(exitUsage, "task", "duplicate")
+
+	preview := decode(run(exitOK, "--json", "--dry-run", "task", "duplicate", "id:t1", "--with-subtasks", "--with-comments", "--with-reminders"))
+	if !preview.DryRun || len(preview.Items) != 6 || len(preview.Mapping) != 0 {
+		t.Fatalf("unexpected dry run: %#v", preview)
+	}
+
+	res := decode(run(exitOK, "--json", "task", "duplicate", "id:t1", "--project", "Work", "--with-subtasks", "--with-comments", "--with-reminders"))
+	if len(res.Mapping) != 6 || res.ID == "" || res.Mapping["t1"] != res.ID {
+		t.Fatalf("unexpected mapping: %#v", res)
+	}
+	var kinds []string
+	for _, item := range res.Items {
+		kinds = append(kinds, item.Kind+":"+item.OldID)
+	}
+	if got := strings.Join(kinds, " "); got != "task:t1 reminder:r1 task:t2 comment:c1 task:t3 task:t4" {
+		t.Fatalf("unexpected order: %s", got)
+	}
+
+	client := api.NewClient(cli.baseURL, "mock", 2*time.Second)
+	reqCtx := context.Background()
+	var root api.Task
+	if _, err := client.Get(reqCtx, "/tasks/"+res.ID, nil, &root); err != nil {
+		t.Fatalf("get copy: %v", err)
+	}
+	if root.ProjectID != "p2" || root.ParentID != "" || root.Description != "summer" || root.Priority != 3 || len(root.Labels) != 1 ||
+		root.Due == nil || root.Due.String != "every monday" || root.Duration == nil || root.Duration.Amount != 30 || root.Deadline == nil || root.Deadline.Date != "2026-11-01" {
+		t.Fatalf("unexpected copied root: %#v", root)
+	}
+	var children api.Paginated[api.Task]
+	if _, err := client.Get(reqCtx, "/tasks", url.Values{"parent_id": {res.Mapping["t2"]}}, &children); err != nil {
+		t.Fatalf("list copied subtasks: %v", err)
+	}
+	if len(children.Results) != 1 || children.Results[0].ID != res.Mapping["t3"] || children.Results[0].Due == nil || children.Results[0].Due.Date != "2026-10-20" {
+		t.Fatalf("unexpected copied subtree: %#v", children.Results)
+	}
+	var comments api.Paginated[api.Comment]
+	if _, err := client.Get(reqCtx, "/comments", url.Values{"task_id": {res.Mapping["t2"]}}, &comments); err != nil {
+		t.Fatalf("list copied comments: %v", err)
+	}
+	if len(comments.Results) != 1 || comments.Results[0].Content != "check prices" {
+		t.Fatalf("unexpected copied comments: %#v", comments.Results)
+	}
+	reminders, _, err := client.FetchReminders(reqCtx)
+	if err != nil || len(reminders) != 2 || reminders[1].ItemID != res.ID || reminders[1].MinuteOffset != 30 {
+		t.Fatalf("unexpected reminders: %#v %v", reminders, err)
+	}
+
+	out := run(exitOK, "task", "dup", "id:t4")
+	if !strings.HasPrefix(out, "task\tt4\t") {
+		t.Fatalf("unexpected plain output: %q", out)
+	}
+}
+
+func TestTaskDuplicateReportsPartialCopy(t *testing.T) {
+	srv := mockserver.New(mockserver.Fixture{
+		Projects: []api.Project{{ID: "p1", Name: "Home"}},
+		Tasks: []api.Task{
+			{ID: "t1", Content: "Plan trip", ProjectID: "p1"},
+			{ID: "t2", Content: "Book flights", ProjectID: "p1", ParentID: "t1"},
+		},
+		Comments: []mockserver.Comment{{Comment: api.Comment{ID: "c1", Content: "check prices"}, TaskID: "t2"}},
+	})
+	cli := newMockCLIHandlerRunner(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/comments") {
+			http.Error(w, `{"error":"comment rejected"}`, http.StatusBadRequest)
+			return
+		}
+		srv.ServeHTTP(w, r)
+	}))
+	stdout, stderr := cli.runWithStderr(exitError, "--json", "task", "duplicate", "id:t1", "--with-subtasks", "--with-comments")
+	var res struct {
+		Partial bool              `json:"partial"`
+		Mapping map[string]string `json:"mapping"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &res); err != nil {
+		t.Fatalf("decode partial result %q: %v", stdout, err)
+	}
+	if !res.Partial || len(res.Mapping) != 2 || res.Mapping["t1"] == "" || res.Mapping["t2"] == "" {
+		t.Fatalf("unexpected partial result: %s", stdout)
+	}
+	if !strings.Contains(stderr, "duplicate stopped after tasks=2 comments=0 reminders=0") {
+		t.Fatalf("unexpected error: %s", stderr)
+	}
+}
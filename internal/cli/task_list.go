@@ -22,15 +22,19 @@ func taskList(ctx *Context, args []string) error {
 	var cursor string
 	var limit int
 	var all bool
+	var maxItems int
 	var allProjects bool
 	var completed bool
 	var completedBy string
 	var since string
 	var until string
 	var wide bool
+	var tree bool
 	var preset string
 	var sortBy string
 	var truncateWidth int
+	var blocked bool
+	var actionable bool
 	var help bool
 	fs.StringVar(&filter, "filter", "", "Filter query")
 	fs.StringVar(&project, "project", "", "Project")
@@ -41,15 +45,19 @@ func taskList(ctx *Context, args []string) error {
 	fs.StringVar(&cursor, "cursor", "", "Cursor")
 	fs.IntVar(&limit, "limit", 50, "Limit")
 	fs.BoolVar(&all, "all", false, "Fetch all pages")
+	fs.IntVar(&maxItems, "max-items", 0, "Cap the number of tasks fetched")
 	fs.BoolVar(&allProjects, "all-projects", false, "List tasks from all projects")
 	fs.BoolVar(&completed, "completed", false, "List completed tasks")
 	fs.StringVar(&completedBy, "completed-by", "completion", "completed or due")
 	fs.StringVar(&since, "since", "", "Start date (RFC3339 or YYYY-MM-DD)")
 	fs.StringVar(&until, "until", "", "End date (RFC3339 or YYYY-MM-DD)")
 	fs.BoolVar(&wide, "wide", false, "Wider table output")
+	fs.BoolVar(&tree, "tree", false, "Nest subtasks under their parents")
 	fs.StringVar(&preset, "preset", "", "Shortcut filter: today, overdue, next7")
 	fs.StringVar(&sortBy, "sort", "", "Sort by: due, priority")
 	fs.IntVar(&truncateWidth, "truncate-width", 0, "Override table width (human output)")
+	fs.BoolVar(&blocked, "blocked", false, "Only tasks waiting on an open blocker")
+	fs.BoolVar(&actionable, "actionable", false, "Only tasks without open blockers")
 	bindHelpFlag(fs, &help)
 	if err := parseFlagSetInterspersed(fs, args); err != nil {
 		return &CodeError{Code: exitUsage, Err: err}
@@ -76,21 +84,99 @@ func taskList(ctx *Context, args []string) error {
 	if err != nil {
 		return &CodeError{Code: exitUsage, Err: err}
 	}
+	if maxItems < 0 {
+		return &CodeError{Code: exitUsage, Err: errors.New("--max-items must be zero or positive")}
+	}
+	if blocked && actionable {
+		return &CodeError{Code: exitUsage, Err: errors.New("--blocked and --actionable cannot be combined")}
+	}
 	if truncateWidth > 0 {
 		ctx.Config.TableWidth = truncateWidth
 	}
 	if plan.Mode == "completed" {
-		return taskListCompleted(ctx, plan.CompletedBy, plan.Filter, project, section, parent, plan.Since, plan.Until, cursor, limit, all, wide)
+		if blocked || actionable {
+			return &CodeError{Code: exitUsage, Err: errors.New("--blocked/--actionable cannot be combined with --completed")}
+		}
+		return taskListCompleted(ctx, plan.CompletedBy, plan.Filter, project, section, parent, plan.Since, plan.Until, cursor, limit, all, maxItems, wide, tree)
+	}
+	var keep func(api.Task) bool
+	if blocked || actionable {
+		if keep, err = blockerFilter(ctx, blocked); err != nil {
+			return err
+		}
+	}
+	opts := taskListOptions{
+		Project:     project,
+		Section:     section,
+		Parent:      parent,
+		Label:       label,
+		IDs:         ids,
+		AllProjects: allProjects,
+		SortBy:      sortBy,
+		Cursor:      cursor,
+		Limit:       limit,
+		All:         all,
+		MaxItems:    maxItems,
+		Wide:        wide,
+		Tree:        tree,
+		Keep:        keep,
 	}
 	if plan.Mode == "filter" {
-		return taskListFiltered(ctx, plan.Filter, cursor, limit, all, wide)
+		return taskListFiltered(ctx, plan.Filter, opts)
 	}
-	return taskListActive(ctx, project, section, parent, label, ids, cursor, limit, all, allProjects, wide, sortBy)
+	return taskListActive(ctx, opts)
 }
 
-func taskListActive(ctx *Context, project, section, parent, label, ids, cursor string, limit int, all bool, allProjects bool, wide bool, sortBy string) error {
+// taskListOptions controls a task listing. The selectors up to SortBy apply
+// to taskListActive only; Keep, when set, narrows fetched tasks client-side.
+type taskListOptions struct {
+	Project     string
+	Section     string
+	Parent      string
+	Label       string
+	IDs         string
+	AllProjects bool
+	SortBy      string
+
+	Cursor   string
+	Limit    int
+	All      bool
+	MaxItems int
+	Wide     bool
+	Tree     bool
+	Keep     func(api.Task) bool
+}
+
+// blockerFilter keeps tasks that wait on an open blocker (blocked) or that
+// wait on none. Blockers count as open while they are active tasks.
+func blockerFilter(ctx *Context, blocked bool) (func(api.Task) bool, error) {
+	active, err := listAllActiveTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	open := apptasks.OpenBlockers(active)
+	return func(task api.Task) bool {
+		return (len(open[task.ID]) > 0) == blocked
+	}, nil
+}
+
+func keepTasks(tasks []api.Task, keep func(api.Task) bool) []api.Task {
+	if keep == nil {
+		return tasks
+	}
+	out := make([]api.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if keep(task) {
+			out = append(out, task)
+		}
+	}
+	return out
+}
+
+func taskListActive(ctx *Context, opts taskListOptions) error {
 	query := url.Values{}
-	if project == "" && section == "" && parent == "" && label == "" && ids == "" && !allProjects {
+	project := opts.Project
+	if project == "" && opts.Section == "" && opts.Parent == "" && opts.Label == "" && opts.IDs == "" && !opts.AllProjects {
 		id, err := inboxProjectID(ctx)
 		if err == nil && id != "" {
 			project = id
@@ -103,45 +189,80 @@ func taskListActive(ctx *Context, project, section, parent, label, ids, cursor s
 		}
 		query.Set("project_id", id)
 	}
-	if section != "" {
-		id, err := resolveSectionID(ctx, section, project)
+	if opts.Section != "" {
+		id, err := resolveSectionID(ctx, opts.Section, project)
 		if err != nil {
 			return err
 		}
 		query.Set("section_id", id)
 	}
-	if parent != "" {
-		query.Set("parent_id", parent)
+	if opts.Parent != "" {
+		query.Set("parent_id", opts.Parent)
 	}
-	if label != "" {
-		name, err := resolveLabelName(ctx, label)
+	if opts.Label != "" {
+		name, err := resolveLabelName(ctx, opts.Label)
 		if err != nil {
 			return err
 		}
 		query.Set("label", name)
 	}
-	if ids != "" {
-		query.Set("ids", ids)
+	if opts.IDs != "" {
+		query.Set("ids", opts.IDs)
 	}
-	query.Set("limit", strconv.Itoa(limit))
-	if cursor != "" {
-		query.Set("cursor", cursor)
+	query.Set("limit", strconv.Itoa(opts.Limit))
+	if opts.Cursor != "" {
+		query.Set("cursor", opts.Cursor)
+	}
+	if opts.SortBy == "" {
+		return listTasksPaginated(ctx, "/tasks", query, opts)
 	}
-	allTasks, next, err := fetchPaginated[api.Task](ctx, "/tasks", query, all)
+	var allTasks []api.Task
+	next, err := streamPaginated(ctx, "/tasks", query, opts.All, opts.MaxItems, func(page []api.Task) error {
+		allTasks = append(allTasks, page...)
+		return nil
+	})
 	if err != nil {
 		return err
 	}
-	sortTasks(allTasks, sortBy)
-	return writeTaskList(ctx, allTasks, next, wide)
+	allTasks = keepTasks(allTasks, opts.Keep)
+	sortTasks(allTasks, opts.SortBy)
+	if opts.Tree {
+		return writeTaskTree(ctx, allTasks, next, opts.Wide)
+	}
+	return writeTaskList(ctx, allTasks, next, opts.Wide)
 }
 
-func taskListFiltered(ctx *Context, filter, cursor string, limit int, all bool, wide bool) error {
-	allTasks, next, err := listTasksByFilter(ctx, filter, cursor, limit, all)
-	if err != nil {
-		return err
+// listTasksPaginated streams flat NDJSON lists; trees, lists narrowed by
+// Keep and other modes buffer every fetched page first.
+func listTasksPaginated(ctx *Context, path string, query url.Values, opts taskListOptions) error {
+	if opts.Tree || opts.Keep != nil {
+		return collectPaginated(ctx, path, query, opts.All, opts.MaxItems, func(tasks []api.Task, next string) error {
+			tasks = keepTasks(tasks, opts.Keep)
+			if opts.Tree {
+				return writeTaskTree(ctx, tasks, next, opts.Wide)
+			}
+			return writeTaskList(ctx, tasks, next, opts.Wide)
+		})
+	}
+	return listPaginated(ctx, path, query, opts.All, opts.MaxItems, func(tasks []api.Task, next string) error {
+		return writeTaskList(ctx, tasks, next, opts.Wide)
+	})
+}
+
+func taskListFiltered(ctx *Context, filter string, opts taskListOptions) error {
+	query := url.Values{}
+	query.Set("query", filter)
+	query.Set("limit", strconv.Itoa(opts.Limit))
+	if opts.Cursor != "" {
+		query.Set("cursor", opts.Cursor)
 	}
 	// Keep original ordering from API for filter; no client sort to preserve meaning.
-	return writeTaskList(ctx, allTasks, next, wide)
+	err := listTasksPaginated(ctx, "/tasks/filter", query, opts)
+	if err == nil || !isInvalidSearchQueryError(err) || !isLikelyLiteralFilter(filter) {
+		return err
+	}
+	query.Set("query", apptasks.ToSearchFilter(filter))
+	return listTasksPaginated(ctx, "/tasks/filter", query, opts)
 }
 
 func listTasksByFilter(ctx *Context, filter, cursor string, limit int, all bool) ([]api.Task, string, error) {
@@ -162,7 +283,7 @@ func listTasksByFilter(ctx *Context, filter, cursor string, limit int, all bool)
 	return fetchPaginated[api.Task](ctx, "/tasks/filter", query, all)
 }
 
-func taskListCompleted(ctx *Context, completedBy, filter, project, section, parent, since, until, cursor string, limit int, all bool, wide bool) error {
+func taskListCompleted(ctx *Context, completedBy, filter, project, section, parent, since, until, cursor string, limit int, all bool, maxItems int, wide bool, tree bool) error {
 	path := "/tasks/completed/by_completion_date"
 	if completedBy == "due" {
 		path = "/tasks/completed/by_due_date"
@@ -202,11 +323,7 @@ func taskListCompleted(ctx *Context, completedBy, filter, project, section, pare
 	if cursor != "" {
 		query.Set("cursor", cursor)
 	}
-	allTasks, next, err := fetchPaginated[api.Task](ctx, path, query, all)
-	if err != nil {
-		return err
-	}
-	return writeTaskList(ctx, allTasks, next, wide)
+	return listTasksPaginated(ctx, path, query, taskListOptions{All: all, MaxItems: maxItems, Wide: wide, Tree: tree})
 }
 
 func isInvalidSearchQueryError(err error) bool {
@@ -1,10 +1,12 @@
 package cli
 
 import (
+	"context"
 	"errors"
 	"strings"
 
 	"github.com/agisilaos/todoist-cli/internal/api"
+	apptasks "github.com/agisilaos/todoist-cli/internal/app/tasks"
 )
 
 func taskAdd(ctx *Context, args []string) error {
@@ -122,16 +124,17 @@ func taskAdd(ctx *Context, args []string) error {
 		return err
 	}
 	if ctx.Global.DryRun {
-		return writeDryRun(ctx, "task add", body)
+		return writeTaskAddDryRun(ctx, body, dueString)
 	}
 	var task api.Task
-	reqCtx, cancel := requestContext(ctx)
-	reqID, err := ctx.Client.Post(reqCtx, "/tasks", nil, body, &task, true)
-	cancel()
+	queued, err := postOrQueue(ctx, "task add", "/tasks", body, &task)
 	if err != nil {
 		return err
 	}
-	setRequestID(ctx, reqID)
+	if queued {
+		return writeQueuedResult(ctx)
+	}
+	invalidateNewLabels(ctx, task.Labels)
 	return writeTaskList(ctx, []api.Task{task}, "", false)
 }
 
@@ -152,11 +155,21 @@ func taskUpdate(ctx *Context, args []string) error {
 	var assignee string
 	var project string
 	var natural bool
+	var addLabels multiValue
+	var removeLabels multiValue
+	var clearLabels bool
+	var filter string
+	var yes bool
 	var help bool
 	fs.StringVar(&id, "id", "", "Task ID")
 	fs.StringVar(&content, "content", "", "Task content")
 	fs.StringVar(&description, "description", "", "Task description")
 	fs.Var(&labels, "label", "Label")
+	fs.Var(&addLabels, "add-label", "Label to add, keeping existing labels (repeatable)")
+	fs.Var(&removeLabels, "remove-label", "Label to remove, keeping the others (repeatable)")
+	fs.BoolVar(&clearLabels, "clear-labels", false, "Remove all labels")
+	fs.StringVar(&filter, "filter", "", "Filter query for bulk update")
+	fs.BoolVar(&yes, "yes", false, "Required for bulk update")
 	fs.Var((*priorityFlag)(&priority), "priority", "Priority (accepts p1..p4)")
 	fs.StringVar(&dueString, "due", "", "Due string")
 	fs.StringVar(&dueDate, "due-date", "", "Due date")
@@ -176,21 +189,36 @@ func taskUpdate(ctx *Context, args []string) error {
 		printTaskHelp(ctx.Stdout)
 		return nil
 	}
-	if id == "" && len(fs.Args()) > 0 {
-		ref := strings.Join(fs.Args(), " ")
-		if err := ensureClient(ctx); err != nil {
-			return err
-		}
-		task, err := resolveTaskRef(ctx, ref)
-		if err != nil {
-			return err
-		}
-		id = task.ID
-	}
-	if id == "" {
+	if id == "" && len(fs.Args()) == 0 && strings.TrimSpace(filter) == "" {
 		printTaskHelp(ctx.Stderr)
 		return &CodeError{Code: exitUsage, Err: errors.New("--id is required (or pass a text reference)")}
 	}
+	labelEdit := apptasks.LabelEdit{Add: addLabels, Remove: removeLabels, Clear: clearLabels}
+	if len(labels) > 0 && !labelEdit.Empty() {
+		return &CodeError{Code: exitUsage, Err: errors.New("--label cannot be combined with --add-label, --remove-label or --clear-labels")}
+	}
+	if strings.TrimSpace(filter) != "" && strings.TrimSpace(content) != "" {
+		return &CodeError{Code: exitUsage, Err: errors.New("--content cannot be combined with --filter")}
+	}
+	if err := ensureClient(ctx); err != nil {
+		return err
+	}
+	svc := apptasks.Service{
+		Resolver: cliTaskResolver{ctx: ctx},
+		Lister:   cliTaskFilterLister{ctx: ctx},
+	}
+	resolved, err := svc.ResolveBulkTargets(context.Background(), apptasks.ResolveBulkInput{
+		Action: "update",
+		ID:     id,
+		Ref:    strings.Join(fs.Args(), " "),
+		Filter: filter,
+		Yes:    yes,
+		Force:  ctx.Global.Force,
+	})
+	if err != nil {
+		return asUsageIfGeneric(err)
+	}
+	id = resolved.ID
 	if natural && strings.TrimSpace(content) != "" {
 		parsed := parseQuickAdd(content)
 		if parsed.Content != "" {
@@ -209,9 +237,6 @@ func taskUpdate(ctx *Context, args []string) error {
 			dueString = parsed.Due
 		}
 	}
-	if err := ensureClient(ctx); err != nil {
-		return err
-	}
 	body, err := buildTaskUpdatePayload(ctx, taskMutationInput{
 		Content:      content,
 		Description:  description,
@@ -234,9 +259,24 @@ func taskUpdate(ctx *Context, args []string) error {
 	if content != "" {
 		body["content"] = content
 	}
-	if len(body) == 0 {
+	if len(body) == 0 && labelEdit.Empty() {
 		return &CodeError{Code: exitUsage, Err: errors.New("no fields to update")}
 	}
+	if resolved.Mode == "bulk" {
+		return taskUpdateBulk(ctx, resolved, body, labelEdit)
+	}
+	if !labelEdit.Empty() {
+		var current api.Task
+		if len(resolved.Tasks) > 0 {
+			current = resolved.Tasks[0]
+		} else if current, err = fetchTask(ctx, id); err != nil {
+			return err
+		}
+		body = withLabelEdit(body, current, labelEdit)
+		if len(body) == 0 {
+			return writeSimpleResult(ctx, "unchanged", id)
+		}
+	}
 	if ctx.Global.DryRun {
 		return writeDryRun(ctx, "task update", body)
 	}
@@ -248,5 +288,77 @@ func taskUpdate(ctx *Context, args []string) error {
 		return err
 	}
 	setRequestID(ctx, reqID)
+	invalidateNewLabels(ctx, task.Labels)
 	return writeTaskList(ctx, []api.Task{task}, "", false)
 }
+
+// withLabelEdit returns body plus the task's edited labels. Labels are only
+// sent when the edit changes them.
+func withLabelEdit(body map[string]any, task api.Task, edit apptasks.LabelEdit) map[string]any {
+	if edit.Empty() {
+		return body
+	}
+	out := make(map[string]any, len(body)+1)
+	for k, v := range body {
+		out[k] = v
+	}
+	if labels := edit.Apply(task.Labels); !apptasks.LabelsEqual(labels, task.Labels) {
+		out["labels"] = labels
+	}
+	return out
+}
+
+// taskUpdateBulk sends body to every task matched by the filter. Label edits
+// are applied to each task's own labels; tasks the update would not change
+// are reported as unchanged without a request.
+func taskUpdateBulk(ctx *Context, resolved apptasks.ResolveBulkResult, body map[string]any, edit apptasks.LabelEdit) error {
+	if ctx.Global.DryRun {
+		preview := make([]api.Task, 0, len(resolved.Tasks))
+		for _, task := range resolved.Tasks {
+			task.Labels = edit.Apply(task.Labels)
+			preview = append(preview, task)
+		}
+		payload := body
+		if !edit.Empty() {
+			payload = map[string]any{}
+			for k, v := range body {
+				payload[k] = v
+			}
+			if len(edit.Add) > 0 {
+				payload["add_labels"] = edit.Add
+			}
+			if len(edit.Remove) > 0 {
+				payload["remove_labels"] = edit.Remove
+			}
+			if edit.Clear {
+				payload["clear_labels"] = true
+			}
+		}
+		return writeBulkPreview(ctx, "task update bulk", resolved.Filter, preview, payload)
+	}
+	bodies := make(map[string]map[string]any, len(resolved.Tasks))
+	changed := make([]api.Task, 0, len(resolved.Tasks))
+	for _, task := range resolved.Tasks {
+		taskBody := withLabelEdit(body, task, edit)
+		if len(taskBody) == 0 {
+			continue
+		}
+		bodies[task.ID] = taskBody
+		changed = append(changed, task)
+	}
+	updated := runBulkReport(ctx, "task update", changed, "updated", func(task api.Task) (string, error) {
+		reqCtx, cancel := requestContext(ctx)
+		defer cancel()
+		return ctx.Client.Post(reqCtx, "/tasks/"+task.ID, nil, bodies[task.ID], nil, true)
+	})
+	results := make([]bulkItemResult, 0, len(resolved.Tasks))
+	for _, task := range resolved.Tasks {
+		if _, ok := bodies[task.ID]; !ok {
+			results = append(results, bulkItemResult{ID: task.ID, Content: task.Content, Status: "unchanged"})
+			continue
+		}
+		results = append(results, updated[0])
+		updated = updated[1:]
+	}
+	return writeBulkResults(ctx, "update", resolved.Filter, results)
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	"github.com/agisilaos/todoist-cli/internal/mockserver"
+)
+
+func TestTaskUpdateIncrementalLabels(t *testing.T) {
+	run := newMockCLIRunner(t, mockserver.Fixture{
+		Projects: []api.Project{{ID: "p1", Name: "Work"}},
+		Tasks: []api.Task{
+			{ID: "t1", Content: "Chase invoice", ProjectID: "p1", Labels: []string{"waiting", "finance"}},
+			{ID: "t2", Content: "Chase reply", ProjectID: "p1", Labels: []string{"waiting"}},
+		},
+	}).run
+	labelsOf := func(id string) string {
+		t.Helper()
+		var task api.Task
+		if err := json.Unmarshal([]byte(run(exitOK, "--json", "task", "view", "id:"+id)), &task); err != nil {
+			t.Fatalf("decode task: %v", err)
+		}
+		return strings.Join(task.Labels, ",")
+	}
+
+	run(exitUsage, "task", "update", "id:t1", "--label", "x", "--remove-label", "waiting")
+
+	run(exitOK, "task", "update", "id:t1", "--remove-label", "WAITING", "--add-label", "done")
+	if got := labelsOf("t1"); got != "finance,done" {
+		t.Fatalf("unexpected labels after edit: %q", got)
+	}
+	if out := run(exitOK, "task", "update", "id:t1", "--add-label", "finance"); !strings.Contains(out, "unchanged t1") {
+		t.Fatalf("expected unchanged result, got %q", out)
+	}
+	run(exitOK, "task", "update", "id:t1", "--clear-labels")
+	if got := labelsOf("t1"); got != "" {
+		t.Fatalf("expected labels cleared, got %q", got)
+	}
+
+	var report struct {
+		Results []bulkItemResult `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(run(exitOK, "--json", "task", "update", "--filter", "#Work", "--remove-label", "waiting", "--yes")), &report); err != nil {
+		t.Fatalf("decode report: %v", err)
+	}
+	statuses := map[string]string{}
+	for _, result := range report.Results {
+		statuses[result.ID] = result.Status
+	}
+	if statuses["t1"] != "unchanged" || statuses["t2"] != "updated" || labelsOf("t2") != "" {
+		t.Fatalf("unexpected bulk results: %#v", report.Results)
+	}
+
+	plan := Plan{Version: 1, ConfirmToken: "abcd", Actions: []Action{{Type: "task_update", TaskID: "t2", AddLabels: []string{"agent", "review"}}}}
+	data, _ := json.Marshal(plan)
+	planPath := filepath.Join(t.TempDir(), "plan.json")
+	if err := os.WriteFile(planPath, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	run(exitOK, "agent", "apply", "--plan", planPath, "--confirm", "abcd")
+	if got := labelsOf("t2"); got != "agent,review" {
+		t.Fatalf("unexpected labels after agent apply: %q", got)
+	}
+}
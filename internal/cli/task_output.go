@@ -103,6 +103,12 @@ func writeTaskList(ctx *Context, tasks []api.Task, cursor string, wide bool) err
 	if ctx.Mode == output.ModeNDJSON {
 		return writeTaskNDJSON(ctx, tasks)
 	}
+	return writeTaskTable(ctx, tasks, nil, wide)
+}
+
+// writeTaskTable renders human/plain rows; depths, when set, indents each
+// task's content in human mode to show the subtask hierarchy.
+func writeTaskTable(ctx *Context, tasks []api.Task, depths []int, wide bool) error {
 	cfg := taskTableConfigFor(ctx, wide)
 	projectNames := map[string]string(nil)
 	sectionNames := map[string]string(nil)
@@ -111,7 +117,7 @@ func writeTaskList(ctx *Context, tasks []api.Task, cursor string, wide bool) err
 		sectionNames = sectionNameMap(ctx)
 	}
 	rows := make([][]string, 0, len(tasks))
-	for _, task := range tasks {
+	for i, task := range tasks {
 		project := task.ProjectID
 		if name, ok := projectNames[task.ProjectID]; ok {
 			project = name
@@ -127,6 +133,9 @@ func writeTaskList(ctx *Context, tasks []api.Task, cursor string, wide bool) err
 		due = accessibleDueValue(ctx, due)
 		priority := accessiblePriorityValue(ctx, task.Priority)
 		if ctx.Mode == output.ModeHuman {
+			if i < len(depths) && depths[i] > 0 {
+				content = strings.Repeat("  ", depths[i]) + content
+			}
 			content = truncateString(content, cfg.Content)
 			project = truncateString(cleanCell(project), cfg.Project)
 			section = truncateString(cleanCell(section), cfg.Section)
@@ -152,11 +161,7 @@ func writeTaskList(ctx *Context, tasks []api.Task, cursor string, wide bool) err
 }
 
 func writeTaskNDJSON(ctx *Context, tasks []api.Task) error {
-	items := make([]any, 0, len(tasks))
-	for _, task := range tasks {
-		items = append(items, task)
-	}
-	return output.WriteNDJSON(ctx.Stdout, items)
+	return output.WriteNDJSONSlice(ctx.Stdout, tasks)
 }
 
 func formatDue(due *api.Due) string {
@@ -0,0 +1,199 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	apptasks "github.com/agisilaos/todoist-cli/internal/app/tasks"
+	"github.com/agisilaos/todoist-cli/internal/output"
+)
+
+type postponeResult struct {
+	ID        string   `json:"id"`
+	Content   string   `json:"content"`
+	Before    *api.Due `json:"before"`
+	After     *api.Due `json:"after,omitempty"`
+	Status    string   `json:"status"`
+	Error     string   `json:"error,omitempty"`
+	RequestID string   `json:"request_id,omitempty"`
+}
+
+func taskPostpone(ctx *Context, args []string) error {
+	fs := newFlagSet("task postpone")
+	var id string
+	var by string
+	var to string
+	var filter string
+	var yes bool
+	var help bool
+	fs.StringVar(&id, "id", "", "Task ID")
+	fs.StringVar(&by, "by", "", "Shift the due date by 2d, 1w, 3h or 30m")
+	fs.StringVar(&to, "to", "", "Move the due date to a date such as monday or mar 10")
+	fs.StringVar(&filter, "filter", "", "Filter query for bulk postpone")
+	fs.BoolVar(&yes, "yes", false, "Confirm bulk postpone")
+	bindHelpFlag(fs, &help)
+	if err := parseFlagSetInterspersed(fs, args); err != nil {
+		return &CodeError{Code: exitUsage, Err: err}
+	}
+	if help {
+		printTaskHelp(ctx.Stdout)
+		return nil
+	}
+	postpone, err := apptasks.ParsePostpone(by, to, applyNow(ctx))
+	if err != nil {
+		printTaskHelp(ctx.Stderr)
+		return &CodeError{Code: exitUsage, Err: err}
+	}
+	ref := strings.Join(fs.Args(), " ")
+	if strings.TrimSpace(id) == "" && strings.TrimSpace(ref) == "" && strings.TrimSpace(filter) == "" {
+		printTaskHelp(ctx.Stderr)
+		return &CodeError{Code: exitUsage, Err: errors.New("task postpone requires --id, a reference or --filter")}
+	}
+	if err := ensureClient(ctx); err != nil {
+		return err
+	}
+	svc := apptasks.Service{
+		Resolver: cliTaskResolver{ctx: ctx},
+		Lister:   cliTaskFilterLister{ctx: ctx},
+	}
+	resolved, err := svc.ResolveBulkTargets(context.Background(), apptasks.ResolveBulkInput{
+		Action: "postpone",
+		ID:     id,
+		Ref:    ref,
+		Filter: filter,
+		Yes:    yes,
+		Force:  ctx.Global.Force,
+	})
+	if err != nil {
+		printTaskHelp(ctx.Stderr)
+		return asUsageIfGeneric(err)
+	}
+	tasks := resolved.Tasks
+	if resolved.Mode == "single" && len(tasks) == 0 {
+		task, err := fetchTask(ctx, resolved.ID)
+		if err != nil {
+			return err
+		}
+		tasks = []api.Task{task}
+	}
+
+	results := make([]postponeResult, len(tasks))
+	planned := make([]string, 0, len(tasks))
+	byID := map[string]int{}
+	for i, task := range tasks {
+		results[i] = postponeResult{ID: task.ID, Content: task.Content, Before: task.Due}
+		after, err := postpone.Apply(task.Due)
+		if err != nil {
+			if resolved.Mode == "single" {
+				return &CodeError{Code: exitConflict, Err: fmt.Errorf("task %s: %w", task.ID, err)}
+			}
+			results[i].Status = "failed"
+			results[i].Error = err.Error()
+			continue
+		}
+		results[i].After = &after
+		results[i].Status = "planned"
+		planned = append(planned, task.ID)
+		byID[task.ID] = i
+	}
+	if ctx.Global.DryRun {
+		return writePostponeResults(ctx, resolved.Filter, results, true)
+	}
+	reqIDs := make([]string, len(results))
+	var mu sync.Mutex
+	errs := runBulk(ctx, "task postpone", planned, func(taskID string) (string, error) {
+		idx := byID[taskID]
+		cmd := api.NewSyncCommand("item_update", apptasks.PostponeSyncArgs(taskID, *results[idx].After))
+		reqCtx, cancel := requestContext(ctx)
+		defer cancel()
+		syncResult, reqID, err := ctx.Client.ExecuteCommands(reqCtx, []api.SyncCommand{cmd})
+		if err != nil {
+			return "", err
+		}
+		mu.Lock()
+		reqIDs[idx] = reqID
+		mu.Unlock()
+		return reqID, syncResult.CommandError(cmd.UUID)
+	})
+	for i, taskID := range planned {
+		result := &results[byID[taskID]]
+		if errs[i] != nil {
+			if resolved.Mode == "single" {
+				return errs[i]
+			}
+			result.Status = "failed"
+			result.Error = errs[i].Error()
+			continue
+		}
+		result.Status = "postponed"
+		result.RequestID = reqIDs[byID[taskID]]
+	}
+	return writePostponeResults(ctx, resolved.Filter, results, false)
+}
+
+// writePostponeResults reports each task's due before and after the
+// reschedule; with dryRun the "planned" rows show what would be sent.
+func writePostponeResults(ctx *Context, filter string, results []postponeResult, dryRun bool) error {
+	failed := 0
+	for _, result := range results {
+		if result.Status == "failed" {
+			failed++
+		}
+	}
+	succeeded := len(results) - failed
+	switch ctx.Mode {
+	case output.ModeJSON:
+		return output.WriteJSON(ctx.Stdout, map[string]any{
+			"action":    "postpone",
+			"filter":    filter,
+			"dry_run":   dryRun,
+			"results":   results,
+			"succeeded": succeeded,
+			"failed":    failed,
+			"count":     len(results),
+		}, output.Meta{RequestID: ctx.RequestID, Count: len(results)})
+	case output.ModeNDJSON:
+		return output.WriteNDJSONSlice(ctx.Stdout, results)
+	case output.ModePlain:
+		rows := make([][]string, 0, len(results))
+		for _, result := range results {
+			rows = append(rows, []string{result.Status, result.ID, dueLabel(result.Before), dueLabel(result.After), result.Content, result.Error})
+		}
+		return output.WritePlain(ctx.Stdout, rows)
+	}
+	if dryRun {
+		fmt.Fprintln(ctx.Stdout, "dry run: task postpone")
+	}
+	for _, result := range results {
+		line := fmt.Sprintf("%s %s %s", result.Status, result.ID, result.Content)
+		if result.Error != "" {
+			line += ": " + result.Error
+		} else {
+			line += fmt.Sprintf(": %s -> %s", dueLabel(result.Before), dueLabel(result.After))
+			if result.After != nil && result.After.IsRecurring {
+				line += fmt.Sprintf(" (keeps %q)", result.After.String)
+			}
+		}
+		fmt.Fprintln(ctx.Stdout, line)
+	}
+	if filter != "" {
+		fmt.Fprintf(ctx.Stdout, "bulk postpone done: succeeded=%d failed=%d total=%d\n", succeeded, failed, len(results))
+	}
+	return nil
+}
+
+func dueLabel(due *api.Due) string {
+	switch {
+	case due == nil:
+		return "no date"
+	case due.Datetime != "":
+		return due.Datetime
+	case due.Date != "":
+		return due.Date
+	}
+	return "no date"
+}
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	"github.com/agisilaos/todoist-cli/internal/mockserver"
+	"github.com/agisilaos/todoist-cli/internal/output"
+)
+
+func TestTaskPostpone(t *testing.T) {
+	today := time.Now().UTC()
+	day := func(offset int) string { return today.AddDate(0, 0, offset).Format(time.DateOnly) }
+	srv := mockserver.New(mockserver.Fixture{
+		Projects: []api.Project{{ID: "p1", Name: "Home"}},
+		Tasks: []api.Task{
+			{ID: "t1", Content: "Water plants", ProjectID: "p1", Due: &api.Due{Date: day(-1), String: "every day", IsRecurring: true}},
+			{ID: "t5", Content: "Review budget", ProjectID: "p1", Due: &api.Due{Date: day(3), String: "every month", IsRecurring: true}},
+			{ID: "t2", Content: "Pay rent", ProjectID: "p1", Due: &api.Due{Date: day(-2)}},
+			{ID: "t3", Content: "Call plumber", ProjectID: "p1", Due: &api.Due{Date: day(0), Datetime: day(0) + "T09:00:00"}},
+			{ID: "t4", Content: "Someday", ProjectID: "p1"},
+		},
+	})
+	srv.Now = func() time.Time { return today }
+	run := newMockCLIHandlerRunner(t, srv).run
+	dueOf := func(id string) api.Due {
+		t.Helper()
+		var task api.Task
+		if err := json.Unmarshal([]byte(run(exitOK, "--json", "task", "view", "id:"+id)), &task); err != nil {
+			t.Fatalf("decode task: %v", err)
+		}
+		if task.Due == nil {
+			return api.Due{}
+		}
+		return *task.Due
+	}
+
+	run(exitUsage, "task", "postpone", "id:t1")
+	run(exitUsage, "task", "postpone", "id:t1", "--by", "2d", "--to", "monday")
+	run(exitUsage, "task", "postpone", "--filter", "overdue", "--by", "1d")
+	run(exitConflict, "task", "postpone", "id:t4", "--by", "1d")
+
+	out := run(exitOK, "task", "postpone", "id:t3", "--by", "90m")
+	if !strings.Contains(out, "postponed\tt3\t"+day(0)+"T09:00:00\t"+day(0)+"T10:30:00") {
+		t.Fatalf("unexpected postpone diff: %q", out)
+	}
+	if got := dueOf("t3"); got.Datetime != day(0)+"T10:30:00" {
+		t.Fatalf("unexpected datetime after postpone: %#v", got)
+	}
+	run(exitOK, "task", "postpone", "id:t5", "--by", "1w")
+	if got := dueOf("t5"); got.Date != day(10) || got.String != "every month" || !got.IsRecurring {
+		t.Fatalf("recurring task did not keep the postponed date and its recurrence: %#v", got)
+	}
+
+	var preview struct {
+		DryRun  bool             `json:"dry_run"`
+		Results []postponeResult `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(run(exitOK, "--json", "--dry-run", "task", "postpone", "--filter", "overdue", "--by", "2d", "--yes")), &preview); err != nil {
+		t.Fatalf("decode preview: %v", err)
+	}
+	if !preview.DryRun || len(preview.Results) != 2 || preview.Results[0].Status != "planned" {
+		t.Fatalf("unexpected preview: %#v", preview)
+	}
+	if got := dueOf("t1"); got.Date != day(-1) {
+		t.Fatalf("dry run changed the task: %#v", got)
+	}
+
+	var report struct {
+		Results   []postponeResult `json:"results"`
+		Succeeded int              `json:"succeeded"`
+	}
+	if err := json.Unmarshal([]byte(run(exitOK, "--json", "task", "postpone", "--filter", "overdue", "--by", "2d", "--yes")), &report); err != nil {
+		t.Fatalf("decode report: %v", err)
+	}
+	if report.Succeeded != 2 {
+		t.Fatalf("unexpected report: %#v", report)
+	}
+	for _, result := range report.Results {
+		if result.Status != "postponed" || result.Before == nil || result.After == nil || result.RequestID == "" {
+			t.Fatalf("unexpected result: %#v", result)
+		}
+	}
+	if got := dueOf("t1"); got.Date != day(1) || got.String != "every day" || !got.IsRecurring {
+		t.Fatalf("recurring task lost its recurrence: %#v", got)
+	}
+	if got := dueOf("t2"); got.Date != day(0) {
+		t.Fatalf("unexpected due after bulk postpone: %#v", got)
+	}
+}
+
+func TestWritePostponeResultsHuman(t *testing.T) {
+	var out bytes.Buffer
+	ctx := &Context{Stdout: &out, Mode: output.ModeHuman}
+	results := []postponeResult{
+		{ID: "t1", Content: "Water plants", Status: "postponed", Before: &api.Due{Date: "2026-10-16", String: "every day"}, After: &api.Due{Date: "2026-10-18", String: "every day", IsRecurring: true}},
+		{ID: "t4", Content: "Someday", Status: "failed", Error: "task has no due date to postpone"},
+	}
+	if err := writePostponeResults(ctx, "overdue", results, false); err != nil {
+		t.Fatalf("writePostponeResults: %v", err)
+	}
+	want := "postponed t1 Water plants: 2026-10-16 -> 2026-10-18 (keeps \"every day\")\n" +
+		"failed t4 Someday: task has no due date to postpone\n" +
+		"bulk postpone done: succeeded=1 failed=1 total=2\n"
+	if out.String() != want {
+		t.Fatalf("unexpected output:\n%s", out.String())
+	}
+}
@@ -0,0 +1,215 @@
+package cli
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	apptasks "github.com/agisilaos/todoist-cli/internal/app/tasks"
+	"github.com/agisilaos/todoist-cli/internal/output"
+)
+
+func writeTaskTree(ctx *Context, tasks []api.Task, cursor string, wide bool) error {
+	roots := apptasks.BuildTree(tasks)
+	if ctx.Mode == output.ModeJSON {
+		return output.WriteJSON(ctx.Stdout, roots, output.Meta{RequestID: ctxRequestIDValue(ctx), Count: len(tasks), Cursor: cursor})
+	}
+	if ctx.Mode == output.ModeNDJSON {
+		return output.WriteNDJSONSlice(ctx.Stdout, roots)
+	}
+	flat := apptasks.Flatten(roots)
+	ordered := make([]api.Task, 0, len(flat))
+	depths := make([]int, 0, len(flat))
+	for _, node := range flat {
+		ordered = append(ordered, node.Task)
+		depths = append(depths, node.Depth)
+	}
+	return writeTaskTable(ctx, ordered, depths, wide)
+}
+
+func writeTaskViewTree(ctx *Context, node *apptasks.TreeNode, full bool) error {
+	if ctx.Mode == output.ModeJSON {
+		return output.WriteJSON(ctx.Stdout, node, output.Meta{RequestID: ctxRequestIDValue(ctx)})
+	}
+	if err := writeTaskView(ctx, node.Task, full); err != nil {
+		return err
+	}
+	if len(node.Children) == 0 {
+		return nil
+	}
+	fmt.Fprintln(ctx.Stdout, "Subtasks:")
+	for _, child := range apptasks.Flatten(node.Children) {
+		fmt.Fprintf(ctx.Stdout, "%s- %s (%s)\n", strings.Repeat("  ", child.Depth+1), child.Task.Content, child.Task.ID)
+	}
+	return nil
+}
+
+// activeSubtree returns root with its open descendants.
+func activeSubtree(ctx *Context, root api.Task) (*apptasks.TreeNode, error) {
+	tasks, err := listAllActiveTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	withRoot := make([]api.Task, 0, len(tasks)+1)
+	withRoot = append(append(withRoot, tasks...), root)
+	if node := apptasks.Subtree(withRoot, root.ID); node != nil {
+		return node, nil
+	}
+	return &apptasks.TreeNode{Task: root, Children: []*apptasks.TreeNode{}}, nil
+}
+
+// completedSubtree returns root with the descendants completed within the
+// completed-tasks API window, found level by level through parent_id.
+func completedSubtree(ctx *Context, root api.Task) (*apptasks.TreeNode, error) {
+	now := time.Now
+	if ctx.Now != nil {
+		now = ctx.Now
+	}
+	today := now().UTC()
+	tasks := []api.Task{root}
+	queue := []string{root.ID}
+	seen := map[string]bool{root.ID: true}
+	for len(queue) > 0 {
+		parentID := queue[0]
+		queue = queue[1:]
+		query := url.Values{}
+		query.Set("parent_id", parentID)
+		query.Set("since", today.AddDate(0, 0, -completedSubtreeDays).Format(time.DateOnly))
+		query.Set("until", today.Format(time.DateOnly))
+		query.Set("limit", "200")
+		children, _, err := fetchPaginated[api.Task](ctx, "/tasks/completed/by_completion_date", query, true)
+		if err != nil {
+			return nil, err
+		}
+		for _, child := range children {
+			if seen[child.ID] {
+				continue
+			}
+			seen[child.ID] = true
+			tasks = append(tasks, child)
+			queue = append(queue, child.ID)
+		}
+	}
+	return apptasks.Subtree(tasks, root.ID), nil
+}
+
+// completedSubtreeDays stays inside the completed-tasks API's three month
+// range limit.
+const completedSubtreeDays = 89
+
+type taskNodeResult struct {
+	ID        string `json:"id"`
+	Content   string `json:"content"`
+	ParentID  string `json:"parent_id,omitempty"`
+	Depth     int    `json:"depth"`
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// runTaskRecursive applies fn to nodes in the given order. A node is skipped
+// when its parent or one of its children already failed or was skipped, so
+// the API never acts implicitly on a subtree that was only partly handled.
+func runTaskRecursive(ctx *Context, command string, nodes []apptasks.FlatNode, status string, fn func(id string) (string, error)) []taskNodeResult {
+	emitProgress(ctx, "recursive_start", map[string]any{"command": command, "count": len(nodes)})
+	blocked := map[string]bool{}
+	blockedChild := map[string]bool{}
+	results := make([]taskNodeResult, 0, len(nodes))
+	failed := 0
+	for _, node := range nodes {
+		result := taskNodeResult{ID: node.Task.ID, Content: node.Task.Content, ParentID: node.Task.ParentID, Depth: node.Depth}
+		if blocked[node.Task.ParentID] || blockedChild[node.Task.ID] {
+			result.Status = "skipped"
+		} else if reqID, err := fn(node.Task.ID); err != nil {
+			result.Status = "failed"
+			result.Error = err.Error()
+			failed++
+		} else {
+			result.Status = status
+			result.RequestID = reqID
+			setRequestID(ctx, reqID)
+		}
+		if result.Status != status {
+			blocked[node.Task.ID] = true
+			blockedChild[node.Task.ParentID] = true
+		}
+		results = append(results, result)
+	}
+	emitProgress(ctx, "recursive_complete", map[string]any{"command": command, "count": len(nodes), "failed": failed})
+	return results
+}
+
+func writeTaskNodeResults(ctx *Context, action, rootID string, results []taskNodeResult) error {
+	counts := map[string]int{}
+	for _, result := range results {
+		counts[result.Status]++
+	}
+	failed, skipped := counts["failed"], counts["skipped"]
+	succeeded := len(results) - failed - skipped
+	switch ctx.Mode {
+	case output.ModeJSON:
+		return output.WriteJSON(ctx.Stdout, map[string]any{
+			"action":    action,
+			"root_id":   rootID,
+			"results":   results,
+			"succeeded": succeeded,
+			"failed":    failed,
+			"skipped":   skipped,
+			"count":     len(results),
+		}, output.Meta{RequestID: ctx.RequestID, Count: len(results)})
+	case output.ModeNDJSON:
+		return output.WriteNDJSONSlice(ctx.Stdout, results)
+	case output.ModePlain:
+		rows := make([][]string, 0, len(results))
+		for _, result := range results {
+			rows = append(rows, []string{result.Status, result.ID, fmt.Sprint(result.Depth), result.Content, result.Error})
+		}
+		return output.WritePlain(ctx.Stdout, rows)
+	}
+	for _, result := range results {
+		line := fmt.Sprintf("%s%s %s %s", strings.Repeat("  ", result.Depth), result.Status, result.ID, result.Content)
+		if result.Error != "" {
+			line += ": " + result.Error
+		}
+		fmt.Fprintln(ctx.Stdout, line)
+	}
+	fmt.Fprintf(ctx.Stdout, "recursive %s done: succeeded=%d failed=%d skipped=%d total=%d\n", action, succeeded, failed, skipped, len(results))
+	return nil
+}
+
+func taskNodeIDs(nodes []apptasks.FlatNode) []string {
+	ids := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		ids = append(ids, node.Task.ID)
+	}
+	return ids
+}
+
+// fetchTask loads one task by ID, including completed ones.
+func fetchTask(ctx *Context, id string) (api.Task, error) {
+	var task api.Task
+	reqCtx, cancel := requestContext(ctx)
+	reqID, err := ctx.Client.Get(reqCtx, "/tasks/"+id, nil, &task)
+	cancel()
+	if err != nil {
+		return api.Task{}, err
+	}
+	setRequestID(ctx, reqID)
+	return task, nil
+}
+
+// recursiveMoveResults reports the moved root and the descendants that moved
+// along with it.
+func recursiveMoveResults(root taskNodeResult, descendants []apptasks.FlatNode) []taskNodeResult {
+	results := []taskNodeResult{root}
+	status := "moved_with_parent"
+	if root.Status != "moved" {
+		status = "skipped"
+	}
+	for _, node := range descendants {
+		results = append(results, taskNodeResult{ID: node.Task.ID, Content: node.Task.Content, ParentID: node.Task.ParentID, Depth: node.Depth, Status: status})
+	}
+	return results
+}
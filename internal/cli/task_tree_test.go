@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	"github.com/agisilaos/todoist-cli/internal/mockserver"
+)
+
+func newTreeTestRunner(t *testing.T) func(wantCode int, args ...string) string {
+	t.Helper()
+	return newMockCLIRunner(t, mockserver.Fixture{
+		Projects: []api.Project{{ID: "p1", Name: "Work"}, {ID: "p2", Name: "Home"}},
+		Tasks: []api.Task{
+			{ID: "t1", Content: "Launch", ProjectID: "p1"},
+			{ID: "t2", Content: "Write docs", ProjectID: "p1", ParentID: "t1"},
+			{ID: "t3", Content: "Proofread", ProjectID: "p1", ParentID: "t2"},
+			{ID: "t4", Content: "Ship", ProjectID: "p1", ParentID: "t1"},
+			{ID: "t5", Content: "Unrelated", ProjectID: "p1"},
+		},
+	}).run
+}
+
+func TestTaskListTreeNestsChildren(t *testing.T) {
+	run := newTreeTestRunner(t)
+	var roots []struct {
+		ID       string `json:"id"`
+		Children []struct {
+			ID       string `json:"id"`
+			Children []struct {
+				ID string `json:"id"`
+			} `json:"children"`
+		} `json:"children"`
+	}
+	if err := json.Unmarshal([]byte(run(exitOK, "--json", "task", "list", "--project", "Work", "--tree")), &roots); err != nil {
+		t.Fatalf("decode tree: %v", err)
+	}
+	if len(roots) != 2 || roots[0].ID != "t1" || len(roots[0].Children) != 2 || roots[0].Children[0].Children[0].ID != "t3" {
+		t.Fatalf("unexpected tree: %#v", roots)
+	}
+
+	out := run(exitOK, "--plain", "task", "view", "id:t1", "--tree")
+	if !strings.Contains(out, "Subtasks:\n  - Write docs (t2)\n    - Proofread (t3)\n  - Ship (t4)\n") {
+		t.Fatalf("unexpected tree view: %q", out)
+	}
+}
+
+func TestRecursiveCompleteReopenMoveAndDelete(t *testing.T) {
+	run := newTreeTestRunner(t)
+	var report struct {
+		Results []taskNodeResult `json:"results"`
+		Failed  int              `json:"failed"`
+	}
+	decode := func(out string) {
+		t.Helper()
+		report.Results = nil
+		if err := json.Unmarshal([]byte(out), &report); err != nil {
+			t.Fatalf("decode report: %v (%s)", err, out)
+		}
+	}
+	ids := func() string {
+		var got []string
+		for _, r := range report.Results {
+			got = append(got, r.ID+"="+r.Status)
+		}
+		return strings.Join(got, ",")
+	}
+
+	decode(run(exitOK, "--json", "task", "complete", "id:t1", "--recursive"))
+	if got := ids(); got != "t3=completed,t2=completed,t4=completed,t1=completed" || report.Failed != 0 {
+		t.Fatalf("unexpected complete report: %s", got)
+	}
+	decode(run(exitOK, "--json", "task", "reopen", "id:t1", "--recursive"))
+	if got := ids(); got != "t1=reopened,t2=reopened,t3=reopened,t4=reopened" {
+		t.Fatalf("unexpected reopen report: %s", got)
+	}
+	decode(run(exitOK, "--json", "task", "move", "id:t1", "--project", "Home", "--recursive"))
+	if got := ids(); got != "t1=moved,t2=moved_with_parent,t3=moved_with_parent,t4=moved_with_parent" {
+		t.Fatalf("unexpected move report: %s", got)
+	}
+	decode(run(exitOK, "--json", "task", "delete", "id:t2", "--yes", "--recursive"))
+	if got := ids(); got != "t3=deleted,t2=deleted" {
+		t.Fatalf("unexpected delete report: %s", got)
+	}
+	if out := run(exitOK, "--plain", "task", "list", "--all-projects"); !strings.Contains(out, "t1\t") || strings.Contains(out, "t3\t") {
+		t.Fatalf("unexpected remaining tasks: %q", out)
+	}
+}
+
+func TestRecursiveMoveRejectsOwnSubtree(t *testing.T) {
+	run := newTreeTestRunner(t)
+	run(exitUsage, "task", "move", "id:t1", "--parent", "t3", "--recursive")
+	run(exitUsage, "task", "complete", "--filter", "today", "--yes", "--recursive")
+}
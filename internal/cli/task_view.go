@@ -15,9 +15,11 @@ func taskView(ctx *Context, args []string) error {
 	fs := newFlagSet("task view")
 	var id string
 	var full bool
+	var tree bool
 	var help bool
 	fs.StringVar(&id, "id", "", "Task ID")
 	fs.BoolVar(&full, "full", false, "Show full task fields")
+	fs.BoolVar(&tree, "tree", false, "Include subtasks")
 	bindHelpFlag(fs, &help)
 	if err := parseFlagSetInterspersed(fs, args); err != nil {
 		return &CodeError{Code: exitUsage, Err: err}
@@ -41,6 +43,13 @@ func taskView(ctx *Context, args []string) error {
 	if err != nil {
 		return err
 	}
+	if tree {
+		node, err := activeSubtree(ctx, task)
+		if err != nil {
+			return err
+		}
+		return writeTaskViewTree(ctx, node, full)
+	}
 	return writeTaskView(ctx, task, full)
 }
 
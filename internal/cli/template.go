@@ -0,0 +1,252 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	apptasks "github.com/agisilaos/todoist-cli/internal/app/tasks"
+	apptemplates "github.com/agisilaos/todoist-cli/internal/app/templates"
+	"github.com/agisilaos/todoist-cli/internal/output"
+)
+
+func templateCommand(ctx *Context, args []string) error {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printTemplateHelp(ctx.Stdout)
+		return nil
+	}
+	switch args[0] {
+	case "export":
+		return templateExport(ctx, args[1:])
+	case "apply":
+		return templateApply(ctx, args[1:])
+	default:
+		return &CodeError{Code: exitUsage, Err: fmt.Errorf("unknown template subcommand: %s", args[0])}
+	}
+}
+
+func templateExport(ctx *Context, args []string) error {
+	fs := newFlagSet("template export")
+	var project string
+	var taskRef string
+	var name string
+	var help bool
+	fs.StringVar(&project, "project", "", "Project to export")
+	fs.StringVar(&taskRef, "task", "", "Task whose subtree to export")
+	fs.StringVar(&name, "name", "", "Template name")
+	bindHelpFlag(fs, &help)
+	if err := parseFlagSetInterspersed(fs, args); err != nil {
+		return &CodeError{Code: exitUsage, Err: err}
+	}
+	if help {
+		printTemplateHelp(ctx.Stdout)
+		return nil
+	}
+	if (project == "") == (taskRef == "") {
+		printTemplateHelp(ctx.Stderr)
+		return &CodeError{Code: exitUsage, Err: errors.New("template export requires exactly one of --project or --task")}
+	}
+	if err := ensureClient(ctx); err != nil {
+		return err
+	}
+	var in apptemplates.ExportInput
+	if taskRef != "" {
+		root, err := resolveTaskRef(ctx, taskRef)
+		if err != nil {
+			return err
+		}
+		node, err := activeSubtree(ctx, root)
+		if err != nil {
+			return err
+		}
+		for _, flat := range apptasks.Flatten([]*apptasks.TreeNode{node}) {
+			task := flat.Task
+			task.SectionID = ""
+			in.Tasks = append(in.Tasks, task)
+		}
+		in.Name = root.Content
+	} else {
+		projectID, err := resolveProjectID(ctx, project)
+		if err != nil {
+			return err
+		}
+		if in.Name, err = projectName(ctx, projectID); err != nil {
+			return err
+		}
+		if in.Sections, err = fetchAllSections(ctx, "id:"+projectID); err != nil {
+			return err
+		}
+		query := url.Values{}
+		query.Set("project_id", projectID)
+		query.Set("limit", "200")
+		if in.Tasks, _, err = fetchPaginated[api.Task](ctx, "/tasks", query, true); err != nil {
+			return err
+		}
+	}
+	if name != "" {
+		in.Name = name
+	}
+	tpl := apptemplates.Export(in)
+	if ctx.Mode == output.ModeJSON {
+		return output.WriteJSON(ctx.Stdout, tpl, output.Meta{RequestID: ctxRequestIDValue(ctx)})
+	}
+	_, err := ctx.Stdout.Write(apptemplates.Encode(tpl))
+	return err
+}
+
+func projectName(ctx *Context, id string) (string, error) {
+	projects, err := listAllProjects(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, p := range projects {
+		if p.ID == id {
+			return p.Name, nil
+		}
+	}
+	return "", &CodeError{Code: exitNotFound, Kind: errCodeRefNotFound, Err: fmt.Errorf("project not found: %s", id)}
+}
+
+func templateApply(ctx *Context, args []string) error {
+	fs := newFlagSet("template apply")
+	var project string
+	var vars multiValue
+	var dueOffset string
+	var onError string
+	var batch bool
+	var help bool
+	fs.StringVar(&project, "project", "", "Target project")
+	fs.Var(&vars, "var", "Template variable name=value (repeatable)")
+	fs.StringVar(&dueOffset, "due-offset", "", "Shift every due date by +Nd/-Nd from today")
+	fs.StringVar(&onError, "on-error", "fail", "On error: fail|continue")
+	fs.BoolVar(&batch, "batch", false, "Create everything with batched Sync API commands")
+	bindHelpFlag(fs, &help)
+	if err := parseFlagSetInterspersed(fs, args); err != nil {
+		return &CodeError{Code: exitUsage, Err: err}
+	}
+	if help {
+		printTemplateHelp(ctx.Stdout)
+		return nil
+	}
+	if len(fs.Args()) != 1 {
+		printTemplateHelp(ctx.Stderr)
+		return &CodeError{Code: exitUsage, Err: errors.New("template apply requires one template file (or - for stdin)")}
+	}
+	if project == "" {
+		printTemplateHelp(ctx.Stderr)
+		return &CodeError{Code: exitUsage, Err: errors.New("--project is required")}
+	}
+	if onError != "fail" && onError != "continue" {
+		return &CodeError{Code: exitUsage, Err: errors.New("invalid --on-error; must be fail or continue")}
+	}
+	values, err := parseTemplateVars(vars)
+	if err != nil {
+		return err
+	}
+	shift, err := apptemplates.ParseOffset(dueOffset)
+	if err != nil {
+		return &CodeError{Code: exitUsage, Err: fmt.Errorf("invalid --due-offset: %w", err)}
+	}
+	path := fs.Args()[0]
+	tpl, err := readTemplateFile(path, ctx.Stdin)
+	if err != nil {
+		return err
+	}
+	if err := ensureClient(ctx); err != nil {
+		return err
+	}
+	projectID, err := resolveProjectID(ctx, project)
+	if err != nil {
+		return err
+	}
+	now := applyNow(ctx)
+	anchor := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, shift)
+	actions, err := tpl.Actions(apptemplates.ApplyInput{ProjectID: projectID, Vars: values, Anchor: anchor})
+	if err != nil {
+		return &CodeError{Code: exitUsage, Err: err}
+	}
+	name := tpl.Name
+	if name == "" {
+		name = path
+	}
+	plan := Plan{ConfirmToken: newConfirmToken(), Actions: actions}
+	if err := normalizeAndValidatePlan(&plan, "template apply "+name, func() time.Time { return now }, 1); err != nil {
+		return err
+	}
+	emitAgentPlanLoaded(ctx, "template apply", len(plan.Actions), "template")
+	if ctx.Global.DryRun {
+		emitAgentApplySummary(ctx, "template apply", nil, true, nil)
+		return writePlanPreview(ctx, plan, true)
+	}
+	results, err := applyPlanActions(ctx, &plan, onError, batch)
+	emitAgentApplySummary(ctx, "template apply", results, false, err)
+	if err != nil && onError == "fail" {
+		return err
+	}
+	plan.AppliedAt = applyNow(ctx).UTC().Format(time.RFC3339)
+	return writePlanApplyResult(ctx, plan, results, err)
+}
+
+func parseTemplateVars(values []string) (map[string]string, error) {
+	vars := map[string]string{}
+	for _, value := range values {
+		name, v, ok := strings.Cut(value, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, &CodeError{Code: exitUsage, Err: fmt.Errorf("invalid --var %q (expected name=value)", value)}
+		}
+		vars[name] = v
+	}
+	return vars, nil
+}
+
+func readTemplateFile(path string, stdin io.Reader) (apptemplates.Template, error) {
+	var data []byte
+	var err error
+	source := path
+	if path == "-" {
+		if stdin == nil {
+			return apptemplates.Template{}, &CodeError{Code: exitUsage, Err: errors.New("stdin not available for template -")}
+		}
+		data, err = io.ReadAll(stdin)
+		source = "stdin"
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		if path != "-" && errors.Is(err, os.ErrNotExist) {
+			return apptemplates.Template{}, &CodeError{Code: exitUsage, Err: fmt.Errorf("template file not found: %s", path)}
+		}
+		return apptemplates.Template{}, err
+	}
+	tpl, err := apptemplates.Decode(data)
+	if err != nil {
+		return apptemplates.Template{}, &CodeError{Code: exitUsage, Err: fmt.Errorf("invalid template in %s: %w", source, err)}
+	}
+	return tpl, nil
+}
+
+func printTemplateHelp(out interface{ Write([]byte) (int, error) }) {
+	fmt.Fprint(out, `Usage:
+  todoist template export (--project <id|name> | --task <ref>) [--name <name>]
+  todoist template apply <file|-> --project <id|name> [--var name=value] [--due-offset +Nd] [--batch] [--on-error fail|continue]
+
+Notes:
+  - export writes YAML (or the template object with --json) covering sections, tasks, subtasks, labels, priorities and due dates.
+  - Due dates are stored as offsets ("+3d") from the earliest exported due date; apply counts them from today, shifted by --due-offset.
+  - {{name}} placeholders in content, descriptions, section names and labels are filled from --var or the template's vars block; unset placeholders are an error.
+  - apply builds an agent plan (section_add/task_add linked by aliases) and runs it through the agent executor; --dry-run prints the plan preview instead.
+  - Priorities accept the API value (4 = most urgent) or p1-p4.
+
+Examples:
+  todoist template export --project "Release" > release.yaml
+  todoist template export --task "id:123" --name checklist > checklist.yaml
+  todoist template apply release.yaml --project "Release 1.4" --var version=1.4 --due-offset +3d --dry-run
+  todoist template apply release.yaml --project "Release 1.4" --var version=1.4 --batch
+`)
+}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	"github.com/agisilaos/todoist-cli/internal/mockserver"
+)
+
+func newTemplateTestRunner(t *testing.T) func(wantCode int, args ...string) string {
+	t.Helper()
+	return newMockCLIRunner(t, mockserver.Fixture{
+		Projects: []api.Project{{ID: "p1", Name: "Release"}, {ID: "p2", Name: "Next"}},
+		Sections: []api.Section{{ID: "s1", Name: "QA", ProjectID: "p1"}},
+		Tasks: []api.Task{
+			{ID: "t1", Content: "Draft notes", ProjectID: "p1", Priority: 3, Due: &api.Due{Date: "2026-03-02"}},
+			{ID: "t2", Content: "Run tests", ProjectID: "p1", SectionID: "s1", Labels: []string{"qa"}, Due: &api.Due{Date: "2026-03-04"}},
+			{ID: "t3", Content: "Smoke test", ProjectID: "p1", SectionID: "s1", ParentID: "t2"},
+		},
+	}).run
+}
+
+func TestTemplateExportThenApply(t *testing.T) {
+	run := newTemplateTestRunner(t)
+	exported := run(exitOK, "template", "export", "--project", "Release")
+	for _, want := range []string{`name: "Release"`, `- name: "QA"`, `due_offset: "+2d"`, `labels: ["qa"]`, `children:`, `content: "Smoke test"`} {
+		if !strings.Contains(exported, want) {
+			t.Fatalf("export missing %q:\n%s", want, exported)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "release.yaml")
+	tpl := strings.Replace(exported, `"Draft notes"`, `"Draft notes for {{version}}"`, 1)
+	if err := os.WriteFile(path, []byte(tpl), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var preview struct {
+		DryRun bool `json:"dry_run"`
+		Plan   Plan `json:"plan"`
+	}
+	if err := json.Unmarshal([]byte(run(exitOK, "--json", "template", "apply", path, "--project", "Next", "--var", "version=1.4", "--dry-run")), &preview); err != nil {
+		t.Fatalf("decode preview: %v", err)
+	}
+	if !preview.DryRun || len(preview.Plan.Actions) != 4 || preview.Plan.Actions[0].Content != "Draft notes for 1.4" {
+		t.Fatalf("unexpected preview: %#v", preview)
+	}
+	if got := run(exitOK, "--json", "task", "list", "--project", "Next"); strings.Contains(got, "Draft notes") {
+		t.Fatalf("dry run created tasks: %s", got)
+	}
+
+	run(exitOK, "template", "apply", path, "--project", "Next", "--var", "version=1.4", "--due-offset", "+3d")
+	var tasks []api.Task
+	if err := json.Unmarshal([]byte(run(exitOK, "--json", "task", "list", "--project", "Next")), &tasks); err != nil {
+		t.Fatalf("decode tasks: %v", err)
+	}
+	byContent := map[string]api.Task{}
+	for _, task := range tasks {
+		byContent[task.Content] = task
+	}
+	anchor := time.Now().AddDate(0, 0, 3)
+	draft, tests, smoke := byContent["Draft notes for 1.4"], byContent["Run tests"], byContent["Smoke test"]
+	if draft.Due == nil || draft.Due.Date != anchor.Format(time.DateOnly) || draft.Priority != 3 {
+		t.Fatalf("unexpected draft task: %#v", draft)
+	}
+	if tests.Due == nil || tests.Due.Date != anchor.AddDate(0, 0, 2).Format(time.DateOnly) || tests.SectionID == "" || len(tests.Labels) != 1 {
+		t.Fatalf("unexpected section task: %#v", tests)
+	}
+	if smoke.ParentID != tests.ID {
+		t.Fatalf("expected subtask under %s, got %#v", tests.ID, smoke)
+	}
+}
+
+func TestTemplateApplyUsageErrors(t *testing.T) {
+	run := newTemplateTestRunner(t)
+	path := filepath.Join(t.TempDir(), "t.yaml")
+	if err := os.WriteFile(path, []byte("tasks:\n  - content: \"Ship {{version}}\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	run(exitUsage, "template", "apply", path)
+	run(exitUsage, "template", "apply", path, "--project", "Next")
+	run(exitUsage, "template", "apply", path, "--project", "Next", "--var", "version")
+	run(exitUsage, "template", "apply", path, "--project", "Next", "--var", "version=1", "--due-offset", "soon")
+	run(exitUsage, "template", "export")
+}
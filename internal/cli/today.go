@@ -8,7 +8,7 @@ func todayCommand(ctx *Context, args []string) error {
 		return nil
 	}
 	filter := "overdue | today"
-	return taskListFiltered(ctx, filter, "", 50, true, false)
+	return taskListFiltered(ctx, filter, taskListOptions{Limit: 50, All: true})
 }
 
 func printTodayHelp(out interface{ Write([]byte) (int, error) }) {
@@ -0,0 +1,521 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	apptasks "github.com/agisilaos/todoist-cli/internal/app/tasks"
+	apptui "github.com/agisilaos/todoist-cli/internal/app/tui"
+	"github.com/agisilaos/todoist-cli/internal/output"
+)
+
+// tuiSession drives the TUI model: it reads keys, loads screens through the
+// usual list helpers and runs task actions through the task commands, so the
+// TUI shares their resolution, dry-run and offline-queue behavior.
+type tuiSession struct {
+	ctx      *Context
+	model    *apptui.Model
+	in       io.Reader
+	lines    *bufio.Reader
+	out      io.Writer
+	lineMode bool
+	color    bool
+	days     int
+	size     func() (int, int)
+}
+
+// tuiLineWords are the commands understood in line mode besides single keys
+// and row numbers.
+var tuiLineWords = map[string]apptui.Key{
+	"":         apptui.KeyEnter,
+	"open":     apptui.KeyEnter,
+	"up":       apptui.KeyUp,
+	"down":     apptui.KeyDown,
+	"back":     apptui.KeyLeft,
+	"projects": "b",
+	"today":    "t",
+	"upcoming": "u",
+	"complete": "c",
+	"reopen":   "r",
+	"postpone": "p",
+	"move":     "m",
+	"labels":   "l",
+	"add":      "a",
+	"refresh":  "R",
+	"help":     "?",
+	"quit":     "q",
+	"exit":     "q",
+}
+
+func tuiCommand(ctx *Context, args []string) error {
+	fs := newFlagSet("tui")
+	var view string
+	var days int
+	var help bool
+	fs.StringVar(&view, "view", string(apptui.ViewProjects), "Start view: projects, today or upcoming")
+	fs.IntVar(&days, "days", 7, "Days shown in the upcoming pane")
+	bindHelpFlag(fs, &help)
+	if err := parseFlagSetInterspersed(fs, args); err != nil {
+		return &CodeError{Code: exitUsage, Err: err}
+	}
+	if help {
+		printTUIHelp(ctx.Stdout)
+		return nil
+	}
+	start := apptui.View(strings.ToLower(strings.TrimSpace(view)))
+	if !containsView(apptui.RootViews, start) {
+		return &CodeError{Code: exitUsage, Err: fmt.Errorf("invalid --view %q (use projects, today or upcoming)", view)}
+	}
+	if days < 1 {
+		return &CodeError{Code: exitUsage, Err: errors.New("--days must be at least 1")}
+	}
+	if ctx.Mode != output.ModeHuman {
+		return &CodeError{Code: exitUsage, Err: errors.New("todoist tui is interactive; drop --json, --plain and --ndjson")}
+	}
+	if ctx.Global.NoInput || !isTTYReader(ctx.Stdin) || !isTTYFile(ctx.Stdout) {
+		return &CodeError{Code: exitUsage, Err: errors.New("todoist tui needs an interactive terminal")}
+	}
+	if err := ensureClient(ctx); err != nil {
+		return err
+	}
+	tty := ctx.Stdin.(*os.File)
+	session := newTUISession(ctx, ctx.Stdin, ctx.Stdout, days)
+	session.lineMode = ctx.Accessible || os.Getenv("TERM") == "dumb"
+	if !session.lineMode {
+		restore, err := enterCbreakMode(tty)
+		if err != nil {
+			fmt.Fprintf(ctx.Stderr, "full-screen mode unavailable (%v); using line mode\n", err)
+			session.lineMode = true
+		} else {
+			defer restore()
+			session.size = func() (int, int) { return terminalSize(tty) }
+		}
+	}
+	return session.run(start)
+}
+
+func newTUISession(ctx *Context, in io.Reader, out io.Writer, days int) *tuiSession {
+	return &tuiSession{
+		ctx:   ctx,
+		model: &apptui.Model{},
+		in:    in,
+		lines: bufio.NewReader(in),
+		out:   out,
+		color: !ctx.Global.NoColor && !ctx.Accessible && os.Getenv("NO_COLOR") == "",
+		days:  days,
+		size:  func() (int, int) { return 24, 80 },
+	}
+}
+
+func (s *tuiSession) run(start apptui.View) error {
+	if s.lineMode {
+		fmt.Fprintln(s.out, "todoist tui (line mode): type a row number to select, a key or command word and Enter; help lists keys, quit exits.")
+	} else {
+		fmt.Fprint(s.out, ansiAltScreenOn)
+		defer fmt.Fprint(s.out, ansiAltScreenOff)
+	}
+	s.exec(apptui.Command{Action: apptui.ActionShow, View: start})
+	for {
+		s.draw()
+		keys, err := s.readKeys()
+		for _, key := range keys {
+			if s.exec(s.model.Handle(key)) {
+				return nil
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *tuiSession) draw() {
+	if !s.lineMode {
+		rows, cols := s.size()
+		fmt.Fprint(s.out, ansiClearHome+strings.Join(apptui.Render(s.model, cols, rows, s.color), "\n"))
+		return
+	}
+	if prompt := s.model.Prompt; prompt != nil {
+		fmt.Fprintf(s.out, "%s: ", prompt.Label)
+		return
+	}
+	for _, line := range apptui.Describe(s.model) {
+		fmt.Fprintln(s.out, line)
+	}
+	fmt.Fprint(s.out, "> ")
+}
+
+func (s *tuiSession) readKeys() ([]apptui.Key, error) {
+	if !s.lineMode {
+		buf := make([]byte, 64)
+		n, err := s.in.Read(buf)
+		return apptui.ParseKeys(buf[:n]), err
+	}
+	line, err := s.lines.ReadString('\n')
+	if line == "" && err != nil {
+		return nil, err
+	}
+	return s.lineKeys(strings.TrimRight(line, "\r\n")), err
+}
+
+// lineKeys turns one line-mode input line into keys. Prompt answers are
+// typed as is; otherwise a number selects a row and "p1".."p4" sets the
+// priority.
+func (s *tuiSession) lineKeys(line string) []apptui.Key {
+	var keys []apptui.Key
+	if s.model.Prompt != nil {
+		for _, r := range line {
+			keys = append(keys, apptui.Key(string(r)))
+		}
+		return append(keys, apptui.KeyEnter)
+	}
+	word := strings.ToLower(strings.TrimSpace(line))
+	if n, err := strconv.Atoi(word); err == nil {
+		s.model.Status = ""
+		if !s.model.Select(n - 1) {
+			s.model.Status = fmt.Sprintf("no item %d", n)
+		}
+		return nil
+	}
+	if len(word) == 2 && word[0] == 'p' && word[1] >= '1' && word[1] <= '4' {
+		return []apptui.Key{apptui.Key(word[1:])}
+	}
+	if key, ok := tuiLineWords[word]; ok {
+		return []apptui.Key{key}
+	}
+	for _, r := range strings.TrimSpace(line) {
+		keys = append(keys, apptui.Key(string(r)))
+	}
+	return keys
+}
+
+// exec runs cmd and reports whether the session should end.
+func (s *tuiSession) exec(cmd apptui.Command) bool {
+	row := cmd.Row
+	switch cmd.Action {
+	case apptui.ActionNone:
+	case apptui.ActionQuit:
+		return true
+	case apptui.ActionShow:
+		screen, err := s.load(tuiRootScreen(cmd.View, s.days))
+		s.model.Reset(screen)
+		s.fail(err)
+	case apptui.ActionOpen:
+		screen := apptui.Screen{View: apptui.ViewTasks, Title: row.Text, ProjectID: row.ProjectID, SectionID: row.ID}
+		if row.Kind == apptui.RowProject {
+			screen = apptui.Screen{View: apptui.ViewSections, Title: row.Text, ProjectID: row.ID}
+		}
+		screen, err := s.load(screen)
+		if err == nil {
+			s.model.Push(screen)
+		}
+		s.fail(err)
+	case apptui.ActionRefresh:
+		if s.reload() {
+			s.model.Status = "refreshed"
+		}
+	case apptui.ActionComplete:
+		if s.runTask("completed "+row.ID, taskComplete, "--id", row.ID, "--show-unblocked") && !s.ctx.Global.DryRun {
+			s.model.MarkDone(row.ID, true)
+		}
+	case apptui.ActionReopen:
+		if s.runTask("reopened "+row.ID, taskReopen, "--id", row.ID) && !s.ctx.Global.DryRun {
+			s.model.MarkDone(row.ID, false)
+		}
+	case apptui.ActionPostpone:
+		flag := "--to"
+		if _, err := apptasks.ParsePostpone(cmd.Input, "", applyNow(s.ctx)); err == nil {
+			flag = "--by"
+		}
+		s.runTaskAndReload("postponed "+row.ID, taskPostpone, "--id", row.ID, flag, cmd.Input)
+	case apptui.ActionMove:
+		s.runTaskAndReload("moved "+row.ID+" to "+cmd.Input, taskMove, "--id", row.ID, "--project", cmd.Input)
+	case apptui.ActionLabels:
+		edit, err := apptui.ParseLabelEdit(cmd.Input)
+		if err != nil {
+			s.fail(err)
+			break
+		}
+		args := []string{"--id", row.ID}
+		if edit.Clear {
+			args = append(args, "--clear-labels")
+		}
+		for _, label := range edit.Remove {
+			args = append(args, "--remove-label", label)
+		}
+		for _, label := range edit.Add {
+			args = append(args, "--add-label", label)
+		}
+		s.runTaskAndReload("updated labels of "+row.ID, taskUpdate, args...)
+	case apptui.ActionPriority:
+		s.runTaskAndReload("set "+cmd.Input+" on "+row.ID, taskUpdate, "--id", row.ID, "--priority", cmd.Input)
+	case apptui.ActionAdd:
+		args := []string{"--content", cmd.Input}
+		if cmd.ProjectID != "" {
+			args = append(args, "--project", "id:"+cmd.ProjectID)
+		}
+		if cmd.SectionID != "" {
+			args = append(args, "--section", "id:"+cmd.SectionID)
+		}
+		if cmd.View == apptui.ViewToday {
+			args = append(args, "--due", "today")
+		}
+		s.runTaskAndReload("added "+cmd.Input, taskAdd, args...)
+	}
+	return false
+}
+
+// runTask runs a task command with its output captured. One-line results
+// (and completions listing unblocked tasks) become the status line; commands
+// that print a task table are summarized by status. Confirmation prompts are
+// off: the terminal belongs to the TUI.
+func (s *tuiSession) runTask(status string, fn func(*Context, []string) error, args ...string) bool {
+	ctx := s.ctx
+	var out, errOut bytes.Buffer
+	stdout, stderr, mode, noInput := ctx.Stdout, ctx.Stderr, ctx.Mode, ctx.Global.NoInput
+	ctx.Stdout, ctx.Stderr, ctx.Mode, ctx.Global.NoInput = &out, &errOut, output.ModeHuman, true
+	err := fn(ctx, args)
+	ctx.Stdout, ctx.Stderr, ctx.Mode, ctx.Global.NoInput = stdout, stderr, mode, noInput
+	if cache := ctx.cache(); cache != nil {
+		cache.activeTasks = nil
+		cache.activeTasksLoaded = false
+	}
+	if err != nil {
+		s.fail(err)
+		return false
+	}
+	var lines []string
+	for _, line := range strings.Split(out.String(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 1 || (len(lines) > 1 && strings.HasPrefix(lines[1], "unblocked ")) {
+		status = strings.Join(lines, "; ")
+	}
+	s.model.Status = status
+	return true
+}
+
+func (s *tuiSession) runTaskAndReload(status string, fn func(*Context, []string) error, args ...string) {
+	if s.runTask(status, fn, args...) {
+		status := s.model.Status
+		if s.reload() {
+			s.model.Status = status
+		}
+	}
+}
+
+func (s *tuiSession) reload() bool {
+	screen := s.model.Current()
+	if screen == nil {
+		return false
+	}
+	loaded, err := s.load(*screen)
+	if err != nil {
+		s.fail(err)
+		return false
+	}
+	s.model.SetRows(loaded.Rows)
+	return true
+}
+
+func (s *tuiSession) fail(err error) {
+	if err != nil {
+		s.model.Status = "error: " + err.Error()
+	}
+}
+
+func tuiRootScreen(view apptui.View, days int) apptui.Screen {
+	switch view {
+	case apptui.ViewToday:
+		return apptui.Screen{View: view, Title: "Today"}
+	case apptui.ViewUpcoming:
+		return apptui.Screen{View: view, Title: fmt.Sprintf("Upcoming (%d days)", days)}
+	}
+	return apptui.Screen{View: apptui.ViewProjects, Title: "Projects"}
+}
+
+// load fills screen.Rows. A project without sections opens straight on its
+// tasks.
+func (s *tuiSession) load(screen apptui.Screen) (apptui.Screen, error) {
+	ctx := s.ctx
+	switch screen.View {
+	case apptui.ViewProjects:
+		projects, err := fetchAllProjects(ctx)
+		if err != nil {
+			return screen, err
+		}
+		screen.Rows = tuiProjectRows(projects)
+	case apptui.ViewSections, apptui.ViewTasks:
+		tasks, err := s.projectTasks(screen.ProjectID)
+		if err != nil {
+			return screen, err
+		}
+		if screen.View == apptui.ViewTasks {
+			screen.Rows = s.taskRows(tasks, screen.SectionID, false)
+			break
+		}
+		sections, err := fetchAllSections(ctx, screen.ProjectID)
+		if err != nil {
+			return screen, err
+		}
+		if len(sections) == 0 {
+			screen.View = apptui.ViewTasks
+			screen.Rows = s.taskRows(tasks, "", false)
+			break
+		}
+		screen.Rows = tuiSectionRows(screen.ProjectID, sections, tasks)
+	case apptui.ViewToday:
+		tasks, _, err := listTasksByFilter(ctx, "overdue | today", "", 200, true)
+		if err != nil {
+			return screen, err
+		}
+		screen.Rows = s.taskRows(tasks, "", true)
+	case apptui.ViewUpcoming:
+		tasks, err := listUpcomingTasks(ctx, s.days, "", "")
+		if err != nil {
+			return screen, err
+		}
+		sortTasks(tasks, "due")
+		screen.Rows = s.taskRows(tasks, "", true)
+	}
+	return screen, nil
+}
+
+func (s *tuiSession) projectTasks(projectID string) ([]api.Task, error) {
+	query := url.Values{}
+	query.Set("project_id", projectID)
+	query.Set("limit", "200")
+	tasks, _, err := fetchPaginated[api.Task](s.ctx, "/tasks", query, true)
+	return tasks, err
+}
+
+func tuiProjectRows(projects []api.Project) []apptui.Row {
+	parents := make(map[string]string, len(projects))
+	for _, project := range projects {
+		parents[project.ID] = project.ParentID
+	}
+	rows := make([]apptui.Row, 0, len(projects))
+	for _, project := range projects {
+		if project.IsArchived {
+			continue
+		}
+		depth := 0
+		for parent := project.ParentID; parent != "" && depth < len(projects); parent = parents[parent] {
+			depth++
+		}
+		rows = append(rows, apptui.Row{Kind: apptui.RowProject, ID: project.ID, ProjectID: project.ID, Text: project.Name, Depth: depth})
+	}
+	return rows
+}
+
+func tuiSectionRows(projectID string, sections []api.Section, tasks []api.Task) []apptui.Row {
+	counts := map[string]int{}
+	for _, task := range tasks {
+		counts[task.SectionID]++
+	}
+	rows := []apptui.Row{{Kind: apptui.RowSection, ProjectID: projectID, Text: "(no section)", Detail: tuiTaskCount(counts[""])}}
+	for _, section := range sections {
+		rows = append(rows, apptui.Row{Kind: apptui.RowSection, ID: section.ID, ProjectID: projectID, Text: section.Name, Detail: tuiTaskCount(counts[section.ID])})
+	}
+	return rows
+}
+
+func tuiTaskCount(n int) string {
+	if n == 1 {
+		return "1 task"
+	}
+	return fmt.Sprintf("%d tasks", n)
+}
+
+// taskRows lists tasks of one section as a tree, or, for the cross-project
+// panes (withProject), flat and labelled with their project.
+func (s *tuiSession) taskRows(tasks []api.Task, sectionID string, withProject bool) []apptui.Row {
+	var projectNames map[string]string
+	if withProject {
+		projectNames = projectNameMap(s.ctx)
+	} else {
+		tasks = keepTasks(tasks, func(task api.Task) bool { return task.SectionID == sectionID })
+	}
+	var nodes []apptasks.FlatNode
+	if withProject {
+		for _, task := range tasks {
+			nodes = append(nodes, apptasks.FlatNode{Task: task})
+		}
+	} else {
+		nodes = apptasks.Flatten(apptasks.BuildTree(tasks))
+	}
+	rows := make([]apptui.Row, 0, len(nodes))
+	for _, node := range nodes {
+		task := node.Task
+		var details []string
+		if due := formatDue(task.Due); due != "" {
+			details = append(details, "due "+due)
+		}
+		for _, label := range task.Labels {
+			details = append(details, "@"+label)
+		}
+		if name := projectNames[task.ProjectID]; name != "" {
+			details = append(details, "#"+name)
+		}
+		rows = append(rows, apptui.Row{
+			Kind:      apptui.RowTask,
+			ID:        task.ID,
+			ProjectID: task.ProjectID,
+			SectionID: task.SectionID,
+			Text:      cleanCell(task.Content),
+			Detail:    strings.Join(details, " "),
+			Depth:     node.Depth,
+			Priority:  task.Priority,
+			Done:      task.Checked,
+		})
+	}
+	return rows
+}
+
+func containsView(views []apptui.View, want apptui.View) bool {
+	for _, view := range views {
+		if view == want {
+			return true
+		}
+	}
+	return false
+}
+
+func printTUIHelp(out interface{ Write([]byte) (int, error) }) {
+	fmt.Fprint(out, `Usage:
+  todoist tui [--view projects|today|upcoming] [--days <n>]
+
+Keys:
+`)
+	for _, line := range apptui.HelpLines {
+		fmt.Fprintf(out, "  %s\n", line)
+	}
+	fmt.Fprint(out, `
+Notes:
+  - Browse projects -> sections -> tasks, or the today and upcoming panes; a project without sections opens on its tasks.
+  - Actions run the matching task commands (complete, reopen, postpone, move, update, add); their result shows in the status line.
+  - Completed tasks stay on screen, checked, until the next refresh so they can be reopened.
+  - New tasks go to the open project/section; on the today pane they are due today; elsewhere they go to the Inbox.
+  - Requires an interactive terminal and human output. --no-color (or NO_COLOR) drops colors; --accessible (or TERM=dumb)
+    switches to line mode: numbered plain-text lists, one command per line, no screen redraws.
+  - Global --dry-run applies to every action.
+
+Examples:
+  todoist tui
+  todoist tui --view today
+  todoist --accessible tui --view upcoming --days 14
+`)
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+const (
+	ansiAltScreenOn  = "\x1b[?1049h\x1b[?25l"
+	ansiAltScreenOff = "\x1b[?25h\x1b[?1049l"
+	ansiClearHome    = "\x1b[H\x1b[2J"
+)
+
+// enterCbreakMode switches the terminal on tty to unbuffered, no-echo input
+// using stty, so the standard library alone can read single keys. Signals
+// are disabled too: ctrl-c arrives as a key and the TUI restores the
+// terminal itself. The returned func restores the previous settings.
+func enterCbreakMode(tty *os.File) (func(), error) {
+	saved, err := runStty(tty, "-g")
+	if err != nil {
+		return nil, fmt.Errorf("read terminal settings: %w", err)
+	}
+	if _, err := runStty(tty, "-icanon", "-echo", "-isig", "-ixon", "min", "1", "time", "0"); err != nil {
+		return nil, fmt.Errorf("set terminal mode: %w", err)
+	}
+	return func() { _, _ = runStty(tty, strings.TrimSpace(saved)) }, nil
+}
+
+// terminalSize reports rows and columns, preferring LINES/COLUMNS, then
+// stty, then 24x80.
+func terminalSize(tty *os.File) (int, int) {
+	rows, cols := envSize("LINES"), envSize("COLUMNS")
+	if (rows == 0 || cols == 0) && tty != nil {
+		if out, err := runStty(tty, "size"); err == nil {
+			if fields := strings.Fields(out); len(fields) == 2 {
+				if rows == 0 {
+					rows, _ = strconv.Atoi(fields[0])
+				}
+				if cols == 0 {
+					cols, _ = strconv.Atoi(fields[1])
+				}
+			}
+		}
+	}
+	if rows <= 0 {
+		rows = 24
+	}
+	if cols <= 0 {
+		cols = 80
+	}
+	return rows, cols
+}
+
+func envSize(name string) int {
+	value, err := strconv.Atoi(os.Getenv(name))
+	if err != nil || value < 0 {
+		return 0
+	}
+	return value
+}
+
+func runStty(tty *os.File, args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = tty
+	out, err := cmd.Output()
+	return string(out), err
+}
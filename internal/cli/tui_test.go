@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	apptui "github.com/agisilaos/todoist-cli/internal/app/tui"
+	"github.com/agisilaos/todoist-cli/internal/config"
+	"github.com/agisilaos/todoist-cli/internal/mockserver"
+	"github.com/agisilaos/todoist-cli/internal/output"
+)
+
+func newTUITestServer(t *testing.T) (*httptest.Server, *Context) {
+	t.Helper()
+	srv := mockserver.New(mockserver.Fixture{
+		Projects: []api.Project{{ID: "p1", Name: "Work"}, {ID: "p2", Name: "Home"}},
+		Sections: []api.Section{{ID: "s1", ProjectID: "p1", Name: "Backlog"}},
+		Labels:   []api.Label{{ID: "l1", Name: "urgent"}},
+		Tasks: []api.Task{
+			{ID: "t1", Content: "Deploy", ProjectID: "p1", SectionID: "s1", Priority: 1},
+			{ID: "t2", Content: "Write notes", ProjectID: "p1", SectionID: "s1", Labels: []string{"urgent"}},
+			{ID: "t3", Content: "Water plants", ProjectID: "p2"},
+		},
+	})
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+	ctx := &Context{
+		Stdout:     &bytes.Buffer{},
+		Stderr:     &bytes.Buffer{},
+		Mode:       output.ModeHuman,
+		Global:     GlobalOptions{NoCache: true},
+		Token:      "mock",
+		Profile:    "default",
+		ConfigPath: filepath.Join(t.TempDir(), "config.json"),
+		Client:     api.NewClient(ts.URL, "mock", 2*time.Second),
+		Config:     config.Config{TimeoutSeconds: 2},
+		Now:        func() time.Time { return time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC) },
+	}
+	return ts, ctx
+}
+
+func fetchTestTask(t *testing.T, baseURL, id string) api.Task {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	t.Setenv("TODOIST_TOKEN", "mock")
+	args := []string{"--config", filepath.Join(t.TempDir(), "config.json"), "--base-url", baseURL, "--no-cache", "--json", "task", "view", "id:" + id}
+	if code := Execute(args, &stdout, &stderr); code != exitOK {
+		t.Fatalf("task view %s exit %d: %s", id, code, stderr.String())
+	}
+	var task api.Task
+	if err := json.Unmarshal(stdout.Bytes(), &task); err != nil {
+		t.Fatalf("decode task: %v", err)
+	}
+	return task
+}
+
+func TestTUILineModeActions(t *testing.T) {
+	ts, ctx := newTUITestServer(t)
+	script := strings.Join([]string{
+		"1",    // select Work
+		"",     // open it: it has sections
+		"2",    // Backlog
+		"open", //
+		"c",    // complete Deploy
+		"r",    // and reopen it
+		"p1",   // priority p1
+		"2",    // Write notes
+		"l",    // labels prompt
+		"-urgent +home",
+		"m",    // move prompt
+		"Home", //
+		"a",    // add prompt
+		"Plan sprint",
+		"quit",
+	}, "\n") + "\n"
+	var out bytes.Buffer
+	session := newTUISession(ctx, strings.NewReader(script), &out, 7)
+	session.lineMode = true
+	if err := session.run(apptui.ViewProjects); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	text := out.String()
+	for _, want := range []string{
+		"Projects (3 items)",
+		"1. project Work (selected)\n2. project Home\n3. project Inbox",
+		"Projects > Work (2 items)\n1. section (no section), 0 tasks (selected)\n2. section Backlog, 2 tasks",
+		"1. task Deploy (selected)",
+		"1. completed task Deploy (selected)\n2. task Write notes, @urgent\ncompleted t1",
+		"reopened t1",
+		"1. task Deploy, priority p1 (selected)",
+		"Labels (+add -remove, none to clear): ",
+		"2. task Write notes, @home (selected)\nupdated labels of t2",
+		"set p1 on t1",
+		"moved t2",
+		"added Plan sprint",
+		"Move to project: ",
+		"New task: ",
+		"2. task Plan sprint",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("output missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "\x1b") {
+		t.Fatalf("line mode wrote escape codes:\n%s", text)
+	}
+	if task := fetchTestTask(t, ts.URL, "t1"); task.Priority != 4 || task.Checked {
+		t.Fatalf("unexpected t1: %#v", task)
+	}
+	if task := fetchTestTask(t, ts.URL, "t2"); task.ProjectID != "p2" || strings.Join(task.Labels, ",") != "home" {
+		t.Fatalf("unexpected t2: %#v", task)
+	}
+}
+
+func TestTUIFullScreenKeys(t *testing.T) {
+	_, ctx := newTUITestServer(t)
+	// Today is empty, so add a task due today there, then postpone it.
+	keys := "t" + "aCall bank\r" + "p1d\r" + "q"
+	var out bytes.Buffer
+	session := newTUISession(ctx, iotest.OneByteReader(strings.NewReader(keys)), &out, 7)
+	session.color = false
+	if err := session.run(apptui.ViewProjects); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	text := out.String()
+	if !strings.HasPrefix(text, ansiAltScreenOn) || !strings.HasSuffix(text, ansiAltScreenOff) {
+		t.Fatalf("expected the alternate screen around the session: %q", text)
+	}
+	frames := strings.Split(text, ansiClearHome)
+	last := frames[len(frames)-1]
+	if !strings.Contains(last, "todoist  Today") || !strings.Contains(last, "  (empty)") || !strings.Contains(last, "postponed") {
+		t.Fatalf("unexpected last frame:\n%s", last)
+	}
+	if strings.Contains(text, "\x1b[7m") {
+		t.Fatalf("reverse video with color off:\n%s", text)
+	}
+	tasks, err := listAllActiveTasks(ctx)
+	if err != nil {
+		t.Fatalf("list tasks: %v", err)
+	}
+	var added api.Task
+	for _, task := range tasks {
+		if task.Content == "Call bank" {
+			added = task
+		}
+	}
+	if added.Due == nil || added.Due.Date != "2026-10-18" {
+		t.Fatalf("expected the added task postponed to tomorrow: %#v", added)
+	}
+}
+
+func TestTUIRequiresInteractiveHumanMode(t *testing.T) {
+	t.Setenv("TODOIST_TOKEN", "mock")
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	for _, args := range [][]string{
+		{"tui"},
+		{"--json", "tui"},
+		{"tui", "--view", "inbox"},
+		{"tui", "--days", "0"},
+	} {
+		var stdout, stderr bytes.Buffer
+		if code := Execute(append([]string{"--config", configPath}, args...), &stdout, &stderr); code != exitUsage {
+			t.Fatalf("%v exit %d, want %d: %s", args, code, exitUsage, stderr.String())
+		}
+	}
+}
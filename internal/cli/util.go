@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -164,28 +165,14 @@ func writeError(ctx *Context, err error) {
 		}
 	}
 	if ctx.Mode == output.ModeJSON {
-		details := map[string]any(nil)
-		var ambiguousErr *AmbiguousMatchError
-		if errors.As(err, &ambiguousErr) {
-			details = map[string]any{
-				"type":    "ambiguous_match",
-				"entity":  ambiguousErr.Entity,
-				"input":   ambiguousErr.Input,
-				"matches": ambiguousErr.Matches,
-			}
-		}
 		enc := json.NewEncoder(ctx.Stderr)
 		if !ctx.Global.QuietJSON {
 			enc.SetIndent("", "  ")
 		}
-		payload := map[string]any{
-			"error": err.Error(),
-			"meta":  meta,
-		}
-		if details != nil {
-			payload["details"] = details
-		}
-		_ = enc.Encode(payload)
+		_ = enc.Encode(struct {
+			Error errorBody   `json:"error"`
+			Meta  output.Meta `json:"meta"`
+		}{Error: newErrorBody(err), Meta: meta})
 		return
 	}
 	if meta.RequestID != "" {
@@ -357,3 +344,16 @@ func isFlagBool(v flag.Value) bool {
 	bf, ok := v.(boolFlag)
 	return ok && bf.IsBoolFlag()
 }
+
+// profileStatePath returns <config dir>/<dir>/<profile>.json, or "" when no
+// config path is known.
+func profileStatePath(ctx *Context, dir string) string {
+	if ctx.ConfigPath == "" {
+		return ""
+	}
+	profile := ctx.Profile
+	if profile == "" {
+		profile = "default"
+	}
+	return filepath.Join(filepath.Dir(ctx.ConfigPath), dir, profile+".json")
+}
@@ -22,6 +22,8 @@ type Config struct {
 	DefaultInboxDue    string   `json:"default_inbox_due"`
 	TableWidth         int      `json:"table_width"`
 	PlannerCmd         string   `json:"planner_cmd"`
+	OfflineQueue       bool     `json:"offline_queue"`
+	BulkConcurrency    int      `json:"bulk_concurrency"`
 }
 
 type Credentials struct {
@@ -141,5 +143,11 @@ func MergeConfig(base Config, override Config) Config {
 	if override.PlannerCmd != "" {
 		result.PlannerCmd = override.PlannerCmd
 	}
+	if override.OfflineQueue {
+		result.OfflineQueue = true
+	}
+	if override.BulkConcurrency > 0 {
+		result.BulkConcurrency = override.BulkConcurrency
+	}
 	return result
 }
@@ -0,0 +1,105 @@
+package mockserver
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+)
+
+func (s *Server) handleListComments(w http.ResponseWriter, r *http.Request) {
+	taskID := r.URL.Query().Get("task_id")
+	projectID := r.URL.Query().Get("project_id")
+	if taskID == "" && projectID == "" {
+		writeError(w, badRequest("ARGUMENT_MISSING", "task_id or project_id is required"))
+		return
+	}
+	writePage(w, r, s.comments.list(func(c Comment) bool {
+		if taskID != "" {
+			return c.TaskID == taskID
+		}
+		return c.ProjectID == projectID
+	}))
+}
+
+func (s *Server) handleGetComment(w http.ResponseWriter, r *http.Request) {
+	comment, ok := s.comments.get(r.PathValue("id"))
+	if !ok {
+		writeError(w, notFound("comment", r.PathValue("id")))
+		return
+	}
+	writeJSON(w, http.StatusOK, comment)
+}
+
+func (s *Server) handleAddComment(w http.ResponseWriter, r *http.Request) {
+	withArgs(w, r, func(args map[string]any) (any, error) { return s.addComment(args) })
+}
+
+func (s *Server) handleUpdateComment(w http.ResponseWriter, r *http.Request) {
+	withArgs(w, r, func(args map[string]any) (any, error) { return s.updateComment(r.PathValue("id"), args) })
+}
+
+func (s *Server) handleDeleteComment(w http.ResponseWriter, r *http.Request) {
+	writeNoContent(w, s.deleteComment(r.PathValue("id")))
+}
+
+func (s *Server) addComment(args map[string]any) (Comment, error) {
+	content, _ := stringArg(args, "content")
+	taskID, _ := stringArg(args, "task_id")
+	projectID, _ := stringArg(args, "project_id")
+	switch {
+	case strings.TrimSpace(content) == "":
+		return Comment{}, badRequest("ARGUMENT_MISSING", "content is required")
+	case taskID == "" && projectID == "":
+		return Comment{}, badRequest("ARGUMENT_MISSING", "task_id or project_id is required")
+	case taskID != "" && !s.tasks.has(taskID):
+		return Comment{}, notFound("task", taskID)
+	case taskID == "" && !s.projects.has(projectID):
+		return Comment{}, notFound("project", projectID)
+	}
+	comment := Comment{Comment: api.Comment{ID: s.newID(), Content: content, PostedAt: s.timestamp()}}
+	if taskID != "" {
+		comment.TaskID = taskID
+	} else {
+		comment.ProjectID = projectID
+	}
+	rev := s.bump()
+	s.comments.insert(comment, rev)
+	if taskID != "" {
+		s.tasks.update(taskID, rev, func(t *api.Task) { t.NoteCount++ })
+	}
+	return comment, nil
+}
+
+func (s *Server) updateComment(id string, args map[string]any) (Comment, error) {
+	if !s.comments.has(id) {
+		return Comment{}, notFound("comment", id)
+	}
+	comment, _ := s.comments.update(id, s.bump(), func(c *Comment) {
+		if content, ok := stringArg(args, "content"); ok && strings.TrimSpace(content) != "" {
+			c.Content = content
+		}
+	})
+	return comment, nil
+}
+
+func (s *Server) deleteComment(id string) error {
+	if !s.comments.has(id) {
+		return notFound("comment", id)
+	}
+	s.removeComments(func(c Comment) bool { return c.ID == id }, s.bump())
+	return nil
+}
+
+func (s *Server) removeComments(match func(Comment) bool, rev int) {
+	for _, comment := range s.comments.list(match) {
+		s.comments.remove(comment.ID, rev)
+		if comment.TaskID != "" {
+			s.tasks.update(comment.TaskID, rev, func(t *api.Task) {
+				if t.NoteCount > 0 {
+					t.NoteCount--
+				}
+			})
+		}
+	}
+}
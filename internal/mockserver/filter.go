@@ -0,0 +1,203 @@
+package mockserver
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+)
+
+type taskMatcher func(api.Task) bool
+
+// compileFilter supports a practical subset of the Todoist filter language:
+// "|", "&", "!", parentheses and "," (treated as "|") around today, tomorrow,
+// yesterday, overdue, "no date", "next N days", p1-p4, #project, ##project,
+// /section, @label, "no labels", "search: text" and "all". Anything else is
+// rejected with INVALID_SEARCH_QUERY like the real API.
+func (s *Server) compileFilter(query string) (taskMatcher, error) {
+	p := &filterParser{server: s, input: query, today: s.Now()}
+	if strings.TrimSpace(query) == "" {
+		return nil, p.invalid()
+	}
+	match, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.input) {
+		return nil, p.invalid()
+	}
+	return match, nil
+}
+
+type filterParser struct {
+	server *Server
+	input  string
+	pos    int
+	today  time.Time
+}
+
+func (p *filterParser) invalid() error {
+	return badRequest("INVALID_SEARCH_QUERY", "invalid filter query: %s", p.input)
+}
+
+func (p *filterParser) skipSpace() {
+	for p.pos < len(p.input) && p.input[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *filterParser) peek(ch byte) bool {
+	p.skipSpace()
+	return p.pos < len(p.input) && p.input[p.pos] == ch
+}
+
+func (p *filterParser) parseOr() (taskMatcher, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek('|') || p.peek(',') {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		a, b := left, right
+		left = func(t api.Task) bool { return a(t) || b(t) }
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (taskMatcher, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek('&') {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		a, b := left, right
+		left = func(t api.Task) bool { return a(t) && b(t) }
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (taskMatcher, error) {
+	switch {
+	case p.peek('!'):
+		p.pos++
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(t api.Task) bool { return !inner(t) }, nil
+	case p.peek('('):
+		p.pos++
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.peek(')') {
+			return nil, p.invalid()
+		}
+		p.pos++
+		return inner, nil
+	}
+	start := p.pos
+	for p.pos < len(p.input) && !strings.ContainsRune("|&,()", rune(p.input[p.pos])) {
+		p.pos++
+	}
+	return p.term(strings.TrimSpace(p.input[start:p.pos]))
+}
+
+func (p *filterParser) term(raw string) (taskMatcher, error) {
+	value := strings.ToLower(raw)
+	day := func(offset int) string { return p.today.AddDate(0, 0, offset).Format(time.DateOnly) }
+	switch value {
+	case "":
+		return nil, p.invalid()
+	case "all", "view all":
+		return func(api.Task) bool { return true }, nil
+	case "today":
+		return func(t api.Task) bool { return dueDate(t) == day(0) }, nil
+	case "tomorrow":
+		return func(t api.Task) bool { return dueDate(t) == day(1) }, nil
+	case "yesterday":
+		return func(t api.Task) bool { return dueDate(t) == day(-1) }, nil
+	case "overdue", "od":
+		return func(t api.Task) bool { due := dueDate(t); return due != "" && due < day(0) }, nil
+	case "no date", "no due date":
+		return func(t api.Task) bool { return dueDate(t) == "" }, nil
+	case "no labels":
+		return func(t api.Task) bool { return len(t.Labels) == 0 }, nil
+	case "p1", "p2", "p3", "p4":
+		priority := 5 - int(value[1]-'0')
+		return func(t api.Task) bool { return t.Priority == priority }, nil
+	}
+	if days, ok := parseNextDays(value); ok {
+		return func(t api.Task) bool {
+			due := dueDate(t)
+			return due != "" && due >= day(0) && due < day(days)
+		}, nil
+	}
+	if strings.HasPrefix(value, "search:") {
+		needle := strings.Trim(strings.TrimSpace(raw[len("search:"):]), `"`)
+		needle = strings.ToLower(strings.ReplaceAll(needle, `\"`, `"`))
+		return func(t api.Task) bool { return strings.Contains(strings.ToLower(t.Content), needle) }, nil
+	}
+	switch {
+	case strings.HasPrefix(raw, "##"):
+		ids := p.projectTree(raw[2:])
+		return func(t api.Task) bool { return ids[t.ProjectID] }, nil
+	case strings.HasPrefix(raw, "#"):
+		project, _ := p.server.projectByName(raw[1:])
+		return func(t api.Task) bool { return project.ID != "" && t.ProjectID == project.ID }, nil
+	case strings.HasPrefix(raw, "/"):
+		name := raw[1:]
+		return func(t api.Task) bool {
+			section, ok := p.server.sections.get(t.SectionID)
+			return ok && strings.EqualFold(section.Name, name)
+		}, nil
+	case strings.HasPrefix(raw, "@"):
+		name := raw[1:]
+		return func(t api.Task) bool { return hasLabel(t, name) }, nil
+	}
+	return nil, p.invalid()
+}
+
+func (p *filterParser) projectTree(name string) map[string]bool {
+	ids := map[string]bool{}
+	root, ok := p.server.projectByName(name)
+	if !ok {
+		return ids
+	}
+	ids[root.ID] = true
+	for grew := true; grew; {
+		grew = false
+		for _, project := range p.server.projects.list(nil) {
+			if ids[project.ParentID] && !ids[project.ID] {
+				ids[project.ID] = true
+				grew = true
+			}
+		}
+	}
+	return ids
+}
+
+// parseNextDays accepts "next N days" and "N days".
+func parseNextDays(value string) (int, bool) {
+	fields := strings.Fields(strings.TrimPrefix(value, "next "))
+	if len(fields) != 2 || (fields[1] != "days" && fields[1] != "day") {
+		return 0, false
+	}
+	n, err := strconv.Atoi(fields[0])
+	if err != nil || n < 1 {
+		return 0, false
+	}
+	return n, true
+}
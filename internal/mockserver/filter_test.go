@@ -0,0 +1,56 @@
+package mockserver
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+)
+
+func TestCompileFilter(t *testing.T) {
+	srv := New(Fixture{
+		Projects: []api.Project{{ID: "p1", Name: "Work"}, {ID: "p2", Name: "Client", ParentID: "p1"}, {ID: "p3", Name: "Home"}},
+		Tasks: []api.Task{
+			{ID: "t1", Content: "Review PR", ProjectID: "p1", Priority: 4, Due: &api.Due{Date: "2026-03-10"}},
+			{ID: "t2", Content: "Pay rent", ProjectID: "p3", Labels: []string{"bills"}, Due: &api.Due{Date: "2026-03-08"}},
+			{ID: "t3", Content: "Call client", ProjectID: "p2", Priority: 1, Due: &api.Due{Datetime: "2026-03-14T10:00:00Z"}},
+			{ID: "t4", Content: "Someday", ProjectID: "p3"},
+		},
+	})
+	srv.Now = func() time.Time { return time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC) }
+
+	cases := map[string]string{
+		"today":                "t1",
+		"overdue | today":      "t1,t2",
+		"#Work":                "t1",
+		"##Work":               "t1,t3",
+		"@bills":               "t2",
+		"p1 & #work":           "t1",
+		"!no date & !p1":       "t2,t3",
+		"next 7 days":          "t1,t3",
+		"no date, @bills":      "t2,t4",
+		`search: "client"`:     "t3",
+		"(today | od) & #Home": "t2",
+	}
+	for query, want := range cases {
+		match, err := srv.compileFilter(query)
+		if err != nil {
+			t.Fatalf("compile %q: %v", query, err)
+		}
+		var got []string
+		for _, task := range srv.tasks.list(match) {
+			got = append(got, task.ID)
+		}
+		if strings.Join(got, ",") != want {
+			t.Fatalf("filter %q: got %v want %s", query, got, want)
+		}
+	}
+
+	for _, query := range []string{"", "weekly review", "(today"} {
+		_, err := srv.compileFilter(query)
+		if err == nil || !strings.Contains(err.Error(), "INVALID_SEARCH_QUERY") {
+			t.Fatalf("expected INVALID_SEARCH_QUERY for %q, got %v", query, err)
+		}
+	}
+}
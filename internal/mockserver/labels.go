@@ -0,0 +1,119 @@
+package mockserver
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+)
+
+func (s *Server) handleListLabels(w http.ResponseWriter, r *http.Request) {
+	writePage(w, r, s.labels.list(nil))
+}
+
+func (s *Server) handleGetLabel(w http.ResponseWriter, r *http.Request) {
+	label, ok := s.labels.get(r.PathValue("id"))
+	if !ok {
+		writeError(w, notFound("label", r.PathValue("id")))
+		return
+	}
+	writeJSON(w, http.StatusOK, label)
+}
+
+func (s *Server) handleAddLabel(w http.ResponseWriter, r *http.Request) {
+	withArgs(w, r, func(args map[string]any) (any, error) { return s.addLabel(args) })
+}
+
+func (s *Server) handleUpdateLabel(w http.ResponseWriter, r *http.Request) {
+	withArgs(w, r, func(args map[string]any) (any, error) { return s.updateLabel(r.PathValue("id"), args) })
+}
+
+func (s *Server) handleDeleteLabel(w http.ResponseWriter, r *http.Request) {
+	writeNoContent(w, s.deleteLabel(r.PathValue("id")))
+}
+
+func (s *Server) addLabel(args map[string]any) (api.Label, error) {
+	name, _ := stringArg(args, "name")
+	if strings.TrimSpace(name) == "" {
+		return api.Label{}, badRequest("ARGUMENT_MISSING", "name is required")
+	}
+	if _, exists := s.labelByName(name); exists {
+		return api.Label{}, badRequest("LABEL_ALREADY_EXISTS", "label %q already exists", name)
+	}
+	label := api.Label{ID: s.newID(), Name: name, Color: "charcoal", Order: len(s.labels.list(nil)) + 1}
+	applyLabelArgs(&label, args)
+	s.labels.insert(label, s.bump())
+	return label, nil
+}
+
+// updateLabel renames the label on every task that carries it.
+func (s *Server) updateLabel(id string, args map[string]any) (api.Label, error) {
+	current, ok := s.labels.get(id)
+	if !ok {
+		return api.Label{}, notFound("label", id)
+	}
+	if name, ok := stringArg(args, "name"); ok && !strings.EqualFold(name, current.Name) {
+		if _, exists := s.labelByName(name); exists {
+			return api.Label{}, badRequest("LABEL_ALREADY_EXISTS", "label %q already exists", name)
+		}
+	}
+	rev := s.bump()
+	label, _ := s.labels.update(id, rev, func(l *api.Label) { applyLabelArgs(l, args) })
+	if label.Name != current.Name {
+		s.relabelTasks(current.Name, label.Name, rev)
+	}
+	return label, nil
+}
+
+func (s *Server) deleteLabel(id string) error {
+	label, ok := s.labels.get(id)
+	if !ok {
+		return notFound("label", id)
+	}
+	rev := s.bump()
+	s.labels.remove(id, rev)
+	s.relabelTasks(label.Name, "", rev)
+	return nil
+}
+
+func applyLabelArgs(label *api.Label, args map[string]any) {
+	if name, ok := stringArg(args, "name"); ok && strings.TrimSpace(name) != "" {
+		label.Name = name
+	}
+	if color, ok := stringArg(args, "color"); ok {
+		label.Color = color
+	}
+	if order, ok := intArg(args, "order"); ok {
+		label.Order = order
+	}
+	if favorite, ok := boolArg(args, "is_favorite"); ok {
+		label.IsFavorite = favorite
+	}
+}
+
+// relabelTasks renames label from on every task, or drops it when to is empty.
+func (s *Server) relabelTasks(from, to string, rev int) {
+	for _, task := range s.tasks.list(func(t api.Task) bool { return hasLabel(t, from) }) {
+		s.tasks.update(task.ID, rev, func(t *api.Task) {
+			labels := make([]string, 0, len(t.Labels))
+			for _, label := range t.Labels {
+				switch {
+				case !strings.EqualFold(label, from):
+					labels = append(labels, label)
+				case to != "":
+					labels = append(labels, to)
+				}
+			}
+			t.Labels = labels
+		})
+	}
+}
+
+func (s *Server) labelByName(name string) (api.Label, bool) {
+	for _, label := range s.labels.list(nil) {
+		if strings.EqualFold(label.Name, name) {
+			return label, true
+		}
+	}
+	return api.Label{}, false
+}
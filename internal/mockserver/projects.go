@@ -0,0 +1,217 @@
+package mockserver
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+)
+
+func (s *Server) handleListProjects(archived bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writePage(w, r, s.projects.list(func(p api.Project) bool { return p.IsArchived == archived }))
+	}
+}
+
+func (s *Server) handleGetProject(w http.ResponseWriter, r *http.Request) {
+	project, ok := s.projects.get(r.PathValue("id"))
+	if !ok {
+		writeError(w, notFound("project", r.PathValue("id")))
+		return
+	}
+	writeJSON(w, http.StatusOK, project)
+}
+
+func (s *Server) handleAddProject(w http.ResponseWriter, r *http.Request) {
+	withArgs(w, r, func(args map[string]any) (any, error) { return s.addProject(args) })
+}
+
+func (s *Server) handleUpdateProject(w http.ResponseWriter, r *http.Request) {
+	withArgs(w, r, func(args map[string]any) (any, error) { return s.updateProject(r.PathValue("id"), args) })
+}
+
+func (s *Server) handleArchiveProject(archived bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		project, err := s.archiveProject(r.PathValue("id"), archived)
+		writeResult(w, project, err)
+	}
+}
+
+func (s *Server) handleDeleteProject(w http.ResponseWriter, r *http.Request) {
+	writeNoContent(w, s.deleteProject(r.PathValue("id")))
+}
+
+// handleListCollaborators reports the mock user as the only collaborator.
+func (s *Server) handleListCollaborators(w http.ResponseWriter, r *http.Request) {
+	if !s.projects.has(r.PathValue("id")) {
+		writeError(w, notFound("project", r.PathValue("id")))
+		return
+	}
+	writePage(w, r, []api.Collaborator{{ID: s.userID, Name: "Mock User", Email: "mock@example.com"}})
+}
+
+func (s *Server) addProject(args map[string]any) (api.Project, error) {
+	name, _ := stringArg(args, "name")
+	if strings.TrimSpace(name) == "" {
+		return api.Project{}, badRequest("ARGUMENT_MISSING", "name is required")
+	}
+	project := api.Project{ID: s.newID(), ViewStyle: "list", CanAssignTask: true}
+	if parentID, _ := stringArg(args, "parent_id"); parentID != "" {
+		if !s.projects.has(parentID) {
+			return api.Project{}, notFound("parent project", parentID)
+		}
+		project.ParentID = parentID
+	}
+	if workspaceID, _ := stringArg(args, "workspace_id"); workspaceID != "" {
+		project.WorkspaceID = workspaceID
+	}
+	applyProjectArgs(&project, args)
+	s.projects.insert(project, s.bump())
+	return project, nil
+}
+
+func (s *Server) updateProject(id string, args map[string]any) (api.Project, error) {
+	if !s.projects.has(id) {
+		return api.Project{}, notFound("project", id)
+	}
+	project, _ := s.projects.update(id, s.bump(), func(p *api.Project) { applyProjectArgs(p, args) })
+	return project, nil
+}
+
+func applyProjectArgs(project *api.Project, args map[string]any) {
+	if name, ok := stringArg(args, "name"); ok && strings.TrimSpace(name) != "" {
+		project.Name = name
+	}
+	if description, ok := stringArg(args, "description"); ok {
+		project.Description = description
+	}
+	if favorite, ok := boolArg(args, "is_favorite"); ok {
+		project.IsFavorite = favorite
+	}
+	if viewStyle, ok := stringArg(args, "view_style"); ok {
+		project.ViewStyle = viewStyle
+	}
+}
+
+func (s *Server) archiveProject(id string, archived bool) (api.Project, error) {
+	project, ok := s.projects.get(id)
+	if !ok {
+		return api.Project{}, notFound("project", id)
+	}
+	if project.IsInbox {
+		return api.Project{}, badRequest("INVALID_ARGUMENT_VALUE", "the inbox project cannot be archived")
+	}
+	project, _ = s.projects.update(id, s.bump(), func(p *api.Project) { p.IsArchived = archived })
+	return project, nil
+}
+
+// deleteProject removes the project, its subprojects and everything in them.
+func (s *Server) deleteProject(id string) error {
+	project, ok := s.projects.get(id)
+	if !ok {
+		return notFound("project", id)
+	}
+	if project.IsInbox {
+		return badRequest("INVALID_ARGUMENT_VALUE", "the inbox project cannot be deleted")
+	}
+	ids := []string{id}
+	for idx := 0; idx < len(ids); idx++ {
+		parent := ids[idx]
+		for _, child := range s.projects.list(func(p api.Project) bool { return p.ParentID == parent }) {
+			ids = append(ids, child.ID)
+		}
+	}
+	rev := s.bump()
+	for _, projectID := range ids {
+		for _, task := range s.tasks.list(func(t api.Task) bool { return t.ProjectID == projectID }) {
+			s.tasks.remove(task.ID, rev)
+			s.removeComments(func(c Comment) bool { return c.TaskID == task.ID }, rev)
+		}
+		for _, section := range s.sections.list(func(sec api.Section) bool { return sec.ProjectID == projectID }) {
+			s.sections.remove(section.ID, rev)
+		}
+		s.removeComments(func(c Comment) bool { return c.ProjectID == projectID }, rev)
+		s.projects.remove(projectID, rev)
+	}
+	return nil
+}
+
+func (s *Server) projectByName(name string) (api.Project, bool) {
+	for _, project := range s.projects.list(nil) {
+		if strings.EqualFold(project.Name, name) {
+			return project, true
+		}
+	}
+	return api.Project{}, false
+}
+
+func (s *Server) handleListSections(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("project_id")
+	writePage(w, r, s.sections.list(func(sec api.Section) bool {
+		return projectID == "" || sec.ProjectID == projectID
+	}))
+}
+
+func (s *Server) handleGetSection(w http.ResponseWriter, r *http.Request) {
+	section, ok := s.sections.get(r.PathValue("id"))
+	if !ok {
+		writeError(w, notFound("section", r.PathValue("id")))
+		return
+	}
+	writeJSON(w, http.StatusOK, section)
+}
+
+func (s *Server) handleAddSection(w http.ResponseWriter, r *http.Request) {
+	withArgs(w, r, func(args map[string]any) (any, error) { return s.addSection(args) })
+}
+
+func (s *Server) handleUpdateSection(w http.ResponseWriter, r *http.Request) {
+	withArgs(w, r, func(args map[string]any) (any, error) { return s.updateSection(r.PathValue("id"), args) })
+}
+
+func (s *Server) handleDeleteSection(w http.ResponseWriter, r *http.Request) {
+	writeNoContent(w, s.deleteSection(r.PathValue("id")))
+}
+
+func (s *Server) addSection(args map[string]any) (api.Section, error) {
+	name, _ := stringArg(args, "name")
+	projectID, _ := stringArg(args, "project_id")
+	if strings.TrimSpace(name) == "" || projectID == "" {
+		return api.Section{}, badRequest("ARGUMENT_MISSING", "name and project_id are required")
+	}
+	if !s.projects.has(projectID) {
+		return api.Section{}, notFound("project", projectID)
+	}
+	section := api.Section{ID: s.newID(), Name: name, ProjectID: projectID}
+	s.sections.insert(section, s.bump())
+	return section, nil
+}
+
+func (s *Server) updateSection(id string, args map[string]any) (api.Section, error) {
+	if !s.sections.has(id) {
+		return api.Section{}, notFound("section", id)
+	}
+	section, _ := s.sections.update(id, s.bump(), func(sec *api.Section) {
+		if name, ok := stringArg(args, "name"); ok && strings.TrimSpace(name) != "" {
+			sec.Name = name
+		}
+		if collapsed, ok := boolArg(args, "is_collapsed"); ok {
+			sec.IsCollapsed = collapsed
+		}
+	})
+	return section, nil
+}
+
+// deleteSection removes the section together with its tasks.
+func (s *Server) deleteSection(id string) error {
+	if !s.sections.has(id) {
+		return notFound("section", id)
+	}
+	rev := s.bump()
+	for _, task := range s.tasks.list(func(t api.Task) bool { return t.SectionID == id }) {
+		s.tasks.remove(task.ID, rev)
+		s.removeComments(func(c Comment) bool { return c.TaskID == task.ID }, rev)
+	}
+	s.sections.remove(id, rev)
+	return nil
+}
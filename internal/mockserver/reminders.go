@@ -0,0 +1,52 @@
+package mockserver
+
+import "github.com/agisilaos/todoist-cli/internal/api"
+
+// addReminder stores a reminder for an existing task. Reminders are only
+// reachable through the Sync API, as in Todoist.
+func (s *Server) addReminder(args map[string]any) (api.Reminder, error) {
+	itemID, _ := stringArg(args, "item_id")
+	if itemID == "" {
+		return api.Reminder{}, badRequest("ARGUMENT_MISSING", "item_id is required")
+	}
+	if !s.tasks.has(itemID) {
+		return api.Reminder{}, notFound("task", itemID)
+	}
+	reminder := api.Reminder{ID: s.newID(), ItemID: itemID, Type: "absolute"}
+	if kind, ok := stringArg(args, "type"); ok && kind != "" {
+		reminder.Type = kind
+	}
+	applyReminderArgs(&reminder, args)
+	if reminder.Due == nil && reminder.MinuteOffset == 0 {
+		return api.Reminder{}, badRequest("ARGUMENT_MISSING", "due or minute_offset is required")
+	}
+	s.reminders.insert(reminder, s.bump())
+	return reminder, nil
+}
+
+func (s *Server) updateReminder(id string, args map[string]any) (api.Reminder, error) {
+	if !s.reminders.has(id) {
+		return api.Reminder{}, notFound("reminder", id)
+	}
+	reminder, _ := s.reminders.update(id, s.bump(), func(r *api.Reminder) { applyReminderArgs(r, args) })
+	return reminder, nil
+}
+
+func (s *Server) deleteReminder(id string) error {
+	if !s.reminders.has(id) {
+		return notFound("reminder", id)
+	}
+	s.reminders.remove(id, s.bump())
+	return nil
+}
+
+func applyReminderArgs(reminder *api.Reminder, args map[string]any) {
+	if offset, ok := intArg(args, "minute_offset"); ok {
+		reminder.MinuteOffset = offset
+	}
+	if due, ok := args["due"].(map[string]any); ok {
+		if date, _ := stringArg(due, "date"); date != "" {
+			reminder.Due = &api.ReminderDue{Date: date}
+		}
+	}
+}
@@ -0,0 +1,363 @@
+// Package mockserver is an in-memory stand-in for the subset of the Todoist
+// API v1 that the CLI talks to. It is stateful, paginates with cursors and
+// accepts any bearer token, so agent plans and bulk operations can run in CI
+// without a real account.
+package mockserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+)
+
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 200
+	defaultUserID    = "mock-user"
+)
+
+// Comment is a task or project comment as stored by the mock server.
+type Comment struct {
+	api.Comment
+	TaskID    string `json:"task_id,omitempty"`
+	ProjectID string `json:"project_id,omitempty"`
+}
+
+// Fixture seeds the server state. Every field is optional; an inbox project
+// is created when none of the seeded projects is marked as the inbox.
+type Fixture struct {
+	UserID     string          `json:"user_id"`
+	Projects   []api.Project   `json:"projects"`
+	Sections   []api.Section   `json:"sections"`
+	Tasks      []api.Task      `json:"tasks"`
+	Labels     []api.Label     `json:"labels"`
+	Comments   []Comment       `json:"comments"`
+	Reminders  []api.Reminder  `json:"reminders"`
+	Workspaces []api.Workspace `json:"workspaces"`
+}
+
+func LoadFixture(path string) (Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Fixture{}, fmt.Errorf("read seed: %w", err)
+	}
+	var fixture Fixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return Fixture{}, fmt.Errorf("decode seed %s: %w", path, err)
+	}
+	return fixture, nil
+}
+
+type Server struct {
+	Now func() time.Time
+
+	mu         sync.Mutex
+	rev        int
+	nextID     int
+	userID     string
+	projects   *table[api.Project]
+	sections   *table[api.Section]
+	tasks      *table[api.Task]
+	labels     *table[api.Label]
+	comments   *table[Comment]
+	reminders  *table[api.Reminder]
+	workspaces []api.Workspace
+	mux        *http.ServeMux
+}
+
+func New(fixture Fixture) *Server {
+	s := &Server{
+		Now:        time.Now,
+		rev:        1,
+		nextID:     1000,
+		userID:     fixture.UserID,
+		projects:   newTable(func(p api.Project) string { return p.ID }),
+		sections:   newTable(func(sec api.Section) string { return sec.ID }),
+		tasks:      newTable(func(t api.Task) string { return t.ID }),
+		labels:     newTable(func(l api.Label) string { return l.ID }),
+		comments:   newTable(func(c Comment) string { return c.ID }),
+		reminders:  newTable(func(r api.Reminder) string { return r.ID }),
+		workspaces: append([]api.Workspace(nil), fixture.Workspaces...),
+	}
+	if s.userID == "" {
+		s.userID = defaultUserID
+	}
+	hasInbox := false
+	for _, project := range fixture.Projects {
+		hasInbox = hasInbox || project.IsInbox
+		s.projects.insert(project, s.rev)
+	}
+	if !hasInbox {
+		s.projects.insert(api.Project{ID: s.newID(), Name: "Inbox", IsInbox: true, ViewStyle: "list", CanAssignTask: true}, s.rev)
+	}
+	for _, section := range fixture.Sections {
+		s.sections.insert(section, s.rev)
+	}
+	for _, task := range fixture.Tasks {
+		if task.Labels == nil {
+			task.Labels = []string{}
+		}
+		s.tasks.insert(task, s.rev)
+	}
+	for _, label := range fixture.Labels {
+		s.labels.insert(label, s.rev)
+	}
+	for _, comment := range fixture.Comments {
+		s.comments.insert(comment, s.rev)
+	}
+	for _, reminder := range fixture.Reminders {
+		s.reminders.insert(reminder, s.rev)
+	}
+	s.routes()
+	return s
+}
+
+// ServeHTTP requires a bearer token (any value) and accepts paths with or
+// without the /api/v1 prefix.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+		writeError(w, &apiError{status: http.StatusUnauthorized, tag: "AUTH_INVALID_TOKEN", msg: "Invalid token"})
+		return
+	}
+	if trimmed := strings.TrimPrefix(r.URL.Path, "/api/v1"); trimmed != r.URL.Path {
+		r.URL.Path = trimmed
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) routes() {
+	s.mux = http.NewServeMux()
+	handle := func(pattern string, fn func(w http.ResponseWriter, r *http.Request)) {
+		s.mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			fn(w, r)
+		})
+	}
+	handle("GET /tasks", s.handleListTasks)
+	handle("POST /tasks", s.handleAddTask)
+	handle("GET /tasks/filter", s.handleFilterTasks)
+	handle("POST /tasks/quick", s.handleQuickAdd)
+	handle("GET /tasks/completed/by_completion_date", s.handleCompleted("completion"))
+	handle("GET /tasks/completed/by_due_date", s.handleCompleted("due"))
+	handle("GET /tasks/{id}", s.handleGetTask)
+	handle("POST /tasks/{id}", s.handleUpdateTask)
+	handle("POST /tasks/{id}/close", s.handleCloseTask)
+	handle("POST /tasks/{id}/reopen", s.handleReopenTask)
+	handle("POST /tasks/{id}/move", s.handleMoveTask)
+	handle("DELETE /tasks/{id}", s.handleDeleteTask)
+
+	handle("GET /projects", s.handleListProjects(false))
+	handle("GET /projects/archived", s.handleListProjects(true))
+	handle("POST /projects", s.handleAddProject)
+	handle("GET /projects/{id}", s.handleGetProject)
+	handle("POST /projects/{id}", s.handleUpdateProject)
+	handle("POST /projects/{id}/archive", s.handleArchiveProject(true))
+	handle("POST /projects/{id}/unarchive", s.handleArchiveProject(false))
+	handle("GET /projects/{id}/collaborators", s.handleListCollaborators)
+	handle("DELETE /projects/{id}", s.handleDeleteProject)
+
+	handle("GET /sections", s.handleListSections)
+	handle("POST /sections", s.handleAddSection)
+	handle("GET /sections/{id}", s.handleGetSection)
+	handle("POST /sections/{id}", s.handleUpdateSection)
+	handle("DELETE /sections/{id}", s.handleDeleteSection)
+
+	handle("GET /labels", s.handleListLabels)
+	handle("POST /labels", s.handleAddLabel)
+	handle("GET /labels/{id}", s.handleGetLabel)
+	handle("POST /labels/{id}", s.handleUpdateLabel)
+	handle("DELETE /labels/{id}", s.handleDeleteLabel)
+
+	handle("GET /comments", s.handleListComments)
+	handle("POST /comments", s.handleAddComment)
+	handle("GET /comments/{id}", s.handleGetComment)
+	handle("POST /comments/{id}", s.handleUpdateComment)
+	handle("DELETE /comments/{id}", s.handleDeleteComment)
+
+	handle("POST /sync", s.handleSync)
+}
+
+func (s *Server) bump() int {
+	s.rev++
+	return s.rev
+}
+
+func (s *Server) newID() string {
+	for {
+		s.nextID++
+		id := strconv.Itoa(s.nextID)
+		if !s.projects.has(id) && !s.sections.has(id) && !s.tasks.has(id) && !s.labels.has(id) && !s.comments.has(id) && !s.reminders.has(id) {
+			return id
+		}
+	}
+}
+
+func (s *Server) timestamp() string {
+	return s.Now().UTC().Format(time.RFC3339)
+}
+
+// apiError mirrors the Todoist error body: {"error", "error_tag", "http_code"}.
+type apiError struct {
+	status int
+	tag    string
+	msg    string
+}
+
+func (e *apiError) Error() string {
+	return e.tag + ": " + e.msg
+}
+
+func (e *apiError) body() map[string]any {
+	return map[string]any{"error": e.msg, "error_tag": e.tag, "http_code": e.status}
+}
+
+func notFound(kind, id string) *apiError {
+	return &apiError{status: http.StatusNotFound, tag: "NOT_FOUND", msg: fmt.Sprintf("%s %s not found", kind, id)}
+}
+
+func badRequest(tag, format string, args ...any) *apiError {
+	return &apiError{status: http.StatusBadRequest, tag: tag, msg: fmt.Sprintf(format, args...)}
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	apiErr, ok := err.(*apiError)
+	if !ok {
+		apiErr = &apiError{status: http.StatusInternalServerError, tag: "INTERNAL_ERROR", msg: err.Error()}
+	}
+	writeJSON(w, apiErr.status, apiErr.body())
+}
+
+func writeResult(w http.ResponseWriter, value any, err error) {
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, value)
+}
+
+func writeNoContent(w http.ResponseWriter, err error) {
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writePage serves one cursor page. Cursors are offsets into the filtered
+// list, so they stay valid only while the list is unchanged.
+func writePage[T any](w http.ResponseWriter, r *http.Request, items []T) {
+	query := r.URL.Query()
+	limit := defaultPageLimit
+	if raw := query.Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			writeError(w, badRequest("INVALID_ARGUMENT_VALUE", "invalid limit %q", raw))
+			return
+		}
+		limit = min(n, maxPageLimit)
+	}
+	offset := 0
+	if raw := query.Get("cursor"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 || n > len(items) {
+			writeError(w, badRequest("INVALID_CURSOR", "invalid cursor %q", raw))
+			return
+		}
+		offset = n
+	}
+	end := min(offset+limit, len(items))
+	var next any
+	if end < len(items) {
+		next = strconv.Itoa(end)
+	}
+	page := items[offset:end]
+	if page == nil {
+		page = []T{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"results": page, "next_cursor": next})
+}
+
+func decodeArgs(r *http.Request) (map[string]any, error) {
+	args := map[string]any{}
+	if r.Body == nil {
+		return args, nil
+	}
+	dec := json.NewDecoder(r.Body)
+	if err := dec.Decode(&args); err != nil && !errors.Is(err, io.EOF) {
+		return nil, badRequest("INVALID_JSON", "invalid request body: %v", err)
+	}
+	return args, nil
+}
+
+// withArgs decodes the JSON body and hands it to fn, writing fn's result.
+func withArgs(w http.ResponseWriter, r *http.Request, fn func(args map[string]any) (any, error)) {
+	args, err := decodeArgs(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	value, err := fn(args)
+	writeResult(w, value, err)
+}
+
+func stringArg(args map[string]any, key string) (string, bool) {
+	value, ok := args[key]
+	if !ok || value == nil {
+		return "", false
+	}
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	default:
+		return fmt.Sprint(v), true
+	}
+}
+
+func boolArg(args map[string]any, key string) (bool, bool) {
+	value, ok := args[key].(bool)
+	return value, ok
+}
+
+func intArg(args map[string]any, key string) (int, bool) {
+	switch v := args[key].(type) {
+	case float64:
+		return int(v), true
+	case string:
+		n, err := strconv.Atoi(v)
+		return n, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func stringsArg(args map[string]any, key string) ([]string, bool) {
+	raw, ok := args[key].([]any)
+	if !ok {
+		return nil, false
+	}
+	out := make([]string, 0, len(raw))
+	for _, value := range raw {
+		if text, ok := value.(string); ok {
+			out = append(out, text)
+		}
+	}
+	return out, true
+}
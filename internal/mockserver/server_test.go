@@ -0,0 +1,200 @@
+package mockserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+)
+
+func newTestServer(t *testing.T, fixture Fixture) (*Server, *api.Client) {
+	t.Helper()
+	srv := New(fixture)
+	srv.Now = func() time.Time { return time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC) }
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+	return srv, api.NewClient(ts.URL, "token", 2*time.Second)
+}
+
+func TestTaskCRUDAndCursorPagination(t *testing.T) {
+	_, client := newTestServer(t, Fixture{Projects: []api.Project{{ID: "p1", Name: "Work"}}})
+	ctx := context.Background()
+	var created []api.Task
+	for _, content := range []string{"one", "two", "three"} {
+		var task api.Task
+		if _, err := client.Post(ctx, "/tasks", nil, map[string]any{"content": content, "project_id": "p1", "priority": 4}, &task, true); err != nil {
+			t.Fatalf("create %s: %v", content, err)
+		}
+		created = append(created, task)
+	}
+
+	query := url.Values{"project_id": {"p1"}, "limit": {"2"}}
+	var page api.Paginated[api.Task]
+	if _, err := client.Get(ctx, "/tasks", query, &page); err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(page.Results) != 2 || page.NextCursor == "" {
+		t.Fatalf("expected first page of two with a cursor: %#v", page)
+	}
+	query.Set("cursor", page.NextCursor)
+	page = api.Paginated[api.Task]{}
+	if _, err := client.Get(ctx, "/tasks", query, &page); err != nil {
+		t.Fatalf("list next page: %v", err)
+	}
+	if len(page.Results) != 1 || page.Results[0].Content != "three" || page.NextCursor != "" {
+		t.Fatalf("unexpected last page: %#v", page)
+	}
+
+	var updated api.Task
+	if _, err := client.Post(ctx, "/tasks/"+created[0].ID, nil, map[string]any{"content": "uno", "due_string": "tomorrow"}, &updated, true); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if updated.Content != "uno" || updated.Due == nil || updated.Due.Date != "2026-03-11" {
+		t.Fatalf("unexpected update: %#v", updated)
+	}
+	if _, err := client.Post(ctx, "/tasks/"+created[1].ID+"/close", nil, nil, nil, true); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if _, err := client.Delete(ctx, "/tasks/"+created[2].ID, nil); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	page = api.Paginated[api.Task]{}
+	if _, err := client.Get(ctx, "/tasks", url.Values{"project_id": {"p1"}}, &page); err != nil {
+		t.Fatalf("list after mutations: %v", err)
+	}
+	if len(page.Results) != 1 || page.Results[0].ID != created[0].ID {
+		t.Fatalf("expected only the open task to remain: %#v", page.Results)
+	}
+	var completed api.Paginated[api.Task]
+	if _, err := client.Get(ctx, "/tasks/completed/by_completion_date", url.Values{"since": {"2026-03-10"}, "until": {"2026-03-10"}}, &completed); err != nil {
+		t.Fatalf("completed: %v", err)
+	}
+	if len(completed.Results) != 1 || completed.Results[0].ID != created[1].ID {
+		t.Fatalf("unexpected completed tasks: %#v", completed.Results)
+	}
+	_, err := client.Get(ctx, "/tasks/"+created[2].ID, nil, &updated)
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusNotFound {
+		t.Fatalf("expected 404 for deleted task, got %v", err)
+	}
+}
+
+func TestQuickAddAndMove(t *testing.T) {
+	_, client := newTestServer(t, Fixture{
+		Projects: []api.Project{{ID: "p1", Name: "Work"}},
+		Sections: []api.Section{{ID: "s1", Name: "Later", ProjectID: "p1"}},
+	})
+	ctx := context.Background()
+	task, _, err := client.QuickAdd(ctx, "Write report #Work @deep p1")
+	if err != nil {
+		t.Fatalf("quick add: %v", err)
+	}
+	if task.Content != "Write report" || task.ProjectID != "p1" || task.Priority != 4 || len(task.Labels) != 1 {
+		t.Fatalf("unexpected quick add task: %#v", task)
+	}
+	var moved api.Task
+	if _, err := client.Post(ctx, "/tasks/"+task.ID+"/move", nil, map[string]any{"section_id": "s1"}, &moved, true); err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	if moved.SectionID != "s1" || moved.ProjectID != "p1" {
+		t.Fatalf("unexpected moved task: %#v", moved)
+	}
+}
+
+func TestSyncCommandsResolveTempIDsAndIncrementalSync(t *testing.T) {
+	_, client := newTestServer(t, Fixture{})
+	ctx := context.Background()
+	state := api.SyncState{}
+	if _, _, err := client.Sync(ctx, &state, true, time.Now()); err != nil {
+		t.Fatalf("full sync: %v", err)
+	}
+	if len(state.Projects) != 1 || !state.Projects[0].IsInbox {
+		t.Fatalf("expected seeded inbox: %#v", state.Projects)
+	}
+
+	project := api.NewSyncCommand("project_add", map[string]any{"name": "Launch"})
+	project.TempID = "tmp-project"
+	task := api.NewSyncCommand("item_add", map[string]any{"content": "Ship", "project_id": "tmp-project", "due": map[string]any{"date": "2026-03-12"}})
+	task.TempID = "tmp-task"
+	bad := api.NewSyncCommand("item_close", map[string]any{"id": "missing"})
+	result, _, err := client.ExecuteCommands(ctx, []api.SyncCommand{project, task, bad})
+	if err != nil {
+		t.Fatalf("execute commands: %v", err)
+	}
+	if result.CommandError(project.UUID) != nil || result.CommandError(task.UUID) != nil {
+		t.Fatalf("expected ok statuses: %#v", result.SyncStatus)
+	}
+	var apiErr *api.APIError
+	if err := result.CommandError(bad.UUID); !errors.As(err, &apiErr) || apiErr.Status != http.StatusNotFound {
+		t.Fatalf("expected not found status for bad command, got %v", err)
+	}
+	projectID := result.ResolveTempID("tmp-project")
+	if projectID == "tmp-project" || result.ResolveTempID("tmp-task") == "tmp-task" {
+		t.Fatalf("expected temp id mapping: %#v", result.TempIDMapping)
+	}
+
+	sync, _, err := client.Sync(ctx, &state, false, time.Now())
+	if err != nil {
+		t.Fatalf("incremental sync: %v", err)
+	}
+	if sync.FullSync || len(state.Items) != 1 || state.Items[0].ProjectID != projectID || state.Items[0].Due.Date != "2026-03-12" {
+		t.Fatalf("unexpected incremental state: %#v %#v", sync, state.Items)
+	}
+
+	del := api.NewSyncCommand("project_delete", map[string]any{"id": projectID})
+	if _, _, err := client.ExecuteCommands(ctx, []api.SyncCommand{del}); err != nil {
+		t.Fatalf("delete project: %v", err)
+	}
+	sync, _, err = client.Sync(ctx, &state, false, time.Now())
+	if err != nil {
+		t.Fatalf("sync after delete: %v", err)
+	}
+	if len(state.Items) != 0 || len(state.Projects) != 1 || sync.Changes["items"].Deleted != 1 {
+		t.Fatalf("expected cascade delete to sync: %#v %#v", sync, state)
+	}
+}
+
+func TestRequestsRequireBearerToken(t *testing.T) {
+	_, client := newTestServer(t, Fixture{})
+	client.Token = ""
+	_, err := client.Get(context.Background(), "/projects", nil, nil)
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without token, got %v", err)
+	}
+}
+
+func TestRemindersAndTaskScheduleFields(t *testing.T) {
+	_, client := newTestServer(t, Fixture{Tasks: []api.Task{{ID: "t1", Content: "Call", ProjectID: "p1"}}})
+	ctx := context.Background()
+	var task api.Task
+	if _, err := client.Post(ctx, "/tasks/t1", nil, map[string]any{"duration": 30, "duration_unit": "minute", "deadline_date": "2026-03-20"}, &task, true); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if task.Duration == nil || task.Duration.Amount != 30 || task.Duration.Unit != "minute" || task.Deadline == nil || task.Deadline.Date != "2026-03-20" {
+		t.Fatalf("unexpected schedule fields: %#v %#v", task.Duration, task.Deadline)
+	}
+
+	id, _, err := client.AddReminder(ctx, api.ReminderAddInput{ItemID: "t1", MinuteOffset: 15})
+	if err != nil {
+		t.Fatalf("add reminder: %v", err)
+	}
+	reminders, _, err := client.FetchReminders(ctx)
+	if err != nil {
+		t.Fatalf("fetch reminders: %v", err)
+	}
+	if len(reminders) != 1 || reminders[0].ID != id || reminders[0].ItemID != "t1" || reminders[0].MinuteOffset != 15 {
+		t.Fatalf("unexpected reminders: %#v", reminders)
+	}
+	if _, err := client.Delete(ctx, "/tasks/t1", nil); err != nil {
+		t.Fatalf("delete task: %v", err)
+	}
+	if reminders, _, err = client.FetchReminders(ctx); err != nil || len(reminders) != 0 {
+		t.Fatalf("expected reminders removed with their task: %#v %v", reminders, err)
+	}
+}
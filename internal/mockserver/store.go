@@ -0,0 +1,101 @@
+package mockserver
+
+// table keeps records in insertion order together with the revision that
+// last touched them. Deleted records stay behind as tombstones so
+// incremental syncs can report them.
+type table[T any] struct {
+	rows []row[T]
+	idOf func(T) string
+}
+
+type row[T any] struct {
+	value   T
+	rev     int
+	deleted bool
+}
+
+func newTable[T any](idOf func(T) string) *table[T] {
+	return &table[T]{idOf: idOf}
+}
+
+func (t *table[T]) find(id string) int {
+	for idx := range t.rows {
+		if !t.rows[idx].deleted && t.idOf(t.rows[idx].value) == id {
+			return idx
+		}
+	}
+	return -1
+}
+
+func (t *table[T]) has(id string) bool {
+	return t.find(id) >= 0
+}
+
+func (t *table[T]) get(id string) (T, bool) {
+	idx := t.find(id)
+	if idx < 0 {
+		var zero T
+		return zero, false
+	}
+	return t.rows[idx].value, true
+}
+
+func (t *table[T]) insert(value T, rev int) {
+	t.rows = append(t.rows, row[T]{value: value, rev: rev})
+}
+
+func (t *table[T]) update(id string, rev int, fn func(*T)) (T, bool) {
+	idx := t.find(id)
+	if idx < 0 {
+		var zero T
+		return zero, false
+	}
+	fn(&t.rows[idx].value)
+	t.rows[idx].rev = rev
+	return t.rows[idx].value, true
+}
+
+func (t *table[T]) remove(id string, rev int) bool {
+	idx := t.find(id)
+	if idx < 0 {
+		return false
+	}
+	t.rows[idx].deleted = true
+	t.rows[idx].rev = rev
+	return true
+}
+
+func (t *table[T]) list(keep func(T) bool) []T {
+	out := make([]T, 0, len(t.rows))
+	for _, r := range t.rows {
+		if r.deleted || (keep != nil && !keep(r.value)) {
+			continue
+		}
+		out = append(out, r.value)
+	}
+	return out
+}
+
+// since returns records changed after rev; tombstones are rendered as
+// {"id": ..., "is_deleted": true}. A zero rev returns live records only.
+func (t *table[T]) since(rev int, render func(T) any) []any {
+	out := make([]any, 0)
+	for _, r := range t.rows {
+		if rev == 0 && r.deleted {
+			continue
+		}
+		if r.rev <= rev {
+			continue
+		}
+		if r.deleted {
+			out = append(out, map[string]any{"id": t.idOf(r.value), "is_deleted": true})
+			continue
+		}
+		if render != nil {
+			out = append(out, render(r.value))
+			continue
+		}
+		out = append(out, r.value)
+	}
+	return out
+}
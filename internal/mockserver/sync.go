@@ -0,0 +1,194 @@
+package mockserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+)
+
+type syncCommand struct {
+	Type   string         `json:"type"`
+	UUID   string         `json:"uuid"`
+	TempID string         `json:"temp_id"`
+	Args   map[string]any `json:"args"`
+}
+
+// handleSync runs form-encoded "commands" and/or reads resources for
+// "sync_token". Sync tokens are server revisions; "*" (or any unknown
+// token) returns a full sync.
+func (s *Server) handleSync(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, badRequest("INVALID_ARGUMENT_VALUE", "invalid form body: %v", err))
+		return
+	}
+	resp := map[string]any{}
+	if raw := r.PostForm.Get("commands"); raw != "" {
+		var commands []syncCommand
+		if err := json.Unmarshal([]byte(raw), &commands); err != nil {
+			writeError(w, badRequest("INVALID_ARGUMENT_VALUE", "invalid commands: %v", err))
+			return
+		}
+		if len(commands) > api.MaxSyncCommands {
+			writeError(w, badRequest("LIMITS_REACHED", "too many commands: %d", len(commands)))
+			return
+		}
+		status, mapping := s.runSyncCommands(commands)
+		resp["sync_status"] = status
+		resp["temp_id_mapping"] = mapping
+	}
+	if token := r.PostForm.Get("sync_token"); token != "" {
+		var types []string
+		if raw := r.PostForm.Get("resource_types"); raw != "" {
+			if err := json.Unmarshal([]byte(raw), &types); err != nil {
+				writeError(w, badRequest("INVALID_ARGUMENT_VALUE", "invalid resource_types: %v", err))
+				return
+			}
+		}
+		since, err := strconv.Atoi(token)
+		if err != nil || since > s.rev {
+			since = 0
+		}
+		resp["full_sync"] = since == 0
+		for _, resource := range types {
+			s.addSyncResource(resp, resource, since)
+		}
+	}
+	resp["sync_token"] = strconv.Itoa(s.rev)
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) addSyncResource(resp map[string]any, resource string, since int) {
+	switch resource {
+	case "all":
+		for _, name := range []string{"items", "projects", "sections", "labels", "notes", "filters", "reminders", "live_notifications", "user", "workspaces"} {
+			s.addSyncResource(resp, name, since)
+		}
+	case "items":
+		resp["items"] = s.tasks.since(since, nil)
+	case "projects":
+		resp["projects"] = s.projects.since(since, nil)
+	case "sections":
+		resp["sections"] = s.sections.since(since, nil)
+	case "labels":
+		resp["labels"] = s.labels.since(since, nil)
+	case "notes":
+		resp["notes"] = s.comments.since(since, func(c Comment) any {
+			return api.SyncNote{Comment: c.Comment, ItemID: c.TaskID, ProjectID: c.ProjectID}
+		})
+	case "reminders":
+		resp["reminders"] = s.reminders.since(since, nil)
+	case "filters", "live_notifications":
+		resp[resource] = []any{}
+	case "user":
+		resp["user"] = map[string]any{"id": s.userID, "full_name": "Mock User", "email": "mock@example.com", "inbox_project_id": s.inboxID()}
+	case "workspaces":
+		resp["workspaces"] = s.workspaces
+	}
+}
+
+// runSyncCommands executes commands in order. Temp ids created earlier in
+// the batch may be referenced by later commands.
+func (s *Server) runSyncCommands(commands []syncCommand) (map[string]any, map[string]string) {
+	status := map[string]any{}
+	mapping := map[string]string{}
+	for _, cmd := range commands {
+		args := make(map[string]any, len(cmd.Args))
+		for key, value := range cmd.Args {
+			if text, ok := value.(string); ok && mapping[text] != "" {
+				value = mapping[text]
+			}
+			args[key] = value
+		}
+		id, err := s.runSyncCommand(cmd.Type, args)
+		if err != nil {
+			apiErr, ok := err.(*apiError)
+			if !ok {
+				apiErr = badRequest("INVALID_ARGUMENT_VALUE", "%v", err)
+			}
+			status[cmd.UUID] = apiErr.body()
+			continue
+		}
+		if cmd.TempID != "" && id != "" {
+			mapping[cmd.TempID] = id
+		}
+		status[cmd.UUID] = "ok"
+	}
+	return status, mapping
+}
+
+func (s *Server) runSyncCommand(commandType string, args map[string]any) (string, error) {
+	id, _ := stringArg(args, "id")
+	switch commandType {
+	case "item_add":
+		task, err := s.addTask(args)
+		return task.ID, err
+	case "item_update":
+		_, err := s.updateTask(id, args)
+		return "", err
+	case "item_move":
+		_, err := s.moveTask(id, args)
+		return "", err
+	case "item_close", "item_complete":
+		return "", s.closeTask(id)
+	case "item_uncomplete":
+		return "", s.reopenTask(id)
+	case "item_delete":
+		return "", s.deleteTask(id)
+	case "project_add":
+		project, err := s.addProject(args)
+		return project.ID, err
+	case "project_update":
+		_, err := s.updateProject(id, args)
+		return "", err
+	case "project_archive", "project_unarchive":
+		_, err := s.archiveProject(id, commandType == "project_archive")
+		return "", err
+	case "project_delete":
+		return "", s.deleteProject(id)
+	case "section_add":
+		section, err := s.addSection(args)
+		return section.ID, err
+	case "section_update":
+		_, err := s.updateSection(id, args)
+		return "", err
+	case "section_delete":
+		return "", s.deleteSection(id)
+	case "label_add", "label_update":
+		if order, ok := args["item_order"]; ok {
+			args["order"] = order
+		}
+		if commandType == "label_add" {
+			label, err := s.addLabel(args)
+			return label.ID, err
+		}
+		_, err := s.updateLabel(id, args)
+		return "", err
+	case "label_delete":
+		return "", s.deleteLabel(id)
+	case "note_add":
+		if itemID, ok := args["item_id"]; ok {
+			args["task_id"] = itemID
+		}
+		comment, err := s.addComment(args)
+		return comment.ID, err
+	case "project_note_add":
+		comment, err := s.addComment(map[string]any{"content": args["content"], "project_id": args["project_id"]})
+		return comment.ID, err
+	case "note_update":
+		_, err := s.updateComment(id, args)
+		return "", err
+	case "note_delete":
+		return "", s.deleteComment(id)
+	case "reminder_add":
+		reminder, err := s.addReminder(args)
+		return reminder.ID, err
+	case "reminder_update":
+		_, err := s.updateReminder(id, args)
+		return "", err
+	case "reminder_delete":
+		return "", s.deleteReminder(id)
+	}
+	return "", badRequest("INVALID_COMMAND", "unsupported command type %q", commandType)
+}
@@ -0,0 +1,468 @@
+package mockserver
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/agisilaos/todoist-cli/internal/api"
+	appdates "github.com/agisilaos/todoist-cli/internal/app/dates"
+)
+
+func (s *Server) handleListTasks(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	ids := map[string]bool{}
+	for _, id := range strings.Split(query.Get("ids"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids[id] = true
+		}
+	}
+	tasks := s.tasks.list(func(t api.Task) bool {
+		switch {
+		case t.Checked:
+			return false
+		case query.Get("project_id") != "" && t.ProjectID != query.Get("project_id"):
+			return false
+		case query.Get("section_id") != "" && t.SectionID != query.Get("section_id"):
+			return false
+		case query.Get("parent_id") != "" && t.ParentID != query.Get("parent_id"):
+			return false
+		case query.Get("label") != "" && !hasLabel(t, query.Get("label")):
+			return false
+		case len(ids) > 0 && !ids[t.ID]:
+			return false
+		}
+		return true
+	})
+	writePage(w, r, tasks)
+}
+
+func (s *Server) handleFilterTasks(w http.ResponseWriter, r *http.Request) {
+	match, err := s.compileFilter(r.URL.Query().Get("query"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writePage(w, r, s.tasks.list(func(t api.Task) bool { return !t.Checked && match(t) }))
+}
+
+func (s *Server) handleCompleted(by string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		since := datePart(query.Get("since"))
+		until := datePart(query.Get("until"))
+		match := func(api.Task) bool { return true }
+		if filter := query.Get("filter_query"); filter != "" {
+			compiled, err := s.compileFilter(filter)
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			match = compiled
+		}
+		tasks := s.tasks.list(func(t api.Task) bool {
+			if !t.Checked {
+				return false
+			}
+			day := datePart(t.CompletedAt)
+			if by == "due" {
+				day = dueDate(t)
+			}
+			switch {
+			case since != "" && (day == "" || day < since):
+				return false
+			case until != "" && (day == "" || day > until):
+				return false
+			case query.Get("project_id") != "" && t.ProjectID != query.Get("project_id"):
+				return false
+			case query.Get("section_id") != "" && t.SectionID != query.Get("section_id"):
+				return false
+			case query.Get("parent_id") != "" && t.ParentID != query.Get("parent_id"):
+				return false
+			}
+			return match(t)
+		})
+		writePage(w, r, tasks)
+	}
+}
+
+func (s *Server) handleGetTask(w http.ResponseWriter, r *http.Request) {
+	task, ok := s.tasks.get(r.PathValue("id"))
+	if !ok {
+		writeError(w, notFound("task", r.PathValue("id")))
+		return
+	}
+	writeJSON(w, http.StatusOK, task)
+}
+
+func (s *Server) handleAddTask(w http.ResponseWriter, r *http.Request) {
+	withArgs(w, r, func(args map[string]any) (any, error) { return s.addTask(args) })
+}
+
+func (s *Server) handleUpdateTask(w http.ResponseWriter, r *http.Request) {
+	withArgs(w, r, func(args map[string]any) (any, error) { return s.updateTask(r.PathValue("id"), args) })
+}
+
+func (s *Server) handleMoveTask(w http.ResponseWriter, r *http.Request) {
+	withArgs(w, r, func(args map[string]any) (any, error) { return s.moveTask(r.PathValue("id"), args) })
+}
+
+func (s *Server) handleCloseTask(w http.ResponseWriter, r *http.Request) {
+	writeNoContent(w, s.closeTask(r.PathValue("id")))
+}
+
+func (s *Server) handleReopenTask(w http.ResponseWriter, r *http.Request) {
+	writeNoContent(w, s.reopenTask(r.PathValue("id")))
+}
+
+func (s *Server) handleDeleteTask(w http.ResponseWriter, r *http.Request) {
+	writeNoContent(w, s.deleteTask(r.PathValue("id")))
+}
+
+// handleQuickAdd understands #project, @label and p1-p4 tokens; everything
+// else becomes the task content.
+func (s *Server) handleQuickAdd(w http.ResponseWriter, r *http.Request) {
+	withArgs(w, r, func(args map[string]any) (any, error) {
+		text, _ := stringArg(args, "text")
+		words := []string{}
+		task := map[string]any{}
+		labels := []any{}
+		for _, word := range strings.Fields(text) {
+			switch {
+			case strings.HasPrefix(word, "#") && len(word) > 1:
+				if project, ok := s.projectByName(word[1:]); ok {
+					task["project_id"] = project.ID
+					continue
+				}
+			case strings.HasPrefix(word, "@") && len(word) > 1:
+				labels = append(labels, word[1:])
+				continue
+			case len(word) == 2 && (word[0] == 'p' || word[0] == 'P') && word[1] >= '1' && word[1] <= '4':
+				task["priority"] = float64(5 - int(word[1]-'0'))
+				continue
+			}
+			words = append(words, word)
+		}
+		task["content"] = strings.Join(words, " ")
+		if len(labels) > 0 {
+			task["labels"] = labels
+		}
+		return s.addTask(task)
+	})
+}
+
+func (s *Server) addTask(args map[string]any) (api.Task, error) {
+	content, _ := stringArg(args, "content")
+	if strings.TrimSpace(content) == "" {
+		return api.Task{}, badRequest("ARGUMENT_MISSING", "content is required")
+	}
+	task := api.Task{ID: s.newID(), Priority: 1, Labels: []string{}, AddedAt: s.timestamp()}
+	if err := s.applyTaskArgs(&task, args); err != nil {
+		return api.Task{}, err
+	}
+	projectID, _ := stringArg(args, "project_id")
+	sectionID, _ := stringArg(args, "section_id")
+	parentID, _ := stringArg(args, "parent_id")
+	if projectID == "" && sectionID == "" && parentID == "" {
+		projectID = s.inboxID()
+	}
+	if err := s.placeTask(&task, projectID, sectionID, parentID); err != nil {
+		return api.Task{}, err
+	}
+	task.UpdatedAt = task.AddedAt
+	s.tasks.insert(task, s.bump())
+	return task, nil
+}
+
+func (s *Server) updateTask(id string, args map[string]any) (api.Task, error) {
+	current, ok := s.tasks.get(id)
+	if !ok {
+		return api.Task{}, notFound("task", id)
+	}
+	if err := s.applyTaskArgs(&current, args); err != nil {
+		return api.Task{}, err
+	}
+	current.UpdatedAt = s.timestamp()
+	task, _ := s.tasks.update(id, s.bump(), func(t *api.Task) { *t = current })
+	return task, nil
+}
+
+func (s *Server) moveTask(id string, args map[string]any) (api.Task, error) {
+	current, ok := s.tasks.get(id)
+	if !ok {
+		return api.Task{}, notFound("task", id)
+	}
+	projectID, _ := stringArg(args, "project_id")
+	sectionID, _ := stringArg(args, "section_id")
+	parentID, _ := stringArg(args, "parent_id")
+	set := 0
+	for _, value := range []string{projectID, sectionID, parentID} {
+		if value != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return api.Task{}, badRequest("INVALID_ARGUMENT_VALUE", "exactly one of project_id, section_id or parent_id is required")
+	}
+	if parentID == id {
+		return api.Task{}, badRequest("INVALID_ARGUMENT_VALUE", "task cannot be its own parent")
+	}
+	if err := s.placeTask(&current, projectID, sectionID, parentID); err != nil {
+		return api.Task{}, err
+	}
+	current.UpdatedAt = s.timestamp()
+	rev := s.bump()
+	task, _ := s.tasks.update(id, rev, func(t *api.Task) { *t = current })
+	for _, childID := range s.descendants(id) {
+		s.tasks.update(childID, rev, func(t *api.Task) {
+			t.ProjectID = current.ProjectID
+			t.SectionID = current.SectionID
+		})
+	}
+	return task, nil
+}
+
+// closeTask completes the task and its subtasks. As in the API, closing a
+// recurring task moves it to its next occurrence instead.
+func (s *Server) closeTask(id string) error {
+	task, ok := s.tasks.get(id)
+	if !ok {
+		return notFound("task", id)
+	}
+	rev := s.bump()
+	now := s.timestamp()
+	if next := nextOccurrence(task.Due); next != nil {
+		s.tasks.update(id, rev, func(t *api.Task) {
+			t.Due = next
+			t.UpdatedAt = now
+		})
+		return nil
+	}
+	for _, taskID := range append([]string{id}, s.descendants(id)...) {
+		s.tasks.update(taskID, rev, func(t *api.Task) {
+			t.Checked = true
+			t.CompletedAt = now
+			t.UpdatedAt = now
+		})
+	}
+	return nil
+}
+
+// nextOccurrence returns due moved past its current date, or nil when due does
+// not recur or its recurrence is not understood.
+func nextOccurrence(due *api.Due) *api.Due {
+	if due == nil || !due.IsRecurring {
+		return nil
+	}
+	current, err := time.Parse(time.DateOnly, due.Date)
+	if err != nil {
+		return nil
+	}
+	parsed, err := appdates.Parse(due.String, current.AddDate(0, 0, 1))
+	if err != nil || parsed.Recurrence == nil {
+		return nil
+	}
+	next := *due
+	next.Date = parsed.Date.Format(time.DateOnly)
+	if len(due.Datetime) > len(due.Date) {
+		next.Datetime = next.Date + due.Datetime[len(due.Date):]
+	}
+	return &next
+}
+
+func (s *Server) reopenTask(id string) error {
+	if !s.tasks.has(id) {
+		return notFound("task", id)
+	}
+	s.tasks.update(id, s.bump(), func(t *api.Task) {
+		t.Checked = false
+		t.CompletedAt = ""
+		t.UpdatedAt = s.timestamp()
+	})
+	return nil
+}
+
+func (s *Server) deleteTask(id string) error {
+	if !s.tasks.has(id) {
+		return notFound("task", id)
+	}
+	rev := s.bump()
+	for _, taskID := range append(s.descendants(id), id) {
+		s.tasks.remove(taskID, rev)
+		s.removeComments(func(c Comment) bool { return c.TaskID == taskID }, rev)
+		for _, reminder := range s.reminders.list(func(r api.Reminder) bool { return r.ItemID == taskID }) {
+			s.reminders.remove(reminder.ID, rev)
+		}
+	}
+	return nil
+}
+
+// applyTaskArgs sets the editable task fields from REST (due_string,
+// due_date, due_datetime) or Sync (due object) arguments.
+func (s *Server) applyTaskArgs(task *api.Task, args map[string]any) error {
+	if content, ok := stringArg(args, "content"); ok {
+		task.Content = content
+	}
+	if description, ok := stringArg(args, "description"); ok {
+		task.Description = description
+	}
+	if labels, ok := stringsArg(args, "labels"); ok {
+		task.Labels = labels
+	}
+	if priority, ok := intArg(args, "priority"); ok {
+		if priority < 1 || priority > 4 {
+			return badRequest("INVALID_ARGUMENT_VALUE", "priority must be between 1 and 4")
+		}
+		task.Priority = priority
+	}
+	if due, ok := args["due"]; ok {
+		dueArgs, _ := due.(map[string]any)
+		date, _ := stringArg(dueArgs, "date")
+		str, _ := stringArg(dueArgs, "string")
+		if strings.Contains(date, "T") {
+			task.Due = s.resolveDue(str, "", date)
+		} else {
+			task.Due = s.resolveDue(str, date, "")
+		}
+	}
+	if amount, ok := intArg(args, "duration"); ok {
+		unit, _ := stringArg(args, "duration_unit")
+		if unit == "" {
+			unit = "minute"
+		}
+		if unit != "minute" && unit != "day" {
+			return badRequest("INVALID_ARGUMENT_VALUE", "duration_unit must be minute or day")
+		}
+		task.Duration = &api.Duration{Amount: amount, Unit: unit}
+	}
+	if assignee, ok := stringArg(args, "assignee_id"); ok {
+		task.ResponsibleUID = assignee
+	}
+	if assignee, ok := stringArg(args, "responsible_uid"); ok {
+		task.ResponsibleUID = assignee
+	}
+	if deadline, ok := stringArg(args, "deadline_date"); ok {
+		task.Deadline = nil
+		if deadline != "" {
+			task.Deadline = &api.Deadline{Date: deadline}
+		}
+	}
+	str, hasString := stringArg(args, "due_string")
+	date, hasDate := stringArg(args, "due_date")
+	datetime, hasDatetime := stringArg(args, "due_datetime")
+	if hasString || hasDate || hasDatetime {
+		task.Due = s.resolveDue(str, date, datetime)
+	}
+	return nil
+}
+
+// resolveDue understands ISO dates and the today/tomorrow/yesterday keywords;
+// other due strings are kept verbatim without a date. As in the API, a date
+// sent along with a recurring string ("every day") moves the next occurrence
+// and keeps the recurrence.
+func (s *Server) resolveDue(str, date, datetime string) *api.Due {
+	due := s.resolveDueDate(str, date, datetime)
+	if due != nil {
+		due.IsRecurring = appdates.LooksRecurring(str)
+	}
+	return due
+}
+
+func (s *Server) resolveDueDate(str, date, datetime string) *api.Due {
+	switch {
+	case datetime != "":
+		return &api.Due{Date: datePart(datetime), Datetime: datetime, String: str}
+	case date != "":
+		return &api.Due{Date: date, String: str}
+	}
+	value := strings.ToLower(strings.TrimSpace(str))
+	today := s.Now()
+	switch value {
+	case "", "no date", "no due date":
+		return nil
+	case "today":
+		return &api.Due{Date: today.Format(time.DateOnly), String: str}
+	case "tomorrow":
+		return &api.Due{Date: today.AddDate(0, 0, 1).Format(time.DateOnly), String: str}
+	case "yesterday":
+		return &api.Due{Date: today.AddDate(0, 0, -1).Format(time.DateOnly), String: str}
+	}
+	if _, err := time.Parse(time.DateOnly, value); err == nil {
+		return &api.Due{Date: value, String: str}
+	}
+	return &api.Due{String: str}
+}
+
+// placeTask moves task under a parent, into a section or into a project;
+// the most specific destination wins and determines the others.
+func (s *Server) placeTask(task *api.Task, projectID, sectionID, parentID string) error {
+	switch {
+	case parentID != "":
+		parent, ok := s.tasks.get(parentID)
+		if !ok {
+			return notFound("parent task", parentID)
+		}
+		task.ParentID, task.SectionID, task.ProjectID = parent.ID, parent.SectionID, parent.ProjectID
+	case sectionID != "":
+		section, ok := s.sections.get(sectionID)
+		if !ok {
+			return notFound("section", sectionID)
+		}
+		task.ParentID, task.SectionID, task.ProjectID = "", section.ID, section.ProjectID
+	case projectID != "":
+		if !s.projects.has(projectID) {
+			return notFound("project", projectID)
+		}
+		task.ParentID, task.SectionID, task.ProjectID = "", "", projectID
+	}
+	return nil
+}
+
+func (s *Server) descendants(id string) []string {
+	var out []string
+	queue := []string{id}
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+		for _, task := range s.tasks.list(func(t api.Task) bool { return t.ParentID == parent }) {
+			out = append(out, task.ID)
+			queue = append(queue, task.ID)
+		}
+	}
+	return out
+}
+
+func (s *Server) inboxID() string {
+	for _, project := range s.projects.list(nil) {
+		if project.IsInbox {
+			return project.ID
+		}
+	}
+	return ""
+}
+
+func hasLabel(task api.Task, name string) bool {
+	for _, label := range task.Labels {
+		if strings.EqualFold(label, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func dueDate(task api.Task) string {
+	if task.Due == nil {
+		return ""
+	}
+	if task.Due.Date != "" {
+		return datePart(task.Due.Date)
+	}
+	return datePart(task.Due.Datetime)
+}
+
+func datePart(value string) string {
+	if len(value) > len(time.DateOnly) {
+		return value[:len(time.DateOnly)]
+	}
+	return value
+}
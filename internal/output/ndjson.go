@@ -0,0 +1,81 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"syscall"
+)
+
+// NDJSONEncoder writes one JSON record per line and flushes after each one,
+// so readers see records as soon as they are encoded. Once a write fails the
+// error sticks and later records are dropped.
+type NDJSONEncoder struct {
+	out   io.Writer
+	buf   bytes.Buffer
+	enc   *json.Encoder
+	count int
+	err   error
+}
+
+func NewNDJSONEncoder(out io.Writer) *NDJSONEncoder {
+	e := &NDJSONEncoder{out: out}
+	e.enc = json.NewEncoder(&e.buf)
+	return e
+}
+
+// Encode writes item as a single line with one Write call and flushes out
+// when it supports Flush.
+func (e *NDJSONEncoder) Encode(item any) error {
+	if e.err != nil {
+		return e.err
+	}
+	e.buf.Reset()
+	if err := e.enc.Encode(item); err != nil {
+		return err
+	}
+	if _, err := e.out.Write(e.buf.Bytes()); err != nil {
+		e.err = err
+		return err
+	}
+	if flusher, ok := e.out.(interface{ Flush() error }); ok {
+		if err := flusher.Flush(); err != nil {
+			e.err = err
+			return err
+		}
+	}
+	e.count++
+	return nil
+}
+
+// Count returns the number of records written.
+func (e *NDJSONEncoder) Count() int {
+	return e.count
+}
+
+// Err returns the write error that stopped the encoder, if any.
+func (e *NDJSONEncoder) Err() error {
+	return e.err
+}
+
+// IsClosedPipe reports whether err means the reader of the output went away,
+// for example `todoist task list --ndjson | head`.
+func IsClosedPipe(err error) bool {
+	return errors.Is(err, syscall.EPIPE) || errors.Is(err, io.ErrClosedPipe) || errors.Is(err, os.ErrClosed)
+}
+
+func WriteNDJSON(out io.Writer, items []any) error {
+	return WriteNDJSONSlice(out, items)
+}
+
+func WriteNDJSONSlice[T any](out io.Writer, items []T) error {
+	enc := NewNDJSONEncoder(out)
+	for _, item := range items {
+		if err := enc.Encode(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
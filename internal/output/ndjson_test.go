@@ -0,0 +1,70 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"syscall"
+	"testing"
+)
+
+type flushRecorder struct {
+	bytes.Buffer
+	flushed []string
+}
+
+func (f *flushRecorder) Flush() error {
+	f.flushed = append(f.flushed, f.String())
+	return nil
+}
+
+type closedPipeWriter struct {
+	writes int
+}
+
+func (w *closedPipeWriter) Write(p []byte) (int, error) {
+	w.writes++
+	if w.writes > 1 {
+		return 0, fmt.Errorf("write /dev/stdout: %w", syscall.EPIPE)
+	}
+	return len(p), nil
+}
+
+func TestNDJSONEncoderFlushesEachRecord(t *testing.T) {
+	var out flushRecorder
+	enc := NewNDJSONEncoder(&out)
+	for _, id := range []string{"1", "2"} {
+		if err := enc.Encode(map[string]string{"id": id}); err != nil {
+			t.Fatalf("encode: %v", err)
+		}
+	}
+	want := []string{"{\"id\":\"1\"}\n", "{\"id\":\"1\"}\n{\"id\":\"2\"}\n"}
+	if len(out.flushed) != 2 || out.flushed[0] != want[0] || out.flushed[1] != want[1] {
+		t.Fatalf("unexpected flushes: %q", out.flushed)
+	}
+	if enc.Count() != 2 {
+		t.Fatalf("expected count 2, got %d", enc.Count())
+	}
+}
+
+func TestNDJSONEncoderStopsOnClosedPipe(t *testing.T) {
+	out := &closedPipeWriter{}
+	enc := NewNDJSONEncoder(out)
+	if err := enc.Encode("a"); err != nil {
+		t.Fatalf("first encode: %v", err)
+	}
+	err := enc.Encode("b")
+	if !IsClosedPipe(err) {
+		t.Fatalf("expected closed pipe error, got %v", err)
+	}
+	if err := enc.Encode("c"); !IsClosedPipe(err) || out.writes != 2 {
+		t.Fatalf("expected sticky error without further writes, got %v after %d writes", err, out.writes)
+	}
+	if enc.Count() != 1 || !IsClosedPipe(enc.Err()) {
+		t.Fatalf("unexpected encoder state: count=%d err=%v", enc.Count(), enc.Err())
+	}
+	if IsClosedPipe(errors.New("boom")) || !IsClosedPipe(io.ErrClosedPipe) {
+		t.Fatalf("unexpected IsClosedPipe classification")
+	}
+}